@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+)
+
+// InitHandle tracks an initialization started with Module.InitAsync.
+type InitHandle struct {
+	done   chan struct{}
+	err    error
+	cancel context.CancelFunc
+}
+
+// Done returns a channel closed once the initialization is over, whether it
+// succeeded or not.
+func (h *InitHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Err returns nil while the initialization is in progress, then its outcome:
+// nil if the module is ready, the error of Module.Init otherwise.
+func (h *InitHandle) Err() error {
+	select {
+	case <-h.done:
+		return h.err
+	default:
+		return nil
+	}
+}
+
+// Ready reports whether the initialization is over and succeeded.
+func (h *InitHandle) Ready() bool {
+	select {
+	case <-h.done:
+		return h.err == nil
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the initialization is over or ctx is canceled, and
+// returns the error of the initialization or of the context.
+func (h *InitHandle) Wait(ctx context.Context) error {
+	select {
+	case <-h.done:
+		return h.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Cancel cancels the initialization. Done is closed once the providers
+// being initialized return.
+func (h *InitHandle) Cancel() {
+	h.cancel()
+}
+
+// InitAsync starts the initialization of the module in the background and
+// returns immediately. Docker-backed providers can take minutes to initialize
+// on first run (image download): applications can start initialization at
+// launch and enable the features relying on the module once Done is closed.
+//
+// The module must not be used before the initialization is over.
+//
+// Parameters:
+//   - ctx: Context for cancellation of the initialization
+//
+// Returns:
+//   - *InitHandle: A handle to wait for the initialization and get its outcome
+func (m *Module) InitAsync(ctx context.Context) *InitHandle {
+	ctx, cancel := context.WithCancel(ctx)
+	h := &InitHandle{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	go func() {
+		defer cancel()
+		h.err = m.InitWithContext(ctx)
+		close(h.done)
+	}()
+	return h
+}
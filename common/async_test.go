@@ -0,0 +1,32 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestInitAsync(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	h := m.InitAsync(context.Background())
+	require.NoError(t, h.Wait(context.Background()))
+	assert.True(t, h.Ready())
+	defer m.Close()
+
+	roman, err := m.Roman("สวัสดีครับ")
+	require.NoError(t, err)
+	assert.NotEmpty(t, roman)
+
+	failing := &common.Module{Lang: tha.Lang, Providers: []common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{
+		&unavailableProvider{name: "docker-backed"},
+	}}
+	h = failing.InitAsync(context.Background())
+	<-h.Done()
+	assert.False(t, h.Ready())
+	assert.ErrorContains(t, h.Err(), "backend unreachable")
+}
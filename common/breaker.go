@@ -0,0 +1,464 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed: requests go to the primary provider.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen: the primary provider failed repeatedly, requests go
+	// to the fallback until the cooldown expires.
+	BreakerOpen
+	// BreakerHalfOpen: the cooldown expired, a single trial request goes to the
+	// primary provider to decide whether to close the breaker again.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker.
+// Zero values are replaced by the defaults documented on each field.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that open the breaker (default 3).
+	FailureThreshold int
+
+	// Cooldown is how long the breaker stays open before letting a trial
+	// request through to the primary provider (default 30s).
+	Cooldown time.Duration
+
+	// Timeout bounds each call to the primary provider. A call that takes
+	// longer counts as a failure, even if the provider ignores the context and
+	// eventually returns a result. Zero means no timeout.
+	Timeout time.Duration
+
+	// BestEffort builds the tokens of a chunk when the breaker is open and
+	// there is no fallback provider. By default the chunk is returned as a
+	// single lexical token without romanization. The tokens are converted to
+	// the token types of Lang, see RegisterTokenConverter.
+	BestEffort func(chunk string) []AnyToken
+
+	// Lang is the ISO 639 code of the language of the best-effort tokens.
+	// WithCircuitBreaker sets it to the language of the module.
+	Lang string
+}
+
+func (cfg CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 3
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	return cfg
+}
+
+// CircuitBreaker wraps a flaky provider (web scraper, remote HTTP service...)
+// so that repeated failures stop being retried for every chunk: after
+// FailureThreshold consecutive failures the breaker opens and subsequent
+// chunks go straight to the fallback provider, or get best-effort output if
+// there is none. After the cooldown the breaker half-opens and lets one
+// request through to the primary provider to probe whether it recovered.
+//
+// Raw input is processed chunk by chunk so that a failure only affects the
+// chunk being processed; pre-tokenized input (TransliteratorMode) is
+// processed in a single call.
+//
+// CircuitBreaker implements Provider and takes the name of the primary provider.
+type CircuitBreaker struct {
+	primary  Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+	fallback Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+	cfg      CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // a trial request is in flight while half-open
+
+	progressCallback ProgressCallback
+}
+
+// NewCircuitBreaker wraps primary in a circuit breaker.
+//
+// Parameters:
+//   - primary: The provider to protect
+//   - fallback: The provider used while the breaker is open, or nil for best-effort output
+//   - cfg: The breaker configuration
+//
+// Returns:
+//   - *CircuitBreaker: The wrapped provider
+func NewCircuitBreaker(primary, fallback Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], cfg CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		primary:  primary,
+		fallback: fallback,
+		cfg:      cfg.withDefaults(),
+	}
+}
+
+// Unwrap returns the primary provider.
+func (b *CircuitBreaker) Unwrap() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+	return b.primary
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cfg.Cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the breaker and clears its failure count.
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// allow reports whether a request may go to the primary provider,
+// transitioning an expired open breaker to half-open.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		fallthrough
+	default: // half-open
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasProbing := b.state == BreakerHalfOpen
+	b.probing = false
+	if err == nil {
+		if wasProbing {
+			Log.Info().Str("provider", b.primary.Name()).Msg("Circuit breaker closed: provider recovered")
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if wasProbing || b.failures >= b.cfg.FailureThreshold {
+		if b.state != BreakerOpen {
+			Log.Warn().
+				Err(err).
+				Str("provider", b.primary.Name()).
+				Int("failures", b.failures).
+				Dur("cooldown", b.cfg.Cooldown).
+				Msg("Circuit breaker opened")
+		}
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// callPrimary calls the primary provider with the configured timeout and
+// records the outcome. Cancellation of the caller's context isn't counted as
+// a failure of the provider.
+func (b *CircuitBreaker) callPrimary(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	callCtx := ctx
+	if b.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, b.cfg.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	out, err := b.primary.ProcessFlowController(callCtx, mode, input)
+	if ctx.Err() != nil {
+		b.mu.Lock()
+		b.probing = false
+		b.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w", b.primary.Name(), ctx.Err())
+	}
+	if err == nil && out == nil {
+		err = fmt.Errorf("nil output")
+	}
+	if err == nil && b.cfg.Timeout > 0 && time.Since(start) > b.cfg.Timeout {
+		// Keep the result but count the call as a failure
+		b.record(fmt.Errorf("call took %s, timeout is %s", time.Since(start), b.cfg.Timeout))
+		return out, nil
+	}
+	b.record(err)
+	return out, err
+}
+
+// bestEffort returns the tokens of a chunk processed by neither provider.
+func (b *CircuitBreaker) bestEffort(chunk string) AnyTokenSliceWrapper {
+	if b.cfg.BestEffort == nil {
+		return ConvertTokens(b.cfg.Lang, []*Tkn{{Surface: chunk, IsLexical: true}})
+	}
+	out := ConvertTokens(b.cfg.Lang, nil)
+	out.Append(b.cfg.BestEffort(chunk)...)
+	return out
+}
+
+// process runs the input through the primary provider if the breaker allows
+// it, otherwise, or if the primary fails, through the fallback. Without
+// fallback, the error of the primary is returned until the breaker opens.
+func (b *CircuitBreaker) process(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper, bestEffort func() AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	if b.allow() {
+		out, err := b.callPrimary(ctx, mode, input)
+		if err == nil {
+			return out, nil
+		}
+		if ctx.Err() != nil || b.fallback == nil && b.State() == BreakerClosed {
+			return nil, err
+		}
+		Log.Debug().Err(err).Str("provider", b.primary.Name()).Msg("Primary provider failed, using fallback")
+	}
+	if b.fallback == nil {
+		return bestEffort(), nil
+	}
+	out, err := b.fallback.ProcessFlowController(ctx, mode, input)
+	if err != nil {
+		return nil, fmt.Errorf("fallback provider %s failed: %w", b.fallback.Name(), err)
+	}
+	return out, nil
+}
+
+// ProcessFlowController processes the input through the primary provider,
+// routing it to the fallback provider while the breaker is open.
+func (b *CircuitBreaker) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if len(raw) == 0 {
+		if b.progressCallback != nil {
+			b.progressCallback(0, 1)
+		}
+		// Pre-tokenized input: without romanization, the tokens are the best effort
+		return b.process(ctx, mode, input, func() AnyTokenSliceWrapper { return input })
+	}
+
+	var out AnyTokenSliceWrapper
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s: context canceled while processing chunk %d: %w", b.Name(), idx, err)
+		}
+		if b.progressCallback != nil {
+			b.progressCallback(idx, len(raw))
+		}
+		res, err := b.process(ctx, mode, &TknSliceWrapper{Raw: []string{chunk}}, func() AnyTokenSliceWrapper {
+			return b.bestEffort(chunk)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", idx, err)
+		}
+		if out == nil {
+			out = res
+			continue
+		}
+		for i := 0; i < res.Len(); i++ {
+			out.Append(res.GetIdx(i))
+		}
+	}
+	input.ClearRaw()
+	return out, nil
+}
+
+// SaveConfig stores the configuration in both providers.
+func (b *CircuitBreaker) SaveConfig(cfg map[string]interface{}) error {
+	if err := b.primary.SaveConfig(cfg); err != nil {
+		return err
+	}
+	if b.fallback != nil {
+		if err := b.fallback.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("fallback provider %s: %w", b.fallback.Name(), err)
+		}
+	}
+	return nil
+}
+
+// ApplyConfig applies the configuration to both providers, see common.ApplyConfig.
+func (b *CircuitBreaker) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	if err := ApplyConfig(ctx, b.primary, cfg); err != nil {
+		return err
+	}
+	if b.fallback != nil {
+		if err := ApplyConfig(ctx, b.fallback, cfg); err != nil {
+			return fmt.Errorf("fallback provider %s: %w", b.fallback.Name(), err)
+		}
+	}
+	return nil
+}
+
+// InitWithContext initializes both providers. If the primary provider fails to
+// initialize and there is a fallback, the breaker opens instead of failing.
+func (b *CircuitBreaker) InitWithContext(ctx context.Context) error {
+	return b.init(ctx, func(p Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error {
+		return p.InitWithContext(ctx)
+	})
+}
+
+func (b *CircuitBreaker) Init() error {
+	return b.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes both providers and closes the breaker.
+func (b *CircuitBreaker) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	b.Reset()
+	return b.init(ctx, func(p Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error {
+		return p.InitRecreateWithContext(ctx, noCache)
+	})
+}
+
+func (b *CircuitBreaker) InitRecreate(noCache bool) error {
+	return b.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (b *CircuitBreaker) init(ctx context.Context, initFn func(Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error) error {
+	if b.fallback != nil {
+		if err := initFn(b.fallback); err != nil {
+			return fmt.Errorf("fallback provider %s init failed: %w", b.fallback.Name(), err)
+		}
+	}
+	if err := initFn(b.primary); err != nil {
+		if b.fallback == nil || ctx.Err() != nil {
+			return err
+		}
+		b.mu.Lock()
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.mu.Unlock()
+		Log.Warn().Err(err).Str("provider", b.primary.Name()).Msg("Provider init failed, circuit breaker opened")
+	}
+	return nil
+}
+
+// CloseWithContext closes both providers.
+func (b *CircuitBreaker) CloseWithContext(ctx context.Context) error {
+	err := b.primary.CloseWithContext(ctx)
+	if b.fallback != nil {
+		if ferr := b.fallback.CloseWithContext(ctx); ferr != nil && err == nil {
+			err = fmt.Errorf("fallback provider %s close failed: %w", b.fallback.Name(), ferr)
+		}
+	}
+	return err
+}
+
+func (b *CircuitBreaker) Close() error {
+	return b.CloseWithContext(context.Background())
+}
+
+// WithProgressCallback sets the progress callback, which the breaker calls
+// itself since it feeds the providers one chunk at a time.
+func (b *CircuitBreaker) WithProgressCallback(callback ProgressCallback) {
+	b.progressCallback = callback
+}
+
+func (b *CircuitBreaker) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	b.primary.WithDownloadProgressCallback(callback)
+	if b.fallback != nil {
+		b.fallback.WithDownloadProgressCallback(callback)
+	}
+}
+
+// Name returns the name of the primary provider.
+func (b *CircuitBreaker) Name() string {
+	return b.primary.Name()
+}
+
+func (b *CircuitBreaker) SupportedModes() []OperatingMode {
+	return b.primary.SupportedModes()
+}
+
+// GetMaxQueryLen returns the smallest maximum query length of the providers,
+// as chunks may be routed to either.
+func (b *CircuitBreaker) GetMaxQueryLen() int {
+	max := b.primary.GetMaxQueryLen()
+	if b.fallback != nil {
+		if f := b.fallback.GetMaxQueryLen(); f > 0 && (max <= 0 || f < max) {
+			max = f
+		}
+	}
+	return max
+}
+
+// PlatformRequirements returns the requirements of the primary provider.
+func (b *CircuitBreaker) PlatformRequirements() PlatformRequirements {
+	return RequirementsOf(b.primary)
+}
+
+// ResourceVersions returns the resource versions of both providers,
+// implementing VersionReporter.
+func (b *CircuitBreaker) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	versions := make(map[string]string)
+	for _, p := range []Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]{b.primary, b.fallback} {
+		reporter, ok := p.(VersionReporter)
+		if !ok {
+			continue
+		}
+		v, err := reporter.ResourceVersions(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", p.Name(), err)
+		}
+		for k, val := range v {
+			versions[k] = val
+		}
+	}
+	return versions, nil
+}
+
+// WithCircuitBreaker wraps the provider playing the given role in a CircuitBreaker.
+// This is meant for providers that depend on a remote service, such as th2en,
+// so that an outage degrades the output instead of slowing down every request.
+//
+// Parameters:
+//   - mode: The role of the provider to wrap (TokenizerMode, TransliteratorMode or CombinedMode)
+//   - fallback: A provider supporting the same mode, used while the breaker is open, or nil
+//   - cfg: The breaker configuration
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithCircuitBreaker(mode OperatingMode, fallback Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], cfg CircuitBreakerConfig) *Module {
+	if _, ok := m.ProviderRoles[mode]; !ok {
+		m.logger().Warn().Str("mode", string(mode)).Msg("WithCircuitBreaker: module has no provider in this role")
+		return m
+	}
+	if fallback != nil && !contains(fallback.SupportedModes(), mode) {
+		m.logger().Warn().
+			Str("mode", string(mode)).
+			Str("fallback", fallback.Name()).
+			Msg("WithCircuitBreaker: fallback provider doesn't support this mode")
+		return m
+	}
+	if cfg.Lang == "" {
+		cfg.Lang = m.Lang
+	}
+	return m.WrapProvider(mode, func(primary Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+		return NewCircuitBreaker(primary, fallback, cfg)
+	})
+}
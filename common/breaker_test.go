@@ -0,0 +1,73 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// failingTokenizer is a tokenizer whose backend is down
+type failingTokenizer struct {
+	tha.DictTokenizerProvider
+	calls int
+}
+
+func (p *failingTokenizer) Name() string {
+	return "failing"
+}
+
+func (p *failingTokenizer) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	p.calls++
+	return nil, errors.New("service unavailable")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	primary := &failingTokenizer{}
+	breaker := common.NewCircuitBreaker(primary, tha.NewDictTokenizerProvider(), common.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Cooldown:         50 * time.Millisecond,
+	})
+	chunks := []string{"ผมชอบกินข้าว", "สวัสดีครับ", "ผมชอบกินข้าว", "สวัสดีครับ"}
+
+	out, err := breaker.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: chunks})
+	require.NoError(t, err)
+	assert.Equal(t, "ผม ชอบ กินข้าว สวัสดี ครับ ผม ชอบ กินข้าว สวัสดี ครับ", out.Tokenized())
+	assert.IsType(t, &tha.TknSliceWrapper{}, out)
+	assert.Equal(t, 2, primary.calls, "the breaker should stop calling the primary once open")
+	assert.Equal(t, common.BreakerOpen, breaker.State())
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, common.BreakerHalfOpen, breaker.State())
+	_, err = breaker.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: chunks[:2]})
+	require.NoError(t, err)
+	assert.Equal(t, 3, primary.calls, "a single trial request should go through when half-open")
+	assert.Equal(t, common.BreakerOpen, breaker.State())
+}
+
+func TestCircuitBreakerWithoutFallback(t *testing.T) {
+	primary := &failingTokenizer{}
+	breaker := common.NewCircuitBreaker(primary, nil, common.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Lang:             tha.Lang,
+	})
+
+	_, err := breaker.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: []string{"สวัสดีครับ"}})
+	require.Error(t, err, "the error of the primary should be returned while the breaker is closed")
+	assert.Contains(t, err.Error(), "service unavailable")
+	assert.Equal(t, common.BreakerClosed, breaker.State())
+
+	out, err := breaker.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: []string{"สวัสดีครับ", "ผมชอบกินข้าว"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.BreakerOpen, breaker.State())
+	assert.Equal(t, 2, primary.calls)
+	require.IsType(t, &tha.TknSliceWrapper{}, out)
+	require.Equal(t, 2, out.Len())
+	assert.IsType(t, &tha.Tkn{}, out.GetIdx(0))
+	assert.Equal(t, "สวัสดีครับ ผมชอบกินข้าว", out.Tokenized())
+}
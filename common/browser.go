@@ -0,0 +1,207 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ErrBrowserPoolClosed is returned by BrowserPool.Acquire once the pool was closed.
+var ErrBrowserPoolClosed = errors.New("browser pool is closed")
+
+// DefaultMaxPages is the number of pages the shared browser pool lets
+// scraper providers keep open at the same time.
+var DefaultMaxPages = 4
+
+// BrowserPool owns a headless browser that scraper providers borrow pages from.
+//
+// The browser is connected to lazily on the first Acquire: to BrowserAccessURL
+// if it is set, otherwise to a browser that go-rod downloads and launches
+// automatically. At most MaxPages pages are lent at the same time; Acquire
+// blocks until one is released or the context is done. If the browser crashed
+// or the connection was lost, it is relaunched (or reconnected) transparently
+// on the next Acquire.
+type BrowserPool struct {
+	// MaxPages is the maximum number of pages lent at the same time.
+	// It must be set before the first Acquire.
+	MaxPages int
+
+	mu       sync.Mutex
+	browser  *rod.Browser
+	launcher *launcher.Launcher
+	slots    chan struct{}
+	pages    map[*rod.Page]struct{}
+	closed   bool
+}
+
+// NewBrowserPool creates a pool lending at most maxPages pages at the same time.
+// A maxPages of zero or less means DefaultMaxPages.
+func NewBrowserPool(maxPages int) *BrowserPool {
+	return &BrowserPool{MaxPages: maxPages}
+}
+
+var (
+	sharedPoolMu sync.Mutex
+	sharedPool   *BrowserPool
+)
+
+// SharedBrowserPool returns the pool shared by the scraper providers of all
+// languages, so that a single browser runs per process.
+func SharedBrowserPool() *BrowserPool {
+	sharedPoolMu.Lock()
+	defer sharedPoolMu.Unlock()
+	if sharedPool == nil || sharedPool.isClosed() {
+		sharedPool = NewBrowserPool(DefaultMaxPages)
+	}
+	return sharedPool
+}
+
+func (bp *BrowserPool) isClosed() bool {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	return bp.closed
+}
+
+// init must be called with bp.mu held.
+func (bp *BrowserPool) init() {
+	if bp.slots != nil {
+		return
+	}
+	n := bp.MaxPages
+	if n <= 0 {
+		n = DefaultMaxPages
+	}
+	bp.slots = make(chan struct{}, n)
+	bp.pages = make(map[*rod.Page]struct{})
+}
+
+// Acquire borrows a new blank page from the pool. It blocks while MaxPages
+// pages are already lent, until one is released or ctx is done.
+// The page must be given back with Release.
+func (bp *BrowserPool) Acquire(ctx context.Context) (*rod.Page, error) {
+	bp.mu.Lock()
+	if bp.closed {
+		bp.mu.Unlock()
+		return nil, ErrBrowserPoolClosed
+	}
+	bp.init()
+	slots := bp.slots
+	bp.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a browser page: %w", ctx.Err())
+	}
+
+	page, err := bp.newPage(ctx)
+	if err != nil {
+		<-slots
+		return nil, err
+	}
+	return page, nil
+}
+
+func (bp *BrowserPool) newPage(ctx context.Context) (*rod.Page, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.closed {
+		return nil, ErrBrowserPoolClosed
+	}
+	if bp.browser != nil {
+		if _, err := bp.browser.Version(); err != nil {
+			Log.Warn().Err(err).Msg("browser is unresponsive, relaunching")
+			bp.shutdown()
+		}
+	}
+	if bp.browser == nil {
+		if err := bp.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+	page, err := bp.browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	bp.pages[page] = struct{}{}
+	return page, nil
+}
+
+// connect must be called with bp.mu held.
+func (bp *BrowserPool) connect(ctx context.Context) error {
+	browserURL := BrowserAccessURL
+	if browserURL == "" {
+		Log.Info().Msg("BrowserAccessURL not set, using automatic browser management")
+		l := launcher.New().Headless(true).Context(ctx)
+		u, err := l.Launch()
+		if err != nil {
+			return fmt.Errorf("failed to launch browser automatically: %w", err)
+		}
+		// ctx only bounds the download and the launch, not the browser's lifetime
+		bp.launcher = l
+		browserURL = u
+		Log.Info().Str("browser_url", u).Msg("Browser launched automatically")
+	} else {
+		Log.Info().Str("browser_url", browserURL).Msg("Using provided browser URL")
+	}
+
+	browser := rod.New().ControlURL(browserURL)
+	if err := browser.Connect(); err != nil {
+		bp.shutdown()
+		return fmt.Errorf("go-rod failed to connect to browser: %w", err)
+	}
+	bp.browser = browser
+	return nil
+}
+
+// shutdown closes the browser and kills the launched process if any.
+// It must be called with bp.mu held.
+func (bp *BrowserPool) shutdown() error {
+	var err error
+	if bp.browser != nil {
+		err = bp.browser.Close()
+		bp.browser = nil
+	}
+	if bp.launcher != nil {
+		bp.launcher.Kill()
+		bp.launcher = nil
+	}
+	return err
+}
+
+// Release closes a page obtained from Acquire and frees its slot.
+// Releasing a page that isn't lent by the pool is a no-op.
+func (bp *BrowserPool) Release(page *rod.Page) {
+	if page == nil {
+		return
+	}
+	bp.mu.Lock()
+	_, lent := bp.pages[page]
+	delete(bp.pages, page)
+	slots := bp.slots
+	bp.mu.Unlock()
+	if !lent {
+		return
+	}
+	// fails harmlessly if the browser was relaunched since the page was acquired
+	if err := page.Close(); err != nil {
+		Log.Debug().Err(err).Msg("failed to close browser page")
+	}
+	<-slots
+}
+
+// Close closes the browser, and with it all lent pages. Acquire fails afterwards.
+func (bp *BrowserPool) Close() error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.closed {
+		return nil
+	}
+	bp.closed = true
+	return bp.shutdown()
+}
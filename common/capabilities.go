@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Capability is what a provider declares it does in its ProviderEntry, so
+// that the registry can check that the providers of a language cover its
+// needs and that applications can look providers up by what they do.
+type Capability string
+
+const (
+	// CapabilityTokenization is declared by the providers splitting text into words
+	CapabilityTokenization Capability = "tokenization"
+	// CapabilityTransliteration is declared by the providers romanizing tokens
+	CapabilityTransliteration Capability = "transliteration"
+	// CapabilityLemmatization is declared by the providers setting the Lemma of tokens
+	CapabilityLemmatization Capability = "lemmatization"
+	// CapabilityNER is declared by the providers setting the NamedEntity of tokens
+	CapabilityNER Capability = "ner"
+)
+
+// Capabilities returns all the known capabilities.
+func Capabilities() []Capability {
+	return []Capability{
+		CapabilityTokenization,
+		CapabilityTransliteration,
+		CapabilityLemmatization,
+		CapabilityNER,
+	}
+}
+
+// Valid reports whether c is one of the known capabilities.
+func (c Capability) Valid() bool {
+	return slices.Contains(Capabilities(), c)
+}
+
+// ParseCapability returns the capability of the given name, e.g. "tokenization".
+func ParseCapability(name string) (Capability, error) {
+	if c := Capability(name); c.Valid() {
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown capability %q", name)
+}
+
+// Has reports whether the entry declares the capability.
+func (e ProviderEntry) Has(c Capability) bool {
+	return slices.Contains(e.Capabilities, c)
+}
+
+// validateCapabilities returns an error if the entry declares an unknown capability.
+func validateCapabilities(entry ProviderEntry) error {
+	for _, c := range entry.Capabilities {
+		if !c.Valid() {
+			return fmt.Errorf("provider %s declares unknown capability %q", entry.Provider.Name(), c)
+		}
+	}
+	return nil
+}
+
+// FindProvidersByCapability returns the providers registered for a language
+// that declare the capability, followed by the multilingual ones (registered
+// for "mul") that aren't shadowed by a provider of the same name.
+//
+// Example usage:
+//
+//	entries, err := common.FindProvidersByCapability("tha", common.CapabilityLemmatization)
+func FindProvidersByCapability(languageCode string, c Capability) ([]ProviderEntry, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	if !c.Valid() {
+		return nil, fmt.Errorf("unknown capability %q", c)
+	}
+
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+
+	var found []ProviderEntry
+	seen := make(map[string]bool)
+	for _, l := range []string{lang, "mul"} {
+		for _, entry := range GlobalRegistry.Providers[l].Providers {
+			name := entry.Provider.Name()
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if entry.Has(c) {
+				found = append(found, entry)
+			}
+		}
+		if lang == "mul" {
+			break
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,24 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestFindProvidersByCapability(t *testing.T) {
+	entries, err := common.FindProvidersByCapability(tha.Lang, common.CapabilityLemmatization)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Provider.Name())
+	}
+	assert.Contains(t, names, "thai-dict")
+	assert.NotContains(t, names, "paiboonizer")
+
+	err = common.Register("nod", common.ProviderEntry{Provider: tha.NewDictTokenizerProvider(), Capabilities: []common.Capability{"romaji"}})
+	assert.ErrorContains(t, err, "unknown capability")
+}
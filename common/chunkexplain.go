@@ -0,0 +1,126 @@
+package common
+
+import (
+	"unicode/utf8"
+)
+
+// Methods reported by Explain for the chunks that weren't produced by a
+// single SplitMethod.
+const (
+	// ChunkMethodWhole is the method of an input that fit within MaxLength
+	// and was sent as a single chunk.
+	ChunkMethodWhole = "whole"
+	// ChunkMethodRecursive is the method of the chunks produced by applying
+	// the split methods in turn to the parts that were still too long.
+	ChunkMethodRecursive = "recursive"
+	// ChunkMethodHybrid is the method of the chunks produced by the last
+	// resort splitting, which tries every split method on every part.
+	ChunkMethodHybrid = "hybrid"
+)
+
+// ChunkExplanation describes a chunk of an input and how the chunkifier
+// arrived at it, see Chunkifier.Explain and Module.ExplainChunks.
+type ChunkExplanation struct {
+	Index int    // index of the chunk in the input (0-based)
+	Text  string // the chunk as sent to the providers
+
+	// Start and End are the byte offsets of the chunk in the input, extended
+	// to the whitespace that the chunkifier may have dropped, like Chunk.
+	Start, End int
+
+	// Method is the name of the SplitMethod the chunk results from, or
+	// ChunkMethodWhole, ChunkMethodRecursive or ChunkMethodHybrid. With
+	// PreserveSentences it is "SplitSentences", followed by "+" and the method
+	// of the forced split for the chunks holding a part of a sentence longer
+	// than MaxLength, e.g. "SplitSentences+SplitSpace".
+	Method string
+
+	Length    int // length of Text as counted against MaxLength (see Chunkifier.Measure)
+	Runes     int // number of runes of Text
+	Bytes     int // number of bytes of Text
+	MaxLength int // MaxLength of the chunkifier, 0 if unbounded
+}
+
+// Explain splits s like Chunkify and describes the resulting chunks: their
+// boundaries in s, the split method that produced each of them and their
+// measured lengths. It is meant to find out how an input was chunked when the
+// output of a provider looks wrong at the edges of the chunks.
+func (c *Chunkifier) Explain(s string) ([]ChunkExplanation, error) {
+	chunks, methods, err := c.split(s)
+	if err != nil {
+		return nil, err
+	}
+	starts := chunkStartOffsets(s, chunks)
+	explanations := make([]ChunkExplanation, len(chunks))
+	for i, chunk := range chunks {
+		end := len(s)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		explanations[i] = ChunkExplanation{
+			Index:     i,
+			Text:      chunk,
+			Start:     starts[i],
+			End:       end,
+			Method:    methods[i],
+			Length:    c.measure(chunk),
+			Runes:     utf8.RuneCountInString(chunk),
+			Bytes:     len(chunk),
+			MaxLength: max(c.MaxLength, 0),
+		}
+	}
+	return explanations, nil
+}
+
+// ExplainChunks returns how the module splits the input into chunks before
+// sending them to its providers: the boundaries of the chunks in the
+// normalized input (see WithNormalization), the split method used for each
+// and their measured lengths. The input isn't processed.
+//
+// Example usage:
+//
+//	chunks, err := m.ExplainChunks(text)
+//	for _, c := range chunks {
+//		fmt.Printf("%d [%d:%d] %s len=%d/%d\n", c.Index, c.Start, c.End, c.Method, c.Length, c.MaxLength)
+//	}
+func (m *Module) ExplainChunks(input string) ([]ChunkExplanation, error) {
+	return m.chunker().Explain(m.getNormalization().Apply(input))
+}
+
+// WithChunkTrace logs how each input processed by the module is chunked,
+// one debug message per chunk with the fields of its ChunkExplanation (see
+// ExplainChunks), so that the chunking can be checked from the logs without
+// changing the code. The messages go to the logger of the module (see
+// WithLogger) or to Log, which must be at debug level or lower.
+//
+// Returns the module for method chaining.
+func (m *Module) WithChunkTrace(enabled bool) *Module {
+	m.chunkTrace = enabled
+	return m
+}
+
+// traceChunks logs the chunking of the input if WithChunkTrace is enabled.
+func (m *Module) traceChunks(input string) {
+	if !m.chunkTrace {
+		return
+	}
+	explanations, err := m.chunker().Explain(input)
+	if err != nil {
+		m.logger().Debug().Err(err).Str("lang", m.Lang).Int("bytes", len(input)).Msg("Chunk trace: input couldn't be chunked")
+		return
+	}
+	for _, c := range explanations {
+		m.logger().Debug().
+			Str("lang", m.Lang).
+			Int("chunk", c.Index).
+			Int("chunks", len(explanations)).
+			Int("start", c.Start).
+			Int("end", c.End).
+			Str("method", c.Method).
+			Int("length", c.Length).
+			Int("runes", c.Runes).
+			Int("bytes", c.Bytes).
+			Int("max_length", c.MaxLength).
+			Msg("Chunk trace")
+	}
+}
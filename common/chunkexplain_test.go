@@ -0,0 +1,42 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestExplainChunks(t *testing.T) {
+	input := "สวัสดี. ผมชอบ กินข้าว มาก. ผมชอบกินข้าวมาก ผมชอบกินข้าว."
+	c := common.NewChunkifier(20)
+	c.PreserveSentences = true
+	chunks, err := c.Chunkify(input)
+	require.NoError(t, err)
+	explained, err := c.Explain(input)
+	require.NoError(t, err)
+	require.Len(t, explained, len(chunks))
+	for i, e := range explained {
+		assert.Equal(t, chunks[i], e.Text)
+		assert.Equal(t, utf8.RuneCountInString(e.Text), e.Length)
+		assert.Equal(t, 20, e.MaxLength)
+		assert.Contains(t, input[e.Start:e.End], strings.TrimSpace(e.Text))
+	}
+	assert.Equal(t, 0, explained[0].Start)
+	assert.Equal(t, len(input), explained[len(explained)-1].End)
+	assert.Equal(t, "SplitSentences", explained[0].Method)
+	assert.Equal(t, "SplitSentences+SplitSpace", explained[len(explained)-1].Method, "the long sentence is split on spaces")
+
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	explained, err = m.ExplainChunks("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	require.Len(t, explained, 1)
+	assert.Equal(t, common.ChunkMethodWhole, explained[0].Method)
+	_, err = m.WithChunkTrace(true).Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+}
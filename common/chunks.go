@@ -2,10 +2,13 @@ package common
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/rivo/uniseg"
+	"github.com/rs/zerolog"
 )
 
 // Default splitter used by NewChunkifier
@@ -20,8 +23,23 @@ type SplitMethod struct {
 	Name    string   // Name for logging and debugging
 	SplitFn SplitFunc
 	Joiner  string
+
+	// Priority orders the methods added with RegisterSplitMethod: methods
+	// with a lower priority are tried first. The default methods have the
+	// priorities PrioritySpace, PrioritySentences and PrioritySplitter.
+	Priority int
 }
 
+// Priorities of the default split methods of NewChunkifier.
+const (
+	PrioritySpace     = 100
+	PrioritySentences = 200
+	PrioritySplitter  = 300
+)
+
+// MeasureFunc returns the length of a string as counted against MaxLength.
+type MeasureFunc func(string) int
+
 // Chunkifier is the main type that orchestrates chunk splitting so that the string
 // passed to the initial provider is guaranteed to be within this provider's input limits.
 type Chunkifier struct {
@@ -34,6 +52,23 @@ type Chunkifier struct {
 
 	// MaxLength is a default maximum chunk size.
 	MaxLength int
+
+	// PreserveSentences refuses to split inside a sentence: chunks are made of
+	// whole sentences, and only a sentence longer than MaxLength is split
+	// further with SplitMethods, which is called a forced split.
+	PreserveSentences bool
+
+	// OnForcedSplit, if set, is called with each sentence that PreserveSentences
+	// had to split because it exceeds MaxLength, and the parts it was split into.
+	OnForcedSplit func(sentence string, parts []string)
+
+	// Measure is how the length of strings is compared to MaxLength,
+	// e.g. in bytes for a provider limiting the size of its requests.
+	// nil counts runes.
+	Measure MeasureFunc
+
+	// Logger receives the trace of the chunking, nil logs to c.log().
+	Logger *zerolog.Logger
 }
 
 // NewChunkifier creates a chunkifier initialized with default fields:
@@ -45,9 +80,9 @@ func NewChunkifier(max int) *Chunkifier {
 	}
 	// Build a default set of split methods:
 	c.SplitMethods = []SplitMethod{
-		{Name: "SplitSpace", SplitFn: c.SplitSpace, Joiner: " "},
-		{Name: "SplitSentences", SplitFn: c.SplitSentences, Joiner: " "},
-		{Name: "SplitOnSplitter", SplitFn: c.SplitOnSplitter, Joiner: ""},
+		{Name: "SplitSpace", SplitFn: c.SplitSpace, Joiner: " ", Priority: PrioritySpace},
+		{Name: "SplitSentences", SplitFn: c.SplitSentences, Joiner: " ", Priority: PrioritySentences},
+		{Name: "SplitOnSplitter", SplitFn: c.SplitOnSplitter, Joiner: "", Priority: PrioritySplitter},
 		// too problematic with writing systems that don't use spaces =
 		// if there is no word delimitations found it will behave like splitGraphemes
 		//{Name: "SplitWords", SplitFn: c.SplitWords, Joiner: ""},
@@ -58,82 +93,211 @@ func NewChunkifier(max int) *Chunkifier {
 	return c
 }
 
+// RegisterSplitMethod inserts a split method among SplitMethods according to
+// its priority: after the methods whose priority is lower or equal and before
+// the others. For example, to try splitting on line breaks before anything else:
+//
+//	c := common.NewChunkifier(500)
+//	c.RegisterSplitMethod(common.SplitMethod{Name: "SplitLines", SplitFn: common.SplitLines, Joiner: ""}, 0)
+func (c *Chunkifier) RegisterSplitMethod(method SplitMethod, priority int) {
+	method.Priority = priority
+	i := len(c.SplitMethods)
+	for j, m := range c.SplitMethods {
+		if m.Priority > priority {
+			i = j
+			break
+		}
+	}
+	c.SplitMethods = slices.Insert(c.SplitMethods, i, method)
+}
+
+// measure returns the length of s according to c.Measure.
+func (c *Chunkifier) measure(s string) int {
+	if c.Measure != nil {
+		return c.Measure(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// log returns the logger of the chunkifier.
+func (c *Chunkifier) log() *zerolog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return &Log
+}
+
 // Chunkify takes the given string s and a max length. The function tries different 
 // approaches to split the text into chunks that are all within the maximum length.
 func (c *Chunkifier) Chunkify(s string) ([]string, error) {
-	Log.Trace().
+	chunks, _, err := c.split(s)
+	return chunks, err
+}
+
+// split is Chunkify, also returning the method that produced each chunk
+// (see Explain).
+func (c *Chunkifier) split(s string) (chunks, methods []string, err error) {
+	c.log().Trace().
 		Int("MaxLength", c.MaxLength).
-		Msgf("Chunkify: starting with input string of length %d", utf8.RuneCountInString(s))
+		Msgf("Chunkify: starting with input string of length %d", c.measure(s))
 	
 	// If a negative max was passed or if the entire string already fits
-	if c.MaxLength <= 0 || utf8.RuneCountInString(s) <= c.MaxLength {
-		Log.Trace().Msg("Chunkify: string fits within max length, returning original string")
-		return []string{s}, nil
+	if c.MaxLength <= 0 || c.measure(s) <= c.MaxLength {
+		c.log().Trace().Msg("Chunkify: string fits within max length, returning original string")
+		return []string{s}, []string{ChunkMethodWhole}, nil
+	}
+	if c.PreserveSentences {
+		return c.chunkifySentences(s)
+	}
+	chunks, method, err := c.chunkify(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	methods = make([]string, len(chunks))
+	for i := range methods {
+		methods[i] = method
 	}
+	return chunks, methods, nil
+}
 
+// chunkify splits s, which exceeds the max length, with the split methods.
+// It returns the chunks and the method that produced them.
+func (c *Chunkifier) chunkify(s string) ([]string, string, error) {
 	// First try the standard method-by-method approach
 	for _, method := range c.SplitMethods {
-		Log.Trace().Msgf("Chunkify: trying split method %s with joiner %q", method.Name, method.Joiner)
+		c.log().Trace().Msgf("Chunkify: trying split method %s with joiner %q", method.Name, method.Joiner)
 		chunks, success, err := c.tryStandardSplit(s, method)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		if success {
-			return chunks, nil
+			return chunks, method.Name, nil
 		}
 	}
 	
 	// If standard splitting fails, try the recursive approach
-	Log.Trace().Msg("Chunkify: standard splitting failed, attempting recursive approach")
+	c.log().Trace().Msg("Chunkify: standard splitting failed, attempting recursive approach")
 	chunks, err := c.tryRecursiveSplit(s)
+	if err == nil {
+		return chunks, ChunkMethodRecursive, nil
+	}
+	// Try a more aggressive hybrid approach as a last resort
+	c.log().Trace().Msg("Chunkify: recursive splitting failed, attempting hybrid approach")
+	chunks, err = c.tryHybridSplit(s)
 	if err != nil {
-		// Try a more aggressive hybrid approach as a last resort
-		Log.Trace().Msg("Chunkify: recursive splitting failed, attempting hybrid approach")
-		chunks, err = c.tryHybridSplit(s)
+		errMsg := fmt.Sprintf("could not decompose string into smaller parts: %q", s)
+		c.log().Trace().Msg(errMsg)
+		return nil, "", fmt.Errorf(errMsg)
+	}
+	return chunks, ChunkMethodHybrid, nil
+}
+
+// chunkifySentences splits s into sentences and combines them into chunks,
+// only splitting the sentences that exceed the max length on their own.
+// The chunks containing a part of such a sentence are reported with the
+// method of the forced split.
+func (c *Chunkifier) chunkifySentences(s string) ([]string, []string, error) {
+	// joiners[i] is put between units[i] and units[i+1] when they are combined:
+	// sentences keep their trailing whitespace, so they are joined as they
+	// are, while the parts of a sentence split inside are joined with the
+	// joiner of the method that split them
+	var units, unitMethods, joiners []string
+	for _, sentence := range c.SplitSentences(s) {
+		if c.measure(sentence) <= c.MaxLength {
+			units = append(units, sentence)
+			unitMethods = append(unitMethods, "")
+			joiners = append(joiners, "")
+			continue
+		}
+		parts, method, err := c.chunkify(sentence)
 		if err != nil {
-			errMsg := fmt.Sprintf("could not decompose string into smaller parts: %q", s)
-			Log.Trace().Msg(errMsg)
-			return nil, fmt.Errorf(errMsg)
+			return nil, nil, fmt.Errorf("forced split of sentence failed: %w", err)
+		}
+		joiner := c.joinerOf(method)
+		trailing := sentence[len(strings.TrimRightFunc(sentence, unicode.IsSpace)):]
+		for i, part := range parts {
+			unitMethods = append(unitMethods, method)
+			// the last part is followed by the next sentence, unless the
+			// split dropped the whitespace between them
+			if i == len(parts)-1 && strings.HasSuffix(part, trailing) {
+				joiners = append(joiners, "")
+			} else {
+				joiners = append(joiners, joiner)
+			}
+		}
+		c.log().Debug().
+			Int("MaxLength", c.MaxLength).
+			Int("parts", len(parts)).
+			Msgf("Chunkify: sentence of length %d exceeds max length, split inside the sentence", c.measure(sentence))
+		if c.OnForcedSplit != nil {
+			c.OnForcedSplit(sentence, parts)
+		}
+		units = append(units, parts...)
+	}
+	chunks := c.combineJoined(units, joiners)
+	if chunks == nil {
+		return nil, nil, fmt.Errorf("failed to combine sentences within max length")
+	}
+	// chunks are concatenations of consecutive units
+	methods := make([]string, len(chunks))
+	u := 0
+	for i, chunk := range chunks {
+		methods[i] = "SplitSentences"
+		for n := 0; n < len(chunk) && u < len(units); u++ {
+			n += len(units[u]) + len(joiners[u])
+			if unitMethods[u] != "" {
+				methods[i] = "SplitSentences+" + unitMethods[u]
+			}
 		}
 	}
-	
-	return chunks, nil
+	return chunks, methods, nil
+}
+
+// joinerOf returns the joiner of the split method with the given name, or
+// "" for the recursive and hybrid splits, which combine several methods.
+func (c *Chunkifier) joinerOf(method string) string {
+	for _, m := range c.SplitMethods {
+		if m.Name == method {
+			return m.Joiner
+		}
+	}
+	return ""
 }
 
 // tryStandardSplit attempts to split the string using a single method
 // and checks if all tokens are within the length limit
 func (c *Chunkifier) tryStandardSplit(s string, method SplitMethod) ([]string, bool, error) {
 	tokens := method.SplitFn(s)
-	Log.Trace().Msgf("Chunkify: obtained %d tokens", len(tokens))
+	c.log().Trace().Msgf("Chunkify: obtained %d tokens", len(tokens))
 	
 	// Check if any tokens are too large
 	allWithinLimit := true
 	for _, token := range tokens {
-		if count := utf8.RuneCountInString(token); count > c.MaxLength {
-			Log.Trace().Msgf("Chunkify: oversized token (len=%d): %s", count, token)
+		if count := c.measure(token); count > c.MaxLength {
+			c.log().Trace().Msgf("Chunkify: oversized token (len=%d): %s", count, token)
 			allWithinLimit = false
 		}
 	}
 	
 	if !allWithinLimit {
-		Log.Trace().Msg("Chunkify: tokens exceed max length, skipping this split method")
+		c.log().Trace().Msg("Chunkify: tokens exceed max length, skipping this split method")
 		return nil, false, nil
 	}
 	
 	// All tokens are within limit, combine them
-	combined := combineTokens(tokens, method.Joiner, c.MaxLength)
+	combined := c.combineTokens(tokens, method.Joiner)
 	if combined == nil {
 		return nil, false, nil
 	}
 	
-	Log.Trace().Msgf("Chunkify: successfully combined tokens into %d chunks", len(combined))
+	c.log().Trace().Msgf("Chunkify: successfully combined tokens into %d chunks", len(combined))
 	return combined, true, nil
 }
 
 // tryRecursiveSplit attempts to split the string recursively
 // by applying different methods to problematic tokens
 func (c *Chunkifier) tryRecursiveSplit(s string) ([]string, error) {
-	Log.Trace().Msg("Chunkify: attempting recursive splitting")
+	c.log().Trace().Msg("Chunkify: attempting recursive splitting")
 	
 	// Try the first method
 	if len(c.SplitMethods) == 0 {
@@ -152,13 +316,13 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 	}
 	
 	method := c.SplitMethods[methodIndex]
-	Log.Trace().Msgf("Chunkify: recursive split using method %d (%s) with joiner %q", 
+	c.log().Trace().Msgf("Chunkify: recursive split using method %d (%s) with joiner %q", 
 		methodIndex, method.Name, method.Joiner)
 	
 	tokens := method.SplitFn(s)
 	if len(tokens) <= 1 {
 		// This method didn't help split the string, try the next one
-		Log.Trace().Msgf("Chunkify: method %s produced only %d tokens, trying next method", 
+		c.log().Trace().Msgf("Chunkify: method %s produced only %d tokens, trying next method", 
 			method.Name, len(tokens))
 		return c.splitRecursively(s, methodIndex+1)
 	}
@@ -166,13 +330,13 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 	// Process each token: either keep it if small enough, or recursively split it
 	var processedTokens []string
 	for i, token := range tokens {
-		tokenLen := utf8.RuneCountInString(token)
+		tokenLen := c.measure(token)
 		if tokenLen <= c.MaxLength {
 			// Token is small enough, keep it
 			processedTokens = append(processedTokens, token)
 		} else {
 			// Token is too large, try to split it with the next method
-			Log.Trace().Msgf("Chunkify: token %d from method %s is too large (len=%d), splitting recursively", 
+			c.log().Trace().Msgf("Chunkify: token %d from method %s is too large (len=%d), splitting recursively", 
 				i, method.Name, tokenLen)
 			
 			var subTokens []string
@@ -185,7 +349,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 					continue
 				}
 				
-				Log.Trace().Msgf("Chunkify: trying alternative method %s on oversized token", 
+				c.log().Trace().Msgf("Chunkify: trying alternative method %s on oversized token", 
 					c.SplitMethods[nextMethodIndex].Name)
 					
 				tempTokens := c.SplitMethods[nextMethodIndex].SplitFn(token)
@@ -193,7 +357,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 					// This method helped split the token
 					allSmall := true
 					for _, t := range tempTokens {
-						if utf8.RuneCountInString(t) > c.MaxLength {
+						if c.measure(t) > c.MaxLength {
 							allSmall = false
 							break
 						}
@@ -202,7 +366,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 					if allSmall {
 						// All sub-tokens are within limit
 						subTokens = tempTokens
-				        Log.Trace().Strs("subTokens", subTokens).Msgf("Chunkify: after using alternative method %s on oversized token: SUCCESS: allSmall is true", 
+				        c.log().Trace().Strs("subTokens", subTokens).Msgf("Chunkify: after using alternative method %s on oversized token: SUCCESS: allSmall is true", 
 					    c.SplitMethods[nextMethodIndex].Name)
 						break
 					}
@@ -214,7 +378,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 				subTokens, err = c.splitRecursively(token, methodIndex+1)
 				if err != nil {
 					// If we can't split this token further, log the problem and propagate the error
-					Log.Trace().Msgf("Chunkify: failed to recursively split token: %s", err)
+					c.log().Trace().Msgf("Chunkify: failed to recursively split token: %s", err)
 					return nil, err
 				}
 			}
@@ -224,7 +388,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 	}
 	
 	// Combine processed tokens
-	combined := combineTokens(processedTokens, method.Joiner, c.MaxLength)
+	combined := c.combineTokens(processedTokens, method.Joiner)
 	if combined == nil {
 		return nil, fmt.Errorf("failed to combine processed tokens within max length")
 	}
@@ -235,7 +399,7 @@ func (c *Chunkifier) splitRecursively(s string, methodIndex int) ([]string, erro
 // tryHybridSplit attempts a hybrid approach that tries each method on
 // each problematic token independently
 func (c *Chunkifier) tryHybridSplit(s string) ([]string, error) {
-	Log.Trace().Msg("Chunkify: attempting hybrid splitting")
+	c.log().Trace().Msg("Chunkify: attempting hybrid splitting")
 	
 	// Start with the whole string as a single token
 	tokens := []string{s}
@@ -254,7 +418,7 @@ func (c *Chunkifier) tryHybridSplit(s string) ([]string, error) {
 		var hasLargeTokens bool
 		
 		for _, token := range tokens {
-			if utf8.RuneCountInString(token) <= c.MaxLength {
+			if c.measure(token) <= c.MaxLength {
 				// This token is fine, keep it
 				newTokens = append(newTokens, token)
 			} else {
@@ -270,7 +434,7 @@ func (c *Chunkifier) tryHybridSplit(s string) ([]string, error) {
 						// Check if any of the resulting tokens are now small enough
 						smallerTokensFound := false
 						for _, st := range splitTokens {
-							if utf8.RuneCountInString(st) < utf8.RuneCountInString(token) {
+							if c.measure(st) < c.measure(token) {
 								smallerTokensFound = true
 								break
 							}
@@ -305,9 +469,9 @@ func (c *Chunkifier) tryHybridSplit(s string) ([]string, error) {
 	// Final check: are all tokens within limit?
 	hasLargeTokens := false
 	for _, token := range tokens {
-		if utf8.RuneCountInString(token) > c.MaxLength {
-			Log.Trace().Msgf("Chunkify: still have oversized token after hybrid split (len=%d): %s", 
-				utf8.RuneCountInString(token), token)
+		if c.measure(token) > c.MaxLength {
+			c.log().Trace().Msgf("Chunkify: still have oversized token after hybrid split (len=%d): %s", 
+				c.measure(token), token)
 			hasLargeTokens = true
 		}
 	}
@@ -318,7 +482,7 @@ func (c *Chunkifier) tryHybridSplit(s string) ([]string, error) {
 	
 	// If we get here, all tokens are within limit
 	// Combine them optimally
-	result := combineTokens(tokens, "", c.MaxLength)
+	result := c.combineTokens(tokens, "")
 	if result == nil {
 		return nil, fmt.Errorf("failed to combine tokens after hybrid splitting")
 	}
@@ -342,7 +506,18 @@ func tokensAreWithinLimit(tokens []string, max int) bool {
 
 // combineTokens greedily merges tokens with the specified joiner
 // without exceeding the max length (if max > 0).
-func combineTokens(tokens []string, joiner string, max int) []string {
+func (c *Chunkifier) combineTokens(tokens []string, joiner string) []string {
+	joiners := make([]string, len(tokens))
+	for i := range joiners {
+		joiners[i] = joiner
+	}
+	return c.combineJoined(tokens, joiners)
+}
+
+// combineJoined is combineTokens with joiners[i] put between tokens[i] and
+// tokens[i+1].
+func (c *Chunkifier) combineJoined(tokens, joiners []string) []string {
+	max := c.MaxLength
 	var result []string
 	var current string
 
@@ -351,8 +526,8 @@ func combineTokens(tokens []string, joiner string, max int) []string {
 			current = token
 			continue
 		}
-		candidate := current + joiner + token
-		if max <= 0 || utf8.RuneCountInString(candidate) <= max {
+		candidate := current + joiners[i-1] + token
+		if max <= 0 || c.measure(candidate) <= max {
 			current = candidate
 		} else {
 			result = append(result, current)
@@ -368,7 +543,7 @@ func combineTokens(tokens []string, joiner string, max int) []string {
 	// Verify the final result doesn't exceed max in any chunk
 	if max > 0 {
 		for _, chunk := range result {
-			if utf8.RuneCountInString(chunk) > max {
+			if c.measure(chunk) > max {
 				return nil
 			}
 		}
@@ -401,6 +576,20 @@ func (c *Chunkifier) SplitSpace(str string) []string {
 	return tokens
 }
 
+// SplitLines splits the text after each line break, which stays at the end of
+// its line. It isn't one of the default methods; register it with
+// RegisterSplitMethod to keep lines (e.g. subtitles, verses) together.
+func SplitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
 // SplitSentences uses uniseg to split the text into sentences.
 func (c *Chunkifier) SplitSentences(text string) (splitted []string) {
 	if len(text) == 0 {
@@ -0,0 +1,60 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestPreserveSentences(t *testing.T) {
+	input := "สวัสดี. ผมชอบ กินข้าว มาก. ผมชอบกินข้าวมาก ผมชอบกินข้าว."
+	c := common.NewChunkifier(20)
+	chunks, err := c.Chunkify(input)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(chunks[0], "สวัสดี.") && strings.Contains(chunks[0], "ผมชอบ"), "the default chunkifier packs words across sentences")
+
+	var forced []string
+	c.PreserveSentences = true
+	c.OnForcedSplit = func(sentence string, parts []string) {
+		forced = append(forced, sentence)
+	}
+	chunks, err = c.Chunkify(input)
+	require.NoError(t, err)
+	assert.Equal(t, "สวัสดี. ", chunks[0])
+	assert.Equal(t, "ผมชอบ กินข้าว มาก. ", chunks[1])
+	assert.Equal(t, []string{"ผมชอบกินข้าวมาก ผมชอบกินข้าว."}, forced)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 20)
+	}
+
+	// the parts of a split sentence are joined with the joiner of the split
+	// method, which restores the space dropped before the next sentence
+	c = common.NewChunkifier(13)
+	c.PreserveSentences = true
+	c.RegisterSplitMethod(common.SplitMethod{Name: "SplitFields", SplitFn: strings.Fields, Joiner: " "}, 0)
+	chunks, err = c.Chunkify("Aaa bbb ccc ddd eee. Fff.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Aaa bbb ccc", "ddd eee. Fff."}, chunks)
+}
+
+func TestRegisterSplitMethod(t *testing.T) {
+	c := common.NewChunkifier(10)
+	c.RegisterSplitMethod(common.SplitMethod{Name: "SplitLines", SplitFn: common.SplitLines}, 0)
+	assert.Equal(t, "SplitLines", c.SplitMethods[0].Name)
+	chunks, err := c.Chunkify("aaa bbb\nccc ddd\neee")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aaa bbb\n", "ccc ddd\n", "eee"}, chunks)
+
+	c = common.NewChunkifier(40)
+	c.Measure = func(s string) int { return len(s) }
+	chunks, err = c.Chunkify("สวัสดีครับ ผมชอบกินข้าว มาก")
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 1)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 40)
+	}
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemeComparison is the romanization of the same input in several schemes,
+// see CompareSchemes.
+type SchemeComparison struct {
+	Schemes  []string          // The schemes compared, in the order they were given
+	Segments []ComparedSegment // The segments of the input, in order
+	rule     SpacingRule
+}
+
+// ComparedSegment is a segment of the input of CompareSchemes along with its
+// romanization in each scheme. As the schemes may tokenize the input
+// differently, a segment is the smallest span of input made of whole tokens
+// in every scheme: one word usually, or several words where the tokenizers
+// disagree on their boundaries.
+type ComparedSegment struct {
+	Surface       string            // The text of the segment in the normalized input
+	Start, End    int               // Byte offsets of the segment in the normalized input
+	Romanizations map[string]string // The romanization of the segment by scheme
+}
+
+// Differs reports whether the schemes don't all romanize the segment the same way.
+func (s ComparedSegment) Differs() bool {
+	first, seen := "", false
+	for _, roman := range s.Romanizations {
+		if seen && roman != first {
+			return true
+		}
+		first, seen = roman, true
+	}
+	return false
+}
+
+// Roman returns the whole input romanized in the given scheme, following
+// the spacing rule of the language.
+func (c SchemeComparison) Roman(scheme string) string {
+	rule := c.rule
+	if rule == nil {
+		rule = DefaultSpacingRule
+	}
+	var builder strings.Builder
+	var prev string
+	for i, segment := range c.Segments {
+		roman := segment.Romanizations[scheme]
+		if i > 0 && rule(prev, roman) {
+			builder.WriteRune(' ')
+		}
+		builder.WriteString(roman)
+		prev = roman
+	}
+	return builder.String()
+}
+
+// CompareSchemes romanizes the input in each of the given schemes of a
+// language (see GetSchemes) and aligns the romanizations segment by segment,
+// e.g. to document the schemes of a language or to help choosing one:
+//
+//	cmp, err := common.CompareSchemes("tha", text, "paiboon-offline", "royin")
+//	for _, segment := range cmp.Segments {
+//		fmt.Println(segment.Surface, segment.Romanizations["paiboon-offline"], segment.Romanizations["royin"])
+//	}
+//
+// The scheme modules are initialized and closed one after the other, since
+// schemes sharing a provider configure it differently.
+//
+// Parameters:
+//   - languageCode: The language of the schemes
+//   - input: The text to be romanized
+//   - schemes: The names of the schemes to compare
+//
+// Returns:
+//   - *SchemeComparison: The aligned romanizations
+//   - error: An error if a scheme doesn't exist or fails to romanize the input
+func CompareSchemes(languageCode, input string, schemes ...string) (*SchemeComparison, error) {
+	return CompareSchemesWithContext(context.Background(), languageCode, input, schemes...)
+}
+
+// CompareSchemesWithContext is CompareSchemes with a context for cancellation
+// and timeout control.
+func CompareSchemesWithContext(ctx context.Context, languageCode, input string, schemes ...string) (*SchemeComparison, error) {
+	if len(schemes) == 0 {
+		return nil, fmt.Errorf("no scheme to compare")
+	}
+	var normalized string
+	var rule SpacingRule
+	tokens := make([][]*Tkn, len(schemes))
+	for i, scheme := range schemes {
+		m, err := GetSchemeModule(languageCode, scheme)
+		if err != nil {
+			return nil, err
+		}
+		if !m.hasTransliterator() {
+			return nil, fmt.Errorf("scheme %s: %w", scheme, ErrRomanizationUnsupported)
+		}
+		if i == 0 {
+			normalized = m.getNormalization().Apply(input)
+			rule = m.getSpacingRule()
+		}
+		tokens[i], err = schemeTokens(ctx, m, input)
+		if err != nil {
+			return nil, fmt.Errorf("scheme %s: %w", scheme, err)
+		}
+	}
+
+	comparison := &SchemeComparison{Schemes: schemes, rule: rule}
+	bounds := commonBoundaries(tokens, len(normalized))
+	next := make([]int, len(schemes)) // index of the first token of each scheme not yet compared
+	for i := 1; i < len(bounds); i++ {
+		start, end := bounds[i-1], bounds[i]
+		segment := ComparedSegment{
+			Surface:       normalized[start:end],
+			Start:         start,
+			End:           end,
+			Romanizations: make(map[string]string, len(schemes)),
+		}
+		hasTokens := false
+		for s, scheme := range schemes {
+			var parts []AnyToken
+			for ; next[s] < len(tokens[s]) && tokens[s][next[s]].Position.End <= end; next[s]++ {
+				parts = append(parts, tokens[s][next[s]])
+			}
+			hasTokens = hasTokens || len(parts) > 0
+			segment.Romanizations[scheme] = joinWithSpacingRule(parts, rule, romanOrSurface)
+		}
+		if hasTokens && strings.TrimSpace(segment.Surface) != "" {
+			comparison.Segments = append(comparison.Segments, segment)
+		}
+	}
+	return comparison, nil
+}
+
+// schemeTokens returns the tokens of the input processed by the module, which
+// is initialized and closed along the way.
+func schemeTokens(ctx context.Context, m *Module, input string) ([]*Tkn, error) {
+	if err := m.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+	defer m.CloseWithContext(ctx)
+	tsw, err := m.TokensWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []*Tkn
+	for _, token := range tsw.Iter() {
+		if tkn := BaseToken(token); tkn != nil {
+			tokens = append(tokens, tkn)
+		}
+	}
+	return tokens, nil
+}
+
+// commonBoundaries returns the offsets, from 0 to end, that are a token
+// boundary in every scheme: no token of any scheme spans across them.
+func commonBoundaries(tokens [][]*Tkn, end int) []int {
+	crossed := make(map[int]bool)
+	candidates := map[int]bool{0: true, end: true}
+	for _, scheme := range tokens {
+		for _, tkn := range scheme {
+			candidates[tkn.Position.Start] = true
+			candidates[tkn.Position.End] = true
+			for offset := tkn.Position.Start + 1; offset < tkn.Position.End; offset++ {
+				crossed[offset] = true
+			}
+		}
+	}
+	var bounds []int
+	for offset := range candidates {
+		if !crossed[offset] && offset <= end {
+			bounds = append(bounds, offset)
+		}
+	}
+	sort.Ints(bounds)
+	return bounds
+}
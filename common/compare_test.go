@@ -0,0 +1,57 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// namedBracketTransliterator is a bracketTransliterator registered under its own name
+type namedBracketTransliterator struct {
+	bracketTransliterator
+}
+
+func (p *namedBracketTransliterator) Name() string {
+	return "brackets"
+}
+
+func TestCompareSchemes(t *testing.T) {
+	// a language of its own, so that the registry of Thai stays untouched
+	const lang = "nod"
+	common.UnregisterLanguage(t, lang)
+	for _, entry := range []common.ProviderEntry{
+		{Provider: tha.NewDictTokenizerProvider(), Capabilities: []common.Capability{common.CapabilityTokenization}},
+		{Provider: tha.NewRulesOnlyPaiboonizerProvider(), Capabilities: []common.Capability{common.CapabilityTransliteration}},
+		{Provider: &namedBracketTransliterator{bracketTransliterator{*tha.NewRulesOnlyPaiboonizerProvider()}}, Capabilities: []common.Capability{common.CapabilityTransliteration}},
+	} {
+		require.NoError(t, common.Register(lang, entry))
+	}
+	require.NoError(t, common.RegisterScheme(lang, common.TranslitScheme{Name: "paiboon", Providers: []string{"thai-dict", "paiboonizer"}}))
+	require.NoError(t, common.RegisterScheme(lang, common.TranslitScheme{Name: "brackets", Providers: []string{"thai-dict", "brackets"}}))
+
+	cmp, err := common.CompareSchemes(lang, "ผมชอบกินข้าว ครับ", "brackets", "paiboon")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brackets", "paiboon"}, cmp.Schemes)
+	surfaces := make([]string, len(cmp.Segments))
+	for i, segment := range cmp.Segments {
+		surfaces[i] = segment.Surface
+		assert.Equal(t, "["+segment.Surface+"]", segment.Romanizations["brackets"])
+		assert.True(t, segment.Differs())
+	}
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว", "ครับ"}, surfaces)
+	assert.Equal(t, "[ผม][ชอบ][กินข้าว][ครับ]", cmp.Roman("brackets"))
+	m, err := common.GetSchemeModule(lang, "paiboon")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+	parts, err := m.RomanParts("ผมชอบกินข้าว ครับ")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(parts, " "), cmp.Roman("paiboon"))
+
+	_, err = common.CompareSchemes(lang, "ผม", "paiboon", "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+)
+
+// Tkn.Confidence is the confidence of the provider that produced the token in
+// its segmentation and analysis, on a scale from 0 to 1:
+//
+//   - 1 means the provider has no doubt, e.g. a run of digits
+//   - values around 0.9 are typical of words found in the provider's dictionary
+//   - values of 0.5 and below flag guesses: words found by a statistical model
+//     (jieba's HMM), unknown words, or clusters left over by the segmenter
+//   - 0 means the provider doesn't estimate confidence
+//
+// Providers reporting scores on another scale convert them with ScoreConfidence.
+const (
+	ConfidenceUnknown    = 0.0 // the provider doesn't estimate confidence
+	ConfidenceDictionary = 0.9 // the token was found in the provider's dictionary
+	ConfidenceGuessed    = 0.4 // the token is a guess of the segmenter
+)
+
+// ScoreConfidence converts a score without upper bound, such as the scores
+// ichiran gives its segmentations, to a confidence between 0 and 1.
+//
+// Parameters:
+//   - score: The score to convert, 0 or less for no confidence
+//   - half: The score mapped to a confidence of 0.5
+//
+// Returns:
+//   - float64: The confidence, which tends to 1 as the score grows
+func ScoreConfidence(score, half float64) float64 {
+	if score <= 0 || half <= 0 {
+		return 0
+	}
+	return score / (score + half)
+}
+
+// LowConfidenceTokensWithContext processes the input and returns its lexical
+// tokens whose confidence is below the threshold, e.g. to point the user to
+// dubious segmentations. Tokens whose provider doesn't estimate confidence are
+// never returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be processed
+//   - threshold: The confidence below which a token is returned
+//
+// Returns:
+//   - []AnyToken: The tokens with low confidence, in order
+//   - error: An error if processing fails or the context is canceled
+func (m *Module) LowConfidenceTokensWithContext(ctx context.Context, input string, threshold float64) ([]AnyToken, error) {
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	var low []AnyToken
+	for i := 0; i < tkns.Len(); i++ {
+		token := tkns.GetIdx(i)
+		tkn := BaseToken(token)
+		if tkn == nil || !tkn.IsLexical || tkn.Confidence == ConfidenceUnknown {
+			continue
+		}
+		if tkn.Confidence < threshold {
+			low = append(low, token)
+		}
+	}
+	return low, err
+}
+
+// LowConfidenceTokens returns the lexical tokens of the input whose confidence
+// is below the threshold using a background context.
+// See LowConfidenceTokensWithContext.
+//
+// Parameters:
+//   - input: The text to be processed
+//   - threshold: The confidence below which a token is returned
+//
+// Returns:
+//   - []AnyToken: The tokens with low confidence, in order
+//   - error: An error if processing fails
+func (m *Module) LowConfidenceTokens(input string, threshold float64) ([]AnyToken, error) {
+	return m.LowConfidenceTokensWithContext(context.Background(), input, threshold)
+}
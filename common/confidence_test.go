@@ -0,0 +1,23 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestLowConfidenceTokens(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	low, err := m.LowConfidenceTokens("ผมชอบกินข้าวฎฏฐ 2024", 0.5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, "ฎฏฐ", low[0].GetSurface())
+	assert.Equal(t, common.ConfidenceGuessed, common.BaseToken(low[0]).Confidence)
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrScriptConversionUnsupported is returned by Convert when no registered
+// converter handles the requested pair of scripts. It wraps errors.ErrUnsupported.
+var ErrScriptConversionUnsupported = fmt.Errorf("no script converter for this pair of scripts: %w", errors.ErrUnsupported)
+
+// ScriptConverter converts text from a script to another, independently of
+// the language (Devanagari to Tamil, Serbian Cyrillic to Serbian Latin...).
+// The names of the scripts are the ones of the converter: the aksharamukha
+// converter registered by the mul package uses the aksharamukha script names
+// for instance.
+type ScriptConverter interface {
+	// Name returns the name of the converter, e.g. "aksharamukha"
+	Name() string
+	// Supports reports whether the converter handles the pair of scripts
+	Supports(fromScript, toScript string) bool
+	// ConvertScript converts text written in fromScript to toScript. The
+	// converter sets up the resources it needs on the first call.
+	ConvertScript(ctx context.Context, text, fromScript, toScript string) (string, error)
+}
+
+var scriptConverters = struct {
+	sync.RWMutex
+	list []ScriptConverter
+}{}
+
+// RegisterScriptConverter adds a converter used by Convert. The converters
+// are tried in the order they were registered, a converter replacing the one
+// of the same name in place.
+func RegisterScriptConverter(converter ScriptConverter) {
+	scriptConverters.Lock()
+	defer scriptConverters.Unlock()
+	for i, c := range scriptConverters.list {
+		if c.Name() == converter.Name() {
+			scriptConverters.list[i] = converter
+			return
+		}
+	}
+	scriptConverters.list = append(scriptConverters.list, converter)
+}
+
+// scriptConverterFor returns the first registered converter handling the pair of scripts.
+func scriptConverterFor(fromScript, toScript string) (ScriptConverter, bool) {
+	scriptConverters.RLock()
+	defer scriptConverters.RUnlock()
+	for _, c := range scriptConverters.list {
+		if c.Supports(fromScript, toScript) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Convert converts text written in a script to another one with the first
+// registered converter handling the pair, without any module involved:
+//
+//	tamil, err := common.Convert("नमस्ते", "Devanagari", "Tamil")
+//	latin, err := common.Convert("Београд", "SerbianCyrillic", "SerbianLatin")
+//
+// See ConvertWithContext.
+func Convert(text, fromScript, toScript string) (string, error) {
+	return ConvertWithContext(context.Background(), text, fromScript, toScript)
+}
+
+// ConvertWithContext is Convert with a context for cancellation and timeout
+// control.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - text: The text to convert
+//   - fromScript: The script the text is written in
+//   - toScript: The script to convert the text to
+//
+// Returns:
+//   - string: The converted text
+//   - error: ErrScriptConversionUnsupported if no converter handles the scripts,
+//     or an error if the conversion fails
+func ConvertWithContext(ctx context.Context, text, fromScript, toScript string) (string, error) {
+	converter, ok := scriptConverterFor(fromScript, toScript)
+	if !ok {
+		return "", fmt.Errorf("%w: %s to %s", ErrScriptConversionUnsupported, fromScript, toScript)
+	}
+	if text == "" || fromScript == toScript {
+		return text, nil
+	}
+	converted, err := converter.ConvertScript(ctx, text, fromScript, toScript)
+	if err != nil {
+		return "", fmt.Errorf("%s: conversion from %s to %s failed: %w", converter.Name(), fromScript, toScript, err)
+	}
+	return converted, nil
+}
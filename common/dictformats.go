@@ -0,0 +1,294 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadCEDICT reads a dictionary in the CC-CEDICT format, one entry per line:
+//
+//	Traditional Simplified [pin1 yin1] /definition 1/definition 2/
+//
+// Both the traditional and simplified headwords are indexed. Each definition
+// becomes a Gloss whose Info holds the pinyin.
+func LoadCEDICT(r io.Reader) (*MemoryDictionary, error) {
+	d := NewMemoryDictionary("zho")
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		headwords, rest, ok := strings.Cut(line, " [")
+		if !ok {
+			return nil, fmt.Errorf("cedict: line %d: missing pinyin", lineNum)
+		}
+		pinyin, defs, ok := strings.Cut(rest, "] ")
+		if !ok {
+			return nil, fmt.Errorf("cedict: line %d: missing definitions", lineNum)
+		}
+		trad, simp, _ := strings.Cut(headwords, " ")
+
+		var glosses []Gloss
+		for _, def := range strings.Split(strings.Trim(defs, "/"), "/") {
+			if def = strings.TrimSpace(def); def != "" {
+				glosses = append(glosses, Gloss{Definition: def, Info: pinyin, Language: DefaultGlossLang})
+			}
+		}
+		d.Add(trad, glosses...)
+		if simp != "" && simp != trad {
+			d.Add(simp, glosses...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cedict: %w", err)
+	}
+	return d, nil
+}
+
+// jmdictEntry is the subset of a JMdict <entry> used for glosses
+type jmdictEntry struct {
+	Kanji    []string `xml:"k_ele>keb"`
+	Readings []string `xml:"r_ele>reb"`
+	Senses   []struct {
+		POS    []string `xml:"pos"`
+		Misc   []string `xml:"misc"`
+		Glosses []struct {
+			Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+// LoadJMdict reads a dictionary in the JMdict XML format. Kanji and kana
+// headwords are indexed. Each sense becomes a Gloss whose Definition joins
+// its glosses in glossLang (ISO 639-2/B as used by JMdict, e.g. "eng", "fre",
+// "ger"; empty for English, "*" for all the languages, one Gloss per
+// language), whose PartOfSpeech holds its part-of-speech entities and Info
+// its misc entities. The Language of the glosses is set in ISO 639-3.
+func LoadJMdict(r io.Reader, glossLang string) (*MemoryDictionary, error) {
+	if glossLang == "" {
+		glossLang = "eng"
+	}
+	d := NewMemoryDictionary("jpn")
+	decoder := xml.NewDecoder(r)
+	// JMdict declares its entities (&n;, &v5k;...) in its DTD, which encoding/xml
+	// doesn't read: in non-strict mode they are kept as is and trimmed below.
+	decoder.Strict = false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jmdict: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "entry" {
+			continue
+		}
+		var entry jmdictEntry
+		if err := decoder.DecodeElement(&entry, &start); err != nil {
+			return nil, fmt.Errorf("jmdict: %w", err)
+		}
+
+		var glosses []Gloss
+		for _, sense := range entry.Senses {
+			// the glosses of the sense by language, in order of appearance
+			var langs []string
+			defs := make(map[string][]string)
+			for _, g := range sense.Glosses {
+				lang := g.Lang
+				if lang == "" {
+					lang = "eng"
+				}
+				if glossLang != "*" && lang != glossLang {
+					continue
+				}
+				if _, seen := defs[lang]; !seen {
+					langs = append(langs, lang)
+				}
+				defs[lang] = append(defs[lang], strings.TrimSpace(g.Text))
+			}
+			pos, misc := joinJMdictEntities(sense.POS), joinJMdictEntities(sense.Misc)
+			for _, lang := range langs {
+				language, _ := IsValidISO639(lang)
+				glosses = append(glosses, Gloss{
+					PartOfSpeech: pos,
+					Definition:   strings.Join(defs[lang], "; "),
+					Info:         misc,
+					Language:     language,
+				})
+			}
+		}
+		if len(glosses) == 0 {
+			continue
+		}
+		for _, headword := range append(entry.Kanji, entry.Readings...) {
+			d.Add(headword, glosses...)
+		}
+	}
+	return d, nil
+}
+
+func joinJMdictEntities(entities []string) string {
+	for i, e := range entities {
+		entities[i] = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(e), "&"), ";")
+	}
+	return strings.Join(entities, ", ")
+}
+
+// LoadStarDict reads a dictionary in the StarDict format from its .ifo file;
+// the .idx and .dict (or dictzip-compressed .dict.dz) files must sit next to
+// it. lang is the language of the headwords. Each article becomes a Gloss
+// whose Definition holds its text (or markup, for HTML and Pango articles)
+// and Info the StarDict type of the article.
+func LoadStarDict(ifoPath, lang string) (*MemoryDictionary, error) {
+	ifo, err := readStarDictIfo(ifoPath)
+	if err != nil {
+		return nil, fmt.Errorf("stardict: %w", err)
+	}
+	base := strings.TrimSuffix(ifoPath, ".ifo")
+
+	idx, err := os.ReadFile(base + ".idx")
+	if err != nil {
+		return nil, fmt.Errorf("stardict: %w", err)
+	}
+	data, err := readStarDictData(base)
+	if err != nil {
+		return nil, fmt.Errorf("stardict: %w", err)
+	}
+
+	offsetSize := 4
+	if ifo["idxoffsetbits"] == "64" {
+		offsetSize = 8
+	}
+	sameTypes := ifo["sametypesequence"]
+
+	d := NewMemoryDictionary(lang)
+	for pos := 0; pos < len(idx); {
+		end := bytes.IndexByte(idx[pos:], 0)
+		if end < 0 || pos+end+1+offsetSize+4 > len(idx) {
+			return nil, fmt.Errorf("stardict: truncated index at byte %d", pos)
+		}
+		word := string(idx[pos : pos+end])
+		pos += end + 1
+		var offset uint64
+		if offsetSize == 8 {
+			offset = binary.BigEndian.Uint64(idx[pos:])
+		} else {
+			offset = uint64(binary.BigEndian.Uint32(idx[pos:]))
+		}
+		pos += offsetSize
+		size := uint64(binary.BigEndian.Uint32(idx[pos:]))
+		pos += 4
+		if offset+size > uint64(len(data)) {
+			return nil, fmt.Errorf("stardict: article of %q out of range", word)
+		}
+		d.Add(word, parseStarDictArticle(data[offset:offset+size], sameTypes)...)
+	}
+	if n, err := strconv.Atoi(ifo["wordcount"]); err == nil && n > 0 && d.Len() == 0 {
+		return nil, fmt.Errorf("stardict: no entries read, %d expected", n)
+	}
+	return d, nil
+}
+
+func readStarDictIfo(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ifo := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			ifo[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return ifo, scanner.Err()
+}
+
+// readStarDictData reads the .dict file, or decompresses the .dict.dz one.
+// dictzip files are regular gzip files, so they can be read as a whole.
+func readStarDictData(base string) ([]byte, error) {
+	if data, err := os.ReadFile(base + ".dict"); err == nil {
+		return data, nil
+	}
+	f, err := os.Open(base + ".dict.dz")
+	if err != nil {
+		return nil, fmt.Errorf("no .dict or .dict.dz file: %w", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
+
+// parseStarDictArticle splits an article into its fields. Without a
+// sametypesequence each field starts with its type; lowercase types are
+// NUL-terminated text, uppercase ones are binary data prefixed by their size.
+// Only text fields are kept.
+func parseStarDictArticle(article []byte, sameTypes string) []Gloss {
+	var glosses []Gloss
+	addText := func(t byte, text []byte) {
+		if s := strings.TrimSpace(string(text)); s != "" {
+			glosses = append(glosses, Gloss{Definition: s, Info: string(t)})
+		}
+	}
+	if sameTypes != "" {
+		// The type of each field is given by sametypesequence and the last
+		// field isn't terminated
+		for i := 0; i < len(sameTypes) && len(article) > 0; i++ {
+			t := sameTypes[i]
+			last := i == len(sameTypes)-1
+			if t >= 'a' && t <= 'z' {
+				end := len(article)
+				if !last {
+					if n := bytes.IndexByte(article, 0); n >= 0 {
+						end = n
+					}
+				}
+				addText(t, article[:end])
+				article = article[min(end+1, len(article)):]
+			} else if last {
+				break
+			} else if len(article) >= 4 {
+				size := int(binary.BigEndian.Uint32(article))
+				article = article[min(4+size, len(article)):]
+			}
+		}
+		return glosses
+	}
+	for len(article) > 0 {
+		t := article[0]
+		article = article[1:]
+		if t >= 'a' && t <= 'z' {
+			end := bytes.IndexByte(article, 0)
+			if end < 0 {
+				end = len(article)
+			}
+			addText(t, article[:end])
+			article = article[min(end+1, len(article)):]
+		} else {
+			if len(article) < 4 {
+				break
+			}
+			size := int(binary.BigEndian.Uint32(article))
+			article = article[min(4+size, len(article)):]
+		}
+	}
+	return glosses
+}
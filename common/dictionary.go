@@ -0,0 +1,246 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Dictionary looks up the definitions of words.
+// Implementations must be safe for concurrent use.
+type Dictionary interface {
+	// Lookup returns the glosses of lemma, a word in the given language
+	// (ISO 639-3), or nil if the dictionary doesn't have it.
+	Lookup(lemma, lang string) []Gloss
+}
+
+// DefaultGlossLang is the language of the glosses of the providers that only
+// have English definitions, such as ichiran and thai2english.com.
+const DefaultGlossLang = "eng"
+
+// ErrGlossLangUnsupported is returned by the providers configured with a
+// "target_gloss_lang" they don't have glosses in. It wraps errors.ErrUnsupported.
+var ErrGlossLangUnsupported = fmt.Errorf("glosses aren't available in this language: %w", errors.ErrUnsupported)
+
+// GlossLanguageReporter is implemented by the providers setting glosses, to
+// report the languages (ISO 639-3) their glosses can be requested in.
+type GlossLanguageReporter interface {
+	GlossLanguages() []string
+}
+
+// GlossLanguagesOf returns the languages the glosses of a provider can be
+// requested in, or nil if the provider doesn't report them.
+func GlossLanguagesOf(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) []string {
+	if r, ok := findWrapped[GlossLanguageReporter](provider); ok {
+		return r.GlossLanguages()
+	}
+	return nil
+}
+
+// TargetGlossLang reads the "target_gloss_lang" key of a provider config: the
+// language, in any ISO 639 format, the glosses should be written in. It
+// returns it as ISO 639-3, or the first of the available languages if the
+// config doesn't have the key.
+//
+// Returns ErrGlossLangUnsupported if the language isn't available.
+func TargetGlossLang(cfg map[string]interface{}, available ...string) (string, error) {
+	raw, ok := cfg["target_gloss_lang"]
+	if !ok || raw == "" {
+		if len(available) == 0 {
+			return "", nil
+		}
+		return available[0], nil
+	}
+	code, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid type %T for target_gloss_lang", raw)
+	}
+	lang, ok := IsValidISO639(code)
+	if !ok {
+		return "", fmt.Errorf(errNotISO639, code)
+	}
+	for _, a := range available {
+		if a == lang {
+			return lang, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (available: %s)", ErrGlossLangUnsupported, lang, strings.Join(available, ", "))
+}
+
+// MemoryDictionary is a Dictionary held in memory, as returned by the readers
+// of the dictionary formats (LoadCEDICT, LoadJMdict, LoadStarDict).
+type MemoryDictionary struct {
+	// Lang is the language of the headwords; lookups in other languages
+	// return nothing. Empty matches any language.
+	Lang    string
+	mu      sync.RWMutex
+	entries map[string][]Gloss
+}
+
+// NewMemoryDictionary creates an empty dictionary of headwords in the given language.
+func NewMemoryDictionary(lang string) *MemoryDictionary {
+	return &MemoryDictionary{
+		Lang:    lang,
+		entries: make(map[string][]Gloss),
+	}
+}
+
+// Add appends glosses to the entry of a headword.
+func (d *MemoryDictionary) Add(headword string, glosses ...Gloss) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries[headword] = append(d.entries[headword], glosses...)
+}
+
+// Lookup implements Dictionary.
+func (d *MemoryDictionary) Lookup(lemma, lang string) []Gloss {
+	if d.Lang != "" && lang != "" && d.Lang != lang {
+		return nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries[lemma]
+}
+
+// Len returns the number of headwords.
+func (d *MemoryDictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// GlossEnricher is an enricher provider that decorates lexical tokens with
+// the definitions found in user-supplied dictionaries. Tokens are looked up
+// by lemma, then by normalized form, then by surface; the glosses of all the
+// dictionaries that have the word are appended in order.
+// Tokens that already have glosses (e.g. from ichiran or thai2english) are
+// left untouched unless Overwrite is set. With a TargetGlossLang, only the
+// glosses in that language are kept, e.g. to take the French definitions of
+// a JMdict loaded with all its languages.
+//
+// Example usage:
+//
+//	cedict, err := common.LoadCEDICT(f)
+//	m.WithEnricher(common.NewGlossEnricher(cedict))
+type GlossEnricher struct {
+	Dictionaries []Dictionary
+	Overwrite    bool
+	// TargetGlossLang is the ISO 639-3 code of the language of the glosses to
+	// keep, empty to keep all of them. Glosses of unknown language are kept.
+	TargetGlossLang string
+
+	config map[string]interface{}
+}
+
+// NewGlossEnricher creates a gloss enricher looking words up in the given dictionaries.
+func NewGlossEnricher(dictionaries ...Dictionary) *GlossEnricher {
+	return &GlossEnricher{Dictionaries: dictionaries}
+}
+
+// Glosses returns the glosses of a token from all the dictionaries.
+// lang is used when the token doesn't specify its language.
+func (e *GlossEnricher) Glosses(tkn *Tkn, lang string) []Gloss {
+	if tkn.Language != "" {
+		lang = tkn.Language
+	}
+	for _, key := range []string{tkn.Lemma, tkn.Normalized, tkn.Surface} {
+		if key == "" {
+			continue
+		}
+		var glosses []Gloss
+		for _, dict := range e.Dictionaries {
+			for _, gloss := range dict.Lookup(key, lang) {
+				if e.TargetGlossLang == "" || gloss.Language == "" || gloss.Language == e.TargetGlossLang {
+					glosses = append(glosses, gloss)
+				}
+			}
+		}
+		if len(glosses) > 0 {
+			return glosses
+		}
+	}
+	return nil
+}
+
+// ProcessFlowController adds glosses to the lexical tokens of the input in place.
+func (e *GlossEnricher) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	if mode != EnricherMode {
+		return nil, fmt.Errorf("gloss-enricher only supports enricher mode, got %s", mode)
+	}
+	lang, _ := e.config["lang"].(string)
+	for i := 0; i < input.Len(); i++ {
+		if i%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("gloss-enricher: context canceled: %w", err)
+			}
+		}
+		tkn := BaseToken(input.GetIdx(i))
+		if tkn == nil || !tkn.IsLexical || (len(tkn.Glosses) > 0 && !e.Overwrite) {
+			continue
+		}
+		if glosses := e.Glosses(tkn, lang); len(glosses) > 0 {
+			tkn.Glosses = glosses
+		}
+	}
+	return input, nil
+}
+
+// SaveConfig stores the configuration. The "lang" key sets the language used
+// for tokens that don't specify theirs; Module.WithEnricher sets it.
+func (e *GlossEnricher) SaveConfig(cfg map[string]interface{}) error {
+	e.config = cfg
+	return nil
+}
+
+func (e *GlossEnricher) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (e *GlossEnricher) Init() error {
+	return e.InitWithContext(context.Background())
+}
+
+func (e *GlossEnricher) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return e.InitWithContext(ctx)
+}
+
+func (e *GlossEnricher) InitRecreate(noCache bool) error {
+	return e.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (e *GlossEnricher) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (e *GlossEnricher) Close() error {
+	return e.CloseWithContext(context.Background())
+}
+
+func (e *GlossEnricher) WithProgressCallback(callback ProgressCallback) {
+	// No-op: lookups are too fast to be worth reporting
+}
+
+// WithDownloadProgressCallback passes the callback to the dictionaries that
+// download their data, i.e. that implement DownloadProgressReporter.
+func (e *GlossEnricher) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	for _, dict := range e.Dictionaries {
+		if reporter, ok := dict.(DownloadProgressReporter); ok {
+			reporter.WithDownloadProgressCallback(callback)
+		}
+	}
+}
+
+func (e *GlossEnricher) Name() string {
+	return "gloss-enricher"
+}
+
+func (e *GlossEnricher) SupportedModes() []OperatingMode {
+	return []OperatingMode{EnricherMode}
+}
+
+func (e *GlossEnricher) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// downloadingDictionary is a dictionary reporting the download of its data.
+type downloadingDictionary struct {
+	callback common.DownloadProgressCallback
+}
+
+func (d *downloadingDictionary) Lookup(lemma, lang string) []common.Gloss {
+	return nil
+}
+
+func (d *downloadingDictionary) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	d.callback = callback
+}
+
+func TestDownloadProgressReporter(t *testing.T) {
+	dict := &downloadingDictionary{}
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WithEnricher(common.NewGlossEnricher(dict))
+
+	var names []string
+	m.WithDownloadProgressCallback(func(providerName string, current, total int64, status string) {
+		names = append(names, providerName)
+	})
+	require.NotNil(t, dict.callback, "the gloss enricher should pass the callback to its dictionaries")
+	dict.callback("", 10, 100, "Downloading...")
+	assert.Equal(t, []string{"gloss-enricher"}, names)
+}
@@ -0,0 +1,349 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/adrg/xdg"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	diskCacheEntries = []byte("entries")
+	diskCacheByTime  = []byte("by_time") // (time stored, key) → nothing, for eviction
+	diskCacheMeta    = []byte("meta")
+	diskCacheCount   = []byte("count") // number of entries, in the meta bucket
+)
+
+// DiskCacheOptions control the size of a DiskCache and how long its entries live.
+type DiskCacheOptions struct {
+	// Dir is the directory of the cache file. Defaults to DefaultDiskCacheDir.
+	Dir string
+	// TTL is how long an entry is kept after it was stored. Zero keeps the
+	// entries forever.
+	TTL time.Duration
+	// MaxEntries is the number of entries above which the oldest are evicted.
+	// Zero means no limit.
+	MaxEntries int
+}
+
+// DiskCache is a persistent store of the results of the providers that scrape
+// websites, so that what they scraped once doesn't need to be scraped again
+// on the next runs. The values are stored as JSON in a bbolt database, one
+// file per cache. It is safe for concurrent use.
+type DiskCache struct {
+	db   *bolt.DB
+	path string
+	opts DiskCacheOptions
+}
+
+type diskCacheEntry struct {
+	Stored int64           `json:"stored"` // Unix time in nanoseconds
+	Value  json.RawMessage `json:"value"`
+}
+
+var openDiskCaches = struct {
+	sync.Mutex
+	byPath map[string]*DiskCache
+}{byPath: make(map[string]*DiskCache)}
+
+// DefaultDiskCacheDir returns the directory of the disk caches, following the
+// XDG base directory specification:
+// - Linux: ~/.cache/langkit/translitkit/
+// - macOS: ~/Library/Caches/langkit/translitkit/
+// - Windows: %LOCALAPPDATA%\cache\langkit\translitkit\
+func DefaultDiskCacheDir() string {
+	return filepath.Join(xdg.CacheHome, "langkit", "translitkit")
+}
+
+// OpenDiskCache opens the disk cache of the given name (e.g. the name of the
+// provider), creating it if needed, and drops its expired and excess entries.
+// A cache already open in this process is returned as is, with the options
+// it was opened with.
+func OpenDiskCache(name string, opts DiskCacheOptions) (*DiskCache, error) {
+	if opts.Dir == "" {
+		opts.Dir = DefaultDiskCacheDir()
+	}
+	path := filepath.Join(opts.Dir, name+".db")
+
+	openDiskCaches.Lock()
+	defer openDiskCaches.Unlock()
+	if c, ok := openDiskCaches.byPath[path]; ok {
+		return c, nil
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// bbolt locks the file: fail instead of waiting forever for another
+	// process using the same cache
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disk cache %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{diskCacheEntries, diskCacheByTime, diskCacheMeta} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize disk cache %s: %w", path, err)
+	}
+
+	c := &DiskCache{db: db, path: path, opts: opts}
+	if err := c.Prune(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	openDiskCaches.byPath[path] = c
+	return c, nil
+}
+
+// DiskCacheFromConfig opens the disk cache of the given name following the
+// keys of a provider config: "disk_cache" (bool, true by default) enables the
+// cache, "disk_cache_dir" (string), "disk_cache_ttl" (time.Duration) and
+// "disk_cache_max_entries" (int) set its DiskCacheOptions. It returns nil if
+// the cache is disabled.
+func DiskCacheFromConfig(name string, cfg map[string]interface{}) (*DiskCache, error) {
+	var opts DiskCacheOptions
+	for key, raw := range cfg {
+		var ok bool
+		switch key {
+		case "disk_cache":
+			var enabled bool
+			if enabled, ok = raw.(bool); ok && !enabled {
+				return nil, nil
+			}
+		case "disk_cache_dir":
+			opts.Dir, ok = raw.(string)
+		case "disk_cache_ttl":
+			opts.TTL, ok = raw.(time.Duration)
+		case "disk_cache_max_entries":
+			opts.MaxEntries, ok = raw.(int)
+		default:
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid type %T for %s", raw, key)
+		}
+	}
+	return OpenDiskCache(name, opts)
+}
+
+// Path returns the path of the cache file.
+func (c *DiskCache) Path() string {
+	return c.path
+}
+
+// Get decodes the value stored under key into v. found is false if there is
+// no such entry or it expired.
+func (c *DiskCache) Get(key string, v any) (found bool, err error) {
+	err = c.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(diskCacheEntries).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		var entry diskCacheEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		if c.expired(entry) {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(entry.Value, v)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to read %q from disk cache: %w", key, err)
+	}
+	return found, nil
+}
+
+// Put stores v under key as JSON, evicting the oldest entries if the cache
+// exceeds MaxEntries.
+func (c *DiskCache) Put(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q for disk cache: %w", key, err)
+	}
+	now := time.Now().UnixNano()
+	raw, err := json.Marshal(diskCacheEntry{Stored: now, Value: value})
+	if err != nil {
+		return err
+	}
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		entries, byTime := tx.Bucket(diskCacheEntries), tx.Bucket(diskCacheByTime)
+		if existed, err := c.unindex(entries, byTime, []byte(key)); err != nil {
+			return err
+		} else if !existed {
+			if err := addCachedCount(tx, 1); err != nil {
+				return err
+			}
+		}
+		if err := entries.Put([]byte(key), raw); err != nil {
+			return err
+		}
+		if err := byTime.Put(timeKey(now, key), nil); err != nil {
+			return err
+		}
+		return c.evict(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %q to disk cache: %w", key, err)
+	}
+	return nil
+}
+
+// ForEach calls fn with the key and JSON value of every entry that hasn't
+// expired, in key order. It stops at the first error returned by fn.
+func (c *DiskCache) ForEach(fn func(key string, value json.RawMessage) error) error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(diskCacheEntries).ForEach(func(k, raw []byte) error {
+			var entry diskCacheEntry
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return fmt.Errorf("corrupted entry %q: %w", k, err)
+			}
+			if c.expired(entry) {
+				return nil
+			}
+			return fn(string(k), entry.Value)
+		})
+	})
+}
+
+// Len returns the number of entries, expired ones included until they are pruned.
+func (c *DiskCache) Len() int {
+	n := 0
+	c.db.View(func(tx *bolt.Tx) error {
+		n = cachedCount(tx)
+		return nil
+	})
+	return n
+}
+
+// Prune drops the expired entries and the oldest ones beyond MaxEntries.
+func (c *DiskCache) Prune() error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if c.opts.TTL > 0 {
+			limit := timeKey(time.Now().Add(-c.opts.TTL).UnixNano(), "")
+			cur := tx.Bucket(diskCacheByTime).Cursor()
+			for k, _ := cur.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = cur.First() {
+				if err := removeCached(tx, k); err != nil {
+					return err
+				}
+			}
+		}
+		return c.evict(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune disk cache %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// Clear drops all entries.
+func (c *DiskCache) Clear() error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{diskCacheEntries, diskCacheByTime, diskCacheMeta} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear disk cache %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// Close closes the cache file. The cache must not be used afterwards, a new
+// one can be opened with OpenDiskCache.
+func (c *DiskCache) Close() error {
+	openDiskCaches.Lock()
+	defer openDiskCaches.Unlock()
+	if openDiskCaches.byPath[c.path] == c {
+		delete(openDiskCaches.byPath, c.path)
+	}
+	return c.db.Close()
+}
+
+func (c *DiskCache) expired(entry diskCacheEntry) bool {
+	return c.opts.TTL > 0 && time.Since(time.Unix(0, entry.Stored)) > c.opts.TTL
+}
+
+// evict removes the oldest entries while there are more than MaxEntries.
+func (c *DiskCache) evict(tx *bolt.Tx) error {
+	if c.opts.MaxEntries <= 0 {
+		return nil
+	}
+	excess := cachedCount(tx) - c.opts.MaxEntries
+	cur := tx.Bucket(diskCacheByTime).Cursor()
+	for k, _ := cur.First(); k != nil && excess > 0; k, _ = cur.First() {
+		if err := removeCached(tx, k); err != nil {
+			return err
+		}
+		excess--
+	}
+	return nil
+}
+
+// removeCached deletes the entry of the given key of the time index.
+func removeCached(tx *bolt.Tx, indexKey []byte) error {
+	if err := tx.Bucket(diskCacheByTime).Delete(indexKey); err != nil {
+		return err
+	}
+	if err := tx.Bucket(diskCacheEntries).Delete(indexKey[8:]); err != nil {
+		return err
+	}
+	return addCachedCount(tx, -1)
+}
+
+// unindex removes the time index of the current entry of key and reports
+// whether there was one.
+func (c *DiskCache) unindex(entries, byTime *bolt.Bucket, key []byte) (bool, error) {
+	raw := entries.Get(key)
+	if raw == nil {
+		return false, nil
+	}
+	var entry diskCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return true, nil // overwritten anyway
+	}
+	return true, byTime.Delete(timeKey(entry.Stored, string(key)))
+}
+
+func cachedCount(tx *bolt.Tx) int {
+	raw := tx.Bucket(diskCacheMeta).Get(diskCacheCount)
+	if len(raw) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(raw))
+}
+
+func addCachedCount(tx *bolt.Tx, delta int) error {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, uint64(max(0, cachedCount(tx)+delta)))
+	return tx.Bucket(diskCacheMeta).Put(diskCacheCount, raw)
+}
+
+// timeKey is the key of the time index: the time the entry was stored, big
+// endian so that the index is sorted by age, followed by the key of the entry.
+func timeKey(stored int64, key string) []byte {
+	k := make([]byte, 8, 8+len(key))
+	binary.BigEndian.PutUint64(k, uint64(stored))
+	return append(k, key...)
+}
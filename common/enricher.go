@@ -0,0 +1,90 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// EnricherFunc decorates the tokens of a wrapper in place.
+type EnricherFunc func(ctx context.Context, tsw AnyTokenSliceWrapper) error
+
+// FuncEnricher is an enricher provider running a function on the tokens,
+// for rule-based post-processing that needs no initialization of its own.
+//
+// Example usage:
+//
+//	m.WithEnricher(common.NewFuncEnricher("lowercase", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+//		...
+//	}))
+type FuncEnricher struct {
+	name string
+	fn   EnricherFunc
+}
+
+// NewFuncEnricher creates an enricher provider named name that runs fn.
+func NewFuncEnricher(name string, fn EnricherFunc) *FuncEnricher {
+	return &FuncEnricher{name: name, fn: fn}
+}
+
+// ProcessFlowController runs the function on the input.
+func (e *FuncEnricher) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	if mode != EnricherMode {
+		return nil, fmt.Errorf("%s only supports enricher mode, got %s", e.name, mode)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s: context canceled: %w", e.name, err)
+	}
+	if err := e.fn(ctx, input); err != nil {
+		return nil, fmt.Errorf("%s: %w", e.name, err)
+	}
+	return input, nil
+}
+
+func (e *FuncEnricher) SaveConfig(cfg map[string]interface{}) error {
+	return nil
+}
+
+func (e *FuncEnricher) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (e *FuncEnricher) Init() error {
+	return e.InitWithContext(context.Background())
+}
+
+func (e *FuncEnricher) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return e.InitWithContext(ctx)
+}
+
+func (e *FuncEnricher) InitRecreate(noCache bool) error {
+	return e.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (e *FuncEnricher) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (e *FuncEnricher) Close() error {
+	return e.CloseWithContext(context.Background())
+}
+
+func (e *FuncEnricher) WithProgressCallback(callback ProgressCallback) {
+	// No-op: rule-based post-processing is too fast to be worth reporting
+}
+
+func (e *FuncEnricher) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	// No-op: nothing to download
+}
+
+func (e *FuncEnricher) Name() string {
+	return e.name
+}
+
+func (e *FuncEnricher) SupportedModes() []OperatingMode {
+	return []OperatingMode{EnricherMode}
+}
+
+func (e *FuncEnricher) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
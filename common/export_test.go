@@ -0,0 +1,19 @@
+package common
+
+import "testing"
+
+// UnregisterLanguage removes the providers and the schemes of a language
+// registered by a test once it completes, so that the languages made up by
+// the tests don't leak into the global registries.
+func UnregisterLanguage(t testing.TB, lang string) {
+	t.Cleanup(func() {
+		GlobalRegistry.mu.Lock()
+		delete(GlobalRegistry.Providers, lang)
+		GlobalRegistry.mu.Unlock()
+
+		GlobalSchemeRegistry.mu.Lock()
+		delete(GlobalSchemeRegistry.schemes, lang)
+		delete(GlobalSchemeRegistry.reverse, lang)
+		GlobalSchemeRegistry.mu.Unlock()
+	})
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// ErrForeignScript is returned by the module when a token is written in a
+// script other than the one(s) of its language and the policy is
+// ForeignScriptError, see WithForeignScriptPolicy.
+var ErrForeignScript = errors.New("token outside the script of the language")
+
+// MetadataForeignScript is the key of Tkn.Metadata holding the script of a
+// token written outside the script of its language (e.g. "Latin" for an
+// English word in Thai text) when the policy is ForeignScriptTag or
+// ForeignScriptSkip.
+const MetadataForeignScript = "foreign_script"
+
+// ForeignScriptPolicy is what a module does with the tokens written in a
+// script other than the one(s) of its language: latin words, numbers spelled
+// in another script, names in the middle of Thai or Japanese text...
+// Tokens without letters (digits, punctuation, spaces) are never foreign.
+type ForeignScriptPolicy int
+
+const (
+	// ForeignScriptPassThrough leaves the tokens as the providers output
+	// them: the transliterators usually keep their surface as is.
+	ForeignScriptPassThrough ForeignScriptPolicy = iota
+	// ForeignScriptSkip tags the tokens like ForeignScriptTag and leaves
+	// them out of the output of Roman(), RomanWithAlignment(), RomanParts()
+	// and RomanAll(). They are still returned by Tokens().
+	ForeignScriptSkip
+	// ForeignScriptTag sets the script of the tokens in their Metadata under
+	// MetadataForeignScript.
+	ForeignScriptTag
+	// ForeignScriptError makes the module fail with ErrForeignScript.
+	ForeignScriptError
+)
+
+// WithForeignScriptPolicy sets what this module does with the tokens written
+// in a script other than the one(s) of its language. The policy is ignored
+// for the languages that have no script registered (see
+// GetUnicodeRangesFromLang).
+//
+// Parameters:
+//   - policy: The policy to apply, ForeignScriptPassThrough restores the default behavior
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithForeignScriptPolicy(policy ForeignScriptPolicy) *Module {
+	m.foreignPolicy = policy
+	m.scriptRanges = nil
+	if policy != ForeignScriptPassThrough {
+		m.scriptRanges, _ = GetUnicodeRangesFromLang(m.Lang)
+	}
+	return m
+}
+
+// applyForeignScriptPolicy tags the foreign tokens of tsw or fails on the
+// first of them, depending on the policy of the module.
+func (m *Module) applyForeignScriptPolicy(tsw AnyTokenSliceWrapper) error {
+	if m.foreignPolicy == ForeignScriptPassThrough || len(m.scriptRanges) == 0 {
+		return nil
+	}
+	for _, token := range tsw.Iter() {
+		script, ok := m.foreignScript(token.GetSurface())
+		if !ok {
+			continue
+		}
+		if m.foreignPolicy == ForeignScriptError {
+			return fmt.Errorf("%w: %q is written in %s", ErrForeignScript, token.GetSurface(), script)
+		}
+		if tkn := BaseToken(token); tkn != nil {
+			if tkn.Metadata == nil {
+				tkn.Metadata = make(map[string]interface{})
+			}
+			tkn.Metadata[MetadataForeignScript] = script
+		}
+	}
+	return nil
+}
+
+// foreignScript returns the script of s if it has letters and none of them
+// belongs to the script(s) of the language of the module.
+func (m *Module) foreignScript(s string) (string, bool) {
+	script := ""
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if unicode.In(r, m.scriptRanges...) {
+			return "", false
+		}
+		if script == "" {
+			script = getScriptCategory(r)
+		}
+	}
+	return script, script != ""
+}
+
+// romanTokens returns the tokens that make up the romanization of tsw, i.e.
+// all of them unless the policy is ForeignScriptSkip.
+func (m *Module) romanTokens(tsw AnyTokenSliceWrapper) AnyTokenSliceWrapper {
+	if m.foreignPolicy != ForeignScriptSkip || len(m.scriptRanges) == 0 {
+		return tsw
+	}
+	return Filter(tsw, m.isRomanToken)
+}
+
+// isRomanToken reports whether the token is part of the romanization, see
+// romanTokens.
+func (m *Module) isRomanToken(token AnyToken) bool {
+	if m.foreignPolicy != ForeignScriptSkip || len(m.scriptRanges) == 0 {
+		return true
+	}
+	tkn := BaseToken(token)
+	if tkn == nil {
+		_, foreign := m.foreignScript(token.GetSurface())
+		return !foreign
+	}
+	_, foreign := tkn.Metadata[MetadataForeignScript]
+	return !foreign
+}
@@ -0,0 +1,51 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestForeignScriptPolicy(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	input := "ผมชอบ coffee มาก"
+	passThrough, err := m.RomanParts(input)
+	require.NoError(t, err)
+	assert.Contains(t, passThrough, "coffee")
+
+	m.WithForeignScriptPolicy(common.ForeignScriptTag)
+	tkns, err := m.Tokens(input)
+	require.NoError(t, err)
+	tagged := 0
+	for _, token := range tkns.Iter() {
+		tkn := common.BaseToken(token)
+		if script, ok := tkn.Metadata[common.MetadataForeignScript]; ok {
+			assert.Equal(t, "coffee", tkn.Surface)
+			assert.Equal(t, "Latin", script)
+			tagged++
+		}
+	}
+	assert.Equal(t, 1, tagged)
+
+	m.WithForeignScriptPolicy(common.ForeignScriptSkip)
+	skipped, err := m.RomanParts(input)
+	require.NoError(t, err)
+	assert.Equal(t, len(passThrough)-1, len(skipped))
+	assert.NotContains(t, skipped, "coffee")
+	roman, err := m.Roman(input)
+	require.NoError(t, err)
+	assert.NotContains(t, roman, "coffee")
+
+	m.WithForeignScriptPolicy(common.ForeignScriptError)
+	_, err = m.Roman(input)
+	assert.ErrorIs(t, err, common.ErrForeignScript)
+	_, err = m.Roman("ผมชอบกินข้าว 123")
+	assert.NoError(t, err, "digits aren't foreign")
+}
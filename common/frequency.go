@@ -0,0 +1,164 @@
+package common
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FreqRankKey is the Tkn.Metadata key holding the frequency rank of a token,
+// set by the enricher returned by NewFrequencyEnricher.
+const FreqRankKey = "freq_rank"
+
+// FrequencyList gives the frequency rank of words.
+// Implementations must be safe for concurrent use.
+type FrequencyList interface {
+	// Rank returns the rank of word, a word in the given language
+	// (ISO 639-3), 1 being the most frequent, or 0 if the list doesn't have it.
+	Rank(word, lang string) int
+}
+
+// MemoryFrequencyList is a FrequencyList held in memory, as returned by
+// LoadFrequencyTSV.
+type MemoryFrequencyList struct {
+	// Lang is the language of the words; lookups in other languages
+	// return nothing. Empty matches any language.
+	Lang  string
+	mu    sync.RWMutex
+	ranks map[string]int
+}
+
+// NewMemoryFrequencyList creates an empty frequency list of words in the given language.
+func NewMemoryFrequencyList(lang string) *MemoryFrequencyList {
+	return &MemoryFrequencyList{
+		Lang:  lang,
+		ranks: make(map[string]int),
+	}
+}
+
+// Add sets the rank of a word, keeping the best rank if it is already listed.
+func (l *MemoryFrequencyList) Add(word string, rank int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if prev, ok := l.ranks[word]; !ok || rank < prev {
+		l.ranks[word] = rank
+	}
+}
+
+// Rank implements FrequencyList.
+func (l *MemoryFrequencyList) Rank(word, lang string) int {
+	if l.Lang != "" && lang != "" && l.Lang != lang {
+		return 0
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.ranks[word]
+}
+
+// Len returns the number of words.
+func (l *MemoryFrequencyList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.ranks)
+}
+
+// LoadFrequencyTSV reads a frequency list of words in the given language, one
+// word per line, from the most to the least frequent:
+//
+//	word[<TAB>count...]
+//
+// The rank of a word is its line number, ignoring empty lines and comments
+// (lines starting with #). Lists giving the rank explicitly are supported too:
+//
+//	rank<TAB>word[<TAB>...]
+func LoadFrequencyTSV(r io.Reader, lang string) (*MemoryFrequencyList, error) {
+	l := NewMemoryFrequencyList(lang)
+	scanner := bufio.NewScanner(r)
+	rank := 0
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rank++
+		fields := strings.Split(line, "\t")
+		word := strings.TrimSpace(fields[0])
+		if len(fields) > 1 {
+			if explicit, err := strconv.Atoi(word); err == nil {
+				if explicit <= 0 {
+					return nil, fmt.Errorf("frequency list: line %d: invalid rank %d", lineNum, explicit)
+				}
+				rank, word = explicit, strings.TrimSpace(fields[1])
+			}
+		}
+		if word == "" {
+			return nil, fmt.Errorf("frequency list: line %d: missing word", lineNum)
+		}
+		l.Add(word, rank)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("frequency list: %w", err)
+	}
+	return l, nil
+}
+
+// FreqRank returns the frequency rank of a token set by the frequency
+// enricher, and whether it has one.
+func FreqRank(tkn *Tkn) (int, bool) {
+	rank, ok := tkn.Metadata[FreqRankKey].(int)
+	return rank, ok
+}
+
+// NewFrequencyEnricher returns an enricher setting Metadata["freq_rank"] on the
+// lexical tokens found in the frequency lists. Tokens are looked up by lemma,
+// then by normalized form, then by surface; the best rank of all the lists wins.
+//
+// Example usage:
+//
+//	list, err := common.LoadFrequencyTSV(f, "jpn")
+//	m.WithEnricher(common.NewFrequencyEnricher(list))
+func NewFrequencyEnricher(lists ...FrequencyList) *FuncEnricher {
+	return NewFuncEnricher("frequency-enricher", func(ctx context.Context, tsw AnyTokenSliceWrapper) error {
+		for i := 0; i < tsw.Len(); i++ {
+			if i%1000 == 0 {
+				if err := ctx.Err(); err != nil {
+					return fmt.Errorf("context canceled: %w", err)
+				}
+			}
+			tkn := BaseToken(tsw.GetIdx(i))
+			if tkn == nil || !tkn.IsLexical {
+				continue
+			}
+			if rank := frequencyRank(tkn, lists); rank > 0 {
+				if tkn.Metadata == nil {
+					tkn.Metadata = make(map[string]interface{})
+				}
+				tkn.Metadata[FreqRankKey] = rank
+			}
+		}
+		return nil
+	})
+}
+
+// frequencyRank returns the best rank of a token in the lists, or 0
+func frequencyRank(tkn *Tkn, lists []FrequencyList) int {
+	for _, key := range []string{tkn.Lemma, tkn.Normalized, tkn.Surface} {
+		if key == "" {
+			continue
+		}
+		best := 0
+		for _, list := range lists {
+			if rank := list.Rank(key, tkn.Language); rank > 0 && (best == 0 || rank < best) {
+				best = rank
+			}
+		}
+		if best > 0 {
+			return best
+		}
+	}
+	return 0
+}
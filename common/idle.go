@@ -0,0 +1,255 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IdleProvider wraps a provider holding an expensive resource, such as the
+// Docker container of pythainlp or ichiran, so that the provider is closed
+// after a period of inactivity and transparently initialized again the next
+// time it is used.
+//
+// IdleProvider implements Provider and takes the name of the wrapped provider.
+type IdleProvider struct {
+	inner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+
+	mu      sync.Mutex
+	timeout time.Duration
+	timer   *time.Timer
+	loaded  bool      // whether inner is initialized
+	active  int       // calls in progress
+	lastUse time.Time // end of the last call
+}
+
+// NewIdleProvider wraps inner so that it is closed once unused for timeout.
+// inner is assumed to be initialized, or to be initialized through the
+// IdleProvider, e.g. by Module.Init.
+//
+// Parameters:
+//   - inner: The provider to wrap
+//   - timeout: The inactivity after which inner is closed, or 0 to never close it
+//
+// Returns:
+//   - *IdleProvider: The wrapped provider
+func NewIdleProvider(inner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], timeout time.Duration) *IdleProvider {
+	return &IdleProvider{inner: inner, timeout: timeout, loaded: true}
+}
+
+// SetIdleTimeout replaces the inactivity after which the wrapped provider is
+// closed, 0 meaning never.
+func (p *IdleProvider) SetIdleTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = timeout
+	p.schedule()
+}
+
+// IdleTimeout returns the inactivity after which the wrapped provider is closed.
+func (p *IdleProvider) IdleTimeout() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.timeout
+}
+
+// Loaded reports whether the wrapped provider is initialized, i.e. neither
+// closed for inactivity nor closed with Close.
+func (p *IdleProvider) Loaded() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.loaded
+}
+
+// Unwrap returns the wrapped provider.
+func (p *IdleProvider) Unwrap() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+	return p.inner
+}
+
+// schedule (re)starts the timer closing the wrapped provider if it is loaded
+// and unused. It must be called with p.mu held.
+func (p *IdleProvider) schedule() {
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	if p.timeout <= 0 || !p.loaded || p.active > 0 {
+		return
+	}
+	p.timer = time.AfterFunc(p.timeout, p.unloadIfIdle)
+}
+
+// unloadIfIdle closes the wrapped provider if it hasn't been used since the
+// timeout elapsed.
+func (p *IdleProvider) unloadIfIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.loaded || p.active > 0 || p.timeout <= 0 || time.Since(p.lastUse) < p.timeout {
+		return
+	}
+	p.loaded = false
+	p.timer = nil
+	if err := p.inner.CloseWithContext(context.Background()); err != nil {
+		Log.Warn().Err(err).Str("provider", p.inner.Name()).Msg("Failed to close idle provider")
+		return
+	}
+	Log.Debug().Str("provider", p.inner.Name()).Dur("idle", p.timeout).Msg("Closed idle provider")
+}
+
+// ProcessFlowController initializes the wrapped provider again if it was
+// closed for inactivity, then processes the input with it.
+func (p *IdleProvider) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	p.mu.Lock()
+	if !p.loaded {
+		Log.Debug().Str("provider", p.inner.Name()).Msg("Initializing idle provider again")
+		if err := p.inner.InitWithContext(ctx); err != nil {
+			p.mu.Unlock()
+			return nil, fmt.Errorf("%s: reinitialization after inactivity failed: %w", p.inner.Name(), err)
+		}
+		p.loaded = true
+	}
+	p.active++
+	p.schedule()
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.active--
+		p.lastUse = time.Now()
+		p.schedule()
+	}()
+	return p.inner.ProcessFlowController(ctx, mode, input)
+}
+
+func (p *IdleProvider) SaveConfig(cfg map[string]interface{}) error {
+	return p.inner.SaveConfig(cfg)
+}
+
+// ApplyConfig applies the configuration to the wrapped provider, see common.ApplyConfig.
+func (p *IdleProvider) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	return ApplyConfig(ctx, p.inner, cfg)
+}
+
+func (p *IdleProvider) InitWithContext(ctx context.Context) error {
+	return p.init(func() error { return p.inner.InitWithContext(ctx) })
+}
+
+func (p *IdleProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *IdleProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.init(func() error { return p.inner.InitRecreateWithContext(ctx, noCache) })
+}
+
+func (p *IdleProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *IdleProvider) init(initFn func() error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := initFn()
+	p.loaded = err == nil
+	p.lastUse = time.Now()
+	p.schedule()
+	return err
+}
+
+// CloseWithContext closes the wrapped provider, unless it was already closed
+// for inactivity.
+func (p *IdleProvider) CloseWithContext(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	if !p.loaded {
+		return nil
+	}
+	p.loaded = false
+	return p.inner.CloseWithContext(ctx)
+}
+
+func (p *IdleProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *IdleProvider) WithProgressCallback(callback ProgressCallback) {
+	p.inner.WithProgressCallback(callback)
+}
+
+func (p *IdleProvider) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	p.inner.WithDownloadProgressCallback(callback)
+}
+
+// Name returns the name of the wrapped provider.
+func (p *IdleProvider) Name() string {
+	return p.inner.Name()
+}
+
+func (p *IdleProvider) SupportedModes() []OperatingMode {
+	return p.inner.SupportedModes()
+}
+
+func (p *IdleProvider) GetMaxQueryLen() int {
+	return p.inner.GetMaxQueryLen()
+}
+
+// PlatformRequirements returns the requirements of the wrapped provider.
+func (p *IdleProvider) PlatformRequirements() PlatformRequirements {
+	return RequirementsOf(p.inner)
+}
+
+// ResourceVersions returns the resource versions of the wrapped provider,
+// implementing VersionReporter.
+func (p *IdleProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if reporter, ok := p.inner.(VersionReporter); ok {
+		return reporter.ResourceVersions(ctx)
+	}
+	return map[string]string{}, nil
+}
+
+// WithIdleTimeout closes the Docker-backed providers of the module (see
+// PlatformRequirements) once they have been unused for timeout, so that a
+// long-running application doesn't keep their containers alive, and
+// initializes them again the next time the module processes an input, which
+// then takes as long as the initialization of the provider. Providers that
+// don't run in Docker are left as they are; to give any provider an idle
+// timeout, wrap it with NewIdleProvider using WrapProvider.
+//
+// Example usage:
+//
+//	m.WithIdleTimeout(15 * time.Minute)
+//
+// Parameters:
+//   - timeout: The inactivity after which the providers are closed, or 0 to never close them
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithIdleTimeout(timeout time.Duration) *Module {
+	for _, mode := range []OperatingMode{TokenizerMode, TransliteratorMode, CombinedMode} {
+		provider, ok := m.ProviderRoles[mode]
+		if !ok || !RequirementsOf(provider).Docker {
+			continue
+		}
+		if idle := findIdleProvider(provider); idle != nil {
+			idle.SetIdleTimeout(timeout)
+			continue
+		}
+		m.WrapProvider(mode, func(inner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+			return NewIdleProvider(inner, timeout)
+		})
+	}
+	return m
+}
+
+// findIdleProvider returns the IdleProvider among the provider and the
+// providers it wraps, if any.
+func findIdleProvider(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *IdleProvider {
+	idle, _ := findWrapped[*IdleProvider](provider)
+	return idle
+}
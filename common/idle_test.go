@@ -0,0 +1,89 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// containerTokenizer is a tokenizer pretending to run in a Docker container
+type containerTokenizer struct {
+	tha.DictTokenizerProvider
+	inits, closes int
+}
+
+func (p *containerTokenizer) InitWithContext(ctx context.Context) error {
+	p.inits++
+	return p.DictTokenizerProvider.InitWithContext(ctx)
+}
+
+func (p *containerTokenizer) CloseWithContext(ctx context.Context) error {
+	p.closes++
+	return p.DictTokenizerProvider.CloseWithContext(ctx)
+}
+
+func (p *containerTokenizer) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	tokenizer := &containerTokenizer{}
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.ProviderRoles[common.TokenizerMode] = tokenizer
+	m.Providers[0] = tokenizer
+	m.WithIdleTimeout(30 * time.Millisecond)
+	require.IsType(t, &common.IdleProvider{}, m.ProviderRoles[common.TokenizerMode])
+	require.NotContains(t, m.Providers, common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper](tokenizer))
+	assert.Equal(t, "paiboonizer", m.ProviderRoles[common.TransliteratorMode].Name(), "providers without Docker aren't wrapped")
+	require.NoError(t, m.Init())
+	idle := m.ProviderRoles[common.TokenizerMode].(*common.IdleProvider)
+
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, 1, tokenizer.inits)
+	assert.Eventually(t, func() bool { return !idle.Loaded() }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, tokenizer.closes)
+
+	roman, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.NotContains(t, roman, "ผ")
+	assert.Equal(t, 2, tokenizer.inits, "the provider should be initialized again on next use")
+
+	m.WithIdleTimeout(0)
+	assert.Same(t, idle, m.ProviderRoles[common.TokenizerMode])
+	require.NoError(t, m.Close())
+	assert.Equal(t, 2, tokenizer.closes)
+}
+
+func TestIdleTimeoutMultiRoleProvider(t *testing.T) {
+	shared := &containerTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider()}
+	m := &common.Module{
+		Lang:      tha.Lang,
+		Providers: []common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{shared},
+		ProviderRoles: map[common.OperatingMode]common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{
+			common.TokenizerMode:      shared,
+			common.TransliteratorMode: shared,
+		},
+	}
+	m.WithIdleTimeout(30 * time.Millisecond)
+	idle, ok := m.ProviderRoles[common.TokenizerMode].(*common.IdleProvider)
+	require.True(t, ok)
+	assert.Same(t, idle, m.ProviderRoles[common.TransliteratorMode], "a provider playing two roles should be wrapped once")
+	require.Len(t, m.Providers, 1)
+	assert.Same(t, idle, m.Providers[0])
+
+	require.NoError(t, m.Init())
+	assert.Equal(t, 1, shared.inits)
+	_, err := idle.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: []string{"ผมชอบกินข้าว"}})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return !idle.Loaded() }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, shared.closes)
+	require.NoError(t, m.Close())
+	assert.Equal(t, 1, shared.closes, "an unloaded provider isn't closed again")
+}
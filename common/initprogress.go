@@ -0,0 +1,99 @@
+package common
+
+import (
+	"sync"
+)
+
+// InitPhase is the stage of the initialization of a provider reported by an InitEvent.
+type InitPhase string
+
+const (
+	// InitStarting is reported when the module starts initializing a provider
+	InitStarting InitPhase = "starting"
+	// InitDownloading is reported by the providers downloading resources
+	// (Docker images, dictionaries...) while they initialize
+	InitDownloading InitPhase = "downloading"
+	// InitReady is reported once a provider is initialized
+	InitReady InitPhase = "ready"
+	// InitFailed is reported when a provider fails to initialize
+	InitFailed InitPhase = "failed"
+)
+
+// InitEvent reports the progress of the initialization of one of the
+// providers of a module, see Module.WithInitProgressCallback.
+type InitEvent struct {
+	Provider string // name of the provider, e.g. "aksharamukha"
+	Phase    InitPhase
+	Current  int64  // bytes downloaded so far, for InitDownloading
+	Total    int64  // estimated total bytes for InitDownloading, 0 if unknown
+	Status   string // status reported by the provider, e.g. "Extracting..."
+	Err      error  // the *ProviderInitError of InitFailed
+}
+
+// InitProgressCallback receives the InitEvents of a module.
+type InitProgressCallback func(event InitEvent)
+
+// initProgress serializes the events of a module, so that the callback is
+// never called concurrently even if providers download in the background.
+type initProgress struct {
+	mu       sync.Mutex
+	callback InitProgressCallback
+}
+
+func (p *initProgress) emit(event InitEvent) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.callback(event)
+}
+
+// WithInitProgressCallback sets a callback receiving a single stream of
+// events covering the initialization of all the providers of the module:
+// when each provider starts initializing, the progress of its downloads
+// and whether it ended up ready or failed. Events are labelled with the name
+// of the provider, so that a user interface can show one progress bar per
+// provider whichever of them pulls images. The callback is never called
+// concurrently.
+//
+// Unlike WithDownloadProgressCallback, which only sees the downloads of the
+// providers that report them, every provider reports at least InitStarting
+// and then InitReady or InitFailed.
+//
+// Returns the module for method chaining.
+func (m *Module) WithInitProgressCallback(callback InitProgressCallback) *Module {
+	if callback == nil {
+		m.initProgress = nil
+	} else {
+		m.initProgress = &initProgress{callback: callback}
+	}
+	for _, provider := range m.Providers {
+		m.passDownloadCallback(provider)
+	}
+	return m
+}
+
+// passDownloadCallback gives the provider a callback forwarding its
+// downloads to the download and init progress callbacks of the module.
+func (m *Module) passDownloadCallback(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) {
+	if m.downloadProgressCallback == nil && m.initProgress == nil {
+		return
+	}
+	downloadCallback, progress := m.downloadProgressCallback, m.initProgress
+	provider.WithDownloadProgressCallback(func(providerName string, current, total int64, status string) {
+		if providerName == "" {
+			providerName = provider.Name()
+		}
+		if downloadCallback != nil {
+			downloadCallback(providerName, current, total, status)
+		}
+		progress.emit(InitEvent{
+			Provider: providerName,
+			Phase:    InitDownloading,
+			Current:  current,
+			Total:    total,
+			Status:   status,
+		})
+	})
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrLanguageNotRegistered is returned by DefaultModule and NewModule for the
+// languages that no provider was registered for, usually because the package
+// of the language wasn't imported.
+var ErrLanguageNotRegistered = errors.New("no providers registered for language")
+
+// LanguageDefaults describes a language to the registry, see
+// RegisterLanguageDefaults.
+type LanguageDefaults struct {
+	// Providers are the names (or aliases) of the default providers of the
+	// language, in processing order: a combined provider, or a tokenizer
+	// followed by a transliterator. They must be registered for the
+	// language or for "mul". See SetDefault.
+	Providers []string
+
+	// Fallback are the names of the providers used instead of Providers on
+	// the platforms that can't run them, see SetPlatformFallback. Optional.
+	Fallback []string
+
+	// NeedsTokenization and NeedsTransliteration declare that the language
+	// isn't written with spaces between words, or isn't written in the
+	// Latin script, for the languages that translitkit doesn't know to be
+	// (see the functions of the same name).
+	NeedsTokenization    bool
+	NeedsTransliteration bool
+
+	// SpacingRule is the spacing rule of the language, see RegisterSpacingRule. Optional.
+	SpacingRule SpacingRule
+}
+
+// RegistrationValidator checks a provider before it is registered for a
+// language with Register, see AddRegistrationValidator.
+type RegistrationValidator func(lang string, entry ProviderEntry) error
+
+var registrationValidators = struct {
+	sync.RWMutex
+	fns []RegistrationValidator
+}{}
+
+// declaredNeeds are the needs declared with RegisterLanguageDefaults.
+var declaredNeeds = struct {
+	sync.RWMutex
+	tokenization, transliteration map[string]bool
+}{tokenization: make(map[string]bool), transliteration: make(map[string]bool)}
+
+// AddRegistrationValidator adds a function that Register calls with every
+// provider before registering it, e.g. to enforce the conventions of an
+// application or of a family of language packages. Register fails with the
+// first error returned by a validator. Validators must not call the registry.
+// Providers registered before the validator was added aren't checked.
+func AddRegistrationValidator(fn RegistrationValidator) error {
+	if fn == nil {
+		return fmt.Errorf("registration validator cannot be nil")
+	}
+	registrationValidators.Lock()
+	defer registrationValidators.Unlock()
+	registrationValidators.fns = append(registrationValidators.fns, fn)
+	return nil
+}
+
+func runRegistrationValidators(lang string, entry ProviderEntry) error {
+	registrationValidators.RLock()
+	defer registrationValidators.RUnlock()
+	for _, fn := range registrationValidators.fns {
+		if err := fn(lang, entry); err != nil {
+			return fmt.Errorf("provider %s rejected for language %s: %w", entry.Provider.Name(), lang, err)
+		}
+	}
+	return nil
+}
+
+// RegisterLanguageDefaults sets up a language whose providers were
+// registered with Register, so that third-party packages can add languages
+// without translitkit importing them: the package registers its providers
+// then its defaults from its init function, and the applications importing
+// it get the language in DefaultModule, NewModule and Languages.
+//
+// The defaults are validated like the chains passed to SetDefault: the
+// providers must be registered and support their role in the chain.
+//
+// Example usage:
+//
+//	func init() {
+//		common.Register("nep", common.ProviderEntry{Provider: NewNepaliRomanizer(), Capabilities: ...})
+//		if err := common.RegisterLanguageDefaults("nep", common.LanguageDefaults{
+//			Providers:            []string{"uniseg", "nepali-romanizer"},
+//			NeedsTransliteration: true,
+//		}); err != nil {
+//			panic(err)
+//		}
+//	}
+func RegisterLanguageDefaults(languageCode string, defaults LanguageDefaults) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if len(defaults.Providers) == 0 {
+		return fmt.Errorf("no default providers given for language %s", lang)
+	}
+
+	declaredNeeds.Lock()
+	if defaults.NeedsTokenization {
+		declaredNeeds.tokenization[lang] = true
+	}
+	if defaults.NeedsTransliteration {
+		declaredNeeds.transliteration[lang] = true
+	}
+	declaredNeeds.Unlock()
+
+	chain, err := lookupChain(lang, defaults.Providers)
+	if err != nil {
+		return fmt.Errorf("default providers of %s: %w", lang, err)
+	}
+	if err := SetDefault(lang, chain); err != nil {
+		return fmt.Errorf("default providers of %s: %w", lang, err)
+	}
+	if len(defaults.Fallback) > 0 {
+		fallback, err := lookupChain(lang, defaults.Fallback)
+		if err != nil {
+			return fmt.Errorf("fallback providers of %s: %w", lang, err)
+		}
+		if err := SetPlatformFallback(lang, fallback); err != nil {
+			return fmt.Errorf("fallback providers of %s: %w", lang, err)
+		}
+	}
+	if defaults.SpacingRule != nil {
+		if err := RegisterSpacingRule(lang, defaults.SpacingRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupChain returns the registered entries of a chain of provider names:
+// a combined provider, or a tokenizer followed by a transliterator.
+func lookupChain(lang string, names []string) ([]ProviderEntry, error) {
+	modes := []OperatingMode{CombinedMode}
+	switch len(names) {
+	case 1:
+	case 2:
+		modes = []OperatingMode{TokenizerMode, TransliteratorMode}
+	default:
+		return nil, fmt.Errorf("expected 1 or 2 providers, got %d", len(names))
+	}
+
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	chain := make([]ProviderEntry, len(names))
+	for i, name := range names {
+		entry, ok := findProvider(lang, modes[i], name)
+		if !ok && len(names) == 1 {
+			// a lone transliterator, for the languages written with spaces
+			entry, ok = findProvider(lang, TransliteratorMode, name)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s provider %q isn't registered for %s nor mul", modes[i], name, lang)
+		}
+		chain[i] = entry
+	}
+	return chain, nil
+}
+
+// languageNotRegistered returns the error of a language without providers,
+// listing the registered languages. It must be called with the registry lock held.
+func languageNotRegistered(lang string) error {
+	langs := make([]string, 0, len(GlobalRegistry.Providers))
+	for l := range GlobalRegistry.Providers {
+		if l != "mul" {
+			langs = append(langs, l)
+		}
+	}
+	if len(langs) == 0 {
+		return fmt.Errorf("%w: %s (no language is registered: import the packages of the languages, e.g. github.com/tassa-yoniso-manasi-karoto/translitkit)", ErrLanguageNotRegistered, lang)
+	}
+	sort.Strings(langs)
+	return fmt.Errorf("%w: %s (registered: %s; import the package of the language to register it)", ErrLanguageNotRegistered, lang, strings.Join(langs, ", "))
+}
@@ -0,0 +1,215 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/client"
+)
+
+// LockfileName is the conventional name of the file recording the exact
+// versions of the external resources used by the providers.
+const LockfileName = "translitkit.lock"
+
+// VersionReporter is implemented by providers that depend on external resources
+// whose version isn't pinned by go.mod, such as Docker images or downloaded
+// dictionaries. Pure Go providers don't need to implement it.
+type VersionReporter interface {
+	// ResourceVersions returns the exact version of each external resource
+	// used by the provider, keyed by resource name
+	// (e.g. image reference → image digest, file name → checksum).
+	ResourceVersions(ctx context.Context) (map[string]string, error)
+}
+
+// Lockfile records the resource versions reported by providers, keyed by provider name.
+type Lockfile struct {
+	Providers map[string]map[string]string `json:"providers"`
+}
+
+// lockfileMu serializes the read-modify-write cycles of modules sharing a lockfile.
+var lockfileMu sync.Mutex
+
+// ReadLockfile reads the lockfile at the given path.
+// A missing file yields an empty lockfile rather than an error.
+func ReadLockfile(path string) (*Lockfile, error) {
+	lf := &Lockfile{Providers: make(map[string]map[string]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lf, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	if err := json.Unmarshal(data, lf); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+	if lf.Providers == nil {
+		lf.Providers = make(map[string]map[string]string)
+	}
+	return lf, nil
+}
+
+// Write writes the lockfile to the given path.
+func (lf *Lockfile) Write(path string) error {
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	return nil
+}
+
+// Pin records the current resource versions of the providers of the module,
+// overwriting any version previously pinned for them.
+func (lf *Lockfile) Pin(ctx context.Context, m *Module) error {
+	for _, provider := range m.Providers {
+		reporter, ok := provider.(VersionReporter)
+		if !ok {
+			continue
+		}
+		versions, err := reporter.ResourceVersions(ctx)
+		if err != nil {
+			return fmt.Errorf("provider %s: failed to get resource versions: %w", provider.Name(), err)
+		}
+		lf.Providers[provider.Name()] = versions
+	}
+	return nil
+}
+
+// Verify compares the current resource versions of the providers of the module
+// against the versions pinned in the lockfile. Resources whose version changed
+// are always reported as errors. Resources that aren't pinned yet are reported
+// as errors if requirePinned is true, otherwise they're pinned in the lockfile.
+//
+// Returns whether the lockfile was modified and the error describing every
+// drifted or unpinned resource, if any.
+func (lf *Lockfile) Verify(ctx context.Context, m *Module, requirePinned bool) (modified bool, err error) {
+	var problems []string
+	for _, provider := range m.Providers {
+		reporter, ok := provider.(VersionReporter)
+		if !ok {
+			continue
+		}
+		versions, err := reporter.ResourceVersions(ctx)
+		if err != nil {
+			return modified, fmt.Errorf("provider %s: failed to get resource versions: %w", provider.Name(), err)
+		}
+		pinned, ok := lf.Providers[provider.Name()]
+		if !ok {
+			pinned = make(map[string]string)
+			lf.Providers[provider.Name()] = pinned
+		}
+		for _, resource := range sortedKeys(versions) {
+			current := versions[resource]
+			want, ok := pinned[resource]
+			switch {
+			case !ok && requirePinned:
+				problems = append(problems, fmt.Sprintf("%s: %s is not pinned (current version %s)", provider.Name(), resource, current))
+			case !ok:
+				pinned[resource] = current
+				modified = true
+			case want != current:
+				problems = append(problems, fmt.Sprintf("%s: %s is pinned to %s but %s is in use", provider.Name(), resource, want, current))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return modified, fmt.Errorf("resource versions don't match the lockfile:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+	return modified, nil
+}
+
+// WithLockfile makes the module check the versions of the external resources
+// used by its providers (Docker image digests, dictionary checksums...) against
+// the lockfile at the given path after initialization, so that romanization
+// output doesn't silently change when upstream resources are updated.
+// Initialization fails if a pinned resource is found in a different version.
+// Resources that aren't pinned yet are added to the lockfile, unless
+// requirePinned is true, in which case initialization fails instead.
+//
+// Parameters:
+//   - path: Path of the lockfile, conventionally LockfileName
+//   - requirePinned: Whether to refuse running with unpinned resources
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithLockfile(path string, requirePinned bool) *Module {
+	m.lockfilePath = path
+	m.requirePinned = requirePinned
+	return m
+}
+
+// checkLockfile verifies the provider resource versions against the lockfile
+// configured with WithLockfile, if any.
+func (m *Module) checkLockfile(ctx context.Context) error {
+	if m.lockfilePath == "" {
+		return nil
+	}
+	lockfileMu.Lock()
+	defer lockfileMu.Unlock()
+
+	lf, err := ReadLockfile(m.lockfilePath)
+	if err != nil {
+		return err
+	}
+	modified, err := lf.Verify(ctx, m, m.requirePinned)
+	if modified {
+		if werr := lf.Write(m.lockfilePath); werr != nil {
+			return werr
+		}
+		m.logger().Info().Str("lang", m.Lang).Str("lockfile", m.lockfilePath).Msg("Pinned provider resource versions")
+	}
+	return err
+}
+
+// DockerImageDigest returns the repository digest (e.g. "sha256:…") of the given
+// image in the local Docker image store, or its image ID if it has no
+// repository digest (i.e. it was built locally).
+func DockerImageDigest(ctx context.Context, image string) (string, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	info, err := cli.ImageInspect(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+	for _, repoDigest := range info.RepoDigests {
+		if _, digest, ok := strings.Cut(repoDigest, "@"); ok {
+			return digest, nil
+		}
+	}
+	return info.ID, nil
+}
+
+// DockerImageVersions returns the digests of the given images, as expected
+// from VersionReporter.ResourceVersions.
+func DockerImageVersions(ctx context.Context, images ...string) (map[string]string, error) {
+	versions := make(map[string]string, len(images))
+	for _, image := range images {
+		digest, err := DockerImageDigest(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		versions[image] = digest
+	}
+	return versions, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
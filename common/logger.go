@@ -4,4 +4,47 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Log is the logger of the library, silent by default. See SetLogger.
 var Log = zerolog.Nop()
+
+// SetLogger sets the logger used by the library and its providers, e.g. to
+// route the logs of translitkit into the logging system of the application.
+// It should be called before the modules are created, as it isn't safe to
+// call it concurrently with them.
+//
+// Example usage:
+//
+//	common.SetLogger(zerolog.New(os.Stderr).Level(zerolog.InfoLevel))
+func SetLogger(l zerolog.Logger) {
+	Log = l
+}
+
+// WithLogger sets the logger used by the module instead of Log, for the logs
+// of the module itself and of its chunking, e.g. to tag them with the module
+// or to silence its trace output with zerolog.Nop(). The providers keep
+// logging to Log.
+//
+// Returns the module for method chaining.
+func (m *Module) WithLogger(l zerolog.Logger) *Module {
+	m.log = &l
+	return m
+}
+
+// logger returns the logger of the module, see WithLogger.
+func (m *Module) logger() *zerolog.Logger {
+	if m.log != nil {
+		return m.log
+	}
+	return &Log
+}
+
+// chunker returns the chunkifier of the module, which logs to the logger of
+// the module unless the chunkifier has a Logger of its own.
+func (m *Module) chunker() *Chunkifier {
+	if m.log == nil || m.chunkifier.Logger != nil {
+		return m.chunkifier
+	}
+	c := *m.chunkifier
+	c.Logger = m.log
+	return &c
+}
@@ -0,0 +1,41 @@
+package common_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestWithLogger(t *testing.T) {
+	var global, local bytes.Buffer
+	defer common.SetLogger(common.Log)
+	common.SetLogger(zerolog.New(&global).Level(zerolog.DebugLevel))
+
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WithLogger(zerolog.New(&local).Level(zerolog.TraceLevel)).WithChunkTrace(true)
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Contains(t, local.String(), "Chunk trace")
+	assert.Contains(t, local.String(), "Chunkify:", "the chunkifier logs to the logger of the module")
+	assert.NotContains(t, global.String(), "Chunk")
+
+	// silenced module, the others keep logging to common.Log
+	m.WithLogger(zerolog.Nop())
+	local.Reset()
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Empty(t, local.String())
+	assert.Empty(t, global.String())
+
+	other, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	_, err = other.WithChunkTrace(true).Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Contains(t, global.String(), "Chunk trace")
+}
@@ -0,0 +1,136 @@
+package common
+
+import (
+	"reflect"
+)
+
+// MergeWrappers concatenates the tokens of several wrappers, e.g. the results
+// of chunks processed separately, into a new wrapper whose positions refer to
+// the concatenation of their inputs: the byte offsets, sentence IDs and chunk
+// IDs of the tokens of each wrapper are shifted past those of the previous
+// ones, their chunk maps are joined and their provider versions merged.
+// A sentence is assumed to end with the input of each wrapper.
+//
+// If all the wrappers have the same type, the new wrapper has that type too,
+// e.g. *jpn.TknSliceWrapper with its NativeSlice set; otherwise it is a
+// *TknSliceWrapper. Nil wrappers are skipped.
+//
+// The tokens are shared, not copied: their Position, and the PrecedingSpace
+// of the first token of each wrapper (which receives the TrailingSpace of
+// the previous wrapper), are updated in place.
+//
+// Example usage:
+//
+//	merged := common.MergeWrappers(tsw1, tsw2)
+//	merged.(*common.TknSliceWrapper).ReconstructOriginal() // input1 + input2
+func MergeWrappers(wrappers ...AnyTokenSliceWrapper) AnyTokenSliceWrapper {
+	var nonNil []AnyTokenSliceWrapper
+	for _, w := range wrappers {
+		if v := reflect.ValueOf(w); w != nil && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+			nonNil = append(nonNil, w)
+		}
+	}
+	merged := newWrapperLike(nonNil)
+
+	var chunks []Chunk
+	versions := make(ProviderVersions)
+	offset, sentences, chunkCount := 0, 0, 0
+	pendingSpace := ""
+	for _, w := range nonNil {
+		wChunks := ChunksOf(w)
+		end, lastSentence, lastChunk := 0, -1, len(wChunks)-1
+		for i, token := range w.Tokens() {
+			if tkn := BaseToken(token); tkn != nil {
+				end = max(end, tkn.Position.End)
+				lastSentence = max(lastSentence, tkn.Position.Sentence)
+				lastChunk = max(lastChunk, tkn.Position.Chunk)
+				if i == 0 {
+					tkn.PrecedingSpace = pendingSpace + tkn.PrecedingSpace
+					pendingSpace = ""
+				}
+				tkn.Position.Start += offset
+				tkn.Position.End += offset
+				tkn.Position.Sentence += sentences
+				tkn.Position.Chunk += chunkCount
+			}
+			merged.Append(token)
+		}
+		for _, c := range wChunks {
+			c.ID += chunkCount
+			c.Start += offset
+			c.End += offset
+			c.FirstSentence += sentences
+			c.LastSentence += sentences
+			chunks = append(chunks, c)
+		}
+		for provider, v := range ProviderVersionsOf(w) {
+			if _, ok := versions[provider]; !ok {
+				versions[provider] = v
+			}
+		}
+
+		trailing := ""
+		if tsk, ok := w.(trailingSpaceKeeper); ok {
+			trailing = tsk.trailingSpace()
+		}
+		pendingSpace += trailing
+		offset += end + len(trailing)
+		sentences += lastSentence + 1
+		chunkCount += lastChunk + 1
+	}
+
+	if cm, ok := merged.(chunkMapper); ok && len(chunks) > 0 {
+		cm.SetChunks(chunks)
+	}
+	if vk, ok := merged.(versionKeeper); ok && len(versions) > 0 {
+		vk.SetProviderVersions(versions)
+	}
+	if w, ok := merged.(trailingSpaceKeeper); ok {
+		w.setTrailingSpace(pendingSpace)
+	}
+	setNativeSlice(merged)
+	return merged
+}
+
+// newWrapperLike returns an empty wrapper of the type shared by all the
+// wrappers, or a *TknSliceWrapper if they differ or the type can't be created.
+func newWrapperLike(wrappers []AnyTokenSliceWrapper) AnyTokenSliceWrapper {
+	if len(wrappers) == 0 {
+		return &TknSliceWrapper{}
+	}
+	t := reflect.TypeOf(wrappers[0])
+	for _, w := range wrappers[1:] {
+		if reflect.TypeOf(w) != t {
+			return &TknSliceWrapper{}
+		}
+	}
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return &TknSliceWrapper{}
+	}
+	if w, ok := reflect.New(t.Elem()).Interface().(AnyTokenSliceWrapper); ok {
+		return w
+	}
+	return &TknSliceWrapper{}
+}
+
+// setNativeSlice fills the NativeSlice of the language-specific wrappers
+// with their tokens, if they all have the type of its elements.
+func setNativeSlice(w AnyTokenSliceWrapper) {
+	v := reflect.ValueOf(w)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	field := v.Elem().FieldByName("NativeSlice")
+	if !field.IsValid() || field.Kind() != reflect.Slice || !field.CanSet() {
+		return
+	}
+	native := reflect.MakeSlice(field.Type(), 0, w.Len())
+	for _, token := range w.Tokens() {
+		tv := reflect.ValueOf(token)
+		if !tv.IsValid() || !tv.Type().AssignableTo(field.Type().Elem()) {
+			return
+		}
+		native = reflect.Append(native, tv)
+	}
+	field.Set(native)
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives measurements of the calls made by modules to
+// their providers, e.g. to export tokenization latency and failure rates as
+// Prometheus metrics. Its methods are called synchronously from the goroutine
+// processing the input and must be safe for concurrent use when modules are.
+type MetricsCollector interface {
+	// OnChunkProcessed is called after a provider successfully processed the
+	// chunks of an input.
+	OnChunkProcessed(metrics ChunkMetrics)
+
+	// OnProviderError is called when a provider returns an error.
+	OnProviderError(provider, lang string, mode OperatingMode, err error)
+}
+
+// ChunkMetrics describes a call of a provider on the chunks of an input.
+// Providers receive all the chunks of an input in a single call, so the
+// duration covers all of them.
+type ChunkMetrics struct {
+	Provider string
+	Lang     string        // ISO-639 Part 3 code of the module
+	Mode     OperatingMode // Role of the provider in the module
+	Duration time.Duration
+	Chunks   int // Number of raw chunks processed, 0 if the input was already tokenized
+	Bytes    int // Size of the input in bytes: raw chunks or surfaces of the tokens
+	Tokens   int // Number of tokens returned
+}
+
+var (
+	metricsMu     sync.RWMutex
+	globalMetrics MetricsCollector
+)
+
+// SetMetricsCollector sets the collector used by the modules that have no
+// collector of their own (see Module.WithMetrics). Pass nil to disable it.
+func SetMetricsCollector(collector MetricsCollector) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	globalMetrics = collector
+}
+
+// WithMetrics sets the collector receiving the measurements of the calls made
+// to the providers of this module, overriding the one set with
+// SetMetricsCollector.
+//
+// Parameters:
+//   - collector: The collector to use, or nil to restore the default behavior
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithMetrics(collector MetricsCollector) *Module {
+	m.metrics = collector
+	return m
+}
+
+func (m *Module) getMetrics() MetricsCollector {
+	if m.metrics != nil {
+		return m.metrics
+	}
+	metricsMu.RLock()
+	defer metricsMu.RUnlock()
+	return globalMetrics
+}
+
+// process runs a provider of the module on the input through the middlewares
+// of the module (see Use).
+func (m *Module) process(ctx context.Context, provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	m.startProgressClock(provider)
+	ctx = m.withTokenWorkers(ctx)
+	if len(m.middlewares) == 0 {
+		return m.processProvider(ctx, provider, mode, input)
+	}
+	return m.processChain(m.processProvider)(ctx, provider, mode, input)
+}
+
+// processProvider runs the provider on the input and reports the call to the
+// metrics collector, if any.
+func (m *Module) processProvider(ctx context.Context, provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	collector := m.getMetrics()
+	if collector == nil {
+		return provider.ProcessFlowController(ctx, mode, input)
+	}
+
+	metrics := ChunkMetrics{
+		Provider: provider.Name(),
+		Lang:     m.Lang,
+		Mode:     mode,
+	}
+	if raw := input.GetRaw(); len(raw) > 0 {
+		metrics.Chunks = len(raw)
+		for _, chunk := range raw {
+			metrics.Bytes += len(chunk)
+		}
+	} else {
+		for i := 0; i < input.Len(); i++ {
+			metrics.Bytes += len(input.GetIdx(i).GetSurface())
+		}
+	}
+
+	start := time.Now()
+	output, err := provider.ProcessFlowController(ctx, mode, input)
+	metrics.Duration = time.Since(start)
+	if err != nil {
+		collector.OnProviderError(metrics.Provider, m.Lang, mode, err)
+		return output, err
+	}
+	if output != nil {
+		metrics.Tokens = output.Len()
+	}
+	collector.OnChunkProcessed(metrics)
+	return output, nil
+}
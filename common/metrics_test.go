@@ -0,0 +1,50 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// recordingCollector records the measurements it receives
+type recordingCollector struct {
+	calls  []common.ChunkMetrics
+	errors []string
+}
+
+func (c *recordingCollector) OnChunkProcessed(metrics common.ChunkMetrics) {
+	c.calls = append(c.calls, metrics)
+}
+
+func (c *recordingCollector) OnProviderError(provider, lang string, mode common.OperatingMode, err error) {
+	c.errors = append(c.errors, provider+": "+err.Error())
+}
+
+func TestMetrics(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	collector := &recordingCollector{}
+	m.WithMetrics(collector)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	require.Len(t, collector.calls, 2)
+	assert.Equal(t, "thai-dict", collector.calls[0].Provider)
+	assert.Equal(t, common.TokenizerMode, collector.calls[0].Mode)
+	assert.Equal(t, 1, collector.calls[0].Chunks)
+	assert.Equal(t, len("ผมชอบกินข้าว"), collector.calls[0].Bytes)
+	assert.Equal(t, 3, collector.calls[0].Tokens)
+	assert.Equal(t, "paiboonizer", collector.calls[1].Provider)
+	assert.Equal(t, 0, collector.calls[1].Chunks)
+	assert.Empty(t, collector.errors)
+
+	m.ProviderRoles[common.TokenizerMode] = &failingTokenizer{}
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.Error(t, err)
+	assert.Equal(t, []string{"failing: service unavailable"}, collector.errors)
+}
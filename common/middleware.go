@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+)
+
+// ProcessFunc runs a provider of a module on the input in the given mode, as
+// done for every chunk the module processes.
+type ProcessFunc func(ctx context.Context, provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error)
+
+// Middleware wraps the calls of a module to its providers, see Module.Use.
+// It receives the next function of the chain and returns the one that
+// replaces it. A middleware can return without calling next, e.g. to serve
+// the output from a cache.
+type Middleware func(next ProcessFunc) ProcessFunc
+
+// Use adds middlewares wrapping each call of the module to its providers
+// (tokenizer, transliterator, combined, reverse and post-processors), so
+// that cross-cutting features like caching, logging, retries or the
+// correction of the tokens can be composed without modifying the providers.
+//
+// The middlewares run in the order they are added: the first one added is
+// the outermost. The metrics (see WithMetrics) are reported for the provider
+// calls only, inside the middlewares.
+//
+// Example usage:
+//
+//	m.Use(func(next common.ProcessFunc) common.ProcessFunc {
+//		return func(ctx context.Context, p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper], mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+//			start := time.Now()
+//			output, err := next(ctx, p, mode, input)
+//			log.Printf("%s (%s): %v", p.Name(), mode, time.Since(start))
+//			return output, err
+//		}
+//	})
+//
+// Parameters:
+//   - mw: The middlewares to add
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) Use(mw ...Middleware) *Module {
+	for _, w := range mw {
+		if w != nil {
+			m.middlewares = append(m.middlewares, w)
+		}
+	}
+	return m
+}
+
+// processChain returns the middlewares of the module wrapped around next.
+func (m *Module) processChain(next ProcessFunc) ProcessFunc {
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		next = m.middlewares[i](next)
+	}
+	return next
+}
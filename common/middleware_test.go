@@ -0,0 +1,64 @@
+package common_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestModuleUse(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	want, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+
+	var calls []string
+	trace := func(name string) common.Middleware {
+		return func(next common.ProcessFunc) common.ProcessFunc {
+			return func(ctx context.Context, p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper], mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+				calls = append(calls, name+" "+string(mode))
+				return next(ctx, p, mode, input)
+			}
+		}
+	}
+	cache := make(map[string]common.AnyTokenSliceWrapper)
+	caching := func(next common.ProcessFunc) common.ProcessFunc {
+		return func(ctx context.Context, p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper], mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+			if mode != common.TokenizerMode {
+				return next(ctx, p, mode, input)
+			}
+			key := strings.Join(input.GetRaw(), "")
+			if output, ok := cache[key]; ok {
+				return output, nil
+			}
+			output, err := next(ctx, p, mode, input)
+			if err == nil {
+				cache[key] = output
+			}
+			return output, err
+		}
+	}
+	m.Use(trace("outer"), caching, trace("inner"))
+
+	got, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, []string{
+		"outer " + string(common.TokenizerMode), "inner " + string(common.TokenizerMode),
+		"outer " + string(common.TransliteratorMode), "inner " + string(common.TransliteratorMode),
+	}, calls)
+
+	calls = nil
+	got, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, []string{
+		"outer " + string(common.TokenizerMode),
+		"outer " + string(common.TransliteratorMode), "inner " + string(common.TransliteratorMode),
+	}, calls, "the cached tokenization doesn't reach the provider")
+}
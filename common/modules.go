@@ -1,13 +1,17 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"math"
 	"context"
+	"unicode"
+	"sync"
 
 	"github.com/k0kubun/pp"
 	"github.com/gookit/color"
+	"github.com/rs/zerolog"
 	//iso "github.com/barbashov/iso639-3"
 )
 
@@ -40,14 +44,38 @@ type Module struct {
 	Providers                []Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
 	ProviderRoles            map[OperatingMode]Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
 	progressCallback         ProgressCallback
+	progressCallbackV2       ProgressCallbackV2 // see WithProgressCallbackV2
+	progressClocks           map[Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]]*progressClock
+	progressClocksMu         sync.Mutex
 	downloadProgressCallback DownloadProgressCallback
+	initProgress             *initProgress // see WithInitProgressCallback
 	chunkifier               *Chunkifier
+	ipa                      bool // set when the module was built from an IPA scheme
+	spacingRule              SpacingRule // overrides the spacing rule registered for the language
+	normalization            *Normalization // overrides the normalization registered for the language
+	romanOptions             RomanizationOptions // see WithRomanizationOptions
+	lockfilePath             string // see WithLockfile
+	requirePinned            bool
+	postProcessors           []postProcessor // see WithEnricher and WithNER
+	scheme                   string // set when the module was built from a scheme
+	transliterators          []extraTransliterator // see WithTransliterator
+	metrics                  MetricsCollector // see WithMetrics
+	foreignPolicy            ForeignScriptPolicy // see WithForeignScriptPolicy
+	scriptRanges             []*unicode.RangeTable // scripts of the language, for foreignPolicy
+	versions                 ProviderVersions // see ProviderVersions
+	partialResults           bool // see WithPartialResults
+	chunkTrace               bool // see WithChunkTrace
+	log                      *zerolog.Logger // see WithLogger
+	tokenMatching            *TokenMatching // see WithTokenMatching
+	middlewares              []Middleware // see Use
+	tokenWorkers             int // see WithTokenWorkers
 }
 
 // NewModule creates a Module for the specified language using either default Providers
 // or the explicitly named ones. If providerNames is empty, default Providers are used.
 // For a combined Provider, specify one name. For separate Providers, specify two names
-// in the order: tokenizer, transliterator.
+// in the order: tokenizer, transliterator. Names are matched ignoring case,
+// and the aliases declared at registration work too (see ProviderEntry.Aliases).
 //
 // Example usage:
 //
@@ -69,6 +97,9 @@ func NewModule(languageCode string, providerNames ...string) (*Module, error) {
 	if len(providerNames) == 1 {
 		// Try to get as combined Provider
 		if provider, err := getProvider(lang, CombinedMode, providerNames[0]); err == nil {
+			if err := checkOffline(provider); err != nil {
+				return nil, err
+			}
 			module.Providers = append(module.Providers, provider)
 			module.ProviderRoles[CombinedMode] = provider
 			module.chunkifier = NewChunkifier(module.getMaxQueryLen())
@@ -89,6 +120,11 @@ func NewModule(languageCode string, providerNames ...string) (*Module, error) {
 		if err != nil {
 			return nil, fmt.Errorf("transliterator %s not found: %w", providerNames[1], err)
 		}
+		for _, provider := range []Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]{tokenizer, transliterator} {
+			if err := checkOffline(provider); err != nil {
+				return nil, err
+			}
+		}
 
 		module.Providers = append(module.Providers, tokenizer)
 		module.Providers = append(module.Providers, transliterator)
@@ -101,6 +137,48 @@ func NewModule(languageCode string, providerNames ...string) (*Module, error) {
 	return nil, fmt.Errorf("invalid number of Provider names: expected 1 or 2, got %d", len(providerNames))
 }
 
+// ErrRomanizationUnsupported is returned by the romanization methods of the
+// modules without a transliterator, e.g. the ones made by NewTokenizerModule.
+// It wraps errors.ErrUnsupported.
+var ErrRomanizationUnsupported = fmt.Errorf("romanization requires a provider with transliteration capability: %w", errors.ErrUnsupported)
+
+// NewTokenizerModule creates a Module that only segments the input with the
+// named tokenizer, for NLP uses that don't need transliteration: Tokens,
+// Tokenized and the like work as usual while Roman and the other romanization
+// methods return ErrRomanizationUnsupported.
+//
+// Example usage:
+//
+//	module, err := NewTokenizerModule("tha", "thai-dict")
+func NewTokenizerModule(languageCode, tokenizerName string) (*Module, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	tokenizer, err := getProvider(lang, TokenizerMode, tokenizerName)
+	if err != nil {
+		return nil, fmt.Errorf("tokenizer %s not found: %w", tokenizerName, err)
+	}
+	if err := checkOffline(tokenizer); err != nil {
+		return nil, err
+	}
+
+	module := newModule()
+	module.Lang = lang
+	module.Providers = append(module.Providers, tokenizer)
+	module.ProviderRoles[TokenizerMode] = tokenizer
+	module.chunkifier = NewChunkifier(module.getMaxQueryLen())
+	return module, nil
+}
+
+// checkOffline rejects a provider that can't run without network access
+// when offline mode is enabled.
+func checkOffline(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error {
+	if OfflineMode() && !CurrentPlatform().Satisfies(RequirementsOf(provider)) {
+		return fmt.Errorf("%w: %s can't run on %s", ErrNoOfflineProviders, provider.Name(), CurrentPlatform())
+	}
+	return nil
+}
 
 func newModule() *Module {
 	return &Module{
@@ -140,6 +218,23 @@ func (m *Module) hasTransliterator() bool {
 	return hasCombined || hasTransliterator
 }
 
+// hasCapability returns true if the registry entry of one of the providers of
+// the module declares the capability (e.g. CapabilityLemmatization)
+func (m *Module) hasCapability(capability Capability) bool {
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	for mode, provider := range m.ProviderRoles {
+		entry, ok := findProvider(m.Lang, mode, provider.Name())
+		if !ok {
+			continue
+		}
+		if entry.Has(capability) {
+			return true
+		}
+	}
+	return false
+}
+
 // ProviderNames returns the names of the provider(s) contained in the module.
 // For combined providers, it returns a single name.
 // For separate providers, it returns both tokenizer and transliterator names.
@@ -162,7 +257,11 @@ func (m *Module) WithProgressCallback(callback ProgressCallback) *Module {
 
 	// Pass the callback to all providers
 	for _, provider := range m.Providers {
-		provider.WithProgressCallback(callback)
+		if m.progressCallbackV2 == nil {
+			provider.WithProgressCallback(callback)
+		} else {
+			m.passProgressCallback(provider)
+		}
 	}
 
 	return m
@@ -171,6 +270,7 @@ func (m *Module) WithProgressCallback(callback ProgressCallback) *Module {
 // WithDownloadProgressCallback sets a callback function to track download progress.
 // This is called during Docker image pulls with current bytes, total bytes, and status.
 // Useful for displaying download progress bars in user interfaces.
+// See WithInitProgressCallback for the whole initialization of the providers.
 //
 // Returns the module for method chaining.
 func (m *Module) WithDownloadProgressCallback(callback DownloadProgressCallback) *Module {
@@ -178,7 +278,7 @@ func (m *Module) WithDownloadProgressCallback(callback DownloadProgressCallback)
 
 	// Pass the callback to all providers
 	for _, provider := range m.Providers {
-		provider.WithDownloadProgressCallback(callback)
+		m.passDownloadCallback(provider)
 	}
 
 	return m
@@ -193,11 +293,176 @@ func (m *Module) WithCustomChunkifier(chunkifier *Chunkifier) *Module {
 	return m
 }
 
+// WithSpacingRule sets the spacing rule used to join tokens in Roman() and
+// Tokenized() output for this module only.
+// By default, the rule registered for the module's language with
+// RegisterSpacingRule is used, or DefaultSpacingRule if there is none.
+//
+// Parameters:
+//   - rule: The spacing rule to use, or nil to restore the default behavior
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithSpacingRule(rule SpacingRule) *Module {
+	m.spacingRule = rule
+	return m
+}
+
+// WithNormalization sets how the input is normalized before being chunked,
+// for this module only. By default, the normalization registered for the
+// module's language with RegisterNormalization is used, or
+// DefaultNormalization if there is none. Pass &Normalization{} to disable it.
+//
+// Parameters:
+//   - n: The normalization to apply, or nil to restore the default behavior
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithNormalization(n *Normalization) *Module {
+	m.normalization = n
+	return m
+}
+
+func (m *Module) getNormalization() Normalization {
+	if m.normalization != nil {
+		return *m.normalization
+	}
+	return GetNormalization(m.Lang)
+}
+
+// WithEnricher appends a provider supporting EnricherMode to the module's
+// pipeline. Enrichers receive the tokens once tokenization and transliteration
+// are done and decorate them in place, e.g. GlossEnricher adds definitions
+// from user-supplied dictionaries. The enricher is initialized and closed
+// with the module and receives the module's language as "lang" config.
+//
+// Parameters:
+//   - enricher: The provider to append
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithEnricher(enricher Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *Module {
+	return m.withPostProcessor(enricher, EnricherMode)
+}
+
+// WithNER appends a named-entity recognition provider (a provider supporting
+// NERMode, e.g. mul's "spacy") to the module's pipeline. Like enrichers, it
+// runs once tokenization and transliteration are done and sets the
+// NamedEntity of the tokens that are part of an entity. Use Entities to
+// group them.
+//
+// NER is experimental: it must be enabled with EnableExperimental(ExperimentalNER).
+//
+// Parameters:
+//   - ner: The provider to append
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithNER(ner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *Module {
+	if !requireExperimental(ExperimentalNER, "Module.WithNER") {
+		return m
+	}
+	return m.withPostProcessor(ner, NERMode)
+}
+
+// postProcessor is a provider running on the tokens produced by the main
+// pipeline, see WithEnricher and WithNER
+type postProcessor struct {
+	provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+	mode     OperatingMode
+}
+
+// WrapProvider replaces the provider playing the given role with the provider
+// returned by wrap, which is meant to decorate it (see WithCircuitBreaker).
+// A provider playing several roles, e.g. a tokenizer that also
+// transliterates, is wrapped once and replaced by the same wrapper in all
+// of them. The progress callbacks of the module are forwarded to the new
+// provider.
+//
+// Parameters:
+//   - mode: The role of the provider to wrap (TokenizerMode, TransliteratorMode or CombinedMode)
+//   - wrap: A function returning the provider decorating the current one
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WrapProvider(mode OperatingMode, wrap func(Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *Module {
+	inner, ok := m.ProviderRoles[mode]
+	if !ok {
+		m.logger().Warn().Str("mode", string(mode)).Msg("WrapProvider: module has no provider in this role")
+		return m
+	}
+	wrapper := wrap(inner)
+	for role, provider := range m.ProviderRoles {
+		if provider == inner {
+			m.ProviderRoles[role] = wrapper
+		}
+	}
+	for i, provider := range m.Providers {
+		if provider == inner {
+			m.Providers[i] = wrapper
+		}
+	}
+	m.passProgressCallback(wrapper)
+	m.passDownloadCallback(wrapper)
+	return m
+}
+
+// findWrapped returns the first provider of type T among the provider and
+// the providers it wraps, as returned by their Unwrap method, so that the
+// optional interfaces of a provider remain available once it is wrapped.
+func findWrapped[T any](provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) (T, bool) {
+	for provider != nil {
+		if p, ok := provider.(T); ok {
+			return p, true
+		}
+		wrapper, ok := provider.(interface {
+			Unwrap() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+		})
+		if !ok {
+			break
+		}
+		provider = wrapper.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
+
+func (m *Module) withPostProcessor(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], mode OperatingMode) *Module {
+	if !contains(provider.SupportedModes(), mode) {
+		m.logger().Warn().Str("provider", provider.Name()).Str("mode", string(mode)).Msg("Provider doesn't support this mode, not added to the module")
+		return m
+	}
+	if err := provider.SaveConfig(map[string]interface{}{"lang": m.Lang}); err != nil {
+		m.logger().Warn().Err(err).Str("provider", provider.Name()).Msg("Failed to configure provider")
+	}
+	m.passProgressCallback(provider)
+	m.passDownloadCallback(provider)
+	m.Providers = append(m.Providers, provider)
+	m.postProcessors = append(m.postProcessors, postProcessor{provider: provider, mode: mode})
+	return m
+}
+
+func (m *Module) getSpacingRule() SpacingRule {
+	if m.spacingRule != nil {
+		return m.spacingRule
+	}
+	return GetSpacingRule(m.Lang)
+}
+
+// isUnbounded reports whether the input can be passed to the providers as a
+// single chunk without going through the chunkifier: none of them limits the
+// length of its input (see getMaxQueryLen) and the chunking isn't traced.
+func (m *Module) isUnbounded() bool {
+	max := m.chunkifier.MaxLength
+	return (max <= 0 || max >= math.MaxInt32) && !m.chunkTrace
+}
+
 // serialize breaks the input text into chunks based on the maximum query length
 // and returns a token slice wrapper containing the raw chunks.
 // The number of chunks can be obtained by checking len(wrapper.GetRaw())
 func (m *Module) serialize(input string, max int) (AnyTokenSliceWrapper, error) {
-	chunks, err := m.chunkifier.Chunkify(input)
+	m.traceChunks(input)
+	chunks, err := m.chunker().Chunkify(input)
 	return &TknSliceWrapper{Raw: chunks}, err
 }
 
@@ -206,31 +471,113 @@ func (m *Module) serialize(input string, max int) (AnyTokenSliceWrapper, error)
 // This allows cancellation during the initialization process.
 // The module will pass the context to the appropriate providers and also set up any
 // progress callbacks that have been registered.
+// If a lockfile was set with WithLockfile, the resource versions of the providers
+// are then checked against it.
 //
-// Returns an error if initialization fails or the context is canceled.
+// All the providers are initialized even if some fail, and their failures are
+// returned together as ProviderInitErrors joined with errors.Join, so that
+// e.g. a missing Docker daemon and a missing browser show up in the same run.
+//
+// Returns an error if initialization fails, the context is canceled or the
+// resource versions don't match the lockfile.
 func (m *Module) InitWithContext(ctx context.Context) error {
 	// Pass progress callback if set
-	if m.progressCallback != nil {
-		for _, provider := range m.Providers {
-			provider.WithProgressCallback(m.progressCallback)
-		}
+	for _, provider := range m.Providers {
+		m.passProgressCallback(provider)
 	}
 
-	// Pass download progress callback if set
-	if m.downloadProgressCallback != nil {
-		for _, provider := range m.Providers {
-			provider.WithDownloadProgressCallback(m.downloadProgressCallback)
-		}
+	// Pass download progress callbacks if set
+	for _, provider := range m.Providers {
+		m.passDownloadCallback(provider)
 	}
 
 	// Initialize all providers
-	for _, provider := range m.Providers {
-		if err := provider.InitWithContext(ctx); err != nil {
-			return fmt.Errorf("provider %s init failed: %w", provider.Name(), err)
+	if err := m.initProviders(ctx, "init", func(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error {
+		return provider.InitWithContext(ctx)
+	}); err != nil {
+		return err
+	}
+
+	m.recordVersions(ctx)
+	return m.checkLockfile(ctx)
+}
+
+// ProviderInitError reports the failure of a provider to initialize.
+// Module.Init returns one per failing provider, joined with errors.Join;
+// use ProviderInitErrors to list them.
+type ProviderInitError struct {
+	Provider string
+	Op       string // "init" or "InitRecreate"
+	// Missing lists what the provider requires that the current platform
+	// doesn't appear to offer (e.g. "Docker"), a likely cause of the failure.
+	Missing []string
+	Err     error
+}
+
+func (e *ProviderInitError) Error() string {
+	msg := fmt.Sprintf("provider %s %s failed: %v", e.Provider, e.Op, e.Err)
+	if len(e.Missing) > 0 {
+		msg += fmt.Sprintf(" (requires %s, unavailable on %s)", strings.Join(e.Missing, ", "), CurrentPlatform())
+	}
+	return msg
+}
+
+func (e *ProviderInitError) Unwrap() error {
+	return e.Err
+}
+
+// ProviderInitErrors returns the provider failures contained in an error
+// returned by Module.Init or its variants.
+func ProviderInitErrors(err error) (errs []*ProviderInitError) {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*ProviderInitError); ok {
+		return []*ProviderInitError{e}
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, ProviderInitErrors(e)...)
 		}
+		return errs
 	}
+	return ProviderInitErrors(errors.Unwrap(err))
+}
 
-	return nil
+// initProviders runs initFn on every provider, even after a failure, so that
+// all the problems are reported at once. Only cancellation of the context
+// stops it early.
+func (m *Module) initProviders(ctx context.Context, op string, initFn func(Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error) error {
+	var errs []error
+	platform := CurrentPlatform()
+	for _, provider := range m.Providers {
+		m.initProgress.emit(InitEvent{Provider: provider.Name(), Phase: InitStarting})
+		err := initFn(provider)
+		if err == nil {
+			m.initProgress.emit(InitEvent{Provider: provider.Name(), Phase: InitReady})
+			continue
+		}
+		initErr := &ProviderInitError{Provider: provider.Name(), Op: op, Err: err}
+		r := RequirementsOf(provider)
+		if r.CGO && !platform.CGO {
+			initErr.Missing = append(initErr.Missing, "CGO")
+		}
+		if r.Docker && !platform.Docker {
+			initErr.Missing = append(initErr.Missing, "Docker")
+		}
+		if r.Browser && !platform.Browser {
+			initErr.Missing = append(initErr.Missing, "a headless browser")
+		}
+		if r.Network && platform.Offline {
+			initErr.Missing = append(initErr.Missing, "network access")
+		}
+		errs = append(errs, initErr)
+		m.initProgress.emit(InitEvent{Provider: provider.Name(), Phase: InitFailed, Err: initErr})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // Init initializes the module and its providers using a background context.
@@ -245,30 +592,29 @@ func (m *Module) Init() error {
 // This can be used to recreate Docker containers or other resources.
 // When noCache is true, caches will be cleared during reinitialization.
 //
+// As with InitWithContext, the failures of all the providers are reported together.
+//
 // Returns an error if reinitialization fails or the context is canceled.
 func (m *Module) InitRecreateWithContext(ctx context.Context, noCache bool) error {
 	// Pass progress callback if set
-	if m.progressCallback != nil {
-		for _, provider := range m.Providers {
-			provider.WithProgressCallback(m.progressCallback)
-		}
+	for _, provider := range m.Providers {
+		m.passProgressCallback(provider)
 	}
 
-	// Pass download progress callback if set
-	if m.downloadProgressCallback != nil {
-		for _, provider := range m.Providers {
-			provider.WithDownloadProgressCallback(m.downloadProgressCallback)
-		}
+	// Pass download progress callbacks if set
+	for _, provider := range m.Providers {
+		m.passDownloadCallback(provider)
 	}
 
 	// Reinitialize all providers
-	for _, provider := range m.Providers {
-		if err := provider.InitRecreateWithContext(ctx, noCache); err != nil {
-			return fmt.Errorf("provider %s InitRecreate failed: %w", provider.Name(), err)
-		}
+	if err := m.initProviders(ctx, "InitRecreate", func(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) error {
+		return provider.InitRecreateWithContext(ctx, noCache)
+	}); err != nil {
+		return err
 	}
 
-	return nil
+	m.recordVersions(ctx)
+	return m.checkLockfile(ctx)
 }
 
 // InitRecreate forces reinitialization of the module's providers using a background context.
@@ -298,6 +644,11 @@ func (m *Module) MustInit() {
 // TokensWithContext processes the input text with the provided context and returns token analysis.
 // It breaks the input into tokens and performs both tokenization and transliteration if appropriate
 // for the language and provider type. The context allows cancellation during processing.
+// The input is first normalized (see WithNormalization). The tokens are given their
+// position in the normalized input (offsets, sentence and chunk IDs, see Tkn.Position)
+// and the wrapper the map of the chunks the input was split into.
+// With WithPartialResults, the tokens of the chunks that could be processed
+// are returned along with a *PartialResultsError if others failed.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
@@ -307,21 +658,70 @@ func (m *Module) MustInit() {
 //   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
 //   - error: An error if processing fails or the context is canceled
 func (m *Module) TokensWithContext(ctx context.Context, input string) (AnyTokenSliceWrapper, error) {
-	tsw, err := m.serialize(input, m.getMaxQueryLen())
-	if err != nil {
+	ctx = m.withTokenMatching(ctx)
+	input = m.getNormalization().Apply(input)
+	var tsw AnyTokenSliceWrapper
+	var err error
+	if m.isUnbounded() {
+		// fast path: the chunkifier would return the input as a single chunk
+		tsw = &TknSliceWrapper{Raw: []string{input}}
+	} else if tsw, err = m.serialize(input, m.getMaxQueryLen()); err != nil {
 		return nil, fmt.Errorf("input serialization failed: len(input)=%d, %w", len(input), err)
 	}
+	// providers clear the raw chunks once processed
+	chunks := tsw.GetRaw()
+
+	var failed []*ChunkError
+	if out, err := m.runProviders(ctx, tsw); err == nil {
+		tsw = out
+	} else if !m.partialResults || len(chunks) < 2 || ctx.Err() != nil {
+		return &TknSliceWrapper{}, err
+	} else if tsw, failed, err = m.runProvidersByChunk(ctx, chunks); err != nil {
+		return &TknSliceWrapper{}, err
+	} else if tsw == nil {
+		return &TknSliceWrapper{}, fmt.Errorf("all %d chunks failed: %w", len(chunks), errors.Join(chunkErrs(failed)...))
+	}
+
+	annotatePositions(input, chunks, tsw)
+	m.setProviderVersions(tsw)
+
+	if err = m.applyForeignScriptPolicy(tsw); err != nil {
+		return &TknSliceWrapper{}, err
+	}
 
+	if len(m.transliterators) > 0 {
+		if err = m.runTransliterators(ctx, tsw); err != nil {
+			return &TknSliceWrapper{}, err
+		}
+	}
+
+	// Enrichers and NER providers run last, in the order they were added
+	for _, pp := range m.postProcessors {
+		if tsw, err = m.process(ctx, pp.provider, pp.mode, tsw); err != nil {
+			return &TknSliceWrapper{}, fmt.Errorf("%s %s failed: %w", pp.mode, pp.provider.Name(), err)
+		}
+	}
+	if len(failed) > 0 {
+		locateChunkErrors(tsw, failed)
+		return tsw, &PartialResultsError{Failed: failed, Chunks: len(chunks)}
+	}
+	return tsw, nil
+}
+
+// runProviders runs the tokenizer and transliterator of the module, or its
+// combined provider, on the raw chunks of tsw.
+func (m *Module) runProviders(ctx context.Context, tsw AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	var err error
 	// Check if we have a combined provider
 	if combined, ok := m.ProviderRoles[CombinedMode]; ok {
-		tsw, err = combined.ProcessFlowController(ctx, CombinedMode, tsw)
+		tsw, err = m.process(ctx, combined, CombinedMode, tsw)
 		if err != nil {
 			return &TknSliceWrapper{}, fmt.Errorf("combined processing failed: %w", err)
 		}
 	} else {
 		// Process with separate providers
 		if tokenizer, ok := m.ProviderRoles[TokenizerMode]; ok {
-			tsw, err = tokenizer.ProcessFlowController(ctx, TokenizerMode, tsw)
+			tsw, err = m.process(ctx, tokenizer, TokenizerMode, tsw)
 			if err != nil {
 				return &TknSliceWrapper{}, fmt.Errorf("tokenization failed: %w", err)
 			}
@@ -331,7 +731,7 @@ func (m *Module) TokensWithContext(ctx context.Context, input string) (AnyTokenS
 		
 		// Transliteration is optional
 		if transliterator, ok := m.ProviderRoles[TransliteratorMode]; ok {
-			if tsw, err = transliterator.ProcessFlowController(ctx, TransliteratorMode, tsw); err != nil {
+			if tsw, err = m.process(ctx, transliterator, TransliteratorMode, tsw); err != nil {
 				return &TknSliceWrapper{}, fmt.Errorf("transliteration failed: %w", err)
 			}
 		}
@@ -343,6 +743,7 @@ func (m *Module) TokensWithContext(ctx context.Context, input string) (AnyTokenS
 	return tsw, nil
 }
 
+
 // Tokens processes the input text using a background context and returns token analysis.
 // This is a convenience method for operations that don't need cancellation control.
 //
@@ -369,10 +770,10 @@ func (m *Module) Tokens(input string) (AnyTokenSliceWrapper, error) {
 //   - error: An error if processing fails or the context is canceled
 func (m *Module) LexicalTokensWithContext(ctx context.Context, input string) (AnyTokenSliceWrapper, error) {
 	raw, err := m.TokensWithContext(ctx, input)
-	if err != nil {
+	if err != nil && !isPartial(err) {
 		return nil, err
 	}
-	return ToAnyLexicalTokens(raw), nil
+	return ToAnyLexicalTokens(raw), err
 }
 
 // LexicalTokens returns only tokens containing lexical content using a background context.
@@ -400,13 +801,14 @@ func (m *Module) LexicalTokens(input string) (AnyTokenSliceWrapper, error) {
 //   - error: An error if processing fails, the context is canceled, or romanization isn't supported
 func (m *Module) RomanWithContext(ctx context.Context, input string) (string, error) {
 	if !m.hasTransliterator() {
-		return "", fmt.Errorf("romanization requires a provider with transliteration capability")
+		return "", ErrRomanizationUnsupported
 	}
 	tkns, err := m.TokensWithContext(ctx, input)
-	if err != nil {
+	if err != nil && !isPartial(err) {
 		return "", err
 	}
-	return tkns.Roman(), nil
+	tkns = m.romanTokens(tkns)
+	return joinWithSpacingRule(anyTokens(tkns), m.getSpacingRule(), m.romanText()), err
 }
 
 // Roman returns the input text romanized (transliterated) using a background context.
@@ -422,6 +824,81 @@ func (m *Module) Roman(input string) (string, error) {
 	return m.RomanWithContext(context.Background(), input)
 }
 
+// RomanWithAlignmentWithContext romanizes the input like RomanWithContext
+// and returns the alignment of each token with the romanized text, e.g. to
+// carry the timing of subtitles over to their romanization.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be romanized
+//
+// Returns:
+//   - string: The romanized text
+//   - []RomanAlignment: The byte offsets of each token in the input and in the romanized text
+//   - error: An error if processing fails, the context is canceled, or romanization isn't supported
+func (m *Module) RomanWithAlignmentWithContext(ctx context.Context, input string) (string, []RomanAlignment, error) {
+	if !m.hasTransliterator() {
+		return "", nil, ErrRomanizationUnsupported
+	}
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return "", nil, err
+	}
+	tkns = m.romanTokens(tkns)
+	roman, alignment := joinAligned(anyTokens(tkns), m.getSpacingRule(), m.romanText(), true)
+	return roman, alignment, err
+}
+
+// RomanWithAlignment romanizes the input like Roman and returns the alignment
+// of each token with the romanized text, using a background context.
+//
+// Parameters:
+//   - input: The text to be romanized
+//
+// Returns:
+//   - string: The romanized text
+//   - []RomanAlignment: The byte offsets of each token in the input and in the romanized text
+//   - error: An error if processing fails or romanization isn't supported
+func (m *Module) RomanWithAlignment(input string) (string, []RomanAlignment, error) {
+	return m.RomanWithAlignmentWithContext(context.Background(), input)
+}
+
+// SupportsIPA returns true if the module was built from a scheme that outputs
+// IPA transcription (see GetIPASchemes and GetIPAModule).
+func (m *Module) SupportsIPA() bool {
+	return m.ipa
+}
+
+// IPAWithContext returns the IPA transcription of the input text with the provided context.
+// The module must have been built from an IPA scheme, e.g. with GetIPAModule.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be transcribed
+//
+// Returns:
+//   - string: The IPA transcription
+//   - error: An error if processing fails, the context is canceled, or the scheme doesn't output IPA
+func (m *Module) IPAWithContext(ctx context.Context, input string) (string, error) {
+	if !m.SupportsIPA() {
+		return "", fmt.Errorf("IPA transcription requires a module built from an IPA scheme (provider(s): %s)", m.ProviderNames())
+	}
+	return m.RomanWithContext(ctx, input)
+}
+
+// IPA returns the IPA transcription of the input text using a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Parameters:
+//   - input: The text to be transcribed
+//
+// Returns:
+//   - string: The IPA transcription
+//   - error: An error if processing fails or the scheme doesn't output IPA
+func (m *Module) IPA(input string) (string, error) {
+	return m.IPAWithContext(context.Background(), input)
+}
+
 // RomanPartsWithContext returns an array of romanized word parts with the provided context.
 // This method only returns the lexical tokens (words), not spaces or punctuation.
 // The context allows cancellation during processing.
@@ -435,13 +912,22 @@ func (m *Module) Roman(input string) (string, error) {
 //   - error: An error if processing fails, the context is canceled, or romanization isn't supported
 func (m *Module) RomanPartsWithContext(ctx context.Context, input string) ([]string, error) {
 	if !m.hasTransliterator() {
-		return nil, fmt.Errorf("romanization requires a provider with transliteration capability")
+		return nil, ErrRomanizationUnsupported
 	}
 	tkns, err := m.LexicalTokensWithContext(ctx, input)
-	if err != nil {
+	if err != nil && !isPartial(err) {
 		return []string{}, err
 	}
-	return tkns.RomanParts(), nil
+	tkns = m.romanTokens(tkns)
+	if m.romanOptions == (RomanizationOptions{}) {
+		return tkns.RomanParts(), err
+	}
+	text := m.romanText()
+	parts := make([]string, tkns.Len())
+	for i := range parts {
+		parts[i] = text(tkns.GetIdx(i))
+	}
+	return parts, err
 }
 
 // RomanParts returns an array of romanized word parts using a background context.
@@ -461,22 +947,32 @@ func (m *Module) RomanParts(input string) ([]string, error) {
 // Tokenization breaks the text into individual linguistic units with appropriate spacing.
 // The context allows cancellation during processing.
 //
+// Without options, the tokens are joined following the spacing rule of the language.
+// With options, they are separated by a delimiter (a space by default) for tools that
+// consume delimiter-separated tokens:
+//
+//	m.Tokenized(input, common.WithDelimiter("|"), common.WithEscaping())
+//
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
 //   - input: The text to be tokenized
+//   - opts: Optional delimiter and escaping, see WithDelimiter and WithEscaping
 //
 // Returns:
 //   - string: The tokenized text
 //   - error: An error if processing fails, the context is canceled, or tokenization isn't supported
-func (m *Module) TokenizedWithContext(ctx context.Context, input string) (string, error) {
+func (m *Module) TokenizedWithContext(ctx context.Context, input string, opts ...TokenizedOption) (string, error) {
 	if !m.hasTokenizer() {
 		return "", fmt.Errorf("tokenization requires a provider with tokenization capability")
 	}
 	tkns, err := m.TokensWithContext(ctx, input)
-	if err != nil {
+	if err != nil && !isPartial(err) {
 		return "", err
 	}
-	return tkns.Tokenized(), nil
+	if len(opts) > 0 {
+		return TokenizedWithOptions(tkns, opts...), err
+	}
+	return TokenizedWithSpacingRule(tkns, m.getSpacingRule()), err
 }
 
 // Tokenized returns the input text tokenized using a background context.
@@ -484,12 +980,13 @@ func (m *Module) TokenizedWithContext(ctx context.Context, input string) (string
 //
 // Parameters:
 //   - input: The text to be tokenized
+//   - opts: Optional delimiter and escaping, see TokenizedWithContext
 //
 // Returns:
 //   - string: The tokenized text
 //   - error: An error if processing fails or tokenization isn't supported
-func (m *Module) Tokenized(input string) (string, error) {
-	return m.TokenizedWithContext(context.Background(), input)
+func (m *Module) Tokenized(input string, opts ...TokenizedOption) (string, error) {
+	return m.TokenizedWithContext(context.Background(), input, opts...)
 }
 
 // TokenizedPartsWithContext returns an array of tokenized word parts with the provided context.
@@ -508,10 +1005,10 @@ func (m *Module) TokenizedPartsWithContext(ctx context.Context, input string) ([
 		return nil, fmt.Errorf("tokenization requires a provider with tokenization capability")
 	}
 	tkns, err := m.LexicalTokensWithContext(ctx, input)
-	if err != nil {
+	if err != nil && !isPartial(err) {
 		return []string{}, err
 	}
-	return tkns.TokenizedParts(), nil
+	return tkns.TokenizedParts(), err
 }
 
 // TokenizedParts returns an array of tokenized word parts using a background context.
@@ -527,6 +1024,50 @@ func (m *Module) TokenizedParts(input string) ([]string, error) {
 	return m.TokenizedPartsWithContext(context.Background(), input)
 }
 
+// LemmasWithContext returns the dictionary form of each word of the input with
+// the provided context, e.g. for vocabulary extraction. Only lexical tokens are
+// considered; a token whose provider found no lemma is returned as is.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be processed
+//
+// Returns:
+//   - []string: The lemma of each word, in order
+//   - error: An error if processing fails, the context is canceled, or lemmatization isn't supported
+func (m *Module) LemmasWithContext(ctx context.Context, input string) ([]string, error) {
+	if !m.hasCapability(CapabilityLemmatization) {
+		return nil, fmt.Errorf("lemmatization requires a provider with lemmatization capability (provider(s): %s)", m.ProviderNames())
+	}
+	tkns, err := m.LexicalTokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return []string{}, err
+	}
+	lemmas := make([]string, 0, tkns.Len())
+	for i := 0; i < tkns.Len(); i++ {
+		token := tkns.GetIdx(i)
+		if tkn := BaseToken(token); tkn != nil && tkn.Lemma != "" {
+			lemmas = append(lemmas, tkn.Lemma)
+			continue
+		}
+		lemmas = append(lemmas, token.GetSurface())
+	}
+	return lemmas, err
+}
+
+// Lemmas returns the dictionary form of each word of the input using a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Parameters:
+//   - input: The text to be processed
+//
+// Returns:
+//   - []string: The lemma of each word, in order
+//   - error: An error if processing fails or lemmatization isn't supported
+func (m *Module) Lemmas(input string) ([]string, error) {
+	return m.LemmasWithContext(context.Background(), input)
+}
+
 // CloseWithContext closes the module and its providers with the provided context.
 // This releases any resources used by the module and its providers, such as
 // database connections or containerized services.
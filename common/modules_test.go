@@ -0,0 +1,150 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/kat"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// unavailableProvider is a provider whose backend can't be started
+type unavailableProvider struct {
+	tha.DictTokenizerProvider
+	name     string
+	requires common.PlatformRequirements
+}
+
+func (p *unavailableProvider) Name() string {
+	return p.name
+}
+
+func (p *unavailableProvider) InitWithContext(ctx context.Context) error {
+	return errors.New("backend unreachable")
+}
+
+func (p *unavailableProvider) PlatformRequirements() common.PlatformRequirements {
+	return p.requires
+}
+
+func TestModuleInitReportsAllFailures(t *testing.T) {
+	common.SetPlatform(&common.Platform{OS: "linux", Arch: "arm64"})
+	defer common.SetPlatform(nil)
+
+	m := &common.Module{Lang: tha.Lang, Providers: []common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{
+		&unavailableProvider{name: "docker-backed", requires: common.PlatformRequirements{Docker: true}},
+		tha.NewDictTokenizerProvider(),
+		&unavailableProvider{name: "browser-backed", requires: common.PlatformRequirements{Browser: true}},
+	}}
+	err := m.Init()
+	require.Error(t, err)
+
+	failures := common.ProviderInitErrors(err)
+	require.Len(t, failures, 2)
+	assert.Equal(t, "docker-backed", failures[0].Provider)
+	assert.Equal(t, []string{"Docker"}, failures[0].Missing)
+	assert.Equal(t, "browser-backed", failures[1].Provider)
+	assert.Equal(t, []string{"a headless browser"}, failures[1].Missing)
+	assert.Contains(t, err.Error(), "provider docker-backed init failed: backend unreachable (requires Docker, unavailable on linux/arm64)")
+}
+
+func TestLemmas(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	lemmas, err := m.Lemmas("ผมชอบกินข้าว!")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว"}, lemmas)
+
+	m, err = common.NewModule(tha.Lang, "thai2english.com")
+	require.NoError(t, err)
+	_, err = m.Lemmas("ผมชอบกินข้าว")
+	assert.ErrorContains(t, err, "lemmatization capability")
+}
+
+func TestRomanAlignment(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	input := "ผมชอบกินข้าว สวัสดีครับ"
+	roman, alignment, err := m.RomanWithAlignment(input)
+	require.NoError(t, err)
+
+	tokens, err := m.Tokens(input)
+	require.NoError(t, err)
+	require.Len(t, alignment, tokens.Len())
+	for i, a := range alignment {
+		tkn := tokens.GetIdx(i)
+		assert.Equal(t, tkn.GetSurface(), input[a.SrcStart:a.SrcEnd])
+		if tkn.Roman() != "" {
+			assert.Equal(t, tkn.Roman(), roman[a.RomStart:a.RomEnd])
+		}
+	}
+
+	wrapperAlignment := tokens.(*tha.TknSliceWrapper).RomanAlignment()
+	wrapperRoman := tokens.Roman()
+	last := wrapperAlignment[len(wrapperAlignment)-1]
+	assert.Equal(t, len(wrapperRoman), last.RomEnd)
+}
+
+func TestNewTokenizerModule(t *testing.T) {
+	m, err := common.NewTokenizerModule(tha.Lang, "thai-dict")
+	require.NoError(t, err)
+	defer m.Close()
+	require.NoError(t, m.Init())
+
+	tokenized, err := m.Tokenized("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, "ผม ชอบ กินข้าว", tokenized)
+
+	_, err = m.Roman("ผมชอบกินข้าว")
+	assert.ErrorIs(t, err, common.ErrRomanizationUnsupported)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	_, err = common.NewTokenizerModule(tha.Lang, "paiboonizer")
+	assert.Error(t, err, "paiboonizer isn't a tokenizer")
+}
+
+// BenchmarkTokens compares the processing of a 1MB input by a module whose
+// providers have no length limit, which passes it as a single chunk, with its
+// processing in chunks of 4096 characters.
+func BenchmarkTokens(b *testing.B) {
+	sentence := "საქართველოს დედაქალაქი თბილისია. "
+	input := strings.Repeat(sentence, 1<<20/len(sentence))
+
+	for _, bc := range []struct {
+		name       string
+		chunkifier *common.Chunkifier
+	}{
+		{"unbounded", nil},
+		{"chunkified", common.NewChunkifier(4096)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			m, err := common.DefaultModule(kat.Lang)
+			require.NoError(b, err)
+			if bc.chunkifier != nil {
+				m.WithCustomChunkifier(bc.chunkifier)
+			}
+			require.NoError(b, m.Init())
+			defer m.Close()
+
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.Tokens(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"strings"
+)
+
+// Named entity labels set in Tkn.NamedEntity. NER providers normalize the
+// labels of their backend to these when there is an equivalent and keep
+// the others (e.g. "DATE", "NORP") as is.
+const (
+	EntityPerson       = "PERSON"
+	EntityLocation     = "LOC"
+	EntityOrganization = "ORG"
+	EntityMisc         = "MISC"
+)
+
+// NormalizeEntityLabel maps the common label variants of NER backends
+// (spaCy, pythainlp, CoNLL...) to EntityPerson, EntityLocation and
+// EntityOrganization.
+func NormalizeEntityLabel(label string) string {
+	switch strings.ToUpper(label) {
+	case "PERSON", "PER":
+		return EntityPerson
+	case "LOC", "LOCATION", "GPE", "FAC":
+		return EntityLocation
+	case "ORG", "ORGANIZATION", "ORGANISATION":
+		return EntityOrganization
+	case "MISC":
+		return EntityMisc
+	}
+	return strings.ToUpper(label)
+}
+
+// EntitySpan is a named entity found by a NER backend. Start and End are byte
+// offsets into the text formed by concatenating the surfaces of the tokens
+// (see SurfaceText).
+type EntitySpan struct {
+	Start, End int
+	Label      string
+}
+
+// SurfaceText concatenates the surfaces of all tokens, filler included,
+// which for tokenizers that keep all the input gives back the original text.
+func SurfaceText(tsw AnyTokenSliceWrapper) string {
+	var sb strings.Builder
+	for i := 0; i < tsw.Len(); i++ {
+		sb.WriteString(tsw.GetIdx(i).GetSurface())
+	}
+	return sb.String()
+}
+
+// AnnotateEntities sets the NamedEntity of the lexical tokens overlapping
+// each span. Entities spanning several tokens label each of them.
+// Spans must be sorted by Start, as NER backends return them.
+func AnnotateEntities(tsw AnyTokenSliceWrapper, spans []EntitySpan) {
+	pos, s := 0, 0
+	for i := 0; i < tsw.Len() && s < len(spans); i++ {
+		token := tsw.GetIdx(i)
+		start, end := pos, pos+len(token.GetSurface())
+		pos = end
+		for s < len(spans) && spans[s].End <= start {
+			s++
+		}
+		if s == len(spans) || spans[s].Start >= end || !token.IsLexicalContent() {
+			continue
+		}
+		if tkn := BaseToken(token); tkn != nil {
+			tkn.NamedEntity = NormalizeEntityLabel(spans[s].Label)
+		}
+	}
+}
+
+// Entity is a named entity made of one or more consecutive tokens.
+type Entity struct {
+	Text  string
+	Label string
+	// Tokens holds the indices of the lexical tokens of the entity
+	Tokens []int
+}
+
+// Entities groups the tokens annotated by a NER provider into entities:
+// consecutive lexical tokens with the same label form a single entity,
+// filler between them (e.g. the space in "New York") included.
+func Entities(tsw AnyTokenSliceWrapper) []Entity {
+	var entities []Entity
+	current := -1 // index of the entity being extended
+	var pending strings.Builder // filler following the current entity
+	for i := 0; i < tsw.Len(); i++ {
+		token := tsw.GetIdx(i)
+		if !token.IsLexicalContent() {
+			if current >= 0 {
+				pending.WriteString(token.GetSurface())
+			}
+			continue
+		}
+		label := ""
+		if tkn := BaseToken(token); tkn != nil {
+			label = tkn.NamedEntity
+		}
+		if current >= 0 && label == entities[current].Label {
+			entities[current].Text += pending.String() + token.GetSurface()
+			entities[current].Tokens = append(entities[current].Tokens, i)
+			pending.Reset()
+			continue
+		}
+		current = -1
+		pending.Reset()
+		if label != "" {
+			entities = append(entities, Entity{Text: token.GetSurface(), Label: label, Tokens: []int{i}})
+			current = len(entities) - 1
+		}
+	}
+	return entities
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// NormalizationForm is the Unicode normalization form applied to the input.
+type NormalizationForm int
+
+const (
+	// NoNormalization leaves the input as is.
+	NoNormalization NormalizationForm = iota
+	// NFC composes canonically equivalent sequences, e.g. a letter followed
+	// by a combining accent becomes the precomposed letter.
+	NFC
+	// NFKC additionally replaces compatibility characters by their canonical
+	// counterpart (ligatures, circled numbers, superscripts...).
+	NFKC
+)
+
+// Normalization describes how the input of a module is normalized before
+// being chunked and passed to the providers.
+//
+// As the providers only see the normalized input, the Position of the tokens
+// is relative to it rather than to the original input.
+type Normalization struct {
+	Form NormalizationForm
+
+	// FoldWidth replaces full-width Latin letters and digits by their ASCII
+	// counterpart and half-width katakana by full-width katakana. Full-width
+	// punctuation, which is regular in CJK text, is left as is.
+	FoldWidth bool
+
+	// MaxRepeat squashes the runs of a same character longer than MaxRepeat,
+	// e.g. "sooooo" becomes "sooo" with MaxRepeat 3. Digits are never
+	// squashed. 0 disables squashing.
+	MaxRepeat int
+}
+
+// DefaultNormalization is the normalization of the languages that have none
+// registered: NFC only.
+var DefaultNormalization = Normalization{Form: NFC}
+
+var normalizations = struct {
+	sync.RWMutex
+	rules map[string]Normalization
+}{rules: make(map[string]Normalization)}
+
+// RegisterNormalization sets the Normalization applied to the input of the
+// modules of the given language, in place of DefaultNormalization.
+// Registering a normalization for a language that already has one replaces it.
+func RegisterNormalization(languageCode string, n Normalization) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	normalizations.Lock()
+	defer normalizations.Unlock()
+	normalizations.rules[lang] = n
+	return nil
+}
+
+// GetNormalization returns the Normalization registered for the given
+// language, or DefaultNormalization if there is none.
+func GetNormalization(languageCode string) Normalization {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return DefaultNormalization
+	}
+	normalizations.RLock()
+	defer normalizations.RUnlock()
+	if n, ok := normalizations.rules[lang]; ok {
+		return n
+	}
+	return DefaultNormalization
+}
+
+// Apply returns the normalized string. Width folding is done before the
+// normalization form so that half-width voiced katakana are composed.
+func (n Normalization) Apply(s string) string {
+	if n.FoldWidth {
+		s = strings.Map(foldWidth, s)
+	}
+	switch n.Form {
+	case NFC:
+		s = norm.NFC.String(s)
+	case NFKC:
+		s = norm.NFKC.String(s)
+	}
+	if n.MaxRepeat > 0 {
+		s = squashRepeats(s, n.MaxRepeat)
+	}
+	return s
+}
+
+func foldWidth(r rune) rune {
+	switch {
+	case r >= '０' && r <= '９', r >= 'Ａ' && r <= 'Ｚ', r >= 'ａ' && r <= 'ｚ':
+		return r - '０' + '0'
+	case r >= '｡' && r <= 'ﾟ':
+		// half-width katakana, including the half-width voicing marks which
+		// fold to the combining ones
+		if folded := []rune(width.Fold.String(string(r))); len(folded) == 1 {
+			return folded[0]
+		}
+	}
+	return r
+}
+
+func squashRepeats(s string, max int) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev rune
+	run := 0
+	for _, r := range s {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run > max && !unicode.IsDigit(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
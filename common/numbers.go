@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NumberSpeller spells out numbers in a given language, see NewNumberExpander.
+type NumberSpeller struct {
+	// Integer returns the romanized spoken form of a non-negative integer
+	// given as ASCII digits without separators, and false if the number is
+	// too large to be read.
+	Integer func(digits string) (string, bool)
+
+	// Point is the romanized word read for the decimal separator. The digits
+	// following it are read one by one.
+	Point string
+}
+
+var reNumber = regexp.MustCompile(`[0-9]+(?:,[0-9]{3})*(?:\.[0-9]+)?`)
+
+// digitZeros are the zeros of the decimal digit blocks that normalizeDigits
+// folds to ASCII.
+var digitZeros = []rune{
+	'０', // full-width
+	'๐', // Thai
+	'໐', // Lao
+	'०', // Devanagari
+	'٠', // Arabic-Indic
+	'۰', // Extended Arabic-Indic
+}
+
+// normalizeDigits folds the decimal digits of other scripts, and the
+// full-width comma and full stop, to ASCII.
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '，':
+			return ','
+		case '．':
+			return '.'
+		}
+		for _, zero := range digitZeros {
+			if r >= zero && r <= zero+9 {
+				return '0' + r - zero
+			}
+		}
+		return r
+	}, s)
+}
+
+// Spell returns the romanized spoken form of a number such as "2024",
+// "1,500" or "3.14", and false if it isn't a number or can't be read.
+func (s NumberSpeller) Spell(number string) (string, bool) {
+	number = normalizeDigits(number)
+	if number == "" || reNumber.FindString(number) != number {
+		return "", false
+	}
+	integer, fraction, hasFraction := strings.Cut(strings.ReplaceAll(number, ",", ""), ".")
+	spoken, ok := s.Integer(integer)
+	if !ok {
+		return "", false
+	}
+	if !hasFraction {
+		return spoken, true
+	}
+	parts := []string{spoken, s.Point}
+	for _, digit := range fraction {
+		word, ok := s.Integer(string(digit))
+		if !ok {
+			return "", false
+		}
+		parts = append(parts, word)
+	}
+	return strings.Join(parts, " "), true
+}
+
+// ExpandNumbers sets the romanization of a token that has none of its own
+// and contains numbers (typically a non-lexical token made of digits) to
+// the spoken form of the numbers. The token is made lexical so that its
+// romanization appears in the output of Roman(). It returns whether the
+// token was expanded.
+func ExpandNumbers(tkn *Tkn, speller NumberSpeller) bool {
+	if tkn.Roman() != "" {
+		return false
+	}
+	surface := normalizeDigits(tkn.Surface)
+	if !reNumber.MatchString(surface) {
+		return false
+	}
+	ok := true
+	expanded := reNumber.ReplaceAllStringFunc(surface, func(number string) string {
+		spoken, spelled := speller.Spell(number)
+		if !spelled {
+			ok = false
+			return number
+		}
+		return " " + spoken + " "
+	})
+	if !ok {
+		return false
+	}
+	tkn.Romanization = strings.Join(strings.Fields(expanded), " ")
+	tkn.IsLexical = true
+	return true
+}
+
+// NewNumberExpander returns an enricher spelling out the numbers of the
+// tokens with ExpandNumbers, e.g. to prepare text for speech synthesis.
+// Language packages provide their speller, see e.g. jpn's WithNumberExpansion.
+func NewNumberExpander(speller NumberSpeller) *FuncEnricher {
+	return NewFuncEnricher("number-expander", func(ctx context.Context, tsw AnyTokenSliceWrapper) error {
+		for i := 0; i < tsw.Len(); i++ {
+			if i%1000 == 0 {
+				if err := ctx.Err(); err != nil {
+					return fmt.Errorf("context canceled: %w", err)
+				}
+			}
+			if tkn := BaseToken(tsw.GetIdx(i)); tkn != nil {
+				ExpandNumbers(tkn, speller)
+			}
+		}
+		return nil
+	})
+}
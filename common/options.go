@@ -0,0 +1,54 @@
+package common
+
+import (
+	"fmt"
+)
+
+// Configurable is implemented by providers that accept a typed option struct
+// (e.g. tha.PyThaiNLPOptions) in addition to the untyped map of SaveConfig.
+// Like SaveConfig, ConfigureWith stores the options to be applied on the next
+// initialization and returns an error if they are invalid.
+type Configurable[T any] interface {
+	ConfigureWith(opts T) error
+}
+
+// Configure applies typed options to a provider, or to the provider it wraps
+// (see WrapProvider) if it doesn't accept them itself.
+//
+// Example usage:
+//
+//	err := common.Configure(provider, tha.PyThaiNLPOptions{RomanEngine: "tltk"})
+//
+// Returns an error if the provider doesn't accept options of type T
+// or if the options are invalid.
+func Configure[T any](provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], opts T) error {
+	c, ok := findWrapped[Configurable[T]](provider)
+	if !ok {
+		return fmt.Errorf("provider %s doesn't accept options of type %T", provider.Name(), opts)
+	}
+	if err := c.ConfigureWith(opts); err != nil {
+		return fmt.Errorf("provider %s: invalid options: %w", provider.Name(), err)
+	}
+	return nil
+}
+
+// ConfigureModule applies typed options to the providers of the module that accept them.
+//
+// Returns an error if no provider of the module accepts options of type T
+// or if the options are invalid.
+func ConfigureModule[T any](m *Module, opts T) error {
+	found := false
+	for _, provider := range m.Providers {
+		if _, ok := findWrapped[Configurable[T]](provider); !ok {
+			continue
+		}
+		found = true
+		if err := Configure(provider, opts); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("no provider of module %s accepts options of type %T", m.ProviderNames(), opts)
+	}
+	return nil
+}
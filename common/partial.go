@@ -0,0 +1,113 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ChunkError reports the failure of the providers on a chunk of the input,
+// see WithPartialResults.
+type ChunkError struct {
+	Chunk      int // ID of the chunk in the chunk map of the wrapper
+	Start, End int // Byte offsets of the chunk in the normalized input, -1 if unknown
+	Err        error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (bytes %d-%d): %v", e.Chunk, e.Start, e.End, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// PartialResultsError is returned along with the tokens of the chunks that
+// were processed successfully when some chunks failed, see WithPartialResults.
+// It unwraps to the ChunkError of each failed chunk.
+type PartialResultsError struct {
+	Failed []*ChunkError
+	Chunks int // Number of chunks the input was split into
+}
+
+func (e *PartialResultsError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, failed := range e.Failed {
+		msgs[i] = failed.Error()
+	}
+	return fmt.Sprintf("%d of %d chunks failed:\n\t%s", len(e.Failed), e.Chunks, strings.Join(msgs, "\n\t"))
+}
+
+func (e *PartialResultsError) Unwrap() []error {
+	return chunkErrs(e.Failed)
+}
+
+func chunkErrs(failed []*ChunkError) []error {
+	errs := make([]error, len(failed))
+	for i, f := range failed {
+		errs[i] = f
+	}
+	return errs
+}
+
+// isPartial reports whether err comes with partial results, which are then
+// worth returning to the caller along with it.
+func isPartial(err error) bool {
+	var partial *PartialResultsError
+	return errors.As(err, &partial)
+}
+
+// WithPartialResults sets whether the module keeps going when the providers
+// fail on some chunks of the input. When enabled, a failed call is retried
+// chunk by chunk: Tokens then returns the tokens of the chunks that succeeded
+// along with a *PartialResultsError detailing which byte ranges of the input
+// failed, and Roman, Tokenized and their variants return the output of these
+// tokens along with the same error. The input of the failed chunks is kept in
+// the PrecedingSpace of the following token so ReconstructOriginal still
+// returns the whole input. Tokens fails altogether if every chunk failed or
+// the context is done.
+//
+// Parameters:
+//   - enabled: Whether to return partial results
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithPartialResults(enabled bool) *Module {
+	m.partialResults = enabled
+	return m
+}
+
+// runProvidersByChunk runs the providers on each chunk separately and merges
+// the tokens of the chunks that succeeded, nil if none did. The chunk errors
+// have their byte range set once the tokens are located in the input.
+func (m *Module) runProvidersByChunk(ctx context.Context, chunks []string) (AnyTokenSliceWrapper, []*ChunkError, error) {
+	var merged AnyTokenSliceWrapper
+	var failed []*ChunkError
+	for i, chunk := range chunks {
+		out, err := m.runProviders(ctx, &TknSliceWrapper{Raw: []string{chunk}})
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		switch {
+		case err != nil:
+			failed = append(failed, &ChunkError{Chunk: i, Start: -1, End: -1, Err: err})
+		case merged == nil:
+			merged = out
+		default:
+			merged.Append(out.Tokens()...)
+		}
+	}
+	return merged, failed, nil
+}
+
+// locateChunkErrors sets the byte range of the failed chunks from the chunk
+// map of the wrapper.
+func locateChunkErrors(tsw AnyTokenSliceWrapper, failed []*ChunkError) {
+	chunks := ChunksOf(tsw)
+	for _, f := range failed {
+		if f.Chunk < len(chunks) {
+			f.Start, f.End = chunks[f.Chunk].Start, chunks[f.Chunk].End
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// pickyTokenizer is a thai-dict tokenizer failing on the chunks containing a given word
+type pickyTokenizer struct {
+	tha.DictTokenizerProvider
+	rejects string
+}
+
+func (p *pickyTokenizer) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	for _, chunk := range input.GetRaw() {
+		if strings.Contains(chunk, p.rejects) {
+			return nil, errors.New("unsupported input")
+		}
+	}
+	return p.DictTokenizerProvider.ProcessFlowController(ctx, mode, input)
+}
+
+func TestPartialResults(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return &pickyTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider(), rejects: "สวัสดี"}
+	})
+	m.WithCustomChunkifier(common.NewChunkifier(15))
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	input := "ผมชอบกินข้าว. สวัสดีครับ. ผมชอบ."
+	_, err = m.Tokens(input)
+	require.Error(t, err, "one failing chunk aborts the whole call by default")
+
+	m.WithPartialResults(true)
+	tkns, err := (&tha.Module{Module: m}).Tokens(input)
+	var partial *common.PartialResultsError
+	require.ErrorAs(t, err, &partial)
+	require.Len(t, partial.Failed, 1)
+	failed := partial.Failed[0]
+	assert.Contains(t, input[failed.Start:failed.End], "สวัสดีครับ")
+	assert.ErrorContains(t, failed, "unsupported input")
+	assert.Equal(t, input, tkns.ReconstructOriginal())
+	for _, tkn := range tkns.NativeSlice {
+		assert.NotEqual(t, "สวัสดี", tkn.Surface)
+	}
+
+	tokenized, err := m.TokenizedParts(input)
+	assert.ErrorAs(t, err, &partial)
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว", "ผม", "ชอบ"}, tokenized)
+
+	lemmas, err := m.Lemmas(input)
+	assert.ErrorAs(t, err, &partial)
+	assert.Equal(t, tokenized, lemmas)
+
+	low, err := m.LowConfidenceTokens("สวัสดีครับ. ผมชอบฎฏฐ.", 0.5)
+	assert.ErrorAs(t, err, &partial)
+	require.Len(t, low, 1)
+	assert.Equal(t, "ฎฏฐ", low[0].GetSurface())
+
+	_, err = m.Tokens("สวัสดีครับ. สวัสดีครับ.")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &partial), "no partial results when every chunk failed")
+}
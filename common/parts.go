@@ -0,0 +1,88 @@
+package common
+
+import (
+	"context"
+	"fmt"
+)
+
+// IndexedPart is a word of the input as returned by RomanParts or
+// TokenizedParts, along with the token it comes from, so that the parts can
+// be mapped back to the tokens and to the input, e.g. to highlight a word.
+type IndexedPart struct {
+	Text string // romanized or tokenized text of the word
+
+	// TokenIndex is the index of the token in the wrapper returned by Tokens
+	// for the same input, which holds the non-lexical tokens as well.
+	TokenIndex int
+
+	// Start and End are the byte offsets of the token in the normalized input
+	// (see Tkn.Position and WithNormalization), -1 for the tokens that don't
+	// embed Tkn.
+	Start, End int
+}
+
+// RomanPartsIndexedWithContext returns the romanized word parts of the input
+// like RomanPartsWithContext, each with the index of its token and its
+// position in the input.
+//
+// Example usage:
+//
+//	parts, err := m.RomanPartsIndexed(text)
+//	for _, p := range parts {
+//		fmt.Printf("%s → %s\n", text[p.Start:p.End], p.Text)
+//	}
+func (m *Module) RomanPartsIndexedWithContext(ctx context.Context, input string) ([]IndexedPart, error) {
+	if !m.hasTransliterator() {
+		return nil, ErrRomanizationUnsupported
+	}
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return []IndexedPart{}, err
+	}
+	return indexedParts(tkns, m.isRomanToken, m.romanText()), err
+}
+
+// RomanPartsIndexed returns the romanized word parts of the input with their
+// token index and position using a background context, see
+// RomanPartsIndexedWithContext.
+func (m *Module) RomanPartsIndexed(input string) ([]IndexedPart, error) {
+	return m.RomanPartsIndexedWithContext(context.Background(), input)
+}
+
+// TokenizedPartsIndexedWithContext returns the tokenized word parts of the
+// input like TokenizedPartsWithContext, each with the index of its token and
+// its position in the input.
+func (m *Module) TokenizedPartsIndexedWithContext(ctx context.Context, input string) ([]IndexedPart, error) {
+	if !m.hasTokenizer() {
+		return nil, fmt.Errorf("tokenization requires a provider with tokenization capability")
+	}
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return []IndexedPart{}, err
+	}
+	return indexedParts(tkns, func(AnyToken) bool { return true }, AnyToken.GetSurface), err
+}
+
+// TokenizedPartsIndexed returns the tokenized word parts of the input with
+// their token index and position using a background context, see
+// TokenizedPartsIndexedWithContext.
+func (m *Module) TokenizedPartsIndexed(input string) ([]IndexedPart, error) {
+	return m.TokenizedPartsIndexedWithContext(context.Background(), input)
+}
+
+// indexedParts returns the text of the lexical tokens of tsw that are kept.
+func indexedParts(tsw AnyTokenSliceWrapper, keep func(AnyToken) bool, text func(AnyToken) string) []IndexedPart {
+	parts := []IndexedPart{}
+	for i := 0; i < tsw.Len(); i++ {
+		token := tsw.GetIdx(i)
+		if !token.IsLexicalContent() || !keep(token) {
+			continue
+		}
+		part := IndexedPart{Text: text(token), TokenIndex: i, Start: -1, End: -1}
+		if tkn := BaseToken(token); tkn != nil {
+			part.Start, part.End = tkn.Position.Start, tkn.Position.End
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,43 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestIndexedParts(t *testing.T) {
+	input := "ผมชอบ กินข้าว ครับ"
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	parts, err := m.RomanParts(input)
+	require.NoError(t, err)
+	indexed, err := m.RomanPartsIndexed(input)
+	require.NoError(t, err)
+	tkns, err := m.Tokens(input)
+	require.NoError(t, err)
+	require.Len(t, indexed, len(parts))
+	for i, part := range indexed {
+		assert.Equal(t, parts[i], part.Text)
+		tkn := tkns.GetIdx(part.TokenIndex)
+		assert.Equal(t, tkn.GetSurface(), input[part.Start:part.End])
+		assert.Equal(t, tkn.Roman(), part.Text)
+	}
+	assert.Greater(t, indexed[len(indexed)-1].TokenIndex, len(indexed)-1, "the indices count the spaces")
+
+	tokenized, err := m.TokenizedPartsIndexed(input)
+	require.NoError(t, err)
+	surfaces := make([]string, len(tokenized))
+	for i, part := range tokenized {
+		surfaces[i] = part.Text
+		assert.Equal(t, part.Text, input[part.Start:part.End])
+		assert.Equal(t, indexed[i].TokenIndex, part.TokenIndex)
+	}
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว", "ครับ"}, surfaces)
+}
@@ -0,0 +1,362 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PlatformRequirements describes what a provider needs from the host
+// beyond the Go runtime.
+type PlatformRequirements struct {
+	CGO     bool // links a C/C++ library, unavailable in CGO_ENABLED=0 builds
+	Docker  bool // runs its backend in a Docker container
+	Browser bool // drives a headless browser
+	Network bool // queries a remote service whenever it is used
+}
+
+// PureGo reports whether the provider runs wherever Go programs do,
+// including windows/arm64 and linux/arm64 builds without CGO.
+func (r PlatformRequirements) PureGo() bool {
+	return !r.CGO && !r.Docker && !r.Browser
+}
+
+// PlatformConstrained is implemented by providers that can't run on every platform.
+// Providers that don't implement it are assumed to be pure Go.
+type PlatformConstrained interface {
+	PlatformRequirements() PlatformRequirements
+}
+
+// RequirementsOf returns the platform requirements of a provider.
+func RequirementsOf(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) PlatformRequirements {
+	if pc, ok := provider.(PlatformConstrained); ok {
+		return pc.PlatformRequirements()
+	}
+	return PlatformRequirements{}
+}
+
+// Platform describes the host the library runs on and what it offers.
+type Platform struct {
+	OS      string
+	Arch    string
+	CGO     bool // the binary was built with CGO enabled
+	Docker  bool // a Docker daemon appears to be reachable
+	Browser bool // a headless browser can be launched
+	Offline bool // no network access, see SetOfflineMode
+}
+
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// Satisfies reports whether the platform meets the given requirements.
+func (p Platform) Satisfies(r PlatformRequirements) bool {
+	return (!r.CGO || p.CGO) && (!r.Docker || p.Docker) && (!r.Browser || p.Browser) && (!r.Network || !p.Offline)
+}
+
+// Supports reports whether all the providers of a chain can run on the platform.
+func (p Platform) Supports(providers []ProviderEntry) bool {
+	for _, entry := range providers {
+		if !p.Satisfies(RequirementsOf(entry.Provider)) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	platformOnce     sync.Once
+	platformMu       sync.RWMutex
+	platformOverride *Platform
+	detectedPlatform Platform
+	offlineMode      atomic.Bool
+)
+
+// ErrNoOfflineProviders is returned by DefaultModule and NewModule in offline
+// mode when the language has no providers that run without network access.
+var ErrNoOfflineProviders = errors.New("no providers usable offline")
+
+// SetOfflineMode declares whether the library must work without network access.
+// In offline mode, CurrentPlatform reports no Docker nor browser, since Docker
+// images may have to be pulled and browsers downloaded on first use, and
+// providers querying a remote service are rejected: DefaultModule selects the
+// platform fallback of a language when its defaults aren't fully local, and
+// fails with ErrNoOfflineProviders when there is none.
+func SetOfflineMode(offline bool) {
+	offlineMode.Store(offline)
+}
+
+// OfflineMode reports whether offline mode is enabled, see SetOfflineMode.
+func OfflineMode() bool {
+	return offlineMode.Load()
+}
+
+// CurrentPlatform returns the platform the library runs on. Docker availability
+// is detected once from DOCKER_HOST, the daemon socket and the docker CLI; the
+// browser is assumed available since go-rod downloads one on demand.
+// Use SetPlatform to override the detection. In offline mode, Docker and the
+// browser are reported unavailable.
+func CurrentPlatform() Platform {
+	p := detectPlatform()
+	if OfflineMode() {
+		p.Offline = true
+		p.Docker = false
+		p.Browser = false
+	}
+	return p
+}
+
+func detectPlatform() Platform {
+	platformMu.RLock()
+	override := platformOverride
+	platformMu.RUnlock()
+	if override != nil {
+		return *override
+	}
+	platformOnce.Do(func() {
+		detectedPlatform = Platform{
+			OS:      runtime.GOOS,
+			Arch:    runtime.GOARCH,
+			CGO:     cgoEnabled,
+			Docker:  dockerAvailable(),
+			Browser: true,
+		}
+	})
+	return detectedPlatform
+}
+
+// SetPlatform overrides the detected platform, e.g. to declare Docker unavailable
+// on a machine that has the CLI installed but no running daemon.
+// Passing nil restores detection.
+func SetPlatform(p *Platform) {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	platformOverride = p
+}
+
+func dockerAvailable() bool {
+	if os.Getenv("DOCKER_HOST") != "" {
+		return true
+	}
+	socket := "/var/run/docker.sock"
+	if runtime.GOOS == "windows" {
+		socket = `\\.\pipe\docker_engine`
+	}
+	if _, err := os.Stat(socket); err == nil {
+		return true
+	}
+	_, err := exec.LookPath("docker")
+	return err == nil
+}
+
+// ProviderSupport is a row of the platform capability matrix of a language.
+type ProviderSupport struct {
+	Provider     string
+	Modes        []OperatingMode
+	Requirements PlatformRequirements
+	Usable       bool // the requirements are met by the platform the matrix was built for
+}
+
+// PlatformMatrix lists the providers registered for a language with their
+// platform requirements and whether they can run on the current platform.
+func PlatformMatrix(languageCode string) ([]ProviderSupport, error) {
+	return PlatformMatrixFor(languageCode, CurrentPlatform())
+}
+
+// PlatformMatrixFor is like PlatformMatrix for an arbitrary platform, which lets
+// callers check e.g. what a CGO_ENABLED=0 windows/arm64 build without Docker offers.
+func PlatformMatrixFor(languageCode string, p Platform) ([]ProviderSupport, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+
+	langProviders, exists := GlobalRegistry.Providers[lang]
+	if !exists {
+		return nil, languageNotRegistered(lang)
+	}
+	matrix := make([]ProviderSupport, 0, len(langProviders.Providers))
+	for _, entry := range langProviders.Providers {
+		r := RequirementsOf(entry.Provider)
+		matrix = append(matrix, ProviderSupport{
+			Provider:     entry.Provider.Name(),
+			Modes:        entry.Provider.SupportedModes(),
+			Requirements: r,
+			Usable:       p.Satisfies(r),
+		})
+	}
+	return matrix, nil
+}
+
+// SetPlatformFallback configures the providers DefaultModule uses for a language
+// when its default providers can't run on the current platform (see
+// ResolveDefaults). The fallback
+// chain follows the same rules as the one passed to SetDefault and should be
+// made of pure Go providers.
+func SetPlatformFallback(languageCode string, providers []ProviderEntry) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if len(providers) == 0 {
+		return fmt.Errorf("cannot set empty fallback providers")
+	}
+	providerInterfaces := make([]Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], len(providers))
+	for i, entry := range providers {
+		providerInterfaces[i] = entry.Provider
+	}
+	if err := validateProviderSetup(lang, providerInterfaces); err != nil {
+		return err
+	}
+
+	GlobalRegistry.mu.Lock()
+	defer GlobalRegistry.mu.Unlock()
+	langProviders := GlobalRegistry.Providers[lang]
+	langProviders.Fallback = providers
+	GlobalRegistry.Providers[lang] = langProviders
+	return nil
+}
+
+// Priorities given to the chains set with SetDefault and SetPlatformFallback
+// when resolving the default chain of a language. A provider registered with
+// a Priority above PriorityDefault takes over the default chain of its
+// language on the platforms it can run on, without the language package
+// having to call SetDefault again.
+const (
+	PriorityFallback = 10
+	PriorityDefault  = 50
+)
+
+// ResolveDefaults returns the chain of providers DefaultModule uses for a
+// language on the current platform.
+//
+// The candidates are the chain set with SetDefault (PriorityDefault), the one
+// set with SetPlatformFallback (PriorityFallback) and the chains that can be
+// made of the registered providers that have a Priority: each combined
+// provider, each transliterator if the language doesn't need tokenization,
+// and each tokenizer followed by each transliterator, a chain having the
+// lowest priority of its providers. The candidate of highest priority that
+// can run on the platform wins, ties going to the explicitly set chains then
+// to registration order. If no candidate can run, the SetDefault chain is
+// returned as is so that initializing it reports what is missing.
+func ResolveDefaults(languageCode string) ([]ProviderEntry, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	return resolveDefaults(lang)
+}
+
+type candidateChain struct {
+	providers []ProviderEntry
+	priority  int
+}
+
+// resolveDefaults must be called with the registry lock held.
+func resolveDefaults(lang string) ([]ProviderEntry, error) {
+	langProviders, exists := GlobalRegistry.Providers[lang]
+	if !exists {
+		return nil, languageNotRegistered(lang)
+	}
+	candidates := candidateChains(lang, langProviders)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no default providers set for language: %s", lang)
+	}
+
+	p := CurrentPlatform()
+	for _, c := range candidates {
+		if !p.Supports(c.providers) {
+			continue
+		}
+		if len(langProviders.Defaults) > 0 && !sameChain(c.providers, langProviders.Defaults) {
+			Log.Info().
+				Str("lang", lang).
+				Str("platform", p.String()).
+				Str("providers", chainNames(c.providers)).
+				Msg("Using providers other than the defaults")
+		}
+		return c.providers, nil
+	}
+	if len(langProviders.Defaults) > 0 {
+		return langProviders.Defaults, nil
+	}
+	return candidates[0].providers, nil
+}
+
+// candidateChains returns the candidate default chains of a language by
+// decreasing priority.
+func candidateChains(lang string, langProviders LanguageProviders) []candidateChain {
+	var candidates []candidateChain
+	if len(langProviders.Defaults) > 0 {
+		candidates = append(candidates, candidateChain{langProviders.Defaults, PriorityDefault})
+	}
+	if len(langProviders.Fallback) > 0 {
+		candidates = append(candidates, candidateChain{langProviders.Fallback, PriorityFallback})
+	}
+
+	needsTokenization, _ := NeedsTokenization(lang)
+	var tokenizers, transliterators []ProviderEntry
+	for _, entry := range langProviders.Providers {
+		if entry.Priority <= 0 {
+			continue
+		}
+		modes := entry.Provider.SupportedModes()
+		if contains(modes, CombinedMode) {
+			candidates = append(candidates, candidateChain{[]ProviderEntry{entry}, entry.Priority})
+		}
+		if contains(modes, TokenizerMode) {
+			tokenizers = append(tokenizers, entry)
+		}
+		if contains(modes, TransliteratorMode) {
+			transliterators = append(transliterators, entry)
+			if !needsTokenization && !contains(modes, CombinedMode) {
+				candidates = append(candidates, candidateChain{[]ProviderEntry{entry}, entry.Priority})
+			}
+		}
+	}
+	for _, tokenizer := range tokenizers {
+		for _, transliterator := range transliterators {
+			if tokenizer.Provider == transliterator.Provider {
+				continue
+			}
+			candidates = append(candidates, candidateChain{
+				providers: []ProviderEntry{tokenizer, transliterator},
+				priority:  min(tokenizer.Priority, transliterator.Priority),
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].priority > candidates[j].priority
+	})
+	return candidates
+}
+
+func sameChain(a, b []ProviderEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Provider.Name() != b[i].Provider.Name() {
+			return false
+		}
+	}
+	return true
+}
+
+func chainNames(providers []ProviderEntry) string {
+	names := make([]string, len(providers))
+	for i, entry := range providers {
+		names[i] = entry.Provider.Name()
+	}
+	return strings.Join(names, "→")
+}
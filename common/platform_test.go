@@ -0,0 +1,28 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestOfflineMode(t *testing.T) {
+	common.SetOfflineMode(true)
+	defer common.SetOfflineMode(false)
+
+	m, err := common.DefaultModule(tha.Lang)
+	require.NoError(t, err)
+	assert.Equal(t, "thai-dict→paiboonizer", m.ProviderNames())
+
+	_, err = common.NewModule(tha.Lang, "thai2english.com")
+	assert.ErrorIs(t, err, common.ErrNoOfflineProviders)
+
+	schemes, err := common.GetUsableSchemes(tha.Lang, common.SchemeConstraints{})
+	require.NoError(t, err)
+	for _, scheme := range schemes {
+		assert.False(t, scheme.Requirements.Internet, scheme.Name)
+	}
+}
@@ -0,0 +1,337 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrModulePoolClosed is returned by ModulePool.Get once the pool was closed.
+var ErrModulePoolClosed = errors.New("module pool is closed")
+
+// ProviderCloner is implemented by providers that can make an independent
+// copy of themselves for ModulePool. The copy shares the heavyweight backend
+// of the original (container, browser, dictionaries...) but none of its
+// per-call state (progress callback, open page...), so that the copies can
+// process inputs concurrently.
+type ProviderCloner interface {
+	Clone() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+}
+
+// ModulePool lends modules of a language for exclusive use, so that several
+// goroutines can process inputs at the same time: a Module isn't safe for
+// concurrent use.
+//
+// The modules are created on demand, up to the size of the pool. The modules
+// created by DefaultModule, NewModule or GetSchemeModule share the provider
+// instances of the registry, so the pool gives each module its own copy of
+// the providers that implement ProviderCloner. The other providers stay
+// shared by the modules of the pool, which take turns using them, and are
+// left open when the pool is closed since the registry owns them.
+type ModulePool struct {
+	newModule func() (*Module, error)
+
+	mu      sync.Mutex
+	slots   chan struct{}
+	done    chan struct{} // closed by Close
+	idle    []*Module
+	modules map[*Module]struct{}
+	shared  map[Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]]*sharedProvider
+	closed  bool
+}
+
+// NewModulePool creates a pool of at most size modules created by newModule.
+// A size of zero or less means 1.
+func NewModulePool(size int, newModule func() (*Module, error)) *ModulePool {
+	if size <= 0 {
+		size = 1
+	}
+	return &ModulePool{
+		newModule: newModule,
+		slots:     make(chan struct{}, size),
+		done:      make(chan struct{}),
+		modules:   make(map[*Module]struct{}),
+		shared:    make(map[Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]]*sharedProvider),
+	}
+}
+
+// NewDefaultModulePool creates a pool of at most size modules with the
+// default providers of the given language, see DefaultModule.
+func NewDefaultModulePool(languageCode string, size int) (*ModulePool, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	return NewModulePool(size, func() (*Module, error) {
+		return DefaultModule(lang)
+	}), nil
+}
+
+// Get borrows an initialized module from the pool. It blocks while all the
+// modules are lent, until one is put back, ctx is done or the pool is closed.
+// The module must be given back with Put.
+func (mp *ModulePool) Get(ctx context.Context) (*Module, error) {
+	mp.mu.Lock()
+	closed := mp.closed
+	mp.mu.Unlock()
+	if closed {
+		return nil, ErrModulePoolClosed
+	}
+
+	select {
+	case mp.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a module: %w", ctx.Err())
+	case <-mp.done:
+		return nil, ErrModulePoolClosed
+	}
+
+	mp.mu.Lock()
+	if mp.closed {
+		mp.mu.Unlock()
+		<-mp.slots
+		return nil, ErrModulePoolClosed
+	}
+	if n := len(mp.idle); n > 0 {
+		m := mp.idle[n-1]
+		mp.idle = mp.idle[:n-1]
+		mp.mu.Unlock()
+		return m, nil
+	}
+	mp.mu.Unlock()
+
+	m, err := mp.create(ctx)
+	if err != nil {
+		<-mp.slots
+		return nil, err
+	}
+	return m, nil
+}
+
+func (mp *ModulePool) create(ctx context.Context) (*Module, error) {
+	m, err := mp.newModule()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module: %w", err)
+	}
+
+	mp.mu.Lock()
+	m.isolateProviders(mp.isolate)
+	mp.mu.Unlock()
+
+	if err := m.InitWithContext(ctx); err != nil {
+		m.CloseWithContext(ctx)
+		return nil, err
+	}
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	if mp.closed {
+		m.CloseWithContext(ctx)
+		return nil, ErrModulePoolClosed
+	}
+	mp.modules[m] = struct{}{}
+	return m, nil
+}
+
+// isolate returns the provider the module of the pool uses in place of p.
+// It must be called with mp.mu held.
+func (mp *ModulePool) isolate(p Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+	if cloner, ok := p.(ProviderCloner); ok {
+		return cloner.Clone()
+	}
+	s, ok := mp.shared[p]
+	if !ok {
+		s = &sharedProvider{provider: p}
+		mp.shared[p] = s
+	}
+	return &pooledProvider{shared: s}
+}
+
+// Put gives back a module obtained from Get. Putting back a module that isn't
+// lent by the pool is a no-op. A module put back after the pool was closed
+// is closed.
+func (mp *ModulePool) Put(m *Module) {
+	mp.mu.Lock()
+	if _, ok := mp.modules[m]; !ok || slices.Contains(mp.idle, m) {
+		mp.mu.Unlock()
+		return
+	}
+	<-mp.slots
+	if !mp.closed {
+		mp.idle = append(mp.idle, m)
+		mp.mu.Unlock()
+		return
+	}
+	delete(mp.modules, m)
+	mp.mu.Unlock()
+
+	if err := m.Close(); err != nil {
+		Log.Warn().Err(err).Str("lang", m.Lang).Msg("Failed to close module put back in closed pool")
+	}
+}
+
+// Close closes the idle modules of the pool. The lent modules are closed
+// when they are put back. Get fails afterwards.
+func (mp *ModulePool) Close() error {
+	mp.mu.Lock()
+	if mp.closed {
+		mp.mu.Unlock()
+		return nil
+	}
+	mp.closed = true
+	close(mp.done)
+	idle := mp.idle
+	mp.idle = nil
+	for _, m := range idle {
+		delete(mp.modules, m)
+	}
+	mp.mu.Unlock()
+
+	var errs []error
+	for _, m := range idle {
+		if err := m.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isolateProviders replaces each provider of the module by the one returned
+// by replace, which is called once per distinct provider.
+func (m *Module) isolateProviders(replace func(Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) {
+	replaced := make(map[Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]]Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper])
+	get := func(p Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+		if r, ok := replaced[p]; ok {
+			return r
+		}
+		r := replace(p)
+		replaced[p] = r
+		return r
+	}
+	for i, p := range m.Providers {
+		m.Providers[i] = get(p)
+	}
+	for mode, p := range m.ProviderRoles {
+		m.ProviderRoles[mode] = get(p)
+	}
+	for i, pp := range m.postProcessors {
+		m.postProcessors[i].provider = get(pp.provider)
+	}
+	for i, t := range m.transliterators {
+		m.transliterators[i].provider = get(t.provider)
+	}
+}
+
+// sharedProvider is a provider used by several modules of a pool in turn.
+type sharedProvider struct {
+	mu          sync.Mutex
+	provider    Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+	initialized bool
+}
+
+// pooledProvider is the handle of a module of a pool on a shared provider.
+// Calls are serialized, the provider is initialized once and left open for
+// the registry that owns it, and each module keeps its own callbacks.
+type pooledProvider struct {
+	shared                   *sharedProvider
+	progressCallback         ProgressCallback
+	downloadProgressCallback DownloadProgressCallback
+}
+
+// Unwrap returns the shared provider.
+func (p *pooledProvider) Unwrap() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+	return p.shared.provider
+}
+
+func (p *pooledProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.shared.mu.Lock()
+	defer p.shared.mu.Unlock()
+	return p.shared.provider.SaveConfig(cfg)
+}
+
+func (p *pooledProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitWithContext initializes the shared provider unless another module
+// of the pool already did.
+func (p *pooledProvider) InitWithContext(ctx context.Context) error {
+	p.shared.mu.Lock()
+	defer p.shared.mu.Unlock()
+	if p.shared.initialized {
+		return nil
+	}
+	p.shared.provider.WithDownloadProgressCallback(p.downloadProgressCallback)
+	err := p.shared.provider.InitWithContext(ctx)
+	p.shared.initialized = err == nil
+	return err
+}
+
+func (p *pooledProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *pooledProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	p.shared.mu.Lock()
+	defer p.shared.mu.Unlock()
+	p.shared.provider.WithDownloadProgressCallback(p.downloadProgressCallback)
+	err := p.shared.provider.InitRecreateWithContext(ctx, noCache)
+	p.shared.initialized = err == nil
+	return err
+}
+
+func (p *pooledProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// CloseWithContext leaves the shared provider open: it is an instance of the
+// registry, which modules outside the pool may be using.
+func (p *pooledProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *pooledProvider) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	p.shared.mu.Lock()
+	defer p.shared.mu.Unlock()
+	p.shared.provider.WithProgressCallback(p.progressCallback)
+	return p.shared.provider.ProcessFlowController(ctx, mode, input)
+}
+
+func (p *pooledProvider) WithProgressCallback(callback ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *pooledProvider) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+func (p *pooledProvider) Name() string {
+	return p.shared.provider.Name()
+}
+
+func (p *pooledProvider) SupportedModes() []OperatingMode {
+	return p.shared.provider.SupportedModes()
+}
+
+func (p *pooledProvider) GetMaxQueryLen() int {
+	return p.shared.provider.GetMaxQueryLen()
+}
+
+// ResourceVersions implements VersionReporter for the shared providers that
+// report their versions.
+func (p *pooledProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	reporter, ok := p.shared.provider.(VersionReporter)
+	if !ok {
+		return nil, nil
+	}
+	p.shared.mu.Lock()
+	defer p.shared.mu.Unlock()
+	return reporter.ResourceVersions(ctx)
+}
+
+// PlatformRequirements implements PlatformConstrained.
+func (p *pooledProvider) PlatformRequirements() PlatformRequirements {
+	return RequirementsOf(p.shared.provider)
+}
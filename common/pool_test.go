@@ -0,0 +1,112 @@
+package common_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestModulePool(t *testing.T) {
+	// the breaker can't be cloned so the modules of the pool take turns using it
+	shared := common.NewCircuitBreaker(tha.NewDictTokenizerProvider(), nil, common.CircuitBreakerConfig{})
+	pool := common.NewModulePool(2, func() (*common.Module, error) {
+		m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+		if err != nil {
+			return nil, err
+		}
+		return m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+			return shared
+		}), nil
+	})
+	defer pool.Close()
+
+	ctx := context.Background()
+	romans := make([]string, 8)
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range romans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := pool.Get(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer pool.Put(m)
+			romans[i], errs[i] = m.Roman("ผมชอบกินข้าว")
+		}()
+	}
+	wg.Wait()
+	for i := range romans {
+		require.NoError(t, errs[i])
+		assert.Equal(t, romans[0], romans[i])
+	}
+	assert.NotContains(t, romans[0], "ผ")
+
+	m1, err := pool.Get(ctx)
+	require.NoError(t, err)
+	m2, err := pool.Get(ctx)
+	require.NoError(t, err)
+	assert.NotSame(t, m1, m2)
+	assert.NotSame(t, m1.ProviderRoles[common.TransliteratorMode], m2.ProviderRoles[common.TransliteratorMode], "paiboonizer should have been cloned")
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = pool.Get(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the pool is exhausted")
+
+	pool.Put(m1)
+	pool.Put(m2)
+	require.NoError(t, pool.Close())
+	_, err = pool.Get(ctx)
+	assert.ErrorIs(t, err, common.ErrModulePoolClosed)
+}
+
+func TestModulePoolClose(t *testing.T) {
+	// stands for a provider instance of the registry, shared with the modules
+	// outside the pool
+	tokenizer := &containerTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider()}
+	shared := common.NewCircuitBreaker(tokenizer, nil, common.CircuitBreakerConfig{})
+	pool := common.NewModulePool(1, func() (*common.Module, error) {
+		m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+		if err != nil {
+			return nil, err
+		}
+		return m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+			return shared
+		}), nil
+	})
+
+	ctx := context.Background()
+	m, err := pool.Get(ctx)
+	require.NoError(t, err)
+	blocked := make(chan error)
+	go func() {
+		_, err := pool.Get(ctx)
+		blocked <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the goroutine wait for the lent module
+
+	require.NoError(t, pool.Close())
+	select {
+	case err := <-blocked:
+		assert.ErrorIs(t, err, common.ErrModulePoolClosed)
+	case <-time.After(time.Second):
+		t.Fatal("Close should wake up the callers waiting for a module")
+	}
+
+	roman, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err, "the lent module should stay usable until it is put back")
+	assert.NotContains(t, roman, "ผ")
+	pool.Put(m)
+	_, err = pool.Get(ctx)
+	assert.ErrorIs(t, err, common.ErrModulePoolClosed)
+	assert.Equal(t, 0, tokenizer.closes, "the shared provider belongs to the registry")
+}
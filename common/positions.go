@@ -0,0 +1,215 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Chunk describes a chunk of the input as it was sent to the providers.
+// Start and End are byte offsets into the input, the whitespace between two
+// chunks belonging to the first; FirstSentence and LastSentence are the IDs
+// of the sentences it overlaps (see Tkn.Position).
+type Chunk struct {
+	ID            int
+	Start, End    int
+	FirstSentence int
+	LastSentence  int
+}
+
+// Text returns the text of the chunk in the input it was taken from.
+func (c Chunk) Text(input string) string {
+	return input[c.Start:c.End]
+}
+
+// SetChunks sets the chunk map of the wrapper. Module.Tokens sets it.
+func (tokens *TknSliceWrapper) SetChunks(chunks []Chunk) {
+	tokens.Chunks = chunks
+}
+
+// GetChunks returns the chunk map of the wrapper.
+func (tokens *TknSliceWrapper) GetChunks() []Chunk {
+	return tokens.Chunks
+}
+
+// ChunkOf returns the chunk a token was produced from. The chunk ID is held
+// by the token itself, so this works on tokens that were filtered out of,
+// moved between or cached apart from the wrapper, as long as they come from
+// the same input.
+func (tokens *TknSliceWrapper) ChunkOf(token AnyToken) (Chunk, bool) {
+	tkn := BaseToken(token)
+	if tkn == nil || tkn.Position.Chunk < 0 || tkn.Position.Chunk >= len(tokens.Chunks) {
+		return Chunk{}, false
+	}
+	return tokens.Chunks[tkn.Position.Chunk], true
+}
+
+// chunkMapper is implemented by the wrappers embedding TknSliceWrapper
+type chunkMapper interface {
+	SetChunks([]Chunk)
+	GetChunks() []Chunk
+}
+
+// ChunksOf returns the chunk map of any token slice wrapper, or nil if the
+// wrapper doesn't keep one.
+func ChunksOf(tsw AnyTokenSliceWrapper) []Chunk {
+	if cm, ok := tsw.(chunkMapper); ok {
+		return cm.GetChunks()
+	}
+	return nil
+}
+
+// copyChunks carries the chunk map and the provider versions over to a
+// wrapper derived from another
+func copyChunks(dst, src AnyTokenSliceWrapper) {
+	if cm, ok := dst.(chunkMapper); ok {
+		cm.SetChunks(ChunksOf(src))
+	}
+	if vk, ok := dst.(versionKeeper); ok {
+		vk.SetProviderVersions(ProviderVersionsOf(src))
+	}
+}
+
+// trailingSpaceKeeper is implemented by the wrappers embedding TknSliceWrapper
+type trailingSpaceKeeper interface {
+	setTrailingSpace(string)
+	trailingSpace() string
+}
+
+func (tokens *TknSliceWrapper) setTrailingSpace(s string) {
+	tokens.TrailingSpace = s
+}
+
+func (tokens *TknSliceWrapper) trailingSpace() string {
+	return tokens.TrailingSpace
+}
+
+// ReconstructOriginal returns the input the tokens were produced from by
+// Module.Tokens, exactly: the text of each token preceded by its
+// PrecedingSpace, then the TrailingSpace of the wrapper. Tokens that weren't
+// found in the input (empty span) are left out since their text is part of
+// the surrounding spaces. The wrapper must hold all the tokens: filtered
+// wrappers such as those of ToLexicalTokens lack the non-lexical text.
+func (tokens *TknSliceWrapper) ReconstructOriginal() string {
+	var b strings.Builder
+	for _, token := range tokens.Slice {
+		tkn := BaseToken(token)
+		if tkn == nil {
+			continue
+		}
+		b.WriteString(tkn.PrecedingSpace)
+		if tkn.Position.End > tkn.Position.Start {
+			b.WriteString(tkn.Surface)
+		}
+	}
+	b.WriteString(tokens.TrailingSpace)
+	return b.String()
+}
+
+// sentenceStarts returns the byte offsets at which the sentences of text start
+func sentenceStarts(text string) []int {
+	starts := []int{0}
+	remaining, state := text, -1
+	for len(remaining) > 0 {
+		var sentence string
+		sentence, remaining, state = uniseg.FirstSentenceInString(remaining, state)
+		if sentence == "" {
+			break
+		}
+		if len(remaining) > 0 {
+			starts = append(starts, len(text)-len(remaining))
+		}
+	}
+	return starts
+}
+
+// indexOf returns the index of the span containing offset, given the sorted
+// start offsets of the spans
+func indexOf(starts []int, offset int) int {
+	return max(sort.Search(len(starts), func(i int) bool { return starts[i] > offset })-1, 0)
+}
+
+// chunkStartOffsets returns the byte offset in the input at which each chunk
+// starts. The chunkifier may add or drop whitespace around the chunks, so
+// they are located by their content and extended to tile the input.
+func chunkStartOffsets(input string, chunks []string) []int {
+	chunkStarts := make([]int, len(chunks))
+	pos := 0
+	for i, chunk := range chunks {
+		chunk = strings.TrimSpace(chunk)
+		if idx := strings.Index(input[pos:], chunk); idx >= 0 && i > 0 {
+			pos += idx
+		}
+		chunkStarts[i] = pos
+		pos = min(pos+len(chunk), len(input))
+	}
+	return chunkStarts
+}
+
+// annotatePositions sets the position of the tokens in the input: byte offsets,
+// sentence ID and chunk ID, and attaches the chunk map to the wrapper.
+// Tokens are located by searching their surface in the input from the end of
+// the previous token, so filler dropped by a provider is skipped; a token
+// whose surface isn't found (e.g. normalized by its provider) is given an
+// empty span at the current position. The input between two tokens is kept
+// in Tkn.PrecedingSpace, and after the last one in the wrapper, so that the
+// input can be rebuilt with ReconstructOriginal.
+func annotatePositions(input string, chunks []string, tsw AnyTokenSliceWrapper) {
+	chunkStarts := chunkStartOffsets(input, chunks)
+	sentStarts := sentenceStarts(input)
+
+	// the tokens are in order: the sentence and chunk of a token are found
+	// from those of the previous one
+	pos, sentence, chunk := 0, 0, 0
+	for i := 0; i < tsw.Len(); i++ {
+		token := tsw.GetIdx(i)
+		tkn := BaseToken(token)
+		if tkn == nil {
+			continue
+		}
+		start, prevEnd := pos, pos
+		if surface := token.GetSurface(); strings.TrimSpace(surface) == "" {
+			// Whitespace tokens may have been added or widened by the
+			// chunkifier: only whitespace found right here is theirs
+			if surface != "" && strings.HasPrefix(input[pos:], surface) {
+				pos += len(surface)
+			}
+		} else if idx := strings.Index(input[pos:], surface); idx >= 0 {
+			start = pos + idx
+			pos = start + len(surface)
+		}
+		tkn.Position.Start, tkn.Position.End = start, pos
+		tkn.PrecedingSpace = input[prevEnd:start]
+		for sentence+1 < len(sentStarts) && sentStarts[sentence+1] <= start {
+			sentence++
+		}
+		for chunk+1 < len(chunkStarts) && chunkStarts[chunk+1] <= start {
+			chunk++
+		}
+		tkn.Position.Sentence, tkn.Position.Chunk = sentence, chunk
+	}
+	if w, ok := tsw.(trailingSpaceKeeper); ok {
+		w.setTrailingSpace(input[pos:])
+	}
+
+	cm, ok := tsw.(chunkMapper)
+	if !ok {
+		return
+	}
+	chunkMap := make([]Chunk, len(chunks))
+	for i, start := range chunkStarts {
+		end := len(input)
+		if i+1 < len(chunkStarts) {
+			end = chunkStarts[i+1]
+		}
+		chunkMap[i] = Chunk{
+			ID:            i,
+			Start:         start,
+			End:           end,
+			FirstSentence: indexOf(sentStarts, start),
+			LastSentence:  indexOf(sentStarts, max(end-1, start)),
+		}
+	}
+	cm.SetChunks(chunkMap)
+}
@@ -0,0 +1,55 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestTokenPositions(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WithCustomChunkifier(common.NewChunkifier(15))
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	input := "ผมชอบกินข้าว. สวัสดีครับ. ผมชอบ."
+	lexical, err := (&tha.Module{Module: m}).LexicalTokens(input)
+	require.NoError(t, err)
+	chunks := lexical.GetChunks()
+	require.Greater(t, len(chunks), 1, "the input should have been split")
+
+	for i := 0; i < lexical.Len(); i++ {
+		tkn := lexical.NativeSlice[i]
+		assert.Equal(t, tkn.Surface, input[tkn.Position.Start:tkn.Position.End])
+		chunk, ok := lexical.ChunkOf(tkn)
+		require.True(t, ok)
+		assert.Contains(t, chunk.Text(input), tkn.Surface)
+		assert.True(t, tkn.Position.Sentence >= chunk.FirstSentence && tkn.Position.Sentence <= chunk.LastSentence)
+	}
+	last := lexical.NativeSlice[lexical.Len()-1]
+	assert.Equal(t, 2, last.Position.Sentence)
+	assert.Equal(t, len(chunks)-1, last.Position.Chunk)
+}
+
+func TestReconstructOriginal(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WithCustomChunkifier(common.NewChunkifier(15))
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	input := "ผมชอบกินข้าว.  สวัสดีครับ. ผมชอบ กินข้าว มาก.\nสวัสดี \t"
+	tkns, err := (&tha.Module{Module: m}).Tokens(input)
+	require.NoError(t, err)
+	assert.Equal(t, input, tkns.ReconstructOriginal())
+	assert.Equal(t, " \t", tkns.TrailingSpace)
+	for _, tkn := range tkns.NativeSlice {
+		if tkn.Surface == "กินข้าว" && tkn.Position.Start > 30 {
+			assert.Equal(t, " ", tkn.PrecedingSpace)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressEvent reports the progress of one of the providers of a module
+// while it processes an input, see Module.WithProgressCallbackV2.
+type ProgressEvent struct {
+	Stage    OperatingMode // role of the provider in the module, e.g. TokenizerMode or EnricherMode
+	Provider string        // name of the provider, e.g. "pythainlp"
+	Chunk    int           // index of the chunk being processed (0-based), or number of tokens processed, see ProgressCallback
+	Total    int           // total number of chunks to process
+	Elapsed  time.Duration // time since the provider started processing the input
+}
+
+// ProgressCallbackV2 receives the ProgressEvents of a module. Unlike
+// ProgressCallback, it tells which provider, and at which stage, reports.
+type ProgressCallbackV2 func(event ProgressEvent)
+
+// progressClock records when a provider started processing the current
+// input, to compute the Elapsed of its events.
+type progressClock struct {
+	mu    sync.Mutex
+	start time.Time
+}
+
+func (c *progressClock) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start = time.Now()
+}
+
+func (c *progressClock) elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.start.IsZero() {
+		return 0
+	}
+	return time.Since(c.start)
+}
+
+// WithProgressCallbackV2 sets a callback receiving the progress of all the
+// providers of the module with the stage running (tokenizer, transliterator,
+// enricher...), the name of the provider, the chunk being processed and the
+// time elapsed since the provider started, so that a user interface can tell
+// the stages of a module apart. It can be set along with WithProgressCallback,
+// which keeps receiving the chunk index and count only.
+//
+// Returns the module for method chaining.
+func (m *Module) WithProgressCallbackV2(callback ProgressCallbackV2) *Module {
+	m.progressCallbackV2 = callback
+	for _, provider := range m.Providers {
+		m.passProgressCallback(provider)
+	}
+	return m
+}
+
+// passProgressCallback gives the provider a callback forwarding its progress
+// to the progress callbacks of the module.
+func (m *Module) passProgressCallback(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) {
+	callback, callbackV2 := m.progressCallback, m.progressCallbackV2
+	if callbackV2 == nil {
+		if callback != nil {
+			provider.WithProgressCallback(callback)
+		}
+		return
+	}
+	clock := m.progressClock(provider)
+	provider.WithProgressCallback(func(current, total int) {
+		if callback != nil {
+			callback(current, total)
+		}
+		callbackV2(ProgressEvent{
+			Stage:    m.stageOf(provider),
+			Provider: provider.Name(),
+			Chunk:    current,
+			Total:    total,
+			Elapsed:  clock.elapsed(),
+		})
+	})
+}
+
+// progressClock returns the clock of the provider, which process resets
+// each time the provider is run.
+func (m *Module) progressClock(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *progressClock {
+	m.progressClocksMu.Lock()
+	defer m.progressClocksMu.Unlock()
+	if m.progressClocks == nil {
+		m.progressClocks = make(map[Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]]*progressClock)
+	}
+	clock, ok := m.progressClocks[provider]
+	if !ok {
+		clock = &progressClock{}
+		m.progressClocks[provider] = clock
+	}
+	return clock
+}
+
+// startProgressClock resets the clock of the provider, if it reports its
+// progress to a ProgressCallbackV2.
+func (m *Module) startProgressClock(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) {
+	if m.progressCallbackV2 != nil {
+		m.progressClock(provider).reset()
+	}
+}
+
+// stageOf returns the role of the provider in the module.
+func (m *Module) stageOf(provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) OperatingMode {
+	for _, mode := range []OperatingMode{CombinedMode, TokenizerMode, TransliteratorMode, ReverseTransliteratorMode} {
+		if m.ProviderRoles[mode] == provider {
+			return mode
+		}
+	}
+	for _, pp := range m.postProcessors {
+		if pp.provider == provider {
+			return pp.mode
+		}
+	}
+	for _, t := range m.transliterators {
+		if t.provider == provider {
+			return TransliteratorMode
+		}
+	}
+	return ""
+}
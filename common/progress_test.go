@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestProgressCallbackV2(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	var legacy int
+	var events []common.ProgressEvent
+	m.WithProgressCallback(func(current, total int) { legacy++ })
+	m.WithProgressCallbackV2(func(event common.ProgressEvent) { events = append(events, event) })
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, len(events), legacy)
+
+	stages := make(map[common.OperatingMode]string)
+	for _, event := range events {
+		stages[event.Stage] = event.Provider
+		assert.LessOrEqual(t, event.Chunk, event.Total)
+		assert.GreaterOrEqual(t, event.Elapsed, time.Duration(0))
+	}
+	assert.Equal(t, map[common.OperatingMode]string{
+		common.TokenizerMode:      "thai-dict",
+		common.TransliteratorMode: "paiboonizer",
+	}, stages)
+}
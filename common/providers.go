@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"context"
+	"slices"
+	"strings"
 )
 
 type OperatingMode string
@@ -12,11 +14,25 @@ const (
 	TokenizerMode      OperatingMode = "tokenizer"
 	TransliteratorMode OperatingMode = "transliterator"
 	CombinedMode       OperatingMode = "combined"
+	// EnricherMode providers run after tokenization and transliteration and
+	// decorate the tokens in place (glosses, frequencies...). See Module.WithEnricher.
+	EnricherMode       OperatingMode = "enricher"
+	// NERMode providers run after tokenization and transliteration and set the
+	// NamedEntity of the tokens part of a named entity. See Module.WithNER.
+	NERMode            OperatingMode = "ner"
+	// ReverseTransliteratorMode providers convert romanized text back to the
+	// native script of the language. They are given the raw chunks of the
+	// input and return one token per chunk, with the native text as Surface
+	// and the chunk as Romanization. See Module.ToNative.
+	ReverseTransliteratorMode OperatingMode = "reverse"
 )
 
 // ProgressCallback is a function that reports the progress of a processing operation
 // current is the index of the chunk currently being processed (0-based)
 // total is the total number of chunks to process
+// The providers processing their tokens with ForEachToken or ForEachIndex
+// report tokens instead: current is then the number of tokens processed so
+// far, failed ones included, reported after each token (1 to total).
 // If a provider returns 0 or math.MaxInt32 (or greater) from GetMaxQueryLen(),
 // the progress cannot be accurately tracked.
 type ProgressCallback func(current, total int)
@@ -28,6 +44,20 @@ type ProgressCallback func(current, total int)
 // status: current operation (e.g., "Downloading...", "Extracting...")
 type DownloadProgressCallback func(providerName string, current, total int64, status string)
 
+// DownloadProgressReporter is implemented by anything that may download
+// resources (Docker images, dictionaries, models...) before it can be used.
+// Every Provider implements it, so that Module passes its download progress
+// callbacks to all its providers; providers that don't download anything
+// implement it as a no-op. Components that aren't providers, such as the
+// Dictionary of a GlossEnricher, can implement it to have the provider
+// holding them forward the callback.
+type DownloadProgressReporter interface {
+	// WithDownloadProgressCallback sets a callback function to report download progress.
+	// This is called during Docker image pulls with current bytes, total bytes,
+	// and status string. Used for displaying download progress bars in user interfaces.
+	WithDownloadProgressCallback(callback DownloadProgressCallback)
+}
+
 // Provider is a unified interface for all providers of any type in the library.
 // It handles tokenization, transliteration, or both combined, for specific languages.
 // Providers process text input and return processed tokens with linguistic annotations.
@@ -83,10 +113,9 @@ type Provider[In AnyTokenSliceWrapper, Out AnyTokenSliceWrapper] interface {
 	// progress bars in user interfaces.
 	WithProgressCallback(callback ProgressCallback)
 
-	// WithDownloadProgressCallback sets a callback function to report download progress.
-	// This is called during Docker image pulls with current bytes, total bytes,
-	// and status string. Used for displaying download progress bars in user interfaces.
-	WithDownloadProgressCallback(callback DownloadProgressCallback)
+	// DownloadProgressReporter lets the Module pass its download progress
+	// callback to every provider, see DownloadProgressReporter.
+	DownloadProgressReporter
 
 	// Name returns the unique identifier of the provider.
 	// This is used for registration and lookup in the provider registry.
@@ -104,12 +133,23 @@ type Provider[In AnyTokenSliceWrapper, Out AnyTokenSliceWrapper] interface {
 
 type LanguageProviders struct {
 	Defaults  []ProviderEntry
+	Fallback  []ProviderEntry // used instead of Defaults when they can't run on the platform
 	Providers []ProviderEntry
 }
 
 type ProviderEntry struct {
 	Provider     Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
-	Capabilities []string
+	Capabilities []Capability
+
+	// Priority makes a registered provider a candidate for the default chain
+	// of its language, the higher the better (see ResolveDefaults).
+	// 0 means the provider is only used when selected explicitly.
+	Priority int
+
+	// Aliases are other names the provider can be selected with, e.g. in
+	// NewModule or the providers of a scheme. Like the name of the
+	// provider, they are matched ignoring case.
+	Aliases []string
 }
 
 
@@ -118,6 +158,9 @@ func getProvider(lang string, mode OperatingMode, name string) (Provider[AnyToke
 	defer GlobalRegistry.mu.RUnlock()
 
 	entry, ok := findProvider(lang, mode, name)
+	if _, exists := GlobalRegistry.Providers[lang]; !ok && !exists {
+		return nil, fmt.Errorf("provider not found: %s (mode: %s): %w", name, mode, languageNotRegistered(lang))
+	}
 	if !ok {
 		return nil, fmt.Errorf("provider not found: %s (mode: %s) for language %s or mul", name, mode, lang)
 	}
@@ -127,41 +170,51 @@ func getProvider(lang string, mode OperatingMode, name string) (Provider[AnyToke
 
 
 // findProvider looks for a provider first in the specified language's registry,
-// then falls back to multilingual providers if not found
+// then falls back to multilingual providers if not found. An exact match of
+// the name of a provider wins over a case-insensitive match of its name or
+// of one of its aliases, e.g. "TH2EN" for thai2english.com.
 func findProvider(lang string, mode OperatingMode, name string) (ProviderEntry, bool) {
-	// Try language-specific provider first
-	if langProviders, exists := GlobalRegistry.Providers[lang]; exists {
-		for _, entry := range langProviders.Providers {
-			if entry.Provider.Name() == name {
-				// Check if provider supports the requested mode
-				for _, supportedMode := range entry.Provider.SupportedModes() {
-					if supportedMode == mode {
-						return entry, true
-					}
-				}
+	exact := func(entry ProviderEntry) bool { return entry.Provider.Name() == name }
+	loose := func(entry ProviderEntry) bool { return entry.matches(name) }
+	for _, match := range []func(ProviderEntry) bool{exact, loose} {
+		// Try language-specific provider first
+		if entry, ok := findProviderIn(lang, mode, match); ok {
+			return entry, true
+		}
+		// Fallback to multilingual provider if not found and not already looking for mul
+		if lang != "mul" {
+			if entry, ok := findProviderIn("mul", mode, match); ok {
+				return entry, true
 			}
 		}
 	}
+	return ProviderEntry{}, false
+}
 
-	// Fallback to multilingual provider if not found and not already looking for mul
-	if lang != "mul" {
-		if mulProviders, exists := GlobalRegistry.Providers["mul"]; exists {
-			for _, entry := range mulProviders.Providers {
-				if entry.Provider.Name() == name {
-					// Check if provider supports the requested mode
-					for _, supportedMode := range entry.Provider.SupportedModes() {
-						if supportedMode == mode {
-							return entry, true
-						}
-					}
-				}
-			}
+// findProviderIn returns the first provider of lang matching and supporting the mode.
+func findProviderIn(lang string, mode OperatingMode, match func(ProviderEntry) bool) (ProviderEntry, bool) {
+	for _, entry := range GlobalRegistry.Providers[lang].Providers {
+		if match(entry) && slices.Contains(entry.Provider.SupportedModes(), mode) {
+			return entry, true
 		}
 	}
-
 	return ProviderEntry{}, false
 }
 
+// matches reports whether name is the name of the provider or one of its
+// aliases, ignoring case.
+func (e ProviderEntry) matches(name string) bool {
+	if strings.EqualFold(e.Provider.Name(), name) {
+		return true
+	}
+	for _, alias := range e.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
 
 // checkCapabilities validates if providers have required capabilities for a language
 // and issues warnings if capabilities are missing
@@ -178,14 +231,8 @@ func checkCapabilities(lang string, entries []ProviderEntry, mode OperatingMode,
 
 	// For Register function, we check a single entry
 	if name != "" {
-		for _, capability := range entries[0].Capabilities {
-			if capability == "tokenization" {
-				hasTokenization = true
-			}
-			if capability == "transliteration" {
-				hasTransliteration = true
-			}
-		}
+		hasTokenization = entries[0].Has(CapabilityTokenization)
+		hasTransliteration = entries[0].Has(CapabilityTransliteration)
 
 		if mustTokenize && !hasTokenization && (mode == TokenizerMode || mode == CombinedMode) {
 			Log.Warn().
@@ -204,14 +251,8 @@ func checkCapabilities(lang string, entries []ProviderEntry, mode OperatingMode,
 
 	// For SetDefault function, we check all entries
 	for _, p := range entries {
-		for _, capability := range p.Capabilities {
-			if capability == "tokenization" {
-				hasTokenization = true
-			}
-			if capability == "transliteration" {
-				hasTransliteration = true
-			}
-		}
+		hasTokenization = hasTokenization || p.Has(CapabilityTokenization)
+		hasTransliteration = hasTransliteration || p.Has(CapabilityTransliteration)
 	}
 
 	if mustTokenize && !hasTokenization {
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket bounding the rate of the requests a provider
+// sends to a remote service, so that scrapers don't get blocked for flooding
+// the website they scrape. It is safe for concurrent use, so the copies of a
+// provider (see ModulePool) can share it.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing perSecond requests per
+// second on average, and up to burst requests at once after a pause.
+// A burst of zero or less means 1.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. A nil limiter or
+// one with a rate of zero or less never blocks.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	// the token is taken right away, so that the requests waiting
+	// concurrently are each given their own slot
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// give the slot back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("waiting for rate limiter: %w", ctx.Err())
+	}
+}
+
+var rateLimiters = struct {
+	sync.RWMutex
+	byProvider map[string]*RateLimiter
+}{byProvider: make(map[string]*RateLimiter)}
+
+// SetRateLimiter sets the rate limiter shared by all the instances of the
+// provider of the given name, replacing the default one the provider may
+// have registered. A nil limiter removes the limit. Providers configured
+// with a rate of their own (see RateLimiterFromConfig) use it instead.
+func SetRateLimiter(providerName string, limiter *RateLimiter) {
+	rateLimiters.Lock()
+	defer rateLimiters.Unlock()
+	if limiter == nil {
+		delete(rateLimiters.byProvider, providerName)
+		return
+	}
+	rateLimiters.byProvider[providerName] = limiter
+}
+
+// GetRateLimiter returns the rate limiter of the provider of the given name,
+// or nil if its requests aren't limited.
+func GetRateLimiter(providerName string) *RateLimiter {
+	rateLimiters.RLock()
+	defer rateLimiters.RUnlock()
+	return rateLimiters.byProvider[providerName]
+}
+
+// RateLimiterFromConfig returns a rate limiter following the "rate_limit"
+// (requests per second, float64) and "rate_burst" (int) keys of a provider
+// config, or nil if the config has no "rate_limit".
+func RateLimiterFromConfig(cfg map[string]interface{}) (*RateLimiter, error) {
+	raw, ok := cfg["rate_limit"]
+	if !ok {
+		return nil, nil
+	}
+	var perSecond float64
+	switch v := raw.(type) {
+	case float64:
+		perSecond = v
+	case int:
+		perSecond = float64(v)
+	default:
+		return nil, fmt.Errorf("rate_limit must be a number of requests per second, got %T", raw)
+	}
+	burst := 1
+	if raw, ok := cfg["rate_burst"]; ok {
+		if burst, ok = raw.(int); !ok {
+			return nil, fmt.Errorf("rate_burst must be an int, got %T", raw)
+		}
+	}
+	return NewRateLimiter(perSecond, burst), nil
+}
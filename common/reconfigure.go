@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrReinitRequired is returned by ConfigApplier.ApplyConfig when the new
+// configuration can't be applied to the running provider, e.g. because it
+// changes the Docker image or the model it loaded. ApplyConfig then falls
+// back to reinitializing the provider.
+var ErrReinitRequired = errors.New("configuration change requires reinitialization")
+
+// ConfigApplier is implemented by providers that can apply a new configuration
+// (scheme, options...) while initialized, without going through a costly
+// reinitialization: thai2english.com selects the new scheme in the page it
+// already opened, go-pinyin just changes its style.
+//
+// ApplyConfig replaces the configuration like SaveConfig does. On a provider
+// that isn't initialized yet, it must behave like SaveConfig.
+type ConfigApplier interface {
+	ApplyConfig(ctx context.Context, cfg map[string]interface{}) error
+}
+
+// ApplyConfig applies a new configuration to an initialized provider: in
+// place if the provider implements ConfigApplier and supports the change,
+// otherwise by saving the configuration and reinitializing the provider.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - provider: The provider to reconfigure
+//   - cfg: The new configuration, as given to SaveConfig
+//
+// Returns:
+//   - error: An error if the configuration is invalid or the reinitialization fails
+func ApplyConfig(ctx context.Context, provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], cfg map[string]interface{}) error {
+	if applier, ok := provider.(ConfigApplier); ok {
+		err := applier.ApplyConfig(ctx, cfg)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrReinitRequired) {
+			return fmt.Errorf("provider %s: %w", provider.Name(), err)
+		}
+		Log.Debug().Str("provider", provider.Name()).Msg("Configuration change requires reinitialization")
+	}
+	if err := provider.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("provider %s: invalid configuration: %w", provider.Name(), err)
+	}
+	if err := provider.InitRecreateWithContext(ctx, false); err != nil {
+		return fmt.Errorf("provider %s reinitialization failed: %w", provider.Name(), err)
+	}
+	return nil
+}
+
+// Reconfigure applies a new configuration to the provider playing the given
+// role in the module, see ApplyConfig. The language of the module is added to
+// the configuration as "lang" and a new "scheme" becomes the scheme of the
+// module, so that changing the thai2english.com scheme or the pinyin style
+// doesn't require recreating the module.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The role of the provider to reconfigure (TokenizerMode, TransliteratorMode or CombinedMode)
+//   - cfg: The new configuration, e.g. {"scheme": "rtgs"}
+//
+// Returns:
+//   - error: An error if the module has no provider in this role or if applying the configuration fails
+func (m *Module) Reconfigure(ctx context.Context, mode OperatingMode, cfg map[string]interface{}) error {
+	provider, ok := m.ProviderRoles[mode]
+	if !ok {
+		return fmt.Errorf("module %s has no %s provider", m.ProviderNames(), mode)
+	}
+	merged := map[string]interface{}{"lang": m.Lang}
+	for k, v := range cfg {
+		merged[k] = v
+	}
+	if err := ApplyConfig(ctx, provider, merged); err != nil {
+		return err
+	}
+	if scheme, ok := cfg["scheme"].(string); ok && mode != TokenizerMode {
+		m.scheme = scheme
+	}
+	return nil
+}
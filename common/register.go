@@ -3,6 +3,9 @@ package common
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	
 	iso "github.com/barbashov/iso639-3"
@@ -24,13 +27,20 @@ type Registry struct {
 var BrowserAccessURL = ""
 
 // Register adds a new Provider to the global registry for the specified language.
-// It performs capability validation and warns if the Provider's capabilities
+// It rejects unknown capabilities and the providers refused by a validator
+// (see AddRegistrationValidator), and warns if the Provider's capabilities
 // don't match the language requirements.
 func Register(languageCode string, entry ProviderEntry) error {
 	lang, ok := IsValidISO639(languageCode)
 	if !ok {
 		return fmt.Errorf(errNotISO639, languageCode)
 	}
+	if entry.Provider == nil {
+		return fmt.Errorf("provider cannot be nil")
+	}
+	if err := runRegistrationValidators(lang, entry); err != nil {
+		return err
+	}
 	GlobalRegistry.mu.Lock()
 	defer GlobalRegistry.mu.Unlock()
 
@@ -52,9 +62,15 @@ func Register(languageCode string, entry ProviderEntry) error {
 	if entry.Provider == nil {
 		return fmt.Errorf("provider cannot be nil")
 	}
+	if err := validateCapabilities(entry); err != nil {
+		return err
+	}
 
 	// Check if provider already registered (avoid duplicates)
 	providers := GlobalRegistry.Providers[lang]
+	if err := validateAliases(providers.Providers, entry); err != nil {
+		return err
+	}
 	for i, existing := range providers.Providers {
 		if existing.Provider.Name() == entry.Provider.Name() {
 			// Update existing entry
@@ -71,9 +87,37 @@ func Register(languageCode string, entry ProviderEntry) error {
 	return nil
 }
 
+// validateAliases returns an error if an alias of the entry is empty or is,
+// ignoring case, the name or an alias of another provider of the language,
+// or if the name of the entry is the alias of another provider.
+func validateAliases(providers []ProviderEntry, entry ProviderEntry) error {
+	name := entry.Provider.Name()
+	for _, existing := range providers {
+		if existing.Provider.Name() != name && slices.ContainsFunc(existing.Aliases, func(alias string) bool {
+			return strings.EqualFold(alias, name)
+		}) {
+			return fmt.Errorf("provider name %s is already an alias of provider %s", name, existing.Provider.Name())
+		}
+	}
+	for _, alias := range entry.Aliases {
+		if alias == "" {
+			return fmt.Errorf("provider %s has an empty alias", name)
+		}
+		for _, existing := range providers {
+			if existing.Provider.Name() != name && existing.matches(alias) {
+				return fmt.Errorf("alias %q of provider %s is already used by provider %s", alias, name, existing.Provider.Name())
+			}
+		}
+	}
+	return nil
+}
+
 
 // DefaultModule returns a new Module configured with the default providers
-// for the specified language.
+// for the specified language, as resolved by ResolveDefaults: the providers
+// set with SetDefault unless registered providers of higher priority can run
+// on the current platform (see CurrentPlatform), or the ones set with
+// SetPlatformFallback if the defaults can't.
 func DefaultModule(languageCode string) (*Module, error) {
 	lang, ok := IsValidISO639(languageCode)
 	if !ok {
@@ -94,16 +138,14 @@ func defaultModule(lang string) (*Module, error) {
 	GlobalRegistry.mu.RLock()
 	defer GlobalRegistry.mu.RUnlock()
 
-	langProviders, exists := GlobalRegistry.Providers[lang]
-	if !exists {
-		return nil, fmt.Errorf("defaultModule: no providers registered for language: %s", lang)
+	providers, err := resolveDefaults(lang)
+	if err != nil {
+		return nil, fmt.Errorf("defaultModule: %w", err)
 	}
-
-	if len(langProviders.Defaults) == 0 {
-		return nil, fmt.Errorf("no default providers set for language: %s", lang)
+	if OfflineMode() && !CurrentPlatform().Supports(providers) {
+		return nil, fmt.Errorf("%w for language %s", ErrNoOfflineProviders, lang)
 	}
-
-	if err := m.setProviders(langProviders.Defaults); err != nil {
+	if err := m.setProviders(providers); err != nil {
 		return nil, fmt.Errorf("failed to set providers: %w", err)
 	}
 	m.chunkifier = NewChunkifier(m.getMaxQueryLen())
@@ -186,6 +228,48 @@ func SetDefault(languageCode string, providers []ProviderEntry) error {
 }
 
 
+// Languages returns the ISO 639-3 codes of the languages that have providers
+// registered, in alphabetical order. The multilingual "mul" pseudo-language
+// isn't included.
+func Languages() []string {
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	langs := make([]string, 0, len(GlobalRegistry.Providers))
+	for lang := range GlobalRegistry.Providers {
+		if lang != "mul" {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// DefaultProviderNames returns the names of the default providers of a
+// language, in processing order, as set with SetDefault or, for the languages
+// relying on provider priorities, the chain of highest priority whatever the
+// platform. See ResolveDefaults for the chain used on the current platform.
+func DefaultProviderNames(languageCode string) ([]string, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	langProviders := GlobalRegistry.Providers[lang]
+	defaults := langProviders.Defaults
+	if len(defaults) == 0 {
+		candidates := candidateChains(lang, langProviders)
+		if len(candidates) > 0 {
+			defaults = candidates[0].providers
+		}
+	}
+	names := make([]string, 0, len(defaults))
+	for _, entry := range defaults {
+		names = append(names, entry.Provider.Name())
+	}
+	return names, nil
+}
+
 func IsValidISO639(lang string) (stdLang string, ok bool) {
 	code := iso.FromAnyCode(lang)
 	if code == nil {
@@ -208,7 +292,9 @@ func NeedsTokenization(languageCode string) (bool, error) {
 			return true, nil
 		}
 	}
-	return false, nil
+	declaredNeeds.RLock()
+	defer declaredNeeds.RUnlock()
+	return declaredNeeds.tokenization[lang], nil
 }
 
 // NeedsTransliteration returns true if the given language doesn't use the roman
@@ -224,7 +310,9 @@ func NeedsTransliteration(languageCode string) (bool, error) {
 			return true, nil
 		}
 	}
-	return false, nil
+	declaredNeeds.RLock()
+	defer declaredNeeds.RUnlock()
+	return declaredNeeds.transliteration[lang], nil
 }
 
 
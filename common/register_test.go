@@ -0,0 +1,55 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestResolveDefaults(t *testing.T) {
+	// a language without a package of its own, so without SetDefault: its
+	// default chain is resolved from the priorities of its providers
+	const lang = "mnp"
+	common.UnregisterLanguage(t, lang)
+	for _, entry := range []common.ProviderEntry{
+		{Provider: tha.NewDictTokenizerProvider(), Priority: 50},
+		{Provider: &unavailableProvider{name: "docker-backed", requires: common.PlatformRequirements{Docker: true}}, Priority: 100},
+		{Provider: tha.NewRulesOnlyPaiboonizerProvider(), Priority: 100},
+		{Provider: &unavailableProvider{name: "unprioritized"}}, // never picked
+	} {
+		require.NoError(t, common.Register(lang, entry))
+	}
+
+	names, err := common.DefaultProviderNames(lang)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docker-backed", "paiboonizer"}, names)
+
+	common.SetPlatform(&common.Platform{OS: "linux", Arch: "amd64", Docker: true})
+	m, err := common.DefaultModule(lang)
+	require.NoError(t, err)
+	assert.Equal(t, "docker-backed→paiboonizer", m.ProviderNames())
+
+	common.SetPlatform(&common.Platform{OS: "linux", Arch: "amd64"})
+	defer common.SetPlatform(nil)
+	m, err = common.DefaultModule(lang)
+	require.NoError(t, err)
+	assert.Equal(t, "thai-dict→paiboonizer", m.ProviderNames())
+}
+
+func TestProviderAliases(t *testing.T) {
+	for _, name := range []string{"thai2english.com", "TH2EN", "Thai2English"} {
+		m, err := common.NewModule("tha", name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "thai2english.com", m.Providers[0].Name())
+	}
+
+	taken := common.ProviderEntry{
+		Provider:     tha.NewDictTokenizerProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization},
+		Aliases:      []string{"th2en"},
+	}
+	assert.Error(t, common.Register("tha", taken), "aliases are unique within a language")
+}
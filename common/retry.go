@@ -0,0 +1,354 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryPolicy configures how a failed provider call is retried.
+// Zero values are replaced by the defaults documented on each field.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one (default 3).
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry (default 500ms).
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts (default 30s).
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor applied to the delay after each retry (default 2).
+	Multiplier float64
+
+	// Retryable reports whether a failed call is worth retrying.
+	// By default every error is, except the cancellation of the caller's
+	// context, which is never retried.
+	Retryable func(err error) bool
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 500 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 30 * time.Second
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+	if p.Retryable == nil {
+		p.Retryable = func(err error) bool { return true }
+	}
+	return p
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		delay *= p.Multiplier
+		if delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(delay)
+}
+
+// CallPolicy bounds the provider calls made by a PolicyProvider.
+type CallPolicy struct {
+	// Timeout is the deadline of each call, i.e. of each attempt at processing
+	// a chunk. Zero means no timeout besides that of the caller's context.
+	Timeout time.Duration
+
+	// Retry is the retry policy of failed calls, or nil to not retry.
+	Retry *RetryPolicy
+}
+
+// PolicyProvider wraps a provider that may hang or fail transiently (web
+// scraper, Docker container...) so that each call gets its own deadline and
+// failed calls are retried with exponential backoff.
+//
+// Raw input is processed chunk by chunk so that the deadline and the retries
+// apply to each chunk rather than to the whole input; pre-tokenized input
+// (TransliteratorMode) is processed in a single call.
+//
+// PolicyProvider implements Provider and takes the name of the wrapped provider.
+type PolicyProvider struct {
+	inner  Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+	policy CallPolicy
+
+	progressCallback ProgressCallback
+}
+
+// NewPolicyProvider wraps inner so that its calls follow the given policy.
+//
+// Parameters:
+//   - inner: The provider to wrap
+//   - policy: The timeout and retry policy of its calls
+//
+// Returns:
+//   - *PolicyProvider: The wrapped provider
+func NewPolicyProvider(inner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper], policy CallPolicy) *PolicyProvider {
+	p := &PolicyProvider{inner: inner}
+	p.SetPolicy(policy)
+	return p
+}
+
+// SetPolicy replaces the policy of the provider.
+func (p *PolicyProvider) SetPolicy(policy CallPolicy) {
+	if policy.Retry != nil {
+		retry := policy.Retry.withDefaults()
+		policy.Retry = &retry
+	}
+	p.policy = policy
+}
+
+// Policy returns the policy of the provider.
+func (p *PolicyProvider) Policy() CallPolicy {
+	return p.policy
+}
+
+// Unwrap returns the wrapped provider.
+func (p *PolicyProvider) Unwrap() Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+	return p.inner
+}
+
+// call makes one attempt at processing the input built by input, within
+// the configured timeout.
+func (p *PolicyProvider) call(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	callCtx := ctx
+	if p.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, p.policy.Timeout)
+		defer cancel()
+	}
+	out, err := p.inner.ProcessFlowController(callCtx, mode, input)
+	if err == nil && out == nil {
+		err = fmt.Errorf("nil output")
+	}
+	if err != nil && callCtx.Err() != nil && ctx.Err() == nil {
+		err = fmt.Errorf("call timed out after %s: %w", p.policy.Timeout, err)
+	}
+	return out, err
+}
+
+// process processes the input, retrying according to the policy. newInput is
+// called before each attempt since providers consume the raw chunks.
+func (p *PolicyProvider) process(ctx context.Context, mode OperatingMode, newInput func() AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	attempts := 1
+	if p.policy.Retry != nil {
+		attempts = p.policy.Retry.MaxAttempts
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		var out AnyTokenSliceWrapper
+		if out, err = p.call(ctx, mode, newInput()); err == nil {
+			return out, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s: %w", p.inner.Name(), errors.Join(ctx.Err(), err))
+		}
+		if attempt >= attempts || !p.policy.Retry.Retryable(err) {
+			break
+		}
+		delay := p.policy.Retry.backoff(attempt)
+		Log.Debug().
+			Err(err).
+			Str("provider", p.inner.Name()).
+			Int("attempt", attempt).
+			Dur("backoff", delay).
+			Msg("Provider call failed, retrying")
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: context canceled while waiting to retry: %w", p.inner.Name(), ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+	if attempts > 1 {
+		return nil, fmt.Errorf("%s failed after %d attempts: %w", p.inner.Name(), attempts, err)
+	}
+	return nil, fmt.Errorf("%s: %w", p.inner.Name(), err)
+}
+
+// ProcessFlowController processes the input through the wrapped provider,
+// one chunk at a time.
+func (p *PolicyProvider) ProcessFlowController(ctx context.Context, mode OperatingMode, input AnyTokenSliceWrapper) (AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if len(raw) == 0 {
+		// The wrapped provider reports its progress on the tokens itself
+		return p.process(ctx, mode, func() AnyTokenSliceWrapper { return input })
+	}
+
+	if p.progressCallback != nil {
+		// The chunks are reported below, the progress of the wrapped provider
+		// within a single chunk would make the reported progress go back
+		p.inner.WithProgressCallback(func(current, total int) {})
+		defer p.inner.WithProgressCallback(p.progressCallback)
+	}
+	var out AnyTokenSliceWrapper
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s: context canceled while processing chunk %d: %w", p.Name(), idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		res, err := p.process(ctx, mode, func() AnyTokenSliceWrapper {
+			return &TknSliceWrapper{Raw: []string{chunk}}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", idx, err)
+		}
+		if out == nil {
+			out = res
+			continue
+		}
+		for i := 0; i < res.Len(); i++ {
+			out.Append(res.GetIdx(i))
+		}
+	}
+	input.ClearRaw()
+	return out, nil
+}
+
+func (p *PolicyProvider) SaveConfig(cfg map[string]interface{}) error {
+	return p.inner.SaveConfig(cfg)
+}
+
+// ApplyConfig applies the configuration to the wrapped provider, see common.ApplyConfig.
+func (p *PolicyProvider) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	return ApplyConfig(ctx, p.inner, cfg)
+}
+
+func (p *PolicyProvider) InitWithContext(ctx context.Context) error {
+	return p.inner.InitWithContext(ctx)
+}
+
+func (p *PolicyProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *PolicyProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.inner.InitRecreateWithContext(ctx, noCache)
+}
+
+func (p *PolicyProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *PolicyProvider) CloseWithContext(ctx context.Context) error {
+	return p.inner.CloseWithContext(ctx)
+}
+
+func (p *PolicyProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// WithProgressCallback sets the progress callback. The provider calls it
+// itself for raw input, which it feeds to the wrapped provider one chunk at
+// a time, and passes it to the wrapped provider for pre-tokenized input.
+func (p *PolicyProvider) WithProgressCallback(callback ProgressCallback) {
+	p.progressCallback = callback
+	p.inner.WithProgressCallback(callback)
+}
+
+func (p *PolicyProvider) WithDownloadProgressCallback(callback DownloadProgressCallback) {
+	p.inner.WithDownloadProgressCallback(callback)
+}
+
+// Name returns the name of the wrapped provider.
+func (p *PolicyProvider) Name() string {
+	return p.inner.Name()
+}
+
+func (p *PolicyProvider) SupportedModes() []OperatingMode {
+	return p.inner.SupportedModes()
+}
+
+func (p *PolicyProvider) GetMaxQueryLen() int {
+	return p.inner.GetMaxQueryLen()
+}
+
+// PlatformRequirements returns the requirements of the wrapped provider.
+func (p *PolicyProvider) PlatformRequirements() PlatformRequirements {
+	return RequirementsOf(p.inner)
+}
+
+// ResourceVersions returns the resource versions of the wrapped provider,
+// implementing VersionReporter.
+func (p *PolicyProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if reporter, ok := p.inner.(VersionReporter); ok {
+		return reporter.ResourceVersions(ctx)
+	}
+	return map[string]string{}, nil
+}
+
+// WithTimeout gives each provider call its own deadline: raw input is fed to
+// the tokenizer (or combined provider) one chunk at a time, each chunk
+// getting perChunk to be processed, and the transliterator of a two-provider
+// module gets perChunk for the whole pre-tokenized input. A call exceeding
+// its deadline fails, and is retried if a policy was set with WithRetry.
+//
+// Parameters:
+//   - perChunk: The deadline of each call, or 0 to remove it
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithTimeout(perChunk time.Duration) *Module {
+	policy := m.callPolicy()
+	policy.Timeout = perChunk
+	return m.withCallPolicy(policy)
+}
+
+// WithRetry retries failed provider calls with exponential backoff. As with
+// WithTimeout, raw input is processed one chunk at a time so that a failure
+// only causes its chunk to be processed again.
+//
+// Example usage:
+//
+//	m.WithTimeout(30 * time.Second).WithRetry(common.RetryPolicy{MaxAttempts: 4})
+//
+// Parameters:
+//   - policy: The retry policy; zero fields take their default value
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithRetry(policy RetryPolicy) *Module {
+	cp := m.callPolicy()
+	cp.Retry = &policy
+	return m.withCallPolicy(cp)
+}
+
+// callPolicy returns the policy currently applied to the providers of the module.
+func (m *Module) callPolicy() CallPolicy {
+	for _, provider := range m.ProviderRoles {
+		if pp, ok := findWrapped[*PolicyProvider](provider); ok {
+			return pp.Policy()
+		}
+	}
+	return CallPolicy{}
+}
+
+// withCallPolicy applies the policy to the tokenizer, transliterator and
+// combined providers, wrapping them in a PolicyProvider the first time.
+func (m *Module) withCallPolicy(policy CallPolicy) *Module {
+	for _, mode := range []OperatingMode{TokenizerMode, TransliteratorMode, CombinedMode} {
+		provider, ok := m.ProviderRoles[mode]
+		if !ok {
+			continue
+		}
+		if pp, ok := findWrapped[*PolicyProvider](provider); ok {
+			pp.SetPolicy(policy)
+			continue
+		}
+		m.WrapProvider(mode, func(inner Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper] {
+			return NewPolicyProvider(inner, policy)
+		})
+	}
+	return m
+}
@@ -0,0 +1,109 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// flakyTokenizer fails its first calls, and hangs until the deadline of the
+// call when hang is set
+type flakyTokenizer struct {
+	tha.DictTokenizerProvider
+	failures int
+	hang     bool
+	calls    int
+}
+
+func (p *flakyTokenizer) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		if p.hang {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("service unavailable")
+	}
+	return p.DictTokenizerProvider.ProcessFlowController(ctx, mode, input)
+}
+
+func TestTimeoutAndRetry(t *testing.T) {
+	flaky := &flakyTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider(), failures: 2, hang: true}
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return flaky
+	})
+	m.WithTimeout(20 * time.Millisecond).WithRetry(common.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	tokenized, err := m.Tokenized("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, "ผม ชอบ กินข้าว", tokenized)
+	assert.Equal(t, 3, flaky.calls, "each timed out call should be retried")
+
+	flaky.calls, flaky.hang = 0, false
+	m.WithRetry(common.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	_, err = m.Tokenized("ผมชอบกินข้าว")
+	assert.ErrorContains(t, err, "failed after 2 attempts")
+	assert.Equal(t, 20*time.Millisecond, m.ProviderRoles[common.TokenizerMode].(*common.PolicyProvider).Policy().Timeout)
+}
+
+// configurableTokenizer is a tokenizer accepting typed options
+type configurableTokenizer struct {
+	tha.DictTokenizerProvider
+	opts string
+}
+
+func (p *configurableTokenizer) ConfigureWith(opts string) error {
+	p.opts = opts
+	return nil
+}
+
+func TestCallPolicyWrapsOnce(t *testing.T) {
+	shared := &configurableTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider()}
+	m := &common.Module{
+		Lang:      tha.Lang,
+		Providers: []common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{shared},
+		ProviderRoles: map[common.OperatingMode]common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]{
+			common.TokenizerMode:      shared,
+			common.TransliteratorMode: shared,
+		},
+	}
+	m.WithTimeout(time.Second).WithRetry(common.RetryPolicy{MaxAttempts: 2})
+	wrapper, ok := m.ProviderRoles[common.TokenizerMode].(*common.PolicyProvider)
+	require.True(t, ok)
+	assert.Same(t, wrapper, m.ProviderRoles[common.TransliteratorMode], "a provider playing two roles should be wrapped once")
+	require.Len(t, m.Providers, 1)
+	assert.Same(t, wrapper, m.Providers[0])
+	assert.Equal(t, 2, wrapper.Policy().Retry.MaxAttempts)
+
+	require.NoError(t, common.ConfigureModule(m, "options"))
+	assert.Equal(t, "options", shared.opts, "the options should reach the wrapped provider")
+}
+
+func TestPolicyProviderProgress(t *testing.T) {
+	tokenizer := tha.NewDictTokenizerProvider()
+	require.NoError(t, tokenizer.Init())
+	tokens, err := tokenizer.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: []string{"ผมชอบกินข้าว"}})
+	require.NoError(t, err)
+
+	paiboonizer := tha.NewRulesOnlyPaiboonizerProvider()
+	require.NoError(t, paiboonizer.Init())
+	p := common.NewPolicyProvider(paiboonizer, common.CallPolicy{Timeout: time.Second})
+	var totals []int
+	p.WithProgressCallback(func(current, total int) {
+		totals = append(totals, total)
+	})
+	_, err = p.ProcessFlowController(context.Background(), common.TransliteratorMode, tokens)
+	require.NoError(t, err)
+	require.NotEmpty(t, totals)
+	assert.Equal(t, tokens.Len(), totals[0], "the progress on the tokens should come from the wrapped provider")
+}
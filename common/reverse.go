@@ -0,0 +1,166 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReverseUnsupported is returned by ToNative for the modules without a
+// provider able to convert romanized text back to the native script.
+// It wraps errors.ErrUnsupported.
+var ErrReverseUnsupported = fmt.Errorf("conversion to the native script requires a provider supporting %s mode: %w", ReverseTransliteratorMode, errors.ErrUnsupported)
+
+// RegisterReverseScheme adds a scheme whose romanizations can be converted
+// back to the native script of a language, see GetReverseModule. The scheme
+// must have a single provider supporting ReverseTransliteratorMode. Reverse
+// schemes are only registered where the conversion is well-defined, i.e.
+// where the romanization of the scheme is unambiguous: most schemes lose
+// information (tones, vowel length, letters sharing a romanization...).
+func RegisterReverseScheme(languageCode string, scheme TranslitScheme) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if len(scheme.Providers) != 1 {
+		return fmt.Errorf("reverse scheme %s must have a single provider, got %d", scheme.Name, len(scheme.Providers))
+	}
+
+	GlobalSchemeRegistry.mu.Lock()
+	defer GlobalSchemeRegistry.mu.Unlock()
+
+	for _, s := range GlobalSchemeRegistry.reverse[lang] {
+		if s.Name == scheme.Name {
+			return fmt.Errorf("reverse scheme %s already registered for language %s", scheme.Name, lang)
+		}
+	}
+	GlobalSchemeRegistry.reverse[lang] = append(GlobalSchemeRegistry.reverse[lang], scheme)
+	return nil
+}
+
+// GetReverseSchemes returns the schemes of a language whose romanizations can
+// be converted back to the native script.
+func GetReverseSchemes(languageCode string) ([]TranslitScheme, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+
+	GlobalSchemeRegistry.mu.RLock()
+	registered, exists := GlobalSchemeRegistry.reverse[lang]
+	schemes := append([]TranslitScheme(nil), registered...)
+	GlobalSchemeRegistry.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrNoSchemesRegistered
+	}
+	for i := range schemes {
+		schemes[i].Requirements = resolveSchemeRequirements(lang, schemes[i])
+	}
+	return schemes, nil
+}
+
+// GetReverseModule returns a module converting text romanized in the given
+// scheme back to the native script of the language with ToNative. The module
+// must be initialized like any other.
+func GetReverseModule(languageCode, schemeName string) (*Module, error) {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil, fmt.Errorf(errNotISO639, languageCode)
+	}
+
+	GlobalSchemeRegistry.mu.RLock()
+	schemes, exists := GlobalSchemeRegistry.reverse[lang]
+	GlobalSchemeRegistry.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrNoSchemesRegistered
+	}
+
+	var targetScheme *TranslitScheme
+	for i := range schemes {
+		if schemes[i].Name == schemeName {
+			targetScheme = &schemes[i]
+			break
+		}
+	}
+	if targetScheme == nil {
+		return nil, fmt.Errorf("reverse scheme %s not found for language %s", schemeName, lang)
+	}
+
+	provider, err := getProvider(lang, ReverseTransliteratorMode, targetScheme.Providers[0])
+	if err != nil {
+		return nil, err
+	}
+	module := newModule()
+	module.Lang = lang
+	module.scheme = targetScheme.Name
+	module.Providers = append(module.Providers, provider)
+	module.ProviderRoles[ReverseTransliteratorMode] = provider
+	module.chunkifier = NewChunkifier(module.getMaxQueryLen())
+
+	if err := provider.SaveConfig(map[string]interface{}{
+		"lang":   lang,
+		"scheme": schemeName,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save configuration for reverse provider: %w", err)
+	}
+	return module, nil
+}
+
+// ToNativeWithContext converts text romanized in the scheme of the module
+// back to the native script of its language, e.g. "namaste" in IAST to
+// "नमस्ते" or "tōkyō" in Hepburn to "とうきょう". The module needs a provider
+// supporting ReverseTransliteratorMode, such as the ones of the modules
+// returned by GetReverseModule. Characters that aren't part of the scheme
+// (spaces, punctuation, digits) are kept as is.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - romanInput: The romanized text to convert
+//
+// Returns:
+//   - string: The text in the native script
+//   - error: An error if processing fails, the context is canceled, or the conversion isn't supported
+func (m *Module) ToNativeWithContext(ctx context.Context, romanInput string) (string, error) {
+	provider, ok := m.ProviderRoles[ReverseTransliteratorMode]
+	if !ok {
+		return "", ErrReverseUnsupported
+	}
+	if strings.TrimSpace(romanInput) == "" {
+		return romanInput, nil
+	}
+	chunks, err := m.chunker().Chunkify(romanInput)
+	if err != nil {
+		return "", fmt.Errorf("input serialization failed: len(input)=%d, %w", len(romanInput), err)
+	}
+	out, err := m.process(ctx, provider, ReverseTransliteratorMode, &TknSliceWrapper{Raw: chunks})
+	if err != nil {
+		return "", fmt.Errorf("reverse transliteration failed: %w", err)
+	}
+	if out.Len() != len(chunks) {
+		return "", fmt.Errorf("reverse transliteration failed: %s returned %d tokens for %d chunks", provider.Name(), out.Len(), len(chunks))
+	}
+
+	// the chunkifier may drop the separators it split the input on, these
+	// are taken back from the input
+	var builder strings.Builder
+	rest := romanInput
+	for i, chunk := range chunks {
+		if idx := strings.Index(rest, chunk); idx >= 0 {
+			builder.WriteString(rest[:idx])
+			rest = rest[idx+len(chunk):]
+		}
+		builder.WriteString(out.GetIdx(i).GetSurface())
+	}
+	builder.WriteString(rest)
+	return builder.String(), nil
+}
+
+// ToNative converts text romanized in the scheme of the module back to the
+// native script of its language using a background context.
+// See ToNativeWithContext.
+func (m *Module) ToNative(romanInput string) (string, error) {
+	return m.ToNativeWithContext(context.Background(), romanInput)
+}
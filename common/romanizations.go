@@ -0,0 +1,211 @@
+package common
+
+import (
+	"context"
+	"fmt"
+)
+
+// RomanizationsKey is the Tkn.Metadata key holding the romanizations of a
+// token by scheme (map[string]string), set by modules having additional
+// transliterators (see WithTransliterator).
+const RomanizationsKey = "romanizations"
+
+// extraTransliterator is a transliterator running after the main one, whose
+// output is kept in the metadata of the tokens
+type extraTransliterator struct {
+	scheme   string
+	provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]
+}
+
+// Romanizations returns the romanizations of a token by scheme, as set by a
+// module having additional transliterators, or nil.
+func Romanizations(tkn *Tkn) map[string]string {
+	romanizations, _ := tkn.Metadata[RomanizationsKey].(map[string]string)
+	return romanizations
+}
+
+// WithTransliterator adds a transliterator whose output is kept alongside the
+// main romanization: it runs on the tokens once the main pipeline is done and
+// its romanization of each token is stored in Metadata["romanizations"][scheme],
+// next to the main romanization (stored under the module's scheme, or the name
+// of its transliterator). The Romanization of the tokens is left untouched.
+// Use RomanAll to get the whole input romanized in every scheme.
+//
+// Registered providers are shared by all modules: since the scheme is passed
+// to the provider as "scheme" config, give each scheme its own instance if the
+// provider supports several.
+//
+// Example usage:
+//
+//	m, err := common.GetSchemeModule("tha", "paiboon-offline")
+//	m.WithTransliterator("rtgs", rtgsProvider)
+//	romans, err := m.RomanAll(text) // romans["paiboon-offline"], romans["rtgs"]
+//
+// Parameters:
+//   - scheme: The key of the romanizations of the transliterator
+//   - provider: A provider supporting TransliteratorMode
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithTransliterator(scheme string, provider Provider[AnyTokenSliceWrapper, AnyTokenSliceWrapper]) *Module {
+	if !contains(provider.SupportedModes(), TransliteratorMode) {
+		m.logger().Warn().Str("provider", provider.Name()).Msg("Provider doesn't support transliteration, not added to the module")
+		return m
+	}
+	for _, t := range m.transliterators {
+		if t.scheme == scheme {
+			m.logger().Warn().Str("scheme", scheme).Msg("Module already has a transliterator for this scheme, not added to the module")
+			return m
+		}
+	}
+	if err := provider.SaveConfig(map[string]interface{}{
+		"lang":   m.Lang,
+		"scheme": scheme,
+	}); err != nil {
+		m.logger().Warn().Err(err).Str("provider", provider.Name()).Msg("Failed to configure provider")
+	}
+	m.passProgressCallback(provider)
+	m.passDownloadCallback(provider)
+	m.Providers = append(m.Providers, provider)
+	m.transliterators = append(m.transliterators, extraTransliterator{scheme: scheme, provider: provider})
+	return m
+}
+
+// mainScheme returns the key of the romanizations of the main transliterator
+func (m *Module) mainScheme() string {
+	if m.scheme != "" {
+		return m.scheme
+	}
+	if provider, ok := m.ProviderRoles[CombinedMode]; ok {
+		return provider.Name()
+	}
+	if provider, ok := m.ProviderRoles[TransliteratorMode]; ok {
+		return provider.Name()
+	}
+	return ""
+}
+
+// Schemes returns the schemes in which the module romanizes its input: that
+// of its main transliterator first, then those added with WithTransliterator.
+func (m *Module) Schemes() []string {
+	var schemes []string
+	if m.hasTransliterator() {
+		schemes = append(schemes, m.mainScheme())
+	}
+	for _, t := range m.transliterators {
+		schemes = append(schemes, t.scheme)
+	}
+	return schemes
+}
+
+// runTransliterators runs the additional transliterators on the tokens and
+// stores the romanizations of every scheme in their metadata.
+func (m *Module) runTransliterators(ctx context.Context, tsw AnyTokenSliceWrapper) error {
+	tokens := make([]*Tkn, tsw.Len())
+	main := make([]string, len(tokens))
+	for i := range tokens {
+		tokens[i] = BaseToken(tsw.GetIdx(i))
+		if tokens[i] == nil {
+			continue
+		}
+		main[i] = tokens[i].Romanization
+		if m.hasTransliterator() && tokens[i].IsLexical && main[i] != "" {
+			setRomanization(tokens[i], m.mainScheme(), main[i])
+		}
+	}
+	// restore the main romanization however the transliterators exit
+	defer func() {
+		for i, tkn := range tokens {
+			if tkn != nil {
+				tkn.Romanization = main[i]
+			}
+		}
+	}()
+
+	for _, t := range m.transliterators {
+		// transliterators may skip the tokens that are already romanized
+		for _, tkn := range tokens {
+			if tkn != nil {
+				tkn.Romanization = ""
+			}
+		}
+		out, err := m.process(ctx, t.provider, TransliteratorMode, tsw)
+		if err != nil {
+			return fmt.Errorf("transliterator %s (scheme %s) failed: %w", t.provider.Name(), t.scheme, err)
+		}
+		if out == nil || out.Len() != len(tokens) {
+			return fmt.Errorf("transliterator %s (scheme %s) changed the number of tokens", t.provider.Name(), t.scheme)
+		}
+		for i := 0; i < out.Len(); i++ {
+			tkn := BaseToken(out.GetIdx(i))
+			if tkn == nil || !tkn.IsLexical || tkn.Romanization == "" || tokens[i] == nil {
+				continue
+			}
+			setRomanization(tokens[i], t.scheme, tkn.Romanization)
+		}
+	}
+	return nil
+}
+
+func setRomanization(tkn *Tkn, scheme, roman string) {
+	if tkn.Metadata == nil {
+		tkn.Metadata = make(map[string]interface{})
+	}
+	romanizations := Romanizations(tkn)
+	if romanizations == nil {
+		romanizations = make(map[string]string)
+		tkn.Metadata[RomanizationsKey] = romanizations
+	}
+	romanizations[scheme] = roman
+}
+
+// RomanAllWithContext returns the input text romanized in every scheme of the
+// module (see Schemes and WithTransliterator), processing the input only once.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be romanized
+//
+// Returns:
+//   - map[string]string: The romanized text by scheme
+//   - error: An error if processing fails, the context is canceled, or romanization isn't supported
+func (m *Module) RomanAllWithContext(ctx context.Context, input string) (map[string]string, error) {
+	if !m.hasTransliterator() && len(m.transliterators) == 0 {
+		return nil, ErrRomanizationUnsupported
+	}
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	tkns = m.romanTokens(tkns)
+	rule := m.getSpacingRule()
+	romans := make(map[string]string)
+	if m.hasTransliterator() {
+		romans[m.mainScheme()] = RomanWithSpacingRule(tkns, rule)
+	}
+	for _, t := range m.transliterators {
+		scheme := t.scheme
+		romans[scheme] = joinWithSpacingRule(anyTokens(tkns), rule, func(token AnyToken) string {
+			if tkn := BaseToken(token); tkn != nil {
+				if r, ok := Romanizations(tkn)[scheme]; ok {
+					return r
+				}
+			}
+			return token.GetSurface()
+		})
+	}
+	return romans, err
+}
+
+// RomanAll returns the input text romanized in every scheme of the module
+// using a background context.
+//
+// Parameters:
+//   - input: The text to be romanized
+//
+// Returns:
+//   - map[string]string: The romanized text by scheme
+//   - error: An error if processing fails or romanization isn't supported
+func (m *Module) RomanAll(input string) (map[string]string, error) {
+	return m.RomanAllWithContext(context.Background(), input)
+}
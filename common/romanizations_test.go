@@ -0,0 +1,47 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// bracketTransliterator romanizes each lexical token as its surface in brackets
+type bracketTransliterator struct {
+	tha.PaiboonizerProvider
+}
+
+func (p *bracketTransliterator) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	for i := 0; i < input.Len(); i++ {
+		if tkn := common.BaseToken(input.GetIdx(i)); tkn.IsLexical {
+			tkn.Romanization = "[" + tkn.Surface + "]"
+		}
+	}
+	return input, nil
+}
+
+func TestRomanAll(t *testing.T) {
+	m, err := common.GetSchemeModule(tha.Lang, "paiboon-offline")
+	require.NoError(t, err)
+	m.WithTransliterator("brackets", &bracketTransliterator{*tha.NewPaiboonizerProvider()})
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	assert.Equal(t, []string{"paiboon-offline", "brackets"}, m.Schemes())
+	romans, err := m.RomanAll("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	roman, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, roman, romans["paiboon-offline"], "the main romanization is left untouched")
+	assert.Equal(t, "[ผม][ชอบ][กินข้าว]", romans["brackets"])
+
+	tsw, err := m.LexicalTokens("ผมชอบ")
+	require.NoError(t, err)
+	tkn := common.BaseToken(tsw.GetIdx(0))
+	assert.Equal(t, "[ผม]", common.Romanizations(tkn)["brackets"])
+	assert.Equal(t, tkn.Romanization, common.Romanizations(tkn)["paiboon-offline"])
+}
@@ -0,0 +1,231 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Capitalization is how RomanizationOptions capitalizes the romanization.
+type Capitalization int
+
+const (
+	// CaseAsIs keeps the case output by the providers.
+	CaseAsIs Capitalization = iota
+	// CaseLower lowercases the romanization.
+	CaseLower
+	// CaseSentence capitalizes the first word of each sentence.
+	CaseSentence
+	// CaseTitle capitalizes every word.
+	CaseTitle
+)
+
+// RomanizationOptions adjusts the romanization output by Roman(),
+// RomanWithAlignment() and RomanParts() whatever the provider, see
+// Module.WithRomanizationOptions. The zero value changes nothing.
+type RomanizationOptions struct {
+	Case Capitalization
+
+	// StripDiacritics removes the combining marks, e.g. "tōkyō" becomes
+	// "tokyo" and "nǐ hǎo" becomes "ni hao". Letters that aren't a base
+	// letter with marks, such as ɔ or ʉ, are kept.
+	StripDiacritics bool
+
+	// ToneNumbers replaces tone marks by tone numbers at the end of the
+	// syllables ("nǐ hǎo" becomes "ni3 hao3") using the converter the
+	// language registered with RegisterToneNumberer. It has no effect on
+	// the languages that have none.
+	ToneNumbers bool
+
+	// CapitalizeEntities capitalizes the romanization of the tokens that
+	// a NER provider labelled as the names of people (EntityPerson) or
+	// places (EntityLocation), e.g. "tōkyō" becomes "Tōkyō", whatever Case.
+	// It has no effect on the modules without NER, see Module.WithNER.
+	CapitalizeEntities bool
+
+	// Scheme converts the romanization of the tokens to another scheme of
+	// the language (e.g. "rtgs" or "ipa" for Thai) using the converter the
+	// language registered with RegisterSchemeConverter, so that the output
+	// doesn't depend on the scheme the provider outputs. The tokens that
+	// can't be converted keep their romanization. It has no effect on the
+	// languages that have no converter.
+	Scheme string
+}
+
+// SchemeConverter converts the romanization of a token from the scheme of
+// the module (see Module.Schemes) to another scheme. It reports false if
+// the token can't be converted.
+type SchemeConverter func(token AnyToken, fromScheme, toScheme string) (string, bool)
+
+var schemeConverters = struct {
+	sync.RWMutex
+	fns map[string]SchemeConverter
+}{fns: make(map[string]SchemeConverter)}
+
+var toneNumberers = struct {
+	sync.RWMutex
+	fns map[string]func(string) string
+}{fns: make(map[string]func(string) string)}
+
+// RegisterToneNumberer sets the function that RomanizationOptions.ToneNumbers
+// uses to convert the tone marks of a romanized word of the given language
+// into tone numbers.
+func RegisterToneNumberer(languageCode string, fn func(roman string) string) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if fn == nil {
+		return fmt.Errorf("tone numberer cannot be nil")
+	}
+	toneNumberers.Lock()
+	defer toneNumberers.Unlock()
+	toneNumberers.fns[lang] = fn
+	return nil
+}
+
+func getToneNumberer(languageCode string) func(string) string {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil
+	}
+	toneNumberers.RLock()
+	defer toneNumberers.RUnlock()
+	return toneNumberers.fns[lang]
+}
+
+// RegisterSchemeConverter sets the function that RomanizationOptions.Scheme
+// uses to convert the romanization of the tokens of the given language.
+func RegisterSchemeConverter(languageCode string, fn SchemeConverter) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if fn == nil {
+		return fmt.Errorf("scheme converter cannot be nil")
+	}
+	schemeConverters.Lock()
+	defer schemeConverters.Unlock()
+	schemeConverters.fns[lang] = fn
+	return nil
+}
+
+func getSchemeConverter(languageCode string) SchemeConverter {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil
+	}
+	schemeConverters.RLock()
+	defer schemeConverters.RUnlock()
+	return schemeConverters.fns[lang]
+}
+
+// WithRomanizationOptions sets how the romanization of this module is
+// adjusted in Roman(), RomanWithAlignment() and RomanParts().
+//
+// Parameters:
+//   - opts: The options to apply, the zero value restores the default behavior
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithRomanizationOptions(opts RomanizationOptions) *Module {
+	m.romanOptions = opts
+	return m
+}
+
+// romanText returns the function giving the text of a token in the
+// romanized output, with the romanization options of the module applied.
+// It must be called on the tokens in order as sentence case depends on the
+// tokens that precede.
+func (m *Module) romanText() func(AnyToken) string {
+	opts := m.romanOptions
+	if opts == (RomanizationOptions{}) {
+		return romanOrSurface
+	}
+	var toneNumberer func(string) string
+	if opts.ToneNumbers {
+		toneNumberer = getToneNumberer(m.Lang)
+	}
+	var convert SchemeConverter
+	fromScheme := m.mainScheme()
+	if opts.Scheme != "" && !strings.EqualFold(opts.Scheme, fromScheme) {
+		convert = getSchemeConverter(m.Lang)
+	}
+	sentence, first := -1, true
+	return func(t AnyToken) string {
+		r := t.Roman()
+		if r == "" {
+			// untransliterated text and punctuation are left as they are
+			return t.GetSurface()
+		}
+		sentenceStart := first
+		if tkn := BaseToken(t); tkn != nil {
+			sentenceStart = sentenceStart || tkn.Position.Sentence != sentence
+			sentence = tkn.Position.Sentence
+		}
+		first = false
+		if convert != nil {
+			if converted, ok := convert(t, fromScheme, opts.Scheme); ok {
+				r = converted
+			}
+		}
+		r = opts.apply(r, toneNumberer, sentenceStart)
+		if opts.CapitalizeEntities && isCapitalizedEntity(t) {
+			r = capitalize(r)
+		}
+		return r
+	}
+}
+
+// isCapitalizedEntity reports whether the token is part of the name of a
+// person or of a place.
+func isCapitalizedEntity(t AnyToken) bool {
+	tkn := BaseToken(t)
+	return tkn != nil && (tkn.NamedEntity == EntityPerson || tkn.NamedEntity == EntityLocation)
+}
+
+func (opts RomanizationOptions) apply(r string, toneNumberer func(string) string, sentenceStart bool) string {
+	if toneNumberer != nil {
+		r = toneNumberer(r)
+	}
+	if opts.StripDiacritics {
+		r = stripDiacritics(r)
+	}
+	switch opts.Case {
+	case CaseLower:
+		r = strings.ToLower(r)
+	case CaseSentence:
+		if sentenceStart {
+			r = capitalize(r)
+		}
+	case CaseTitle:
+		r = capitalize(r)
+	}
+	return r
+}
+
+// stripDiacritics removes the combining marks of s.
+func stripDiacritics(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(s))
+	return norm.NFC.String(s)
+}
+
+// capitalize uppercases the first letter of s.
+func capitalize(s string) string {
+	s = norm.NFC.String(s)
+	for i, r := range s {
+		if unicode.IsLetter(r) {
+			return s[:i] + string(unicode.ToTitle(r)) + s[i+utf8.RuneLen(r):]
+		}
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"fmt"
+)
+
+// SchemeRequirements describes what a transliteration scheme needs from the
+// host, so that callers can tell which schemes are usable e.g. offline or on
+// a machine without Docker.
+type SchemeRequirements struct {
+	Docker   bool // runs a provider in a Docker container
+	Scraper  bool // drives a headless browser to scrape a website
+	CGO      bool // links a C/C++ library, unavailable in CGO_ENABLED=0 builds
+	Internet bool // needs network access whenever it is used, not only to download its resources
+
+	// DownloadSize is the approximate size in bytes of what is downloaded the
+	// first time the scheme is used (Docker images, browser, models), 0 if
+	// nothing is.
+	DownloadSize int64
+}
+
+// Platform returns the platform requirements of the scheme.
+func (r SchemeRequirements) Platform() PlatformRequirements {
+	return PlatformRequirements{CGO: r.CGO, Docker: r.Docker, Browser: r.Scraper, Network: r.Internet}
+}
+
+// SchemeConstraints restricts the schemes returned by GetUsableSchemes.
+// The zero value only excludes the schemes the platform can't run.
+type SchemeConstraints struct {
+	NoDocker  bool // exclude the schemes running a Docker container
+	NoScraper bool // exclude the schemes scraping a website
+	Offline   bool // exclude the schemes needing network access once their resources are downloaded
+
+	// MaxDownloadSize excludes the schemes downloading more than this many
+	// bytes on first use. 0 means no limit.
+	MaxDownloadSize int64
+
+	// Platform is the platform the schemes must run on, nil for the current one.
+	Platform *Platform
+}
+
+// Allows reports whether a scheme having the given requirements meets the constraints.
+func (c SchemeConstraints) Allows(r SchemeRequirements) bool {
+	platform := c.Platform
+	if platform == nil {
+		current := CurrentPlatform()
+		platform = &current
+	}
+	switch {
+	case c.NoDocker && r.Docker:
+		return false
+	case c.NoScraper && r.Scraper:
+		return false
+	case c.Offline && r.Internet:
+		return false
+	case c.MaxDownloadSize > 0 && r.DownloadSize > c.MaxDownloadSize:
+		return false
+	}
+	return platform.Satisfies(r.Platform())
+}
+
+// GetUsableSchemes returns the transliteration schemes of a language that
+// meet the constraints, in the order they were registered.
+//
+// Example usage:
+//
+//	// schemes usable on a machine without Docker nor internet access
+//	schemes, err := GetUsableSchemes("tha", SchemeConstraints{NoDocker: true, Offline: true})
+//
+// Parameters:
+//   - languageCode: The ISO-639 code of the language
+//   - constraints: What the host allows
+//
+// Returns:
+//   - []TranslitScheme: The usable schemes, with their requirements
+//   - error: An error if the language code is invalid or has no schemes
+func GetUsableSchemes(languageCode string, constraints SchemeConstraints) ([]TranslitScheme, error) {
+	schemes, err := GetSchemes(languageCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schemes: %w", err)
+	}
+	var usable []TranslitScheme
+	for _, scheme := range schemes {
+		if constraints.Allows(scheme.Requirements) {
+			usable = append(usable, scheme)
+		}
+	}
+	return usable, nil
+}
+
+// resolveSchemeRequirements completes the declared requirements of a scheme
+// with the legacy flags and the CGO requirement of its providers. Docker and
+// scraping are left to the declaration of the scheme: the requirements of a
+// provider may depend on the scheme it is configured for (e.g. paiboonizer
+// only needs Docker outside of the offline scheme).
+func resolveSchemeRequirements(lang string, scheme TranslitScheme) SchemeRequirements {
+	r := scheme.Requirements
+	r.Docker = r.Docker || scheme.NeedsDocker
+	r.Scraper = r.Scraper || scheme.NeedsScraper
+
+	GlobalRegistry.mu.RLock()
+	defer GlobalRegistry.mu.RUnlock()
+	for _, name := range scheme.Providers {
+		for _, mode := range []OperatingMode{CombinedMode, TokenizerMode, TransliteratorMode} {
+			if entry, ok := findProvider(lang, mode, name); ok {
+				r.CGO = r.CGO || RequirementsOf(entry.Provider).CGO
+				break
+			}
+		}
+	}
+	// scraped websites are queried on every use
+	r.Internet = r.Internet || r.Scraper
+	return r
+}
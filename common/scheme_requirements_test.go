@@ -0,0 +1,34 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestGetUsableSchemes(t *testing.T) {
+	noDocker := &common.Platform{CGO: true, Browser: true}
+	schemes, err := common.GetUsableSchemes(tha.Lang, common.SchemeConstraints{Offline: true, Platform: noDocker})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"paiboon-offline"}, common.GetSchemesNames(schemes))
+
+	schemes, err = common.GetUsableSchemes(tha.Lang, common.SchemeConstraints{Platform: noDocker, MaxDownloadSize: 500 << 20})
+	require.NoError(t, err)
+	assert.Contains(t, common.GetSchemesNames(schemes), "rtgs")
+	assert.NotContains(t, common.GetSchemesNames(schemes), "royin")
+
+	all, err := common.GetSchemes(tha.Lang)
+	require.NoError(t, err)
+	for _, scheme := range all {
+		switch scheme.Name {
+		case "paiboon-hybrid":
+			assert.True(t, scheme.Requirements.Docker)
+			assert.False(t, scheme.Requirements.Internet)
+		case "rtgs":
+			assert.True(t, scheme.Requirements.Scraper && scheme.Requirements.Internet)
+		}
+	}
+}
@@ -16,18 +16,29 @@ type TranslitScheme struct {
 	Name         string   // e.g., "IAST", "Harvard-Kyoto"
 	Description  string
 	Providers    []string // Provider names in order (tokenizer, transliterator)
-	NeedsDocker  bool
-	NeedsScraper bool
+	NeedsDocker  bool     // shorthand for Requirements.Docker
+	NeedsScraper bool     // shorthand for Requirements.Scraper
+	IPA          bool // Scheme outputs a phonetic transcription in IPA rather than a romanization
+	// Requirements is what the scheme needs from the host. The schemes returned
+	// by GetSchemes have it completed from NeedsDocker, NeedsScraper and the
+	// CGO requirement of their providers.
+	Requirements SchemeRequirements
 }
 
 // SchemeRegistry manages available transliteration schemes for languages
 type SchemeRegistry struct {
 	mu      sync.RWMutex
 	schemes map[string][]TranslitScheme // key: ISO 639-3 language code
+	reverse map[string][]TranslitScheme // reverse schemes, see RegisterReverseScheme
 }
 
+// GlobalSchemeRegistry holds the schemes of all languages.
+//
+// Deprecated: use RegisterScheme and GetSchemes, which validate the language
+// code and take the lock. The registry will be unexported.
 var GlobalSchemeRegistry = &SchemeRegistry{
 	schemes: make(map[string][]TranslitScheme),
+	reverse: make(map[string][]TranslitScheme),
 }
 
 // RegisterScheme adds a transliteration scheme for a language
@@ -64,13 +75,17 @@ func GetSchemes(languageCode string) ([]TranslitScheme, error) {
 	}
 
 	GlobalSchemeRegistry.mu.RLock()
-	defer GlobalSchemeRegistry.mu.RUnlock()
+	registered, exists := GlobalSchemeRegistry.schemes[lang]
+	schemes := append([]TranslitScheme(nil), registered...)
+	GlobalSchemeRegistry.mu.RUnlock()
 
-	schemes, exists := GlobalSchemeRegistry.schemes[lang]
 	if !exists {
 		return nil, ErrNoSchemesRegistered
 	}
 
+	for i := range schemes {
+		schemes[i].Requirements = resolveSchemeRequirements(lang, schemes[i])
+	}
 	return schemes, nil
 }
 
@@ -105,6 +120,8 @@ func GetSchemeModule(languageCode, schemeName string) (*Module, error) {
 
 	module := newModule()
 	module.Lang = lang
+	module.ipa = targetScheme.IPA
+	module.scheme = targetScheme.Name
 
 	// Handle based on number of providers
 	switch len(targetScheme.Providers) {
@@ -201,11 +218,37 @@ func GetSchemeModule(languageCode, schemeName string) (*Module, error) {
 	default:
 		return nil, fmt.Errorf("unsupported provider configuration: %d providers", len(targetScheme.Providers))
 	}
-	
-	return module, nil
 }
 
 
+// GetIPASchemes returns the transliteration schemes of a language that output IPA
+func GetIPASchemes(languageCode string) ([]TranslitScheme, error) {
+	schemes, err := GetSchemes(languageCode)
+	if err != nil {
+		return nil, err
+	}
+	var ipaSchemes []TranslitScheme
+	for _, scheme := range schemes {
+		if scheme.IPA {
+			ipaSchemes = append(ipaSchemes, scheme)
+		}
+	}
+	return ipaSchemes, nil
+}
+
+// GetIPAModule returns a pre-configured module for the first IPA scheme registered
+// for a language, ready to be used with Module.IPA.
+func GetIPAModule(languageCode string) (*Module, error) {
+	schemes, err := GetIPASchemes(languageCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemes) == 0 {
+		return nil, fmt.Errorf("no IPA scheme registered for language %s", languageCode)
+	}
+	return GetSchemeModule(languageCode, schemes[0].Name)
+}
+
 // GetSchemesNames returns a slice of strings with all Names of translit schemes
 func GetSchemesNames(schemes []TranslitScheme) []string {
 	var names []string
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// romanPunctuation maps the punctuation of the native scripts to the Latin
+// punctuation used in the sentences returned by RomanSentences.
+var romanPunctuation = map[rune]rune{
+	// CJK
+	'。': '.', '．': '.', '！': '!', '？': '?', '，': ',', '、': ',', '：': ':', '；': ';',
+	'（': '(', '）': ')', '「': '"', '」': '"', '『': '"', '』': '"',
+	// danda of Devanagari, used by most Indic scripts
+	'।': '.', '॥': '.',
+	// Arabic
+	'؟': '?', '،': ',', '؛': ';',
+	// Greek question mark, Armenian full stop
+	'\u037E': '?', '։': '.',
+	// Ethiopic, Khmer, Myanmar
+	'።': '.', '፣': ',', '។': '.', '၊': ',', '။': '.',
+}
+
+// RomanSentencesWithContext romanizes the input like RomanWithContext and
+// returns one string per sentence of the input, e.g. to romanize subtitles
+// or to feed a TTS engine sentence by sentence.
+//
+// The sentences are normalized for Latin script output: the punctuation of
+// the native script is replaced by its Latin equivalent ("。" becomes "."),
+// the spaces around the punctuation are fixed and the first letter of each
+// sentence is capitalized, unless the module lowercases its romanization
+// (see RomanizationOptions.Case). Sentences without text are dropped.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - input: The text to be romanized
+//
+// Returns:
+//   - []string: The romanized sentences
+//   - error: An error if processing fails, the context is canceled, or romanization isn't supported
+func (m *Module) RomanSentencesWithContext(ctx context.Context, input string) ([]string, error) {
+	if !m.hasTransliterator() {
+		return nil, ErrRomanizationUnsupported
+	}
+	tkns, err := m.TokensWithContext(ctx, input)
+	if err != nil && !isPartial(err) {
+		return []string{}, err
+	}
+	tkns = m.romanTokens(tkns)
+	rule, text := m.getSpacingRule(), m.romanText()
+	sentences := []string{}
+	for _, group := range groupBySentence(anyTokens(tkns)) {
+		s := normalizeRomanSentence(joinWithSpacingRule(group, rule, text))
+		if s == "" {
+			continue
+		}
+		if m.romanOptions.Case != CaseLower {
+			s = capitalize(s)
+		}
+		sentences = append(sentences, s)
+	}
+	return sentences, err
+}
+
+// RomanSentences returns the romanized sentences of the input using a
+// background context, see RomanSentencesWithContext.
+func (m *Module) RomanSentences(input string) ([]string, error) {
+	return m.RomanSentencesWithContext(context.Background(), input)
+}
+
+// groupBySentence splits the tokens by the sentence they belong to, see
+// Tkn.Position. The tokens that don't embed Tkn stay in the current sentence.
+func groupBySentence(tokens []AnyToken) [][]AnyToken {
+	var groups [][]AnyToken
+	sentence := -1
+	for _, token := range tokens {
+		if tkn := BaseToken(token); len(groups) == 0 || tkn != nil && tkn.Position.Sentence != sentence {
+			groups = append(groups, nil)
+			if tkn != nil {
+				sentence = tkn.Position.Sentence
+			}
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], token)
+	}
+	return groups
+}
+
+// normalizeRomanSentence replaces the native punctuation of s, removes the
+// spaces before the punctuation that follows a word and puts a space after
+// the native one, then collapses and trims the whitespace. The result is in
+// NFC like the capitalized sentences.
+func normalizeRomanSentence(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		latin, native := romanPunctuation[r]
+		if native {
+			r = latin
+		}
+		if unicode.IsSpace(r) {
+			if i+1 < len(runes) && isFollowingPunctuation(runes[i+1]) {
+				continue
+			}
+			r = ' '
+		}
+		b.WriteRune(r)
+		// the native scripts that don't separate words don't put a space
+		// after their punctuation either
+		if native && isFollowingPunctuation(r) && i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+			b.WriteRune(' ')
+		}
+	}
+	return norm.NFC.String(strings.Join(strings.Fields(b.String()), " "))
+}
+
+// isFollowingPunctuation reports whether r is punctuation that follows a
+// word without space in Latin script.
+func isFollowingPunctuation(r rune) bool {
+	if latin, ok := romanPunctuation[r]; ok {
+		r = latin
+	}
+	switch r {
+	case '.', ',', '!', '?', ';', ':':
+		return true
+	}
+	return false
+}
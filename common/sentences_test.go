@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestRomanSentences(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	sentences, err := m.RomanSentences("ผมชอบกินข้าว！คุณล่ะ？ ")
+	require.NoError(t, err)
+	require.Len(t, sentences, 2)
+	assert.True(t, strings.HasPrefix(sentences[0], "Pǒm "), sentences[0])
+	assert.True(t, strings.HasSuffix(sentences[0], "!"), "full-width punctuation is replaced")
+	assert.True(t, strings.HasSuffix(sentences[1], "?"))
+
+	plain, err := m.RomanSentences("ผมชอบกินข้าว. คุณชอบไหม?")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pǒm chɔ̂ɔp gin-kâao.", "Kun chɔ̂ɔp mǎi?"}, plain)
+
+	m.WithRomanizationOptions(common.RomanizationOptions{Case: common.CaseLower})
+	lower, err := m.RomanSentences("ผมชอบกินข้าว. คุณชอบไหม?")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pǒm chɔ̂ɔp gin-kâao.", "kun chɔ̂ɔp mǎi?"}, lower)
+
+	empty, err := m.RomanSentences("  ")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var spacingRules = struct {
+	sync.RWMutex
+	rules map[string]SpacingRule
+}{rules: make(map[string]SpacingRule)}
+
+// RegisterSpacingRule sets the SpacingRule used by modules of the given language
+// to join tokens in Roman() and Tokenized() output, in place of DefaultSpacingRule.
+// Registering a rule for a language that already has one replaces it.
+func RegisterSpacingRule(languageCode string, rule SpacingRule) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if rule == nil {
+		return fmt.Errorf("spacing rule cannot be nil")
+	}
+	spacingRules.Lock()
+	defer spacingRules.Unlock()
+	spacingRules.rules[lang] = rule
+	return nil
+}
+
+// GetSpacingRule returns the SpacingRule registered for the given language,
+// or DefaultSpacingRule if there is none.
+func GetSpacingRule(languageCode string) SpacingRule {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return DefaultSpacingRule
+	}
+	spacingRules.RLock()
+	defer spacingRules.RUnlock()
+	if rule, ok := spacingRules.rules[lang]; ok {
+		return rule
+	}
+	return DefaultSpacingRule
+}
+
+// RomanWithSpacingRule joins the romanization of the tokens of the wrapper
+// using the given spacing rule.
+func RomanWithSpacingRule(wrapper AnyTokenSliceWrapper, rule SpacingRule) string {
+	return joinWithSpacingRule(anyTokens(wrapper), rule, romanOrSurface)
+}
+
+// TokenizedWithSpacingRule joins the surfaces of the tokens of the wrapper
+// using the given spacing rule.
+func TokenizedWithSpacingRule(wrapper AnyTokenSliceWrapper, rule SpacingRule) string {
+	return joinWithSpacingRule(anyTokens(wrapper), rule, AnyToken.GetSurface)
+}
+
+// RomanAlignment maps a token to the part of the romanized string it produced.
+// SrcStart and SrcEnd are the byte offsets of the token in the input of
+// Module.Tokens (see Tkn.Position), RomStart and RomEnd its byte offsets in
+// the output of Roman(). The spaces inserted between tokens by the spacing
+// rule belong to no token.
+type RomanAlignment struct {
+	SrcStart, SrcEnd int
+	RomStart, RomEnd int
+}
+
+// RomanWithAlignment joins the romanization of the tokens of the wrapper
+// using the given spacing rule, like RomanWithSpacingRule, and returns the
+// alignment of each token with the romanized string, in the order of the tokens.
+func RomanWithAlignment(wrapper AnyTokenSliceWrapper, rule SpacingRule) (string, []RomanAlignment) {
+	return joinAligned(anyTokens(wrapper), rule, romanOrSurface, true)
+}
+
+func romanOrSurface(t AnyToken) string {
+	if r := t.Roman(); r != "" {
+		return r
+	}
+	return t.GetSurface()
+}
+
+func joinWithSpacingRule(tokens []AnyToken, rule SpacingRule, text func(AnyToken) string) string {
+	s, _ := joinAligned(tokens, rule, text, false)
+	return s
+}
+
+// joinAligned joins the text of the tokens using the spacing rule and, if
+// align is set, records where the text of each token ended up.
+func joinAligned(tokens []AnyToken, rule SpacingRule, text func(AnyToken) string, align bool) (string, []RomanAlignment) {
+	var builder strings.Builder
+	var alignment []RomanAlignment
+	if align {
+		alignment = make([]RomanAlignment, 0, len(tokens))
+	}
+	var prev string
+	for i, token := range tokens {
+		s := text(token)
+		if i > 0 && rule(prev, s) {
+			builder.WriteRune(' ')
+		}
+		if align {
+			a := RomanAlignment{SrcStart: -1, SrcEnd: -1, RomStart: builder.Len(), RomEnd: builder.Len() + len(s)}
+			if tkn := BaseToken(token); tkn != nil {
+				a.SrcStart, a.SrcEnd = tkn.Position.Start, tkn.Position.End
+			}
+			alignment = append(alignment, a)
+		}
+		builder.WriteString(s)
+		prev = s
+	}
+	return builder.String(), alignment
+}
+
+func anyTokens(wrapper AnyTokenSliceWrapper) []AnyToken {
+	tokens := make([]AnyToken, wrapper.Len())
+	for i := range tokens {
+		tokens[i] = wrapper.GetIdx(i)
+	}
+	return tokens
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"sync"
+)
+
+// Compatibility policy
+//
+// The exported API of translitkit falls into three tiers:
+//
+//   - Stable: everything not listed below. It follows semantic versioning:
+//     breaking changes only happen in a new major version.
+//   - Experimental: the features listed as Experimental* constants. They are
+//     off until enabled with EnableExperimental and may change or be removed
+//     in any minor version. Using one without enabling it logs a warning once
+//     and has no effect.
+//   - Deprecated: identifiers documented as "Deprecated:". They keep working
+//     for at least one minor version after deprecation, log a warning the
+//     first time they are used, and are removed in a following minor version
+//     (or the next major version once the module reaches v1).
+
+// Experimental is a feature whose API may still change, see EnableExperimental.
+type Experimental string
+
+const (
+	// ExperimentalNER gates Module.WithNER and the NERMode providers.
+	ExperimentalNER Experimental = "ner"
+)
+
+var experimental = struct {
+	sync.RWMutex
+	enabled map[Experimental]bool
+}{enabled: make(map[Experimental]bool)}
+
+// EnableExperimental opts in to experimental features. Their API may change
+// in any minor version, see the compatibility policy in stability.go.
+func EnableExperimental(features ...Experimental) {
+	experimental.Lock()
+	defer experimental.Unlock()
+	for _, f := range features {
+		experimental.enabled[f] = true
+	}
+}
+
+// DisableExperimental opts out of experimental features.
+func DisableExperimental(features ...Experimental) {
+	experimental.Lock()
+	defer experimental.Unlock()
+	for _, f := range features {
+		delete(experimental.enabled, f)
+	}
+}
+
+// ExperimentalEnabled reports whether an experimental feature was enabled.
+func ExperimentalEnabled(feature Experimental) bool {
+	experimental.RLock()
+	defer experimental.RUnlock()
+	return experimental.enabled[feature]
+}
+
+// warnedOnce holds the API already warned about, so that each warning is only
+// logged once per process
+var warnedOnce sync.Map
+
+// requireExperimental reports whether the feature used by api is enabled,
+// warning once if it isn't.
+func requireExperimental(feature Experimental, api string) bool {
+	if ExperimentalEnabled(feature) {
+		return true
+	}
+	if _, warned := warnedOnce.LoadOrStore("experimental:"+api, true); !warned {
+		Log.Warn().Str("api", api).Str("feature", string(feature)).
+			Msg("Experimental feature not enabled, ignored; call common.EnableExperimental to opt in")
+	}
+	return false
+}
+
+// deprecated warns once that api is deprecated in favor of replacement.
+func deprecated(api, replacement string) {
+	if _, warned := warnedOnce.LoadOrStore("deprecated:"+api, true); !warned {
+		Log.Warn().Str("api", api).Str("replacement", replacement).
+			Msg("Deprecated API, will be removed in a future version")
+	}
+}
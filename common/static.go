@@ -122,7 +122,7 @@ var langsNeedTokenization = []string{
 	"khm", // Khmer - 16 million
 	"mnp", // Chinese (Min Bei/Northern Min) - 10 million
 	"lao", // Lao - 7 million
-	"tib", // Tibetan - 1.2 million
+	"bod", // Tibetan - 1.2 million
 }
 
 var langsNeedTransliteration = []string{
@@ -172,7 +172,7 @@ var langsNeedTransliteration = []string{
 	"mon", // Mongolian (Traditional Mongolian script) - 5 million
 	"uzb", // Uzbek (Cyrillic script) - 27 million
 	"kir", // Kirghiz (Cyrillic script) - 4.5 million
-	"tib", // Tibetan - 1.2 million
+	"bod", // Tibetan - 1.2 million
 	"dzo", // Dzongkha (Tibetan script) - 640,000
 	"san", // Sanskrit (Devanagari script)
 	"grc", // Ancient Greek - (historical)
@@ -0,0 +1,212 @@
+// Package testkit provides golden-file based conformance tests of
+// romanization, shared by the language packages and by the providers
+// implemented outside of translitkit.
+//
+// A corpus is a YAML file listing inputs with the outputs expected from a
+// module:
+//
+//	lang: jpn
+//	scheme: kana-hepburn
+//	cases:
+//	  - input: がっこうにいきます
+//	    roman: gakkō ni ikimasu
+//	    parts: [gakkō, ni, ikimasu]
+//	  - input: すし
+//	    tokens: [すし]
+//	    note: a single word
+//
+// Each case checks the outputs it lists: roman against Module.Roman, parts
+// against Module.RomanParts and tokens against Module.TokenizedParts.
+// Setting the environment variable TRANSLITKIT_UPDATE_GOLDEN=1 rewrites the
+// listed outputs of the corpus with those of the module instead of comparing
+// them, e.g. after deliberately changing a provider.
+package testkit
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"gopkg.in/yaml.v2"
+)
+
+// UpdateEnv is the environment variable which, set to 1, makes
+// AssertRomanization update the corpus instead of checking it.
+const UpdateEnv = "TRANSLITKIT_UPDATE_GOLDEN"
+
+// Corpus is the content of a corpus file.
+type Corpus struct {
+	Lang   string `yaml:"lang"`             // ISO 639-3 code of the language of the inputs
+	Scheme string `yaml:"scheme,omitempty"` // scheme the outputs were produced with, informative
+	Cases  []Case `yaml:"cases"`
+}
+
+// Case is an input of a corpus with its expected outputs. Empty outputs
+// aren't checked.
+type Case struct {
+	Input  string   `yaml:"input"`
+	Roman  string   `yaml:"roman,omitempty"`
+	Parts  []string `yaml:"parts,omitempty,flow"`
+	Tokens []string `yaml:"tokens,omitempty,flow"`
+	Note   string   `yaml:"note,omitempty"` // why the case is in the corpus
+}
+
+// LoadCorpus reads a corpus file.
+func LoadCorpus(path string) (*Corpus, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+	var corpus Corpus
+	if err := yaml.UnmarshalStrict(content, &corpus); err != nil {
+		return nil, fmt.Errorf("failed to parse corpus %s: %w", path, err)
+	}
+	if _, ok := common.IsValidISO639(corpus.Lang); !ok {
+		return nil, fmt.Errorf("corpus %s: invalid language %q", path, corpus.Lang)
+	}
+	for i, c := range corpus.Cases {
+		if c.Input == "" {
+			return nil, fmt.Errorf("corpus %s: case %d has no input", path, i)
+		}
+	}
+	return &corpus, nil
+}
+
+// Save writes the corpus to a file.
+func (c *Corpus) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode corpus: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write corpus: %w", err)
+	}
+	return nil
+}
+
+// Mismatch is an output of a module differing from the one of the corpus.
+type Mismatch struct {
+	Case     int // index of the case in the corpus
+	Input    string
+	Output   string // "roman", "parts" or "tokens"
+	Expected any
+	Got      any
+	Err      error // set if the module failed to process the input
+}
+
+func (m Mismatch) String() string {
+	if m.Err != nil {
+		return fmt.Sprintf("case %d %q: %s: %v", m.Case, m.Input, m.Output, m.Err)
+	}
+	return fmt.Sprintf("case %d %q: %s: expected %q, got %q", m.Case, m.Input, m.Output, m.Expected, m.Got)
+}
+
+// Check runs the cases of the corpus through an initialized module and
+// returns the outputs that differ from the corpus.
+func (c *Corpus) Check(m *common.Module) []Mismatch {
+	var mismatches []Mismatch
+	for i, tc := range c.Cases {
+		for _, output := range c.outputs(m, i) {
+			if output.err != nil {
+				mismatches = append(mismatches, Mismatch{Case: i, Input: tc.Input, Output: output.name, Err: output.err})
+			} else if !output.matches() {
+				mismatches = append(mismatches, Mismatch{Case: i, Input: tc.Input, Output: output.name, Expected: output.expected, Got: output.got})
+			}
+		}
+	}
+	return mismatches
+}
+
+// Update replaces the outputs listed by the cases with those of the module.
+func (c *Corpus) Update(m *common.Module) error {
+	for i := range c.Cases {
+		for _, output := range c.outputs(m, i) {
+			if output.err != nil {
+				return fmt.Errorf("case %d %q: %s: %w", i, c.Cases[i].Input, output.name, output.err)
+			}
+			output.update()
+		}
+	}
+	return nil
+}
+
+type output struct {
+	name          string
+	expected, got any
+	err           error
+	update        func()
+}
+
+func (o output) matches() bool {
+	switch expected := o.expected.(type) {
+	case string:
+		return expected == o.got.(string)
+	case []string:
+		return slices.Equal(expected, o.got.([]string))
+	}
+	return false
+}
+
+// outputs computes the outputs of the module listed by the case i.
+func (c *Corpus) outputs(m *common.Module, i int) []output {
+	tc := &c.Cases[i]
+	var outputs []output
+	if tc.Roman != "" {
+		got, err := m.Roman(tc.Input)
+		outputs = append(outputs, output{"roman", tc.Roman, got, err, func() { tc.Roman = got }})
+	}
+	if len(tc.Parts) > 0 {
+		got, err := m.RomanParts(tc.Input)
+		outputs = append(outputs, output{"parts", tc.Parts, got, err, func() { tc.Parts = got }})
+	}
+	if len(tc.Tokens) > 0 {
+		got, err := m.TokenizedParts(tc.Input)
+		outputs = append(outputs, output{"tokens", tc.Tokens, got, err, func() { tc.Tokens = got }})
+	}
+	return outputs
+}
+
+// AssertRomanization checks the outputs of an initialized module against the
+// corpus file casesFile, reporting each mismatch as a test error. With
+// TRANSLITKIT_UPDATE_GOLDEN=1, the corpus is rewritten with the outputs of
+// the module instead.
+//
+// Example usage, with the corpus in the testdata directory of the package:
+//
+//	func TestKanaCorpus(t *testing.T) {
+//		m, err := common.GetSchemeModule("jpn", "kana-hepburn")
+//		require.NoError(t, err)
+//		require.NoError(t, m.Init())
+//		testkit.AssertRomanization(t, m, "testdata/kana-hepburn.yaml")
+//	}
+//
+// Returns whether the module conforms to the corpus.
+func AssertRomanization(t testing.TB, m *common.Module, casesFile string) bool {
+	t.Helper()
+	corpus, err := LoadCorpus(casesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang, _ := common.IsValidISO639(corpus.Lang); lang != m.Lang {
+		t.Fatalf("corpus %s is in %s, the module in %s", casesFile, lang, m.Lang)
+	}
+
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := corpus.Update(m); err != nil {
+			t.Fatal(err)
+		}
+		if err := corpus.Save(casesFile); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated %s", casesFile)
+		return true
+	}
+
+	mismatches := corpus.Check(m)
+	for _, mismatch := range mismatches {
+		t.Errorf("%s: %s", casesFile, mismatch)
+	}
+	return len(mismatches) == 0
+}
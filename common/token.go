@@ -2,7 +2,10 @@ package common
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
+	"sync"
 	"unicode"
 	"crypto/md5"
 	"encoding/hex"
@@ -18,6 +21,8 @@ type AnyTokenSliceWrapper interface {
 	ClearRaw()
 	Append(...AnyToken)
 	Len()			int
+	Tokens()		[]AnyToken
+	Iter()			iter.Seq2[int, AnyToken]
 
 	Roman()			string
 	RomanParts()		[]string
@@ -35,21 +40,37 @@ type AnyToken interface {
 // FilterAny receives any token slice wrapper and returns a new wrapper
 // containing only tokens that contain lexical content (ie. it excludes space, punctuations...)
 func ToAnyLexicalTokens(wrapper AnyTokenSliceWrapper) AnyTokenSliceWrapper {
+	return Filter(wrapper, AnyToken.IsLexicalContent)
+}
+
+// Filter returns a new wrapper containing the tokens of wrapper for which
+// keep returns true, in order. The tokens are shared, not copied.
+func Filter(wrapper AnyTokenSliceWrapper, keep func(AnyToken) bool) AnyTokenSliceWrapper {
 	filtered := &TknSliceWrapper{}
-	for i := 0; i < wrapper.Len(); i++ {
-		token := wrapper.GetIdx(i)
-		if token.IsLexicalContent() {
+	for _, token := range wrapper.Iter() {
+		if keep(token) {
 			filtered.Append(token)
 		}
 	}
+	copyChunks(filtered, wrapper)
 	return filtered
 }
 
+// Map returns the result of f for each token of wrapper, in order, e.g.
+// Map(tsw, AnyToken.GetSurface) returns the surfaces of the tokens.
+func Map[T any](wrapper AnyTokenSliceWrapper, f func(AnyToken) T) []T {
+	out := make([]T, 0, wrapper.Len())
+	for _, token := range wrapper.Iter() {
+		out = append(out, f(token))
+	}
+	return out
+}
+
 
 // Filter receives *common.TknSliceWrapper and returns a new wrapper
 // containing only tokens that contain lexical content (ie. it excludes space, punctuations...)
 func ToLexicalTokens(wrapper *TknSliceWrapper) *TknSliceWrapper {
-	filtered := &TknSliceWrapper{}
+	filtered := &TknSliceWrapper{Chunks: wrapper.Chunks, Versions: wrapper.Versions}
 	for i := 0; i < wrapper.Len(); i++ {
 		token := wrapper.GetIdx(i)
 		if token.IsLexicalContent() {
@@ -62,6 +83,12 @@ func ToLexicalTokens(wrapper *TknSliceWrapper) *TknSliceWrapper {
 type TknSliceWrapper struct {
 	Slice []AnyToken //alt.: Sentences [][]AnyToken ?
 	Raw   []string
+	// Chunks maps the Position.Chunk of the tokens to the chunks of the input
+	Chunks []Chunk
+	// TrailingSpace is the input following the last token (see ReconstructOriginal)
+	TrailingSpace string
+	// Versions are the resource versions of the providers that produced the tokens
+	Versions ProviderVersions
 }
 
 // TODO maybe make some of these methods private
@@ -89,12 +116,32 @@ func (tokens *TknSliceWrapper) Append(tkn ...AnyToken) {
 }
 
 
-// return the unwrapped slice contained by the wrapper
-//func (tokens TknSliceWrapper) Tokens() []AnyToken // FIXME may come in handy?
+// Tokens returns the unwrapped slice contained by the wrapper.
+func (tokens TknSliceWrapper) Tokens() []AnyToken {
+	return tokens.Slice
+}
+
+// Iter returns an iterator over the index and the token of each token of the
+// wrapper, to be used with range:
+//
+//	for i, tkn := range tsw.Iter() { ... }
+func (tokens TknSliceWrapper) Iter() iter.Seq2[int, AnyToken] {
+	return slices.All(tokens.Slice)
+}
 
 func (tokens TknSliceWrapper) Roman() string {
 	return defaultRoman(tokens.Slice)
 }
+
+// RomanAlignment returns the alignment of each token with the output of
+// Roman(), e.g. to find which part of the romanized string corresponds to a
+// given part of the input of Module.Tokens. Tokens that don't embed Tkn have
+// SrcStart and SrcEnd set to -1.
+func (tokens TknSliceWrapper) RomanAlignment() []RomanAlignment {
+	_, alignment := joinAligned(tokens.Slice, DefaultSpacingRule, romanOrSurface, true)
+	return alignment
+}
+
 func (tokens TknSliceWrapper) RomanParts() []string {
 	return romanParts(tokens.Slice)
 }
@@ -129,17 +176,28 @@ type Tkn struct {
 	// Type of token (word, punctuation, etc.)
 	// TokenType  TokenType 
 	
+	// Position of the token in the input of Module.Tokens, set by the module
+	// once all providers have run. Start and End are byte offsets; Sentence and
+	// Chunk are stable IDs that can be looked up in the chunk map of the
+	// wrapper (see TknSliceWrapper.ChunkOf).
 	Position struct {
 		Start     int // Start position in original text
 		End       int // End position in original text
 		Sentence  int // Index of containing sentence
 		Paragraph int // Index of containing paragraph
+		Chunk     int // Index of the chunk of the input the token was produced from
 	}
 
+	// PrecedingSpace is the input between the previous token and this one,
+	// set along with Position: whitespace the provider didn't make a token
+	// of, or filler it dropped. See TknSliceWrapper.ReconstructOriginal.
+	PrecedingSpace string
+
 	// Linguistic Features
 	Romanization  string            // Latin alphabet representation
 	Lemma         string            // Base/dictionary form
 	PartOfSpeech  string            // Grammatical category (noun, verb, etc.)
+	UPOS          string            // PartOfSpeech as a Universal POS tag (see ToUPOS)
 	MorphFeatures map[string]string // Morphological features (gender, number, tense, etc.)
 	Glosses       []Gloss           // Definitions/meanings with associated metadata
 
@@ -156,7 +214,7 @@ type Tkn struct {
 	IsCompound bool  // Whether this is a compound token
 
 	// Additional Information
-	Confidence float64                // Confidence score of the analysis
+	Confidence float64                // Confidence of the provider in the analysis, from 0 to 1 (0: not estimated, see ConfidenceDictionary)
 	Script     string                 // Writing system used (Latin, Cyrillic, etc.)
 	Language   string                 // ISO 639-3 code of the token's language
 	Metadata   map[string]interface{} // Provider-specific additional data
@@ -165,11 +223,34 @@ type Tkn struct {
 
 // IntegrateProviderTokensV2 is an improved version of deprecated IntegrateProviderTokens
 // that adds better error handling and reporting for token matching issues.
+// It returns an error if more than 20% of the tokens can't be found in the
+// original, see IntegrateProviderTokensWithMatching.
 func IntegrateProviderTokensV2(original string, providerTokens []string) ([]*Tkn, error) {
-	var result []*Tkn
+	return IntegrateProviderTokensWithMatching(original, providerTokens, TokenMatching{Strategy: MissingTokensError})
+}
+
+// IntegrateProviderTokensWithMatching combines the tokens produced by a
+// provider with the intervening text of the original, like
+// IntegrateProviderTokensV2, and handles the tokens it can't find in the
+// original according to tm.
+//
+// The tokens are allocated together and their surfaces are substrings of the
+// original, so that large inputs don't cost an allocation per token.
+func IntegrateProviderTokensWithMatching(original string, providerTokens []string, tm TokenMatching) ([]*Tkn, error) {
+	tm = tm.withDefaults()
+	// first pass: locate the tokens, then allocate the result at once
+	spans := spanPool.Get().(*[]tokenSpan)
+	defer func() {
+		// don't keep the buffers grown by very large inputs around
+		if cap(*spans) <= maxPooledSpans {
+			*spans = (*spans)[:0]
+			spanPool.Put(spans)
+		}
+	}()
 	pos := 0
 	missedTokens := 0
 	totalTokens := len(providerTokens)
+	var aligned *foldedText // see MissingTokensAlign
 	
 	for i, token := range providerTokens {
 		// Skip empty tokens
@@ -178,7 +259,27 @@ func IntegrateProviderTokensV2(original string, providerTokens []string) ([]*Tkn
 		}
 		
 		// Find the token starting from the current position
-		idx := strings.Index(original[pos:], token)
+		idx, end := strings.Index(original[pos:], token), 0
+		if idx != -1 {
+			end = idx + len(token)
+		}
+		switch tm.Strategy {
+		case MissingTokensFuzzy:
+			if idx == -1 {
+				idx, end = fuzzyIndex(original[pos:], token)
+			}
+		case MissingTokensAlign:
+			if aligned == nil {
+				aligned = newFoldedText(original)
+			}
+			// the normalized match wins if it's closer than the exact one
+			if start, stop, ok := aligned.locate(pos, token); ok && (idx == -1 || start < pos+idx) {
+				idx, end = start-pos, stop-pos
+			} else if idx == -1 && foldString(token) == "" {
+				// punctuation replaced by the provider, it stays in the filler
+				continue
+			}
+		}
 		if idx == -1 {
 			missedTokens++
 			Log.Debug().
@@ -189,49 +290,81 @@ func IntegrateProviderTokensV2(original string, providerTokens []string) ([]*Tkn
 			continue
 		}
 		
-		// Adjust index relative to the whole string
-		idx += pos
+		// Adjust the range relative to the whole string
+		idx, end = idx+pos, end+pos
 		
 		// Capture any text between the current position and the token's start as a fake token
 		if pos < idx {
-			fake := original[pos:idx]
-			result = append(result, &Tkn{Surface: fake, IsLexical: false})
+			*spans = append(*spans, tokenSpan{pos, idx, false})
 		}
 		
 		// Append the provider token
-		result = append(result, &Tkn{Surface: token, IsLexical: true})
+		*spans = append(*spans, tokenSpan{idx, end, true})
 		
 		// Update the position after the token
-		pos = idx + len(token)
+		pos = end
 	}
 	
 	// Capture any trailing characters as a fake token
 	if pos < len(original) {
-		fake := original[pos:]
-		result = append(result, &Tkn{Surface: fake, IsLexical: false})
+		*spans = append(*spans, tokenSpan{pos, len(original), false})
 	}
-	
-	// If we missed more than 20% of tokens, return an error but still return results
-	if totalTokens > 0 && missedTokens > totalTokens/5 {
-		return result, fmt.Errorf("token matching issues: missed %d of %d tokens (%.1f%%)", 
-			missedTokens, totalTokens, float64(missedTokens)/float64(totalTokens)*100)
+
+	var result []*Tkn
+	if len(*spans) > 0 {
+		tkns := make([]Tkn, len(*spans))
+		result = make([]*Tkn, len(*spans))
+		for i, span := range *spans {
+			tkns[i] = Tkn{Surface: original[span.start:span.end], IsLexical: span.lexical}
+			result[i] = &tkns[i]
+		}
 	}
 	
+	// If we missed too many tokens, report it but still return results
+	if totalTokens == 0 || float64(missedTokens) <= tm.MaxMissedRatio*float64(totalTokens) {
+		return result, nil
+	}
+	err := fmt.Errorf("token matching issues: missed %d of %d tokens (%.1f%%)", 
+		missedTokens, totalTokens, float64(missedTokens)/float64(totalTokens)*100)
+	if tm.Strategy == MissingTokensError {
+		return result, err
+	}
+	Log.Warn().Err(err).Msg("Provider tokens missing from the original text")
 	return result, nil
 }
 
+// tokenSpan is the byte range of a token found by IntegrateProviderTokensV2.
+type tokenSpan struct {
+	start, end int
+	lexical    bool
+}
+
+// maxPooledSpans is the capacity above which a buffer of spans isn't pooled.
+const maxPooledSpans = 1 << 16
+
+// spanPool holds the buffers of spans of IntegrateProviderTokensV2. The
+// tokens it returns belong to the caller and can't be pooled themselves.
+var spanPool = sync.Pool{
+	New: func() interface{} {
+		spans := make([]tokenSpan, 0, 256)
+		return &spans
+	},
+}
+
 // GetContentHash generates a hash for a text chunk for caching purposes
 func GetContentHash(text string) string {
 	hash := md5.Sum([]byte(text))
 	return hex.EncodeToString(hash[:])
 }
 
-// DEPRECATED: USE IntegrateProviderTokensV2 INSTEAD!
 // Some tokenization providers have a lossy tokenization that offers only the core, lexical content.
 // IntegrateProviderTokens combines the tokens produced by the provider with the
 // intervening text (such as punctuation, spaces, or other characters) that the provider
 // did not tokenize by tracking their positions and capturing any gaps as filler tokens.
+//
+// Deprecated: use IntegrateProviderTokensV2, which reports the tokens it couldn't match.
 func IntegrateProviderTokens(original string, providerTokens []string) []*Tkn {
+	deprecated("IntegrateProviderTokens", "IntegrateProviderTokensV2")
 	var result []*Tkn
 	pos := 0
 
@@ -271,6 +404,23 @@ type Gloss struct {
 	PartOfSpeech	string  // Part of speech
 	Definition	string  // Definition/meaning
 	Info		string  // Additional information
+	Language	string  // ISO 639-3 code of the language of Definition, empty if unknown
+}
+
+// Base returns the common Tkn. Since language-specific tokens embed Tkn, this
+// gives access to the common annotations of any token through AnyToken,
+// see BaseToken.
+func (t *Tkn) Base() *Tkn {
+	return t
+}
+
+// BaseToken returns the common Tkn embedded in a token, or nil if the token
+// doesn't embed one.
+func BaseToken(token AnyToken) *Tkn {
+	if b, ok := token.(interface{ Base() *Tkn }); ok {
+		return b.Base()
+	}
+	return nil
 }
 
 func (t *Tkn) GetSurface() string {
@@ -321,47 +471,18 @@ func tokenizedParts(tokens []AnyToken) []string {
 
 // roman constructs the romanized string intelligently using the provided spacing rule.
 func defaultRoman(tokens []AnyToken) string {
-	spacingRule := DefaultSpacingRule
-	var builder strings.Builder
-	var prev string
-
-	for i, token := range tokens {
-		var text string
-		// Use token.Roman() if available; otherwise, use token.GetSurface().
-		if r := token.Roman(); r != "" {
-			text = r
-		} else {
-			text = token.GetSurface()
-		}
-
-		if i > 0 && spacingRule(prev, text) {
-			builder.WriteRune(' ')
-		}
-		builder.WriteString(text)
-		prev = text
-	}
-	return builder.String()
+	// Use token.Roman() if available; otherwise, use token.GetSurface().
+	return joinWithSpacingRule(tokens, DefaultSpacingRule, romanOrSurface)
 }
 
 // defaultTokenized constructs the tokenized string intelligently using the provided spacing rule.
 func defaultTokenized(tokens []AnyToken) string {
-	spacingRule := DefaultSpacingRule
-	var builder strings.Builder
-	var prev string
-
-	for i, token := range tokens {
-		text := token.GetSurface()
-		if i > 0 && spacingRule(prev, text) {
-			builder.WriteRune(' ')
-		}
-		builder.WriteString(text)
-		prev = text
-	}
-	return builder.String()
+	return joinWithSpacingRule(tokens, DefaultSpacingRule, AnyToken.GetSurface)
 }
 
 
 // SpacingRule defines a function signature for deciding if a space is needed between tokens.
+// Languages can register their own with RegisterSpacingRule.
 type SpacingRule func(prev, current string) bool
 
 // DefaultSpacingRule determines if a space should be inserted between two tokens
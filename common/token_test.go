@@ -0,0 +1,49 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestWrapperIteration(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	defer m.Close()
+
+	tsw, err := m.Tokens("ผมชอบ กินข้าว")
+	require.NoError(t, err)
+	require.Equal(t, tsw.Len(), len(tsw.Tokens()))
+
+	var surfaces []string
+	for i, tkn := range tsw.Iter() {
+		assert.Equal(t, tsw.GetIdx(i), tkn)
+		surfaces = append(surfaces, tkn.GetSurface())
+	}
+	assert.Equal(t, surfaces, common.Map(tsw, common.AnyToken.GetSurface))
+	assert.Equal(t, "ผมชอบ กินข้าว", strings.Join(surfaces, ""))
+
+	lexical := common.Filter(tsw, common.AnyToken.IsLexicalContent)
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว"}, common.Map(lexical, common.AnyToken.GetSurface))
+
+	for range tsw.Iter() {
+		break // stopping early must not panic
+	}
+}
+
+func BenchmarkIntegrateProviderTokensV2(b *testing.B) {
+	text := strings.Repeat("ผมชอบกินข้าวมาก, คุณชอบไหม? ", 4096)
+	words := tha.SegmentWords(text)
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := common.IntegrateProviderTokensV2(text, words); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
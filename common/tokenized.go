@@ -0,0 +1,106 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TokenizedOption configures the output of Module.Tokenized.
+type TokenizedOption func(*tokenizedConfig)
+
+type tokenizedConfig struct {
+	delimiter string
+	escape    bool
+}
+
+// WithDelimiter separates the tokens with delim instead of the spaces of the
+// spacing rule. The whitespace between words is dropped, as the delimiter now
+// marks the word boundaries; punctuation is kept as tokens of its own.
+func WithDelimiter(delim string) TokenizedOption {
+	return func(c *tokenizedConfig) {
+		c.delimiter = delim
+	}
+}
+
+// WithEscaping escapes the delimiter and backslashes inside tokens with a
+// backslash, so that tokens containing the delimiter (e.g. "New York" with
+// the default " " delimiter) survive a round trip through SplitTokenized.
+func WithEscaping() TokenizedOption {
+	return func(c *tokenizedConfig) {
+		c.escape = true
+	}
+}
+
+func newTokenizedConfig(opts []TokenizedOption) tokenizedConfig {
+	c := tokenizedConfig{delimiter: " "}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// TokenizedWithOptions joins the surfaces of the tokens of the wrapper with
+// a delimiter, see WithDelimiter and WithEscaping. The delimiter is a space
+// by default.
+func TokenizedWithOptions(wrapper AnyTokenSliceWrapper, opts ...TokenizedOption) string {
+	c := newTokenizedConfig(opts)
+	var builder strings.Builder
+	first := true
+	for i := 0; i < wrapper.Len(); i++ {
+		s := wrapper.GetIdx(i).GetSurface()
+		if strings.TrimFunc(s, unicode.IsSpace) == "" {
+			continue
+		}
+		if !first {
+			builder.WriteString(c.delimiter)
+		}
+		first = false
+		if c.escape {
+			s = escapeToken(s, c.delimiter)
+		}
+		builder.WriteString(s)
+	}
+	return builder.String()
+}
+
+func escapeToken(s, delim string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	if delim != "" {
+		s = strings.ReplaceAll(s, delim, `\`+delim)
+	}
+	return s
+}
+
+// SplitTokenized splits the output of Tokenized produced with the same
+// options back into tokens, unescaping them if WithEscaping was used.
+func SplitTokenized(s string, opts ...TokenizedOption) []string {
+	c := newTokenizedConfig(opts)
+	if s == "" {
+		return nil
+	}
+	if !c.escape || c.delimiter == "" {
+		return strings.Split(s, c.delimiter)
+	}
+	var tokens []string
+	var current strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			if strings.HasPrefix(s[i+1:], c.delimiter) {
+				current.WriteString(c.delimiter)
+				i += 1 + len(c.delimiter)
+			} else {
+				current.WriteByte(s[i+1])
+				i += 2
+			}
+		case strings.HasPrefix(s[i:], c.delimiter):
+			tokens = append(tokens, current.String())
+			current.Reset()
+			i += len(c.delimiter)
+		default:
+			current.WriteByte(s[i])
+			i++
+		}
+	}
+	return append(tokens, current.String())
+}
@@ -0,0 +1,27 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestTokenizedDelimiter(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	tokenized, err := m.Tokenized("ผมชอบกินข้าว สวัสดีครับ!", common.WithDelimiter("|"))
+	require.NoError(t, err)
+	assert.Equal(t, "ผม|ชอบ|กินข้าว|สวัสดี|ครับ|!", tokenized)
+
+	tsw := &common.TknSliceWrapper{}
+	tsw.Append(&common.Tkn{Surface: "New York", IsLexical: true}, &common.Tkn{Surface: " "}, &common.Tkn{Surface: `a\b`, IsLexical: true})
+	escaped := common.TokenizedWithOptions(tsw, common.WithEscaping())
+	assert.Equal(t, `New\ York a\\b`, escaped)
+	assert.Equal(t, []string{"New York", `a\b`}, common.SplitTokenized(escaped, common.WithEscaping()))
+}
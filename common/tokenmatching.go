@@ -0,0 +1,235 @@
+package common
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// MissingTokenStrategy is what IntegrateProviderTokensWithMatching does with
+// the provider tokens it can't find in the original text.
+type MissingTokenStrategy int
+
+const (
+	// MissingTokensWarn logs a warning when more than MaxMissedRatio of the
+	// tokens are missing and keeps the tokens that were found.
+	MissingTokensWarn MissingTokenStrategy = iota
+	// MissingTokensError returns an error when more than MaxMissedRatio of
+	// the tokens are missing, along with the tokens that were found.
+	// The providers then fail the chunk, see Module.WithPartialResults.
+	MissingTokensError
+	// MissingTokensFuzzy looks for the missing tokens again with their width
+	// and case folded, e.g. for providers that return "ＡＢＣ" as "abc",
+	// then warns like MissingTokensWarn.
+	MissingTokensFuzzy
+	// MissingTokensAlign aligns the tokens with the text once both are
+	// normalized (compatibility forms, case, without whitespace and
+	// punctuation), and with a diff of the text that follows for the tokens
+	// the provider altered further, then warns like MissingTokensWarn. It
+	// suits the providers that normalize their output, e.g. by replacing
+	// the punctuation or the half-width katakana of the input. The tokens
+	// made only of punctuation that isn't in the text are ignored.
+	MissingTokensAlign
+)
+
+// TokenMatching configures how the tokens returned by a provider are matched
+// against the text they come from, see Module.WithTokenMatching.
+type TokenMatching struct {
+	// MaxMissedRatio is the ratio of provider tokens that may be missing
+	// from the text before Strategy applies (default 0.2).
+	MaxMissedRatio float64
+
+	Strategy MissingTokenStrategy
+}
+
+func (tm TokenMatching) withDefaults() TokenMatching {
+	if tm.MaxMissedRatio <= 0 {
+		tm.MaxMissedRatio = 0.2
+	}
+	return tm
+}
+
+type tokenMatchingKey struct{}
+
+// WithTokenMatching sets how the providers of the module that integrate the
+// tokens of an external tokenizer with the text (e.g. web scrapers, see
+// IntegrateProviderTokensWithContext) handle the tokens they can't find in
+// the text. By default, they log a warning when more than 20% of the tokens
+// of a chunk are missing.
+//
+// Parameters:
+//   - tm: The threshold and the strategy
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithTokenMatching(tm TokenMatching) *Module {
+	m.tokenMatching = &tm
+	return m
+}
+
+// IntegrateProviderTokensWithContext integrates the provider tokens with the
+// text like IntegrateProviderTokensWithMatching, with the TokenMatching of
+// the module processing the input (see Module.WithTokenMatching).
+func IntegrateProviderTokensWithContext(ctx context.Context, original string, providerTokens []string) ([]*Tkn, error) {
+	tm, _ := ctx.Value(tokenMatchingKey{}).(TokenMatching)
+	return IntegrateProviderTokensWithMatching(original, providerTokens, tm)
+}
+
+// withTokenMatching returns ctx carrying the TokenMatching of the module.
+func (m *Module) withTokenMatching(ctx context.Context) context.Context {
+	if m.tokenMatching == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tokenMatchingKey{}, *m.tokenMatching)
+}
+
+// fuzzyIndex returns the byte range of the first match of token in s once
+// the width and the case of both are folded, or -1, -1.
+func fuzzyIndex(s, token string) (int, int) {
+	want := foldWidthCase(token)
+	if want == "" {
+		return -1, -1
+	}
+	for start := 0; start < len(s); {
+		if end, ok := fuzzyPrefix(s[start:], want); ok {
+			return start, start + end
+		}
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// fuzzyPrefix reports whether s starts with the folded text want, and the
+// length of the match in s.
+func fuzzyPrefix(s, want string) (int, bool) {
+	for i, r := range s {
+		if want == "" {
+			return i, true
+		}
+		folded := foldWidthCase(string(r))
+		if !strings.HasPrefix(want, folded) {
+			return 0, false
+		}
+		want = want[len(folded):]
+	}
+	return len(s), want == ""
+}
+
+func foldWidthCase(s string) string {
+	return strings.ToLower(width.Fold.String(s))
+}
+
+// foldedText is the text as compared by MissingTokensAlign (see foldRune),
+// along with the byte range in the text of the rune each byte comes from.
+type foldedText struct {
+	folded       string
+	starts, ends []int
+}
+
+func newFoldedText(s string) *foldedText {
+	var b strings.Builder
+	ft := &foldedText{}
+	for i, r := range s {
+		f := foldRune(r)
+		b.WriteString(f)
+		for range len(f) {
+			ft.starts = append(ft.starts, i)
+			ft.ends = append(ft.ends, i+utf8.RuneLen(r))
+		}
+	}
+	ft.folded = b.String()
+	return ft
+}
+
+// foldRune returns the form of r compared by MissingTokensAlign: nothing for
+// whitespace and punctuation, its lowercased compatibility decomposition
+// otherwise, so that e.g. "ｶﾞ" and "ガ" compare equal rune by rune.
+func foldRune(r rune) string {
+	if unicode.IsSpace(r) || unicode.IsPunct(r) {
+		return ""
+	}
+	return strings.ToLower(norm.NFKD.String(string(r)))
+}
+
+func foldString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(foldRune(r))
+	}
+	return b.String()
+}
+
+// locate returns the byte range in the text of the first match of token at
+// or after the byte offset pos.
+func (ft *foldedText) locate(pos int, token string) (int, int, bool) {
+	want := foldString(token)
+	if want == "" {
+		return 0, 0, false
+	}
+	from := sort.SearchInts(ft.starts, pos)
+	if i := strings.Index(ft.folded[from:], want); i >= 0 {
+		i += from
+		return ft.starts[i], ft.ends[i+len(want)-1], true
+	}
+	return ft.diff(from, want)
+}
+
+// diff aligns the folded token with the longest common subsequence of the
+// folded text that follows from, within a window of a few times the length
+// of the token. The token is located if at least half of it matched.
+func (ft *foldedText) diff(from int, want string) (int, int, bool) {
+	tok := []rune(want)
+	var win []rune
+	var offsets []int // of the runes of win in folded
+	for i, r := range ft.folded[from:] {
+		if len(win) >= 2*len(tok)+8 {
+			break
+		}
+		win = append(win, r)
+		offsets = append(offsets, from+i)
+	}
+
+	// lcs[i][j] is the length of the LCS of tok[i:] and win[j:]
+	n, m := len(tok), len(win)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if tok[i] == win[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// walk the LCS, skipping the runes of the token first so that the
+	// match starts as early as possible in the text
+	first, last, matched := -1, -1, 0
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case tok[i] == win[j]:
+			if first < 0 {
+				first = j
+			}
+			last, matched = j, matched+1
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	if matched*2 < n || last-first >= 2*n {
+		return 0, 0, false
+	}
+	return ft.starts[offsets[first]], ft.ends[offsets[last]], true
+}
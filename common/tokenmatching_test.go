@@ -0,0 +1,115 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// noisyTokenizer returns words that don't match the input exactly, like a
+// web scraper would
+type noisyTokenizer struct {
+	tha.DictTokenizerProvider
+	words []string
+}
+
+func (p *noisyTokenizer) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	tsw := &tha.TknSliceWrapper{}
+	for _, chunk := range input.GetRaw() {
+		tokens, err := common.IntegrateProviderTokensWithContext(ctx, chunk, p.words)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			tsw.Append(&tha.Tkn{Tkn: *token})
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+func TestWithTokenMatching(t *testing.T) {
+	noisy := &noisyTokenizer{words: []string{"ผม", "ชอบ", "bangkok", "ไม่มี"}}
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return noisy
+	})
+	input := "ผมชอบ Bangkok"
+	surfaces := func() ([]string, error) {
+		tkns, err := m.Tokens(input)
+		if err != nil {
+			return nil, err
+		}
+		words := make([]string, tkns.Len())
+		for i := range words {
+			words[i] = tkns.GetIdx(i).GetSurface()
+		}
+		return words, nil
+	}
+
+	// by default, the missing tokens are only logged
+	parts, err := surfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ผม", "ชอบ", " Bangkok"}, parts)
+
+	m.WithTokenMatching(common.TokenMatching{Strategy: common.MissingTokensError})
+	_, err = surfaces()
+	assert.ErrorContains(t, err, "missed 2 of 4 tokens")
+
+	m.WithTokenMatching(common.TokenMatching{Strategy: common.MissingTokensError, MaxMissedRatio: 0.5})
+	_, err = surfaces()
+	assert.NoError(t, err)
+
+	m.WithTokenMatching(common.TokenMatching{Strategy: common.MissingTokensFuzzy})
+	parts, err = surfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ผม", "ชอบ", " ", "Bangkok"}, parts, "the surface is taken from the input")
+
+	tkns, err := common.IntegrateProviderTokensWithMatching("ＡＢＣ def", []string{"abc", "DEF"}, common.TokenMatching{Strategy: common.MissingTokensFuzzy})
+	require.NoError(t, err)
+	require.Len(t, tkns, 3)
+	assert.Equal(t, "ＡＢＣ", tkns[0].Surface)
+	assert.Equal(t, " ", tkns[1].Surface)
+	assert.Equal(t, "def", tkns[2].Surface)
+}
+
+func TestAlignProviderTokens(t *testing.T) {
+	align := common.TokenMatching{Strategy: common.MissingTokensAlign}
+	surfaces := func(tkns []*common.Tkn) []string {
+		s := make([]string, len(tkns))
+		for i, tkn := range tkns {
+			s[i] = tkn.Surface
+		}
+		return s
+	}
+
+	// half-width katakana and punctuation replaced by the provider
+	input := "ｶﾞｯｺｳへ行く。"
+	tokens := []string{"ガッコウ", "へ", "行く", "."}
+	_, err := common.IntegrateProviderTokensV2(input, tokens)
+	assert.Error(t, err)
+	tkns, err := common.IntegrateProviderTokensWithMatching(input, tokens, align)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ｶﾞｯｺｳ", "へ", "行く", "。"}, surfaces(tkns))
+	assert.True(t, tkns[0].IsLexical)
+	assert.False(t, tkns[3].IsLexical, "the replaced punctuation stays in the filler")
+
+	// a token altered beyond normalization is aligned with a diff
+	tkns, err = common.IntegrateProviderTokensWithMatching("東京に行きます", []string{"東京", "に", "行ます"}, align)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"東京", "に", "行きます"}, surfaces(tkns))
+
+	// the closest match wins, even if it isn't the exact one
+	tkns, err = common.IntegrateProviderTokensWithMatching("ABC abc", []string{"abc"}, align)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ABC", " abc"}, surfaces(tkns))
+
+	tkns, err = common.IntegrateProviderTokensWithMatching("ผมชอบ", []string{"ผม", "xyz"}, align)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ผม", "ชอบ"}, surfaces(tkns), "tokens that can't be aligned are still missing")
+}
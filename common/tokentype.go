@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TokenConverter wraps common tokens in the token slice wrapper of a
+// language, converting them to the token type of that language.
+type TokenConverter func(tokens []*Tkn) AnyTokenSliceWrapper
+
+var tokenConverters = struct {
+	sync.RWMutex
+	fns map[string]TokenConverter
+}{fns: make(map[string]TokenConverter)}
+
+// RegisterTokenConverter sets the function that converts common tokens to
+// the token type of the given language. It is used where translitkit builds
+// tokens itself rather than a provider, such as the best-effort tokens of a
+// CircuitBreaker, so that the Module of the language package can assert its
+// own types on them. The language packages register theirs in their
+// generated code.
+func RegisterTokenConverter(languageCode string, fn TokenConverter) error {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return fmt.Errorf(errNotISO639, languageCode)
+	}
+	if fn == nil {
+		return fmt.Errorf("token converter cannot be nil")
+	}
+	tokenConverters.Lock()
+	defer tokenConverters.Unlock()
+	tokenConverters.fns[lang] = fn
+	return nil
+}
+
+// ConvertTokens wraps tokens in the token slice wrapper of the given
+// language, converted to its token type, see RegisterTokenConverter. If the
+// language has no converter, the tokens are returned in a TknSliceWrapper.
+func ConvertTokens(languageCode string, tokens []*Tkn) AnyTokenSliceWrapper {
+	if fn := getTokenConverter(languageCode); fn != nil {
+		return fn(tokens)
+	}
+	tsw := &TknSliceWrapper{}
+	for _, tkn := range tokens {
+		tsw.Append(tkn)
+	}
+	return tsw
+}
+
+func getTokenConverter(languageCode string) TokenConverter {
+	lang, ok := IsValidISO639(languageCode)
+	if !ok {
+		return nil
+	}
+	tokenConverters.RLock()
+	defer tokenConverters.RUnlock()
+	return tokenConverters.fns[lang]
+}
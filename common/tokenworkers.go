@@ -0,0 +1,164 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type tokenWorkersKey struct{}
+
+// WithTokenWorkers sets the number of tokens that the transliterators
+// processing the tokens one by one may process concurrently, e.g. to hide the
+// latency of the transliterators backed by a container or a web service
+// (aksharamukha, paiboonizer with pythainlp). The tokens keep their order.
+// By default, or if n <= 1, the tokens are processed one after the other.
+//
+// Only the providers that declare that their processing of a token is safe
+// for concurrent use, by processing the tokens with ForEachToken, are
+// affected.
+//
+// Parameters:
+//   - n: The maximum number of tokens processed at once
+//
+// Returns:
+//   - *Module: The module instance for method chaining
+func (m *Module) WithTokenWorkers(n int) *Module {
+	m.tokenWorkers = n
+	return m
+}
+
+// withTokenWorkers returns ctx carrying the number of token workers of the
+// module, see TokenWorkers.
+func (m *Module) withTokenWorkers(ctx context.Context) context.Context {
+	if m.tokenWorkers <= 1 {
+		return ctx
+	}
+	return context.WithValue(ctx, tokenWorkersKey{}, m.tokenWorkers)
+}
+
+// TokenWorkers returns the number of tokens that may be processed at once
+// by the provider called with ctx, see Module.WithTokenWorkers. It is at
+// least 1.
+func TokenWorkers(ctx context.Context) int {
+	if n, ok := ctx.Value(tokenWorkersKey{}).(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// ForEachToken calls fn on each token of the input, on up to TokenWorkers(ctx)
+// tokens at once, see ForEachIndex. fn must be safe for concurrent use when
+// the tokens differ: calling ForEachToken is how a provider declares that its
+// tokens can be processed concurrently.
+//
+// Example usage:
+//
+//	err := common.ForEachToken(ctx, input, p.progressCallback, func(ctx context.Context, idx int, tkn common.AnyToken) error {
+//		roman, err := p.romanize(ctx, tkn.GetSurface())
+//		tkn.SetRoman(roman)
+//		return err
+//	})
+func ForEachToken(ctx context.Context, input AnyTokenSliceWrapper, progress ProgressCallback, fn func(ctx context.Context, idx int, tkn AnyToken) error) error {
+	return ForEachIndex(ctx, input.Len(), progress, func(ctx context.Context, idx int) error {
+		return fn(ctx, idx, input.GetIdx(idx))
+	})
+}
+
+// ForEachIndex calls fn with each index from 0 to total-1, on up to
+// TokenWorkers(ctx) indexes at once, e.g. for the providers that process
+// their tokens in batches. The progress callback, if any, is called after
+// each index is processed, whether fn failed or not, with the number of
+// indexes processed so far. It is never called concurrently.
+//
+// ForEachIndex stops at the first error, which it returns: the indexes that
+// aren't processed yet are skipped and the context passed to fn is canceled.
+func ForEachIndex(ctx context.Context, total int, progress ProgressCallback, fn func(ctx context.Context, idx int) error) error {
+	workers := min(TokenWorkers(ctx), total)
+	if workers <= 1 {
+		for idx := 0; idx < total; idx++ {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("context canceled while processing item %d: %w", idx, err)
+			}
+			err := fn(ctx, idx)
+			if progress != nil {
+				progress(idx+1, total)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		next     int
+		done     int
+		firstErr error
+		// the progress is reported outside mu, so that a slow callback
+		// doesn't keep the workers from claiming indexes, in the order of done
+		progressMu sync.Mutex
+		reported   int
+	)
+	progressed := sync.NewCond(&progressMu)
+	report := func(n int) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		for reported != n-1 {
+			progressed.Wait()
+		}
+		progress(n, total)
+		reported = n
+		progressed.Broadcast()
+	}
+	// claim returns the next index to process, or false once all the indexes
+	// are claimed or one failed
+	claim := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next >= total || firstErr != nil {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				idx, ok := claim()
+				if !ok {
+					return
+				}
+				processed := false
+				err := ctx.Err()
+				if err != nil {
+					err = fmt.Errorf("context canceled while processing item %d: %w", idx, err)
+				} else {
+					err, processed = fn(ctx, idx), true
+				}
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				n := 0
+				if processed {
+					done++
+					n = done
+				}
+				mu.Unlock()
+				if n > 0 && progress != nil {
+					report(n)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,111 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+func TestWithTokenWorkers(t *testing.T) {
+	input := strings.Repeat("ผมชอบกินข้าวเหนียวมะม่วงมากๆ วันนี้อากาศดีครับ ", 20)
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	want, err := m.Roman(input)
+	require.NoError(t, err)
+
+	m.WithTokenWorkers(4)
+	got, err := m.Roman(input)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "the tokens keep their order")
+
+	// the tokens are processed by at most 4 workers at once
+	var mu sync.Mutex
+	running, peak, seen := 0, 0, 0
+	m.Use(func(next common.ProcessFunc) common.ProcessFunc {
+		return func(ctx context.Context, p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper], mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+			if mode != common.TransliteratorMode {
+				return next(ctx, p, mode, input)
+			}
+			assert.Equal(t, 4, common.TokenWorkers(ctx))
+			err := common.ForEachToken(ctx, input, nil, func(context.Context, int, common.AnyToken) error {
+				mu.Lock()
+				running, seen = running+1, seen+1
+				peak = max(peak, running)
+				mu.Unlock()
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, input.Len(), seen)
+			return next(ctx, p, mode, input)
+		}
+	})
+	_, err = m.Roman(input)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak, 4)
+	assert.Greater(t, peak, 1)
+
+	// the first error stops the processing
+	tsw := &tha.TknSliceWrapper{}
+	for _, w := range tha.SegmentWords(input) {
+		tsw.Append(&tha.Tkn{Tkn: common.Tkn{Surface: w, IsLexical: true}})
+	}
+	failure := errors.New("failure")
+	err = common.ForEachToken(context.Background(), tsw, nil, func(_ context.Context, idx int, _ common.AnyToken) error {
+		if idx == 3 {
+			return failure
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, common.TokenWorkers(context.Background()))
+}
+
+func TestForEachIndexProgress(t *testing.T) {
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	var concurrent context.Context
+	m.WithTokenWorkers(4).Use(func(next common.ProcessFunc) common.ProcessFunc {
+		return func(ctx context.Context, p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper], mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+			concurrent = ctx
+			return next(ctx, p, mode, input)
+		}
+	})
+	_, err = m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+
+	// both paths report the number of indexes processed so far, failed ones
+	// included, after processing them
+	failure := errors.New("failure")
+	for _, ctx := range []context.Context{context.Background(), concurrent} {
+		var reported []int
+		var calls atomic.Int32
+		err := common.ForEachIndex(ctx, 10, func(current, total int) {
+			assert.Equal(t, 10, total)
+			reported = append(reported, current)
+		}, func(_ context.Context, idx int) error {
+			calls.Add(1)
+			if idx == 0 {
+				return failure
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, failure)
+		require.Len(t, reported, int(calls.Load()))
+		for i, current := range reported {
+			assert.Equal(t, i+1, current)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Universal POS tags of Universal Dependencies (https://universaldependencies.org/u/pos/),
+// set in Tkn.UPOS.
+const (
+	UPOSAdj   = "ADJ"   // adjective
+	UPOSAdp   = "ADP"   // adposition
+	UPOSAdv   = "ADV"   // adverb
+	UPOSAux   = "AUX"   // auxiliary
+	UPOSCconj = "CCONJ" // coordinating conjunction
+	UPOSDet   = "DET"   // determiner
+	UPOSIntj  = "INTJ"  // interjection
+	UPOSNoun  = "NOUN"  // noun
+	UPOSNum   = "NUM"   // numeral
+	UPOSPart  = "PART"  // particle
+	UPOSPron  = "PRON"  // pronoun
+	UPOSPropn = "PROPN" // proper noun
+	UPOSPunct = "PUNCT" // punctuation
+	UPOSSconj = "SCONJ" // subordinating conjunction
+	UPOSSym   = "SYM"   // symbol
+	UPOSVerb  = "VERB"  // verb
+	UPOSX     = "X"     // other
+)
+
+var uposTags = map[string]bool{
+	UPOSAdj: true, UPOSAdp: true, UPOSAdv: true, UPOSAux: true, UPOSCconj: true,
+	UPOSDet: true, UPOSIntj: true, UPOSNoun: true, UPOSNum: true, UPOSPart: true,
+	UPOSPron: true, UPOSPropn: true, UPOSPunct: true, UPOSSconj: true, UPOSSym: true,
+	UPOSVerb: true, UPOSX: true,
+}
+
+// IsUPOS reports whether tag is one of the 17 Universal POS tags.
+func IsUPOS(tag string) bool {
+	return uposTags[tag]
+}
+
+var posMappings = struct {
+	sync.RWMutex
+	tables map[string]map[string]string
+}{tables: make(map[string]map[string]string)}
+
+// RegisterPOSMapping sets the table converting the POS tags emitted by a
+// provider (or by a family of providers sharing a tag set, e.g. "jieba") to
+// Universal POS tags. Registering a table for a name that already has one
+// replaces it.
+func RegisterPOSMapping(provider string, table map[string]string) error {
+	if provider == "" {
+		return fmt.Errorf("provider name cannot be empty")
+	}
+	for tag, upos := range table {
+		if !IsUPOS(upos) {
+			return fmt.Errorf("POS mapping of %s: %q maps to %q which isn't a Universal POS tag", provider, tag, upos)
+		}
+	}
+	posMappings.Lock()
+	defer posMappings.Unlock()
+	posMappings.tables[provider] = table
+	return nil
+}
+
+// ToUPOS converts a POS tag emitted by a provider to a Universal POS tag using
+// the table registered with RegisterPOSMapping.
+//
+// Tags that aren't in the table are looked up by their longest prefix in it,
+// so that subcategories map to their category (jieba "nrfg" → "nr" → PROPN,
+// JMdict "v5k" → "v" → VERB). Tags listing several parts of speech, such as
+// ichiran's "[n,vs]", are converted from the first one. Tags that already are
+// Universal POS tags are returned as is. Anything else yields UPOSX.
+func ToUPOS(provider, pos string) string {
+	pos = strings.TrimSpace(strings.Trim(pos, "[]"))
+	if i := strings.IndexByte(pos, ','); i >= 0 {
+		pos = strings.TrimSpace(pos[:i])
+	}
+	if pos == "" {
+		return UPOSX
+	}
+	posMappings.RLock()
+	table := posMappings.tables[provider]
+	posMappings.RUnlock()
+	for prefix := pos; prefix != ""; prefix = prefix[:len(prefix)-1] {
+		if upos, ok := table[prefix]; ok {
+			return upos
+		}
+	}
+	if IsUPOS(pos) {
+		return pos
+	}
+	return UPOSX
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// ProviderVersions are the versions of the external resources used by
+// providers (see VersionReporter), keyed by provider name then resource name.
+type ProviderVersions map[string]map[string]string
+
+// ResourceVersions queries the current resource versions of the providers of
+// the module that implement VersionReporter. The module must be initialized.
+func (m *Module) ResourceVersions(ctx context.Context) (ProviderVersions, error) {
+	versions := make(ProviderVersions)
+	for _, provider := range m.Providers {
+		reporter, ok := provider.(VersionReporter)
+		if !ok {
+			continue
+		}
+		v, err := reporter.ResourceVersions(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: failed to get resource versions: %w", provider.Name(), err)
+		}
+		if len(v) > 0 {
+			versions[provider.Name()] = v
+		}
+	}
+	return versions, nil
+}
+
+// ProviderVersions returns the resource versions of the providers of the
+// module as recorded when it was initialized, or nil if none of them reports
+// any. The same versions are attached to the wrappers returned by Tokens so
+// that cached or exported results can be traced back to the resources that
+// produced them, see ProviderVersionsOf.
+func (m *Module) ProviderVersions() ProviderVersions {
+	return m.versions
+}
+
+// recordVersions records the resource versions of the providers once they
+// are initialized. A provider failing to report its versions doesn't prevent
+// the module from running, the versions are left unrecorded then.
+func (m *Module) recordVersions(ctx context.Context) {
+	versions, err := m.ResourceVersions(ctx)
+	if err != nil {
+		m.logger().Warn().Err(err).Str("lang", m.Lang).Msg("Failed to record provider resource versions")
+		m.versions = nil
+		return
+	}
+	if len(versions) == 0 {
+		versions = nil
+	}
+	m.versions = versions
+}
+
+// versionKeeper is implemented by the wrappers embedding TknSliceWrapper
+type versionKeeper interface {
+	SetProviderVersions(ProviderVersions)
+	GetProviderVersions() ProviderVersions
+}
+
+// SetProviderVersions sets the resource versions of the providers that
+// produced the tokens of the wrapper. Module.Tokens sets them.
+func (tokens *TknSliceWrapper) SetProviderVersions(versions ProviderVersions) {
+	tokens.Versions = versions
+}
+
+// GetProviderVersions returns the resource versions of the providers that
+// produced the tokens of the wrapper.
+func (tokens *TknSliceWrapper) GetProviderVersions() ProviderVersions {
+	return tokens.Versions
+}
+
+// ProviderVersionsOf returns the resource versions of the providers that
+// produced the tokens of any token slice wrapper, or nil if the wrapper
+// doesn't keep them or none of the providers reports any.
+func ProviderVersionsOf(tsw AnyTokenSliceWrapper) ProviderVersions {
+	if vk, ok := tsw.(versionKeeper); ok {
+		return vk.GetProviderVersions()
+	}
+	return nil
+}
+
+// setProviderVersions attaches a copy of the versions recorded by the module
+// to the wrapper, so that the results don't change if the module is
+// reinitialized.
+func (m *Module) setProviderVersions(tsw AnyTokenSliceWrapper) {
+	vk, ok := tsw.(versionKeeper)
+	if !ok || m.versions == nil {
+		return
+	}
+	versions := make(ProviderVersions, len(m.versions))
+	for provider, v := range m.versions {
+		versions[provider] = maps.Clone(v)
+	}
+	vk.SetProviderVersions(versions)
+}
@@ -0,0 +1,49 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tha"
+)
+
+// versionedTokenizer is a thai-dict tokenizer reporting the version of its dictionary
+type versionedTokenizer struct {
+	tha.DictTokenizerProvider
+	version string
+}
+
+func (p *versionedTokenizer) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	return map[string]string{"dictionary": p.version}, nil
+}
+
+func TestProviderVersions(t *testing.T) {
+	tokenizer := &versionedTokenizer{DictTokenizerProvider: *tha.NewDictTokenizerProvider(), version: "v1"}
+	m, err := common.NewModule(tha.Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	m.WrapProvider(common.TokenizerMode, func(common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return tokenizer
+	})
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	want := common.ProviderVersions{"thai-dict": {"dictionary": "v1"}}
+	assert.Equal(t, want, m.ProviderVersions())
+
+	tkns, err := (&tha.Module{Module: m}).Tokens("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, want, common.ProviderVersionsOf(tkns))
+	assert.Equal(t, want, common.ProviderVersionsOf(tkns.ToLexicalTokens()))
+	lexical, err := m.LexicalTokens("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, want, common.ProviderVersionsOf(lexical))
+
+	// the results keep the versions they were produced with
+	tokenizer.version = "v2"
+	require.NoError(t, m.InitRecreate(false))
+	assert.Equal(t, "v2", m.ProviderVersions()["thai-dict"]["dictionary"])
+	assert.Equal(t, want, common.ProviderVersionsOf(tkns))
+}
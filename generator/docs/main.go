@@ -0,0 +1,348 @@
+// Command docs renders the provider and scheme registry into a Markdown or
+// HTML capability reference, so that user-facing docs follow the code.
+//
+// Examples of output come from the golden corpus (golden.yaml), which records
+// the output of every scheme for a canonical sentence of each language. Run
+// with -update to refresh it from the current providers; schemes that need
+// Docker or a scraper are only refreshed with -all.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./generator/docs -o docs/capabilities.md
+//	go run ./generator/docs -format html -o docs/capabilities.html
+//	go run ./generator/docs -update [-all]
+//	go run ./generator/docs -check
+package main
+
+//go:generate go run . -golden golden.yaml -templates ../templates -o ../../docs/capabilities.md
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	iso "github.com/barbashov/iso639-3"
+	"gopkg.in/yaml.v2"
+
+	// registers all the languages
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// GoldenEntry is the golden output of the schemes of a language for its canonical sentence.
+type GoldenEntry struct {
+	Sentence string            `yaml:"sentence"`
+	Outputs  map[string]string `yaml:"outputs"` // scheme name → romanization
+}
+
+// LanguageDoc is what the templates render for a language.
+type LanguageDoc struct {
+	Code      string
+	Name      string
+	Defaults  string
+	Sentence  string
+	Providers []ProviderDoc
+	Schemes   []SchemeDoc
+}
+
+type ProviderDoc struct {
+	Name         string
+	Modes        string
+	Requirements string
+	Default      bool
+}
+
+type SchemeDoc struct {
+	Name         string
+	Description  string
+	Providers    string
+	Requirements string
+	Example      string
+}
+
+func main() {
+	format := flag.String("format", "md", "output format: md or html")
+	out := flag.String("o", "", "output file (default: stdout)")
+	goldenPath := flag.String("golden", "generator/docs/golden.yaml", "golden corpus")
+	templates := flag.String("templates", "generator/templates", "directory of the docs templates")
+	update := flag.Bool("update", false, "refresh the golden corpus from the current providers")
+	all := flag.Bool("all", false, "with -update, also run the schemes that need Docker or a scraper")
+	check := flag.Bool("check", false, "check that the pure Go schemes still produce the golden outputs")
+	flag.Parse()
+
+	golden, err := loadGolden(*goldenPath)
+	if err != nil {
+		fail(err)
+	}
+
+	switch {
+	case *update:
+		if err := updateGolden(golden, *all); err != nil {
+			fail(err)
+		}
+		if err := saveGolden(*goldenPath, golden); err != nil {
+			fail(err)
+		}
+		return
+	case *check:
+		if mismatches := checkGolden(golden); len(mismatches) > 0 {
+			fail(fmt.Errorf("golden corpus out of date:\n%s", strings.Join(mismatches, "\n")))
+		}
+		return
+	}
+
+	docs, err := buildDocs(golden)
+	if err != nil {
+		fail(err)
+	}
+	var buf bytes.Buffer
+	switch *format {
+	case "md":
+		tmpl, err := template.ParseFiles(filepath.Join(*templates, "docs.md.tmpl"))
+		if err != nil {
+			fail(err)
+		}
+		err = tmpl.Execute(&buf, docs)
+	case "html":
+		tmpl, err := htmltemplate.ParseFiles(filepath.Join(*templates, "docs.html.tmpl"))
+		if err != nil {
+			fail(err)
+		}
+		err = tmpl.Execute(&buf, docs)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fail(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "docs: %v\n", err)
+	os.Exit(1)
+}
+
+func loadGolden(path string) (map[string]*GoldenEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden corpus: %w", err)
+	}
+	golden := make(map[string]*GoldenEntry)
+	if err := yaml.Unmarshal(content, &golden); err != nil {
+		return nil, fmt.Errorf("failed to parse golden corpus: %w", err)
+	}
+	return golden, nil
+}
+
+func saveGolden(path string, golden map[string]*GoldenEntry) error {
+	content, err := yaml.Marshal(golden)
+	if err != nil {
+		return fmt.Errorf("failed to encode golden corpus: %w", err)
+	}
+	header := "# Golden corpus: output of each scheme for a canonical sentence per language.\n" +
+		"# Refresh with: go run ./generator/docs -update [-all]\n"
+	return os.WriteFile(path, append([]byte(header), content...), 0644)
+}
+
+// pureGo reports whether a scheme can run without Docker or a scraper
+func pureGo(scheme common.TranslitScheme) bool {
+	return !scheme.NeedsDocker && !scheme.NeedsScraper
+}
+
+func romanize(lang string, scheme common.TranslitScheme, sentence string) (string, error) {
+	m, err := common.GetSchemeModule(lang, scheme.Name)
+	if err != nil {
+		return "", err
+	}
+	defer m.Close()
+	if err := m.Init(); err != nil {
+		return "", err
+	}
+	return m.Roman(sentence)
+}
+
+func updateGolden(golden map[string]*GoldenEntry, all bool) error {
+	for lang, entry := range golden {
+		schemes, err := common.GetSchemes(lang)
+		if err != nil {
+			continue
+		}
+		if entry.Outputs == nil {
+			entry.Outputs = make(map[string]string)
+		}
+		for _, scheme := range schemes {
+			if !all && !pureGo(scheme) {
+				continue
+			}
+			roman, err := romanize(lang, scheme, entry.Sentence)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "docs: %s/%s: %v\n", lang, scheme.Name, err)
+				continue
+			}
+			entry.Outputs[scheme.Name] = roman
+		}
+	}
+	return nil
+}
+
+func checkGolden(golden map[string]*GoldenEntry) (mismatches []string) {
+	for _, lang := range sortedKeys(golden) {
+		entry := golden[lang]
+		schemes, err := common.GetSchemes(lang)
+		if err != nil {
+			continue
+		}
+		for _, scheme := range schemes {
+			expected, ok := entry.Outputs[scheme.Name]
+			if !ok || !pureGo(scheme) {
+				continue
+			}
+			roman, err := romanize(lang, scheme, entry.Sentence)
+			if err != nil {
+				mismatches = append(mismatches, fmt.Sprintf("%s/%s: %v", lang, scheme.Name, err))
+			} else if roman != expected {
+				mismatches = append(mismatches, fmt.Sprintf("%s/%s: got %q, golden %q", lang, scheme.Name, roman, expected))
+			}
+		}
+	}
+	return
+}
+
+func buildDocs(golden map[string]*GoldenEntry) ([]LanguageDoc, error) {
+	mulProviders := make(map[string]common.ProviderSupport)
+	if matrix, err := common.PlatformMatrix("mul"); err == nil {
+		for _, row := range matrix {
+			mulProviders[row.Provider] = row
+		}
+	}
+
+	var docs []LanguageDoc
+	for _, lang := range common.Languages() {
+		doc := LanguageDoc{Code: lang, Name: lang}
+		if code := iso.FromPart3Code(lang); code != nil {
+			doc.Name = code.Name
+		}
+		defaults, err := common.DefaultProviderNames(lang)
+		if err != nil {
+			return nil, err
+		}
+		doc.Defaults = strings.Join(defaults, " → ")
+		isDefault := make(map[string]bool)
+		for _, name := range defaults {
+			isDefault[name] = true
+		}
+
+		matrix, err := common.PlatformMatrix(lang)
+		if err != nil {
+			return nil, err
+		}
+		listed := make(map[string]bool)
+		addProvider := func(row common.ProviderSupport) {
+			if listed[row.Provider] {
+				return
+			}
+			listed[row.Provider] = true
+			doc.Providers = append(doc.Providers, ProviderDoc{
+				Name:         row.Provider,
+				Modes:        joinModes(row.Modes),
+				Requirements: requirements(row.Requirements),
+				Default:      isDefault[row.Provider],
+			})
+		}
+		for _, row := range matrix {
+			addProvider(row)
+		}
+		for _, name := range defaults {
+			if row, ok := mulProviders[name]; ok {
+				addProvider(row)
+			}
+		}
+
+		schemes, _ := common.GetSchemes(lang)
+		entry := golden[lang]
+		if entry != nil {
+			doc.Sentence = entry.Sentence
+		}
+		for _, scheme := range schemes {
+			var reqs []string
+			if scheme.NeedsDocker {
+				reqs = append(reqs, "Docker")
+			}
+			if scheme.NeedsScraper {
+				reqs = append(reqs, "browser")
+			}
+			s := SchemeDoc{
+				Name:         scheme.Name,
+				Description:  scheme.Description,
+				Providers:    strings.Join(scheme.Providers, " → "),
+				Requirements: strings.Join(reqs, ", "),
+			}
+			if s.Requirements == "" {
+				s.Requirements = "pure Go"
+			}
+			if entry != nil {
+				s.Example = entry.Outputs[scheme.Name]
+			}
+			doc.Schemes = append(doc.Schemes, s)
+			// Multilingual providers used by the language's schemes
+			for _, name := range scheme.Providers {
+				if row, ok := mulProviders[name]; ok {
+					addProvider(row)
+				}
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func joinModes(modes []common.OperatingMode) string {
+	s := make([]string, len(modes))
+	for i, mode := range modes {
+		s[i] = string(mode)
+	}
+	return strings.Join(s, ", ")
+}
+
+func requirements(r common.PlatformRequirements) string {
+	var reqs []string
+	if r.CGO {
+		reqs = append(reqs, "CGO")
+	}
+	if r.Docker {
+		reqs = append(reqs, "Docker")
+	}
+	if r.Browser {
+		reqs = append(reqs, "browser")
+	}
+	if len(reqs) == 0 {
+		return "pure Go"
+	}
+	return strings.Join(reqs, ", ")
+}
+
+func sortedKeys(m map[string]*GoldenEntry) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
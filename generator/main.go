@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,10 +15,90 @@ type LanguageConfig struct {
 	Code string
 	Name string
 	IsIndic bool
+
+	// Provider, if set, scaffolds a provider for the language along with
+	// its registration and tests, see ProviderConfig.
+	Provider *ProviderConfig
+	// Tests are the cases of the scaffolded table-driven tests.
+	Tests []TestCase
+}
+
+// ProviderConfig describes the provider scaffolded for a language:
+// lang/<code>/<name>.go holds a skeleton implementing common.Provider,
+// lang/<code>/init_gen.go registers it with its schemes and sets it as
+// default, and lang/<code>/<name>_test.go tests it with the cases of the
+// config. The skeleton and the tests are only written if they don't exist
+// yet, so that they can be edited; init_gen.go is regenerated on every run.
+type ProviderConfig struct {
+	Name    string // as returned by Provider.Name()
+	Type    string // Go type name, e.g. "GeorgianProvider"
+	Mode    string // "transliterator" (the default, after uniseg) or "combined"
+	Schemes []SchemeConfig
+}
+
+type SchemeConfig struct {
+	Name        string
+	Description string
+}
+
+type TestCase struct {
+	Input    string
+	Scheme   string // defaults to the first scheme
+	Expected string
+}
+
+// Combined reports whether the provider tokenizes the text itself.
+func (p ProviderConfig) Combined() bool {
+	return p.Mode == "combined"
+}
+
+// ModeConst is the name of the common.OperatingMode constant of the provider.
+func (p ProviderConfig) ModeConst() string {
+	if p.Combined() {
+		return "CombinedMode"
+	}
+	return "TransliteratorMode"
+}
+
+// FileName is the name of the scaffolded provider file, without extension.
+func (p ProviderConfig) FileName() string {
+	return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(p.Name))
+}
+
+func (c TestCase) SchemeOr(defaultScheme string) string {
+	if c.Scheme == "" {
+		return defaultScheme
+	}
+	return c.Scheme
+}
+
+// DefaultScheme is the first scheme of the scaffolded provider.
+func (c LanguageConfig) DefaultScheme() string {
+	if c.Provider == nil || len(c.Provider.Schemes) == 0 {
+		return ""
+	}
+	return c.Provider.Schemes[0].Name
+}
+
+func (c LanguageConfig) validate() error {
+	if c.Provider == nil {
+		return nil
+	}
+	switch {
+	case c.Provider.Name == "":
+		return fmt.Errorf("provider.name is required")
+	case c.Provider.Type == "":
+		return fmt.Errorf("provider.type is required")
+	case len(c.Provider.Schemes) == 0:
+		return fmt.Errorf("provider.schemes needs at least one scheme")
+	case c.Provider.Mode != "" && c.Provider.Mode != "transliterator" && c.Provider.Mode != "combined":
+		return fmt.Errorf("unsupported provider.mode %q", c.Provider.Mode)
+	}
+	return nil
 }
 
 var IndicLangs = []string{
-	"hin", "ben", "fas", "guj", "mar", "pan", "sin", "urd", "tam", "tel",
+	"hin", "ben", "guj", "mar", "pan", "tam", "tel", "kan", "mal", "ori",
 }
 
 func main() {
@@ -26,10 +108,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Load both templates
 	tmpl, err := template.ParseFiles(
 		"generator/templates/token.go.tmpl",
 		"generator/templates/init.go.tmpl",
+		"generator/templates/lang.go.tmpl",
+		"generator/templates/provider.go.tmpl",
+		"generator/templates/provider_test.go.tmpl",
+		"generator/templates/register.go.tmpl",
 	)
 	if err != nil {
 		fmt.Printf("Error loading templates: %v\n", err)
@@ -55,6 +140,11 @@ func generateFiles(tmpl *template.Template, lang string, config LanguageConfig)
 		return err
 	}
 
+	// Scaffold the provider, which registers itself in place of the Indic init_gen.go
+	if config.Provider != nil {
+		return scaffoldProvider(tmpl, outDir, config)
+	}
+
 	// Generate init_gen.go for Indic languages
 	if isIndicLanguage(lang) {
 		if err := generateFile(tmpl, "init.go.tmpl", filepath.Join(outDir, "init_gen.go"), config); err != nil {
@@ -65,6 +155,29 @@ func generateFiles(tmpl *template.Template, lang string, config LanguageConfig)
 	return nil
 }
 
+func scaffoldProvider(tmpl *template.Template, outDir string, config LanguageConfig) error {
+	if err := generateFormattedFile(tmpl, "register.go.tmpl", filepath.Join(outDir, "init_gen.go"), config); err != nil {
+		return err
+	}
+	name := config.Provider.FileName()
+	scaffolds := []struct{ templateName, outFile string }{
+		{"lang.go.tmpl", config.Code + ".go"},
+		{"provider.go.tmpl", name + ".go"},
+		{"provider_test.go.tmpl", name + "_test.go"},
+	}
+	for _, s := range scaffolds {
+		outFile := filepath.Join(outDir, s.outFile)
+		if _, err := os.Stat(outFile); err == nil {
+			continue
+		}
+		if err := generateFormattedFile(tmpl, s.templateName, outFile, config); err != nil {
+			return err
+		}
+		fmt.Printf("Scaffolded %s\n", outFile)
+	}
+	return nil
+}
+
 func generateFile(tmpl *template.Template, templateName, outFile string, config LanguageConfig) error {
 	f, err := os.Create(outFile)
 	if err != nil {
@@ -75,6 +188,19 @@ func generateFile(tmpl *template.Template, templateName, outFile string, config
 	return tmpl.ExecuteTemplate(f, templateName, config)
 }
 
+// generateFormattedFile is like generateFile but gofmts the output.
+func generateFormattedFile(tmpl *template.Template, templateName, outFile string, config LanguageConfig) error {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, templateName, config); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w", outFile, err)
+	}
+	return os.WriteFile(outFile, src, 0644)
+}
+
 func loadConfigs(configDir string) (map[string]LanguageConfig, error) {
 	configs := make(map[string]LanguageConfig)
 	
@@ -102,6 +228,9 @@ func loadConfigs(configDir string) (map[string]LanguageConfig, error) {
 		
 		config.Code = langCode
 		config.IsIndic = isIndicLanguage(langCode)
+		if err := config.validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", file.Name(), err)
+		}
 		
 		configs[langCode] = config
 	}
@@ -0,0 +1,81 @@
+package bel
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Tkn extends common.Tkn with Belarusian-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Morphological features
+	Case          GramCase        // 6 cases plus the residual Vocative
+	Number        Number          // Singular, Plural
+	Gender        Gender          // Masculine, Feminine, Neuter
+	Animacy       Animacy        // Animate vs Inanimate (affects accusative case)
+	
+	// Verb-specific features
+	Aspect        Aspect         // Perfective vs Imperfective
+	Tense         Tense          // Past, Present, Future
+	Person        Person         // 1st, 2nd, 3rd person
+	
+	// Stress and spelling features
+	StressPos     int            // Position of stressed syllable
+	HasApostrophe bool           // Contains an apostrophe separating a consonant from an iotated vowel (сям'я)
+	HasShortU     bool           // Contains ў
+	
+	// Phonetic spelling
+	Akanne        bool           // Whether unstressed о is written а (аканне)
+	Dzekanne      bool           // Whether д/т soften to дз/ц (дзеканне, цеканне)
+}
+
+// Enums for Belarusian linguistic features
+type GramCase string
+const (
+	Nominative   GramCase = "nom"
+	Genitive     GramCase = "gen"
+	Dative       GramCase = "dat"
+	Accusative   GramCase = "acc"
+	Instrumental GramCase = "ins"
+	Locative     GramCase = "loc"
+	Vocative     GramCase = "voc"
+)
+
+type Number string
+const (
+	Singular Number = "sg"
+	Plural   Number = "pl"
+)
+
+type Gender string
+const (
+	Masculine Gender = "m"
+	Feminine  Gender = "f"
+	Neuter    Gender = "n"
+)
+
+type Animacy string
+const (
+	Animate   Animacy = "anim"
+	Inanimate Animacy = "inan"
+)
+
+type Aspect string
+const (
+	Perfective   Aspect = "perf"
+	Imperfective Aspect = "imperf"
+)
+
+type Tense string
+const (
+	Past    Tense = "past"
+	Present Tense = "pres"
+	Future  Tense = "fut"
+)
+
+type Person string
+const (
+	First  Person = "1"
+	Second Person = "2"
+	Third  Person = "3"
+)
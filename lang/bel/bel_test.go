@@ -0,0 +1,29 @@
+package bel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestSchemes(t *testing.T) {
+	tests := []struct {
+		scheme string
+		input  string
+		want   []string
+	}{
+		{"national", "Гомель Магілёў Віцебск", []string{"Homieĺ", "Mahilioŭ", "Viciebsk"}},
+		{"national", "Ельск сям'я", []string{"Jeĺsk", "siamja"}},
+		{"bgn_pcgn", "Гомель Барысаў", []string{"Homel’", "Barysaw"}},
+	}
+	for _, tt := range tests {
+		m, err := common.GetSchemeModule(Lang, tt.scheme)
+		require.NoError(t, err)
+		require.NoError(t, m.Init())
+		parts, err := m.RomanParts(tt.input)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, parts, tt.scheme)
+	}
+}
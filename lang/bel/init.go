@@ -0,0 +1,26 @@
+
+package bel
+
+import (
+	"fmt"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     &mul.UnisegProvider{},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     mul.NewIuliiaProvider(Lang),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	err := common.SetDefault(Lang, defaultProviders)
+	if err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
\ No newline at end of file
@@ -0,0 +1,26 @@
+package bod
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptTibetan = "Tibt" // Tibetan script (Uchen and Ume)
+	ScriptLatin   = "Latn" // Romanized/Latin script (Wylie, EWTS...)
+)
+
+// Tkn extends the common Token with Tibetan-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Syllable structure, in Tibetan letters (empty for transliterated Sanskrit)
+	Prefix      string // Prefixed letter (སྔོན་འཇུག): ག ད བ མ འ
+	Superscript string // Letter written above the root (མགོ་ཅན): ར ལ ས
+	Root        string // Root letter (མིང་གཞི), which carries the vowel
+	Subscripts  string // Letters written below the root (འདོགས་ཅན): ྱ ྲ ླ ྭ
+	Vowel       string // Vowel sign, empty for the inherent a
+	Suffix      string // Suffixed letter (རྗེས་འཇུག)
+	PostSuffix  string // Second suffix (ཡང་འཇུག): ས ད
+
+	IsSanskrit bool // Syllable that doesn't fit the Tibetan syllable structure (transliterated Sanskrit)
+}
@@ -0,0 +1,54 @@
+package bod
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func init() {
+	tokenizerEntry := common.ProviderEntry{
+		Provider:     NewSyllableTokenizerProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization},
+	}
+	wylieEntry := common.ProviderEntry{
+		Provider:     NewWylieProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, tokenizerEntry); err != nil {
+		panic(fmt.Sprintf("failed to register tibetan-syllables provider: %v", err))
+	}
+	if err := common.Register(Lang, wylieEntry); err != nil {
+		panic(fmt.Sprintf("failed to register wylie provider: %v", err))
+	}
+
+	for _, scheme := range tibetanSchemes {
+		scheme.Providers = []string{"tibetan-syllables", "wylie"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Tibetan scheme")
+		}
+	}
+
+	if err := common.RegisterSpacingRule(Lang, SpacingRule); err != nil {
+		panic(fmt.Sprintf("failed to register Tibetan spacing rule: %v", err))
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     NewSyllableTokenizerProvider(),
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     NewWylieProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
@@ -0,0 +1,422 @@
+package bod
+
+import (
+	"strings"
+)
+
+const (
+	tsheg       = '་'
+	tshegNoBrk  = '༌'
+	achung      = 'འ'
+	aChungSign  = 'ཱ' // a-chung written below a letter: long vowel
+	halanta     = '྄'
+	subjoinedOf = 0x50 // offset between a letter and its subjoined form
+)
+
+// consonants maps the Tibetan letters to their EWTS transliteration. The
+// subjoined forms (U+0F90-U+0FBC) are the letters offset by subjoinedOf.
+var consonants = map[rune]string{
+	'ཀ': "k", 'ཁ': "kh", 'ག': "g", '\u0F43': "g+h", 'ང': "ng",
+	'ཅ': "c", 'ཆ': "ch", 'ཇ': "j", 'ཉ': "ny",
+	'ཊ': "T", 'ཋ': "Th", 'ཌ': "D", '\u0F4D': "D+h", 'ཎ': "N",
+	'ཏ': "t", 'ཐ': "th", 'ད': "d", '\u0F52': "d+h", 'ན': "n",
+	'པ': "p", 'ཕ': "ph", 'བ': "b", '\u0F57': "b+h", 'མ': "m",
+	'ཙ': "ts", 'ཚ': "tsh", 'ཛ': "dz", '\u0F5C': "dz+h", 'ཝ': "w",
+	'ཞ': "zh", 'ཟ': "z", 'འ': "'", 'ཡ': "y", 'ར': "r", 'ལ': "l",
+	'ཤ': "sh", 'ཥ': "Sh", 'ས': "s", 'ཧ': "h", 'ཨ': "", '\u0F69': "k+Sh",
+	'ཪ': "R", 'ཫ': "kk", 'ཬ': "rr",
+}
+
+// fixedForms maps the subjoined letters that aren't offsets of a letter
+var fixedForms = map[rune]string{
+	'ྺ': "W", 'ྻ': "Y", 'ྼ': "R",
+}
+
+// vowelSigns maps the vowel signs; a-chung (U+0F71) lengthens the vowel
+// written with it
+var vowelSigns = map[rune]string{
+	'ི': "i", 'ུ': "u", 'ེ': "e", 'ཻ': "ai",
+	'ོ': "o", 'ཽ': "au", 'ྀ': "-i",
+}
+
+// decomposed maps the precomposed vowel signs to their components
+var decomposed = map[rune][]rune{
+	'\u0F73': {aChungSign, 'ི'},
+	'\u0F75': {aChungSign, 'ུ'},
+	'\u0F81': {aChungSign, 'ྀ'},
+	'\u0F76': {'ྲ', 'ྀ'},
+	'\u0F77': {'ྲ', aChungSign, 'ྀ'},
+	'\u0F78': {'ླ', 'ྀ'},
+	'\u0F79': {'ླ', aChungSign, 'ྀ'},
+}
+
+// marks maps the signs written after the vowel
+var marks = map[rune]string{
+	'ཾ': "M", 'ཿ': "H", 'ྂ': "~M`", 'ྃ': "~M",
+	halanta: "?", '༵': "~X", '༷': "X", '༹': "^",
+}
+
+// punctuation maps the punctuation marks, digits and symbols
+var punctuation = map[rune]string{
+	tsheg: " ", tshegNoBrk: "*",
+	'ༀ': "oM", '༄': "@", '༅': "#", '༆': "$", '༇': "%",
+	'༈': "!", '།': "/", '༎': "//", '༏': ";", '༑': "|",
+	'༔': ":", '༴': "=", '༺': "<", '༻': ">", '༼': "(",
+	'༽': ")", '྅': "&",
+	'༠': "0", '༡': "1", '༢': "2", '༣': "3", '༤': "4",
+	'༥': "5", '༦': "6", '༧': "7", '༨': "8", '༩': "9",
+}
+
+// The letters allowed in each position of a Tibetan syllable
+var (
+	prefixes     = "གདབམའ"
+	superscripts = "རལས"
+	suffixes     = "གངདནབམའརལས"
+	postSuffixes = "སད"
+)
+
+// stack is a consonant stack: a letter with the letters subjoined to it, and
+// the vowel and signs written on it
+type stack struct {
+	letters []rune // the base letter then the subjoined ones, as written
+	vowels  []rune
+	marks   []rune
+}
+
+// syllable is a Tibetan syllable analysed into its stacks. In a standard
+// syllable (prefix, root stack, suffixes) only the root carries a vowel;
+// other syllables, mostly transliterated Sanskrit, are read stack by stack.
+type syllable struct {
+	stacks  []stack
+	root    int // index of the root stack, -1 if the syllable isn't a standard one
+	endings int // number of trailing a-chung stacks carrying a vowel, e.g. the genitive འི
+}
+
+func isLetter(r rune) bool {
+	_, ok := consonants[r]
+	return ok
+}
+
+func isSubjoined(r rune) bool {
+	return r >= 'ྐ' && r <= 'ྼ'
+}
+
+// isSyllableRune reports whether r is part of a syllable: a letter, a
+// subjoined letter, a vowel or a sign written on a stack
+func isSyllableRune(r rune) bool {
+	if isLetter(r) || isSubjoined(r) || r == aChungSign {
+		return true
+	}
+	if _, ok := vowelSigns[r]; ok {
+		return true
+	}
+	if _, ok := decomposed[r]; ok {
+		return true
+	}
+	_, ok := marks[r]
+	return ok
+}
+
+// baseOf returns the letter of a subjoined letter
+func baseOf(r rune) rune {
+	if isSubjoined(r) && fixedForms[r] == "" {
+		return r - subjoinedOf
+	}
+	return r
+}
+
+func letterWylie(r rune) string {
+	if s, ok := fixedForms[r]; ok {
+		return s
+	}
+	return consonants[baseOf(r)]
+}
+
+// parseStacks splits the runes of a syllable into stacks
+func parseStacks(rs []rune) []stack {
+	var stacks []stack
+	current := func() *stack {
+		if len(stacks) == 0 {
+			stacks = append(stacks, stack{})
+		}
+		return &stacks[len(stacks)-1]
+	}
+	for _, r := range rs {
+		components := []rune{r}
+		if d, ok := decomposed[r]; ok {
+			components = d
+		}
+		for _, c := range components {
+			switch {
+			case isLetter(c):
+				stacks = append(stacks, stack{letters: []rune{c}})
+			case isSubjoined(c):
+				s := current()
+				s.letters = append(s.letters, c)
+			case c == aChungSign || vowelSigns[c] != "":
+				s := current()
+				s.vowels = append(s.vowels, c)
+			default:
+				s := current()
+				s.marks = append(s.marks, c)
+			}
+		}
+	}
+	return stacks
+}
+
+// analyseSyllable finds the root stack of a syllable, see syllable
+func analyseSyllable(rs []rune) syllable {
+	stacks := parseStacks(rs)
+	syl := syllable{stacks: stacks, root: -1}
+	isEnding := func(s stack) bool {
+		return len(s.letters) == 1 && s.letters[0] == achung && len(s.vowels) > 0
+	}
+	for len(stacks)-syl.endings > 1 && isEnding(stacks[len(stacks)-syl.endings-1]) {
+		syl.endings++
+	}
+	core := stacks[:len(stacks)-syl.endings]
+	root := findRoot(core, syl.endings > 0)
+	if isStandard(core, root) {
+		syl.root = root
+	}
+	return syl
+}
+
+// findRoot returns the index of the most likely root stack of a syllable:
+// the one with a vowel, else the one with subjoined letters, else the one
+// suggested by the number of letters.
+func findRoot(core []stack, hasEndings bool) int {
+	for i, s := range core {
+		if len(s.vowels) > 0 {
+			return i
+		}
+	}
+	for i, s := range core {
+		if len(s.letters) > 1 {
+			return i
+		}
+	}
+	switch {
+	case hasEndings:
+		// endings follow open syllables (དགའི dga'i)
+		return len(core) - 1
+	case len(core) >= 3 && isPrefixOf(core[0].letters[0], core[1].letters[0]):
+		return 1
+	}
+	return 0
+}
+
+// prefixRoots lists the root letters each prefix can be written before
+var prefixRoots = map[rune]string{
+	'ག': "ཅཉཏདནཙཞཟཡཤས",
+	'ད': "ཀགངཔབམ",
+	'བ': "ཀགཅཏདཙཞཟཤས",
+	'མ': "ཁགངཆཇཉཐདནཚཛ",
+	'འ': "ཁགཆཇཐདཕབཚཛ",
+}
+
+func isPrefixOf(prefix, root rune) bool {
+	return strings.ContainsRune(prefixRoots[prefix], root)
+}
+
+// isStandard reports whether the stacks form a Tibetan syllable having the given root
+func isStandard(core []stack, root int) bool {
+	single := func(s stack, allowed string) bool {
+		return len(s.letters) == 1 && len(s.vowels) == 0 && strings.ContainsRune(allowed, s.letters[0])
+	}
+	if root < 0 || root > 1 || len(core) == 0 {
+		return false
+	}
+	if root == 1 && !single(core[0], prefixes) {
+		return false
+	}
+	if len(core[root].letters) == 0 || !isStandardStack(core[root].letters) {
+		return false
+	}
+	after := core[root+1:]
+	switch {
+	case len(after) > 2:
+		return false
+	case len(after) >= 1 && !single(after[0], suffixes):
+		return false
+	case len(after) == 2 && !single(after[1], postSuffixes):
+		return false
+	}
+	return true
+}
+
+// isStandardStack reports whether the letters of a stack are a superscript,
+// a root and subscripts, which EWTS writes without "+" (rky, sgr, lh)
+func isStandardStack(letters []rune) bool {
+	if len(letters) == 1 {
+		return true
+	}
+	rest := letters
+	if len(rest) > 1 && rest[len(rest)-1] == 'ྭ' { // wa-zur
+		rest = rest[:len(rest)-1]
+	}
+	if len(rest) > 1 && strings.ContainsRune("ྱྲླ", rest[len(rest)-1]) {
+		rest = rest[:len(rest)-1]
+	}
+	if len(rest) == 2 && rest[1] == 'ྷ' && strings.ContainsRune("ལར", rest[0]) { // lh, rh
+		rest = rest[:1]
+	}
+	switch len(rest) {
+	case 1:
+		return !isSubjoined(rest[0]) && !strings.Contains(letterWylie(rest[0]), "+")
+	case 2:
+		if !strings.ContainsRune(superscripts, rest[0]) || !isSubjoined(rest[1]) {
+			return false
+		}
+		// s above h would read sh
+		_, ambiguous := wylieConsonants[letterWylie(rest[0])+letterWylie(rest[1])]
+		return !ambiguous && !strings.Contains(letterWylie(rest[1]), "+")
+	}
+	return false
+}
+
+// wylieConsonants is the set of the EWTS transliterations of the letters
+var wylieConsonants = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range consonants {
+		if s != "" {
+			set[s] = true
+		}
+	}
+	return set
+}()
+
+func stackWylie(s stack) string {
+	sep := ""
+	if !isStandardStack(s.letters) {
+		sep = "+"
+	}
+	parts := make([]string, len(s.letters))
+	for i, r := range s.letters {
+		parts[i] = letterWylie(r)
+	}
+	return strings.Join(parts, sep)
+}
+
+// vowelWylie returns the vowel of a stack, "a" for the inherent vowel
+func vowelWylie(s stack) string {
+	var vowel string
+	long := false
+	for _, v := range s.vowels {
+		if v == aChungSign {
+			long = true
+			continue
+		}
+		vowel += vowelSigns[v]
+	}
+	if long {
+		switch vowel {
+		case "":
+			return "A"
+		case "i", "u", "-i":
+			return strings.ToUpper(vowel)
+		}
+		return "A" + vowel
+	}
+	if vowel == "" {
+		return "a"
+	}
+	return vowel
+}
+
+func marksWylie(s stack) string {
+	var out strings.Builder
+	for _, m := range s.marks {
+		out.WriteString(marks[m])
+	}
+	return out.String()
+}
+
+// needsDot reports whether a prefix followed by the root stack would be read
+// as another letter or stack in EWTS, e.g. གཡ g.y (not གྱ gy), or དཟ d.z
+// (not ཛ dz)
+func needsDot(prefix rune, root stack) bool {
+	if len(root.letters) == 1 && strings.ContainsRune("ཡརལཝ", root.letters[0]) {
+		return true
+	}
+	p := letterWylie(prefix)
+	joined := p + stackWylie(root)
+	for c := range wylieConsonants {
+		if len(c) > len(p) && strings.HasPrefix(joined, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// wylie returns the EWTS transliteration of the syllable
+func (syl syllable) wylie() string {
+	var out strings.Builder
+	core := syl.stacks[:len(syl.stacks)-syl.endings]
+	for i, s := range core {
+		switch {
+		case syl.root < 0:
+			// read stack by stack, with the inherent vowel unless killed by a halanta
+			out.WriteString(stackWylie(s))
+			if len(s.vowels) > 0 || !containsRune(s.marks, halanta) {
+				out.WriteString(vowelWylie(s))
+			}
+		case i == syl.root:
+			if i == 1 && needsDot(core[0].letters[0], s) {
+				out.WriteByte('.')
+			}
+			out.WriteString(stackWylie(s))
+			out.WriteString(vowelWylie(s))
+		default:
+			out.WriteString(stackWylie(s))
+		}
+		out.WriteString(marksWylie(s))
+	}
+	for _, s := range syl.stacks[len(core):] {
+		out.WriteString(stackWylie(s))
+		out.WriteString(vowelWylie(s))
+		out.WriteString(marksWylie(s))
+	}
+	return out.String()
+}
+
+func containsRune(rs []rune, r rune) bool {
+	for _, c := range rs {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// ToWylie returns the Extended Wylie (EWTS) transliteration of Tibetan text:
+// བོད་སྐད། → "bod skad/". The tsheg becomes a space, the shad a slash, and
+// characters outside of the Tibetan block are kept as is.
+//
+// Each syllable is analysed into its prefix, root stack and suffixes to place
+// the inherent vowel (བསྒྲུབས bsgrubs, དགའ dga'); syllables that don't fit that
+// structure, as in transliterated Sanskrit, are read stack by stack with "+"
+// between the letters of the non-Tibetan stacks (པདྨ pad+ma).
+func ToWylie(text string) string {
+	var out strings.Builder
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		if !isSyllableRune(rs[i]) {
+			if s, ok := punctuation[rs[i]]; ok {
+				out.WriteString(s)
+			} else {
+				out.WriteRune(rs[i])
+			}
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(rs) && isSyllableRune(rs[j]) {
+			j++
+		}
+		out.WriteString(analyseSyllable(rs[i:j]).wylie())
+		i = j
+	}
+	return out.String()
+}
@@ -0,0 +1,185 @@
+package bod
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// shads are the punctuation marks closing a clause or a verse, which stay
+// attached to the syllable they follow
+const shads = "།༎༏༐༑༔"
+
+// SyllableTokenizerProvider is a pure Go tokenizer splitting Tibetan text into
+// syllables on the tsheg (་). Tibetan marks syllables rather than words, so each
+// token is a syllable with its trailing tsheg or shad; it is analysed into
+// its prefix, root, subscripts, vowel and suffixes (see Tkn).
+type SyllableTokenizerProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewSyllableTokenizerProvider creates a new Tibetan syllable tokenizer
+func NewSyllableTokenizerProvider() *SyllableTokenizerProvider {
+	return &SyllableTokenizerProvider{}
+}
+
+func (p *SyllableTokenizerProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *SyllableTokenizerProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the tokenizer has no resources to download
+}
+
+// SaveConfig stores configuration for later application during initialization
+func (p *SyllableTokenizerProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *SyllableTokenizerProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *SyllableTokenizerProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *SyllableTokenizerProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// ProcessFlowController segments the raw input chunks into syllables
+func (p *SyllableTokenizerProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("tibetan-syllables: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("tibetan-syllables only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("tibetan-syllables: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("tibetan-syllables: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tokens, err := common.IntegrateProviderTokensV2(chunk, SegmentSyllables(chunk))
+		if err != nil {
+			common.Log.Debug().
+				Err(err).
+				Msg("Token integration had issues, continuing with partial results")
+		}
+		for _, token := range tokens {
+			tsw.Append(newSyllableToken(token))
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+func (p *SyllableTokenizerProvider) Name() string {
+	return "tibetan-syllables"
+}
+
+func (p *SyllableTokenizerProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *SyllableTokenizerProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// SegmentSyllables splits text into syllables: each run of Tibetan letters is
+// a syllable, kept with the tsheg or shads following it (བོད་, སྐད།), and runs
+// of Tibetan digits and of other letters and digits are kept whole. Spaces and
+// other punctuation are left out.
+func SegmentSyllables(text string) []string {
+	var syllables []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch {
+		case isSyllableRune(rs[i]):
+			for j < len(rs) && isSyllableRune(rs[j]) {
+				j++
+			}
+			if j < len(rs) && (rs[j] == tsheg || rs[j] == tshegNoBrk) {
+				j++
+			}
+			for j < len(rs) && strings.ContainsRune(shads, rs[j]) {
+				j++
+			}
+			syllables = append(syllables, string(rs[i:j]))
+		case unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]):
+			for j < len(rs) && !isSyllableRune(rs[j]) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			syllables = append(syllables, string(rs[i:j]))
+		}
+		i = j
+	}
+	return syllables
+}
+
+// newSyllableToken wraps a token and analyses the structure of its syllable
+func newSyllableToken(token *common.Tkn) *Tkn {
+	tkn := &Tkn{Tkn: *token}
+	rs := []rune(strings.TrimRight(token.Surface, string([]rune{tsheg, tshegNoBrk})+shads))
+	if !token.IsLexical || len(rs) == 0 || !isSyllableRune(rs[0]) {
+		return tkn
+	}
+	tkn.Script = ScriptTibetan
+	syl := analyseSyllable(rs)
+	if syl.root < 0 {
+		tkn.IsSanskrit = true
+		return tkn
+	}
+	letter := func(s stack) string {
+		return string(s.letters)
+	}
+	core := syl.stacks[:len(syl.stacks)-syl.endings]
+	if syl.root == 1 {
+		tkn.Prefix = letter(core[0])
+	}
+	root := core[syl.root]
+	letters := root.letters
+	if len(letters) > 1 && strings.ContainsRune(superscripts, letters[0]) && isSubjoined(letters[1]) &&
+		!strings.ContainsRune("ྱྲླྭྷ", letters[1]) {
+		tkn.Superscript = string(letters[0])
+		letters = letters[1:]
+	}
+	tkn.Root = string(baseOf(letters[0]))
+	tkn.Subscripts = string(letters[1:])
+	tkn.Vowel = string(root.vowels)
+	if after := core[syl.root+1:]; len(after) > 0 {
+		tkn.Suffix = letter(after[0])
+		if len(after) > 1 {
+			tkn.PostSuffix = letter(after[1])
+		}
+	}
+	return tkn
+}
@@ -0,0 +1,160 @@
+package bod
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const ewtsScheme = "ewts"
+
+// tibetanSchemes lists the transliteration schemes offered by the WylieProvider.
+var tibetanSchemes = []common.TranslitScheme{
+	{Name: ewtsScheme, Description: "Extended Wylie Transliteration Scheme (THL EWTS), ASCII-only and reversible: bod skad, bsgrubs, pad+ma"},
+}
+
+// WylieProvider transliterates Tibetan tokens to Extended Wylie (EWTS) in
+// pure Go, see ToWylie.
+type WylieProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewWylieProvider creates a new WylieProvider.
+func NewWylieProvider() *WylieProvider {
+	return &WylieProvider{}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *WylieProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Wylie transliterator).
+func (p *WylieProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Wylie transliterator doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *WylieProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the transliteration scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *WylieProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("wylie: context canceled during initialization: %w", err)
+	}
+	if scheme, _ := p.config["scheme"].(string); scheme != "" && scheme != ewtsScheme {
+		return fmt.Errorf("wylie: unsupported transliteration scheme: %s", scheme)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *WylieProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *WylieProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *WylieProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *WylieProvider) Name() string {
+	return "wylie"
+}
+
+func (p *WylieProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *WylieProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *WylieProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *WylieProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController transliterates the Tibetan tokens of the input. The
+// tsheg ending a syllable is dropped from its romanization: the spacing rule
+// separates the syllables instead.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *WylieProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("wylie: context canceled during processing: %w", err)
+	}
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("wylie: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("wylie: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(strings.TrimRight(ToWylie(s), " *"))
+	}
+	return input, nil
+}
+
+// SpacingRule is the spacing rule registered for Tibetan. Syllables are
+// separated by their tsheg, or by a space once transliterated; a shad stays
+// attached to the syllable it follows.
+func SpacingRule(prev, current string) bool {
+	if strings.TrimSpace(prev) == "" || strings.TrimSpace(current) == "" {
+		return false
+	}
+	last := []rune(prev)[len([]rune(prev))-1]
+	first := []rune(current)[0]
+	switch {
+	case last == tsheg || last == tshegNoBrk:
+		return false
+	case strings.ContainsRune(shads, first) || first == '/':
+		return false
+	case last == '\'' || first == '\'':
+		// a-chung, which the default rule takes for an apostrophe ('dzin, dga')
+		return true
+	}
+	return common.DefaultSpacingRule(prev, current)
+}
@@ -0,0 +1,62 @@
+package bod_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/bod"
+)
+
+func TestToWylie(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"བོད་སྐད།", "bod skad/"},
+		{"བཀྲ་ཤིས་བདེ་ལེགས།", "bkra shis bde legs/"},
+		{"བསྒྲུབས", "bsgrubs"}, // prefix, superscript, subscript and two suffixes
+		{"བརྒྱད", "brgyad"},    // prefix before a superscript
+		{"སངས་རྒྱས", "sangs rgyas"},
+		{"དགའ", "dga'"},   // inherent vowel on the root, not the prefix
+		{"དགའི", "dga'i"}, // genitive ending
+		{"གཡག", "g.yag"},  // prefix g before the root y
+		{"གྱང", "gyang"},  // subscript y
+		{"ལྷ་ས", "lha sa"},
+		{"པདྨ", "pad+ma"}, // Sanskrit stack
+		{"ཨོཾ་མ་ཎི་པདྨེ་ཧཱུྃ།", "oM ma Ni pad+me hU~M/"},
+		{"༡༢༣", "123"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, bod.ToWylie(c.input), "input %q", c.input)
+	}
+}
+
+func TestModule(t *testing.T) {
+	m, err := bod.DefaultModule()
+	require.NoError(t, err)
+
+	roman, err := m.Roman("བཀྲ་ཤིས་བདེ་ལེགས། ང་བོད་པ་ཡིན།")
+	require.NoError(t, err)
+	assert.Equal(t, "bkra shis bde legs/ nga bod pa yin/", roman)
+
+	tokenized, err := m.Tokenized("བཀྲ་ཤིས་བདེ་ལེགས།")
+	require.NoError(t, err)
+	assert.Equal(t, "བཀྲ་ཤིས་བདེ་ལེགས།", tokenized)
+
+	tokens, err := m.Tokens("བསྒྲུབས་པདྨ")
+	require.NoError(t, err)
+	require.Len(t, tokens.Slice, 2)
+
+	tkn := tokens.Slice[0].(*bod.Tkn)
+	assert.Equal(t, "བསྒྲུབས་", tkn.Surface)
+	assert.Equal(t, "bsgrubs", tkn.Roman())
+	assert.Equal(t, "བ", tkn.Prefix)
+	assert.Equal(t, "ས", tkn.Superscript)
+	assert.Equal(t, "ག", tkn.Root)
+	assert.Equal(t, "ྲ", tkn.Subscripts)
+	assert.Equal(t, "ུ", tkn.Vowel)
+	assert.Equal(t, "བ", tkn.Suffix)
+	assert.Equal(t, "ས", tkn.PostSuffix)
+	assert.True(t, tokens.Slice[1].(*bod.Tkn).IsSanskrit)
+}
@@ -0,0 +1,44 @@
+package fas
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	persianEntry := common.ProviderEntry{
+		Provider:     NewPersianProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, persianEntry); err != nil {
+		panic(fmt.Sprintf("failed to register persian provider: %v", err))
+	}
+
+	for _, scheme := range persianSchemes {
+		scheme.Providers = []string{"persian"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Persian scheme")
+		}
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     &mul.UnisegProvider{},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     NewPersianProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
@@ -0,0 +1,105 @@
+package fas
+
+// lexicon holds the pronunciation of frequent words whose short vowels cannot be
+// recovered from the unvocalized script. Entries are in scholarly notation and are
+// converted to the target scheme like the output of the letter-by-letter romanizer.
+var lexicon = map[string]string{
+	// Pronouns
+	"من":    "man",
+	"تو":    "to",
+	"او":    "ū",
+	"ما":    "mā",
+	"شما":   "šomā",
+	"آنها":  "ānhā",
+	"ایشان": "īšān",
+	"خود":   "xod",
+
+	// Function words
+	"و":    "va",
+	"در":   "dar",
+	"بر":   "bar",
+	"از":   "az",
+	"به":   "be",
+	"با":   "bā",
+	"تا":   "tā",
+	"را":   "rā",
+	"که":   "ke",
+	"اگر":  "agar",
+	"اما":  "ammā",
+	"ولی":  "valī",
+	"برای": "barāye",
+	"هم":   "ham",
+	"هر":   "har",
+	"همه":  "hame",
+	"چه":   "če",
+	"چرا":  "čerā",
+	"کجا":  "kojā",
+	"کی":   "key",
+	"می":   "mī",
+	"نمی":  "nemī",
+
+	// Copula and frequent verbs
+	"است":    "ast",
+	"هست":    "hast",
+	"نیست":   "nīst",
+	"بود":    "būd",
+	"شد":     "šod",
+	"کرد":    "kard",
+	"گفت":    "goft",
+	"داشت":   "dāšt",
+	"کردن":   "kardan",
+	"شدن":    "šodan",
+	"بودن":   "būdan",
+	"خواستن": "xāstan",
+	"روم":    "ravam",
+
+	// Numerals
+	"یک":   "yek",
+	"دو":   "do",
+	"سه":   "se",
+	"چهار": "čahār",
+	"پنج":  "panj",
+	"شش":   "šeš",
+	"هفت":  "haft",
+	"هشت":  "hašt",
+	"ده":   "dah",
+	"صد":   "ṣad",
+	"هزار": "hezār",
+
+	// Common nouns and adjectives
+	"خیلی":  "xeylī",
+	"خوب":   "xūb",
+	"بد":    "bad",
+	"بزرگ":  "bozorg",
+	"کوچک":  "kūček",
+	"روز":   "rūz",
+	"شب":    "šab",
+	"سال":   "sāl",
+	"کار":   "kār",
+	"مرد":   "mard",
+	"زن":    "zan",
+	"دست":   "dast",
+	"دل":    "del",
+	"سر":    "sar",
+	"چشم":   "češm",
+	"آب":    "āb",
+	"نان":   "nān",
+	"خانه":  "xāne",
+	"کتاب":  "ketāb",
+	"سلام":  "salām",
+	"زبان":  "zabān",
+	"پدر":   "pedar",
+	"مادر":  "mādar",
+	"برادر": "barādar",
+	"خواهر": "xāhar",
+	"دوست":  "dūst",
+	"شهر":   "šahr",
+	"کشور":  "kešvar",
+	"مردم":  "mardom",
+	"ایران": "īrān",
+	"فارسی": "fārsī",
+	"تهران": "tehrān",
+	"ژاپن":  "žāpon",
+	"دنیا":  "donyā",
+	"جهان":  "jahān",
+}
@@ -0,0 +1,452 @@
+package fas
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	zwnj          = '\u200c' // Zero-width non-joiner (نیم‌فاصله), e.g. in می‌روم
+	fatha         = 'َ'
+	kasra         = 'ِ'
+	damma         = 'ُ'
+	shadda        = 'ّ'
+	sukun         = 'ْ'
+	fathatan      = 'ً'
+	dammatan      = 'ٌ'
+	kasratan      = 'ٍ'
+	hamzaAbove    = 'ٔ'
+	daggerAlef    = 'ٰ'
+	tatweel       = 'ـ'
+	defaultScheme = "un1967"
+)
+
+// persianSchemes lists the romanization schemes offered by the PersianProvider.
+var persianSchemes = []common.TranslitScheme{
+	{Name: "un1967", Description: "United Nations 1967 romanization of Persian (ā, ī, ū, kh, sh, gh...)"},
+	{Name: "scholarly", Description: "Scholarly transliteration distinguishing Arabic letters (ḥ, ṣ, ṭ, ẓ, x, š, č...)"},
+	{Name: "simplified", Description: "Simplified romanization without diacritics"},
+}
+
+// schemeConverters turn the scholarly notation produced by the romanizer into
+// the target scheme. A nil converter means the scholarly notation is kept as is.
+var schemeConverters = map[string]*strings.Replacer{
+	"scholarly": nil,
+	"un1967":    strings.NewReplacer(unReplacements...),
+	"simplified": strings.NewReplacer(append(unReplacements,
+		"ā", "a", "ī", "i", "ū", "u", "ʻ", "", "ʼ", "")...),
+}
+
+var unReplacements = []string{
+	"s̱", "s", "ẕ", "z", "ḥ", "h", "ṣ", "s", "ż", "z", "ṭ", "t", "ẓ", "z",
+	"x", "kh", "ġ", "gh", "š", "sh", "č", "ch", "ž", "zh", "ʿ", "ʻ", "ʾ", "ʼ",
+}
+
+// consonants maps Persian letters with a fixed value to their scholarly notation.
+// Alef, vāv, ye and he are handled contextually by romanizePart.
+var consonants = map[rune]string{
+	'ب': "b", 'پ': "p", 'ت': "t", 'ث': "s̱", 'ج': "j", 'چ': "č",
+	'ح': "ḥ", 'خ': "x", 'د': "d", 'ذ': "ẕ", 'ر': "r", 'ز': "z",
+	'ژ': "ž", 'س': "s", 'ش': "š", 'ص': "ṣ", 'ض': "ż", 'ط': "ṭ",
+	'ظ': "ẓ", 'ع': "ʿ", 'غ': "ġ", 'ف': "f", 'ق': "q", 'ک': "k",
+	'گ': "g", 'ل': "l", 'م': "m", 'ن': "n", 'ء': "ʾ", 'ؤ': "ʾ", 'ئ': "ʾ",
+}
+
+// arabicVariants normalizes Arabic code points commonly found in Persian text
+// to their Persian counterparts.
+var arabicVariants = strings.NewReplacer(
+	"ك", "ک",
+	"ي", "ی",
+	"ى", "ی",
+	"ۀ", "هٔ",
+	string(tatweel), "",
+)
+
+// PersianProvider romanizes Persian tokens written in the Perso-Arabic script.
+// Unlike the generic Arabic tables, it handles the Persian-specific letters
+// (پ چ ژ گ ک ی), the silent vāv of خوا, the word-final silent he and the ezāfe,
+// whether marked by a kasra, a hamza on he (ۀ) or a zero-width non-joiner.
+// Short vowels are generally not written in Persian: they are taken from the
+// diacritics when present and from a lexicon of frequent words otherwise.
+type PersianProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+	scheme           string
+}
+
+// NewPersianProvider creates a new PersianProvider using the default scheme.
+func NewPersianProvider() *PersianProvider {
+	return &PersianProvider{
+		scheme: defaultScheme,
+	}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *PersianProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Persian romanizer).
+func (p *PersianProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Persian romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+// This allows the provider to be configured before being initialized.
+//
+// Returns an error if the configuration is invalid.
+func (p *PersianProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// PersianOptions are the typed options of PersianProvider, see common.Configure.
+type PersianOptions struct {
+	// Scheme is the name of a registered Persian scheme. Defaults to defaultScheme.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *PersianProvider) ConfigureWith(opts PersianOptions) error {
+	if _, ok := schemeConverters[opts.Scheme]; !ok && opts.Scheme != "" {
+		return fmt.Errorf("unsupported transliteration scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// InitWithContext initializes the provider with the given context.
+// This validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *PersianProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("persian: context canceled during initialization: %w", err)
+	}
+
+	scheme, _ := p.config["scheme"].(string)
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if _, ok := schemeConverters[scheme]; !ok {
+		return fmt.Errorf("persian: unsupported transliteration scheme: %s", scheme)
+	}
+	p.scheme = scheme
+	return nil
+}
+
+// Init initializes the provider with a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Returns an error if initialization fails.
+func (p *PersianProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes the provider from scratch with the given context.
+// For the Persian romanizer, this is equivalent to InitWithContext as there are no persistent resources.
+//
+// Returns an error if reinitialization fails or the context is canceled.
+func (p *PersianProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Returns an error if reinitialization fails.
+func (p *PersianProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *PersianProvider) Name() string {
+	return "persian"
+}
+
+func (p *PersianProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *PersianProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext releases resources used by the provider with the given context.
+// For the Persian romanizer, this is a no-op as there are no persistent resources to release.
+//
+// Returns nil as there are no resources to release.
+func (p *PersianProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close releases resources used by the provider with a background context.
+// For the Persian romanizer, this is a no-op as there are no persistent resources to release.
+//
+// Returns nil as there are no resources to release.
+func (p *PersianProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController processes input tokens using the specified context.
+// This processes pre-tokenized input, adding romanization to Persian tokens.
+// The context is used for cancellation during processing.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *PersianProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("persian: context canceled during processing: %w", err)
+	}
+
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("persian: raw input not accepted, a tokenizer must run first")
+	}
+
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("persian: context canceled while processing token %d: %w", i, err)
+		}
+
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		roman, _ := Romanize(s, p.scheme)
+		tkn.SetRoman(roman)
+	}
+
+	return input, nil
+}
+
+// Romanize converts a Persian word or phrase to the given scheme
+// ("un1967", "scholarly" or "simplified").
+//
+// Returns an error if the scheme is not supported.
+func Romanize(text, scheme string) (string, error) {
+	conv, ok := schemeConverters[scheme]
+	if !ok {
+		return "", fmt.Errorf("persian: unsupported transliteration scheme: %s", scheme)
+	}
+
+	words := strings.Fields(arabicVariants.Replace(text))
+	for i, word := range words {
+		// Parts joined by a ZWNJ (prefixes like می, suffixes like ها) are
+		// romanized separately and hyphenated.
+		parts := strings.Split(word, string(zwnj))
+		for j, part := range parts {
+			if j > 0 && (part == "ی" || part == "ای") {
+				// Ezāfe written after a ZWNJ: خانه‌ی xāne-ye
+				parts[j] = "ye"
+				continue
+			}
+			parts[j] = romanizePart(part)
+		}
+		words[i] = strings.Join(parts, "-")
+	}
+
+	roman := strings.Join(words, " ")
+	if conv != nil {
+		roman = conv.Replace(roman)
+	}
+	return roman, nil
+}
+
+// romanizePart romanizes a single word (without ZWNJ) in scholarly notation.
+func romanizePart(word string) string {
+	if word == "" {
+		return ""
+	}
+
+	// Detect an explicitly marked ezāfe and strip it before the lexicon lookup
+	ezafe := ""
+	switch {
+	case strings.HasSuffix(word, string(kasra)):
+		word = strings.TrimSuffix(word, string(kasra))
+		ezafe = "-e"
+	case strings.HasSuffix(word, "ه"+string(hamzaAbove)):
+		word = strings.TrimSuffix(word, string(hamzaAbove))
+		ezafe = "-ye"
+	}
+
+	if roman, ok := lexicon[word]; ok {
+		return roman + ezafe
+	}
+
+	rs := []rune(word)
+	var out strings.Builder
+	atStart := true
+	prevVowel := false
+	lastCons := ""
+
+	emitCons := func(s string) {
+		out.WriteString(s)
+		lastCons = s
+		prevVowel = false
+		atStart = false
+	}
+	emitVowel := func(s string) {
+		out.WriteString(s)
+		prevVowel = true
+		atStart = false
+	}
+	at := func(i int) rune {
+		if i >= 0 && i < len(rs) {
+			return rs[i]
+		}
+		return 0
+	}
+	isFinal := func(i int) bool {
+		for _, r := range rs[i+1:] {
+			if !unicode.Is(unicode.Mn, r) {
+				return false
+			}
+		}
+		return true
+	}
+	// vowelFollows reports whether the letter at i is followed by a vowel,
+	// in which case vāv and ye are consonants.
+	vowelFollows := func(i int) bool {
+		switch at(i + 1) {
+		case fatha, kasra, damma, fathatan, dammatan, kasratan, 'ا', 'آ':
+			return true
+		}
+		return false
+	}
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if cons, ok := consonants[r]; ok {
+			emitCons(cons)
+			continue
+		}
+
+		switch r {
+		case 'ا':
+			switch {
+			case at(i+1) == fathatan:
+				emitVowel("an")
+				i++
+			case !atStart:
+				emitVowel("ā")
+			case at(i+1) == 'ی':
+				emitVowel("ī")
+				i++
+			case at(i+1) == 'و':
+				emitVowel("ū")
+				i++
+			case unicode.Is(unicode.Mn, at(i+1)):
+				// Alef only carries the vowel written on it
+				atStart = false
+			default:
+				emitVowel("a")
+			}
+		case 'آ':
+			emitVowel("ā")
+		case 'أ':
+			if atStart {
+				emitVowel("a")
+			} else {
+				emitCons("ʾ")
+			}
+		case 'إ':
+			if atStart {
+				emitVowel("e")
+			} else {
+				emitCons("ʾ")
+			}
+		case 'و':
+			switch {
+			case at(i-1) == 'خ' && at(i+1) == 'ا':
+				// Silent vāv: خواهر xāhar, خواستن xāstan
+			case atStart || prevVowel || vowelFollows(i) || at(i+1) == 'و' || at(i+1) == shadda:
+				emitCons("v")
+			default:
+				emitVowel("ū")
+			}
+		case 'ی':
+			if atStart || prevVowel || vowelFollows(i) || at(i+1) == 'و' || at(i+1) == shadda {
+				emitCons("y")
+			} else {
+				emitVowel("ī")
+			}
+		case 'ه':
+			// Word-final he after a consonant is silent and stands for -e: خانه xāne
+			if isFinal(i) && !atStart && !prevVowel {
+				emitVowel("e")
+			} else {
+				emitCons("h")
+			}
+		case 'ة':
+			emitCons("at")
+		case fatha:
+			emitVowel("a")
+		case kasra:
+			if at(i+1) == 'ی' && !vowelFollows(i+1) {
+				emitVowel("ī")
+				i++
+			} else {
+				emitVowel("e")
+			}
+		case damma:
+			if at(i+1) == 'و' && !vowelFollows(i+1) {
+				emitVowel("ū")
+				i++
+			} else {
+				emitVowel("o")
+			}
+		case shadda:
+			if lastCons != "" {
+				out.WriteString(lastCons)
+				prevVowel = false
+			}
+		case fathatan:
+			emitVowel("an")
+			if at(i+1) == 'ا' {
+				i++
+			}
+		case dammatan:
+			emitVowel("on")
+		case kasratan:
+			emitVowel("en")
+		case daggerAlef:
+			emitVowel("ā")
+		case sukun, hamzaAbove:
+		default:
+			switch {
+			case r >= '۰' && r <= '۹':
+				out.WriteRune('0' + r - '۰')
+			case r >= '٠' && r <= '٩':
+				out.WriteRune('0' + r - '٠')
+			default:
+				out.WriteRune(r)
+			}
+			prevVowel = false
+			atStart = false
+		}
+	}
+
+	return out.String() + ezafe
+}
@@ -0,0 +1,36 @@
+package fas_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/fas"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, scheme, expected string
+	}{
+		{"سلام", "un1967", "salām"},
+		{"خانه", "un1967", "khāne"},
+		{"خانهٔ", "un1967", "khāne-ye"},  // ezāfe marked by hamza on he
+		{"کتابِ", "un1967", "ketāb-e"},   // ezāfe marked by kasra
+		{"خواهر", "scholarly", "xāhar"},  // silent vāv
+		{"می‌روم", "un1967", "mī-ravam"}, // ZWNJ-joined prefix
+		{"خانه‌ی", "un1967", "khāne-ye"}, // ezāfe after a ZWNJ
+		{"ایران", "simplified", "iran"},
+		{"كتاب", "scholarly", "ketāb"}, // Arabic kaf normalized
+		{"ژاپن", "un1967", "zhāpon"},   // Persian-specific letters
+		{"۱۴۰۳", "un1967", "1403"},
+	}
+	for _, c := range cases {
+		roman, err := fas.Romanize(c.input, c.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, roman, "input %q with scheme %s", c.input, c.scheme)
+	}
+
+	_, err := fas.Romanize("سلام", "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,401 @@
+package hin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const colloquialScheme = "hindi-colloquial"
+
+// hindiSchemes lists the romanization schemes offered by the HindiProvider.
+var hindiSchemes = []common.TranslitScheme{
+	{Name: colloquialScheme, Description: "Colloquial romanization with schwa deletion, as Hindi is pronounced (namaste, karna, kamra)"},
+}
+
+const (
+	virama       = '\u094D'
+	nukta        = '\u093C'
+	anusvara     = '\u0902'
+	chandrabindu = '\u0901'
+	visarga      = '\u0903'
+)
+
+// consonantRoman maps the Devanagari consonants, with their nukta forms, to
+// their colloquial romanization
+var consonantRoman = map[string]string{
+	"क": "k", "ख": "kh", "ग": "g", "घ": "gh", "ङ": "n",
+	"च": "ch", "छ": "chh", "ज": "j", "झ": "jh", "ञ": "n",
+	"ट": "t", "ठ": "th", "ड": "d", "ढ": "dh", "ण": "n",
+	"त": "t", "थ": "th", "द": "d", "ध": "dh", "न": "n",
+	"प": "p", "फ": "ph", "ब": "b", "भ": "bh", "म": "m",
+	"य": "y", "र": "r", "ल": "l", "व": "v", "ळ": "l",
+	"श": "sh", "ष": "sh", "स": "s", "ह": "h",
+	// nukta forms, precomposed (U+0958-U+095F)
+	"\u0958": "q", "\u0959": "kh", "\u095A": "gh", "\u095B": "z",
+	"\u095C": "d", "\u095D": "dh", "\u095E": "f", "\u095F": "y",
+	// and decomposed
+	"क\u093C": "q", "ख\u093C": "kh", "ग\u093C": "gh", "ज\u093C": "z",
+	"ड\u093C": "d", "ढ\u093C": "dh", "फ\u093C": "f", "य\u093C": "y",
+}
+
+// vowels maps the independent vowels to their colloquial romanization;
+// long and short vowels are not told apart
+var vowels = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u",
+	'ऋ': "ri", 'ए': "e", 'ऐ': "ai", 'ओ': "o", 'औ': "au", 'ऑ': "o", 'ऍ': "e",
+}
+
+// matras maps the dependent vowel signs to their colloquial romanization
+var matras = map[rune]string{
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri",
+	'े': "e", 'ै': "ai", 'ो': "o", 'ौ': "au", 'ॉ': "o", 'ॅ': "e",
+}
+
+// others maps the remaining signs of the block
+var others = map[rune]string{
+	'।': ".", '॥': ".", 'ॐ': "om", 'ऽ': "",
+	'०': "0", '१': "1", '२': "2", '३': "3", '४': "4",
+	'५': "5", '६': "6", '७': "7", '८': "8", '९': "9",
+}
+
+// akshara is an orthographic syllable: a consonant cluster (possibly empty)
+// and its vowel
+type akshara struct {
+	text       string   // Devanagari spelling
+	consonants []string // Devanagari consonants, with their nukta
+	vowel      string   // romanized vowel, empty after a final virama
+	inherent   bool     // the vowel is the inherent schwa
+	deleted    bool     // the inherent schwa is silent
+	nasal      rune     // anusvara or chandrabindu, 0 if none
+	visarga    bool
+	other      string // text that isn't a syllable (digits, punctuation, other scripts)
+}
+
+// HindiProvider romanizes Hindi tokens written in Devanagari the way they
+// are pronounced: unlike a transliteration, which writes the inherent vowel
+// of every consonant (करना karanā), the schwas that are silent in Hindi are
+// deleted (karna). See DeleteSchwas for the rules.
+type HindiProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewHindiProvider creates a new HindiProvider.
+func NewHindiProvider() *HindiProvider {
+	return &HindiProvider{}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *HindiProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Hindi romanizer).
+func (p *HindiProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Hindi romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *HindiProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *HindiProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("hindi: context canceled during initialization: %w", err)
+	}
+	if scheme, _ := p.config["scheme"].(string); scheme != "" && scheme != colloquialScheme {
+		return fmt.Errorf("hindi: unsupported transliteration scheme: %s", scheme)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *HindiProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *HindiProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *HindiProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *HindiProvider) Name() string {
+	return "hindi"
+}
+
+func (p *HindiProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *HindiProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *HindiProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *HindiProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController romanizes the Devanagari tokens of the input.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *HindiProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("hindi: context canceled during processing: %w", err)
+	}
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("hindi: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("hindi: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(Romanize(s))
+	}
+	return input, nil
+}
+
+// Romanize converts Hindi text written in Devanagari to its colloquial
+// romanization, deleting the silent schwas of each word (नमस्ते namaste,
+// करना karna, समझना samajhna). Characters outside of Devanagari are kept as is.
+func Romanize(text string) string {
+	var out strings.Builder
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			out.WriteString(renderAksharas(deleteSchwas(parseAksharas(word))))
+			word = word[:0]
+		}
+	}
+	for _, r := range text {
+		if isDevanagariLetter(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		if s, ok := others[r]; ok {
+			out.WriteString(s)
+		} else {
+			out.WriteRune(r)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// DeleteSchwas returns the Devanagari text with a virama written on the
+// consonants whose inherent vowel is silent in Hindi, e.g. करना → कर्ना.
+//
+// The rules are those of Ohala (1983), applied to each word:
+//   - the final schwa is deleted, unless it follows a consonant cluster (मित्र mitra)
+//     or the word has a single syllable (न na);
+//   - a medial schwa is deleted in the context VC_CV, scanning the word from
+//     right to left so that a deletion blocks the one before it (समझना
+//     samajhna, not samjhna), and provided it doesn't create a cluster of
+//     three consonants (ज़िंदगी zindagi).
+//
+// As the rules ignore morpheme boundaries, compounds may get a wrong
+// pronunciation.
+func DeleteSchwas(text string) string {
+	var out strings.Builder
+	var word []rune
+	flush := func() {
+		for _, a := range deleteSchwas(parseAksharas(word)) {
+			out.WriteString(a.text)
+			if a.deleted {
+				out.WriteRune(virama)
+			}
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if isDevanagariLetter(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		out.WriteRune(r)
+	}
+	flush()
+	return out.String()
+}
+
+func isDevanagariLetter(r rune) bool {
+	if _, ok := others[r]; ok {
+		return false
+	}
+	return r >= 0x0900 && r <= 0x097F
+}
+
+func isConsonant(s string) bool {
+	_, ok := consonantRoman[s]
+	return ok
+}
+
+// parseAksharas splits a Devanagari word into syllables.
+func parseAksharas(word []rune) []akshara {
+	var aksharas []akshara
+	// consonant returns the consonant at i with its nukta, and the index after it
+	consonant := func(i int) (string, int) {
+		if i+1 < len(word) && word[i+1] == nukta {
+			return string(word[i : i+2]), i + 2
+		}
+		return string(word[i]), i + 1
+	}
+	for i := 0; i < len(word); {
+		r := word[i]
+		start := i
+		var a akshara
+		switch {
+		case isConsonant(string(r)):
+			c, next := consonant(i)
+			a.consonants = append(a.consonants, c)
+			i = next
+			for i < len(word) && word[i] == virama {
+				if i+1 < len(word) && isConsonant(string(word[i+1])) {
+					c, next = consonant(i + 1)
+					a.consonants = append(a.consonants, c)
+					i = next
+					continue
+				}
+				i++ // final virama: no vowel
+				break
+			}
+			if i < len(word) && matras[word[i]] != "" {
+				a.vowel = matras[word[i]]
+				i++
+			} else if i == 0 || word[i-1] != virama {
+				a.vowel, a.inherent = "a", true
+			}
+		case vowels[r] != "":
+			a.vowel = vowels[r]
+			i++
+		default:
+			a.text, a.other = string(r), string(r)
+			i++
+			aksharas = append(aksharas, a)
+			continue
+		}
+		for ; i < len(word); i++ {
+			if word[i] == anusvara || word[i] == chandrabindu {
+				a.nasal = word[i]
+			} else if word[i] == visarga {
+				a.visarga = true
+			} else {
+				break
+			}
+		}
+		a.text = string(word[start:i])
+		aksharas = append(aksharas, a)
+	}
+	return aksharas
+}
+
+// deleteSchwas marks the silent inherent vowels of a word, see DeleteSchwas.
+func deleteSchwas(aksharas []akshara) []akshara {
+	candidate := func(a akshara) bool {
+		return a.inherent && len(a.consonants) == 1 && a.nasal == 0 && !a.visarga
+	}
+	// a syllable made of a single consonant followed by a pronounced vowel
+	cv := func(a akshara) bool {
+		return len(a.consonants) == 1 && a.vowel != "" && !a.deleted
+	}
+	last := len(aksharas) - 1
+	if last > 0 && candidate(aksharas[last]) {
+		aksharas[last].deleted = true
+	}
+	for i := last - 1; i >= 1; i-- {
+		prev, next := aksharas[i-1], aksharas[i+1]
+		// an anusvara before the consonant is pronounced as one: deleting
+		// the schwa would make a cluster of three
+		if !candidate(aksharas[i]) || prev.vowel == "" || prev.deleted || prev.nasal == anusvara || !cv(next) {
+			continue
+		}
+		aksharas[i].deleted = true
+	}
+	return aksharas
+}
+
+// renderAksharas writes the colloquial romanization of a word.
+func renderAksharas(aksharas []akshara) string {
+	var out strings.Builder
+	for i, a := range aksharas {
+		if a.other != "" {
+			out.WriteString(a.other)
+			continue
+		}
+		consonants := a.consonants
+		if len(consonants) >= 2 && consonants[0] == "ज" && consonants[1] == "ञ" {
+			// ज्ञ is pronounced gy in Hindi (ज्ञान gyan)
+			out.WriteString("gy")
+			consonants = consonants[2:]
+		}
+		for _, c := range consonants {
+			out.WriteString(consonantRoman[c])
+		}
+		if !a.deleted {
+			out.WriteString(a.vowel)
+		}
+		if a.nasal != 0 {
+			out.WriteString(nasalBefore(aksharas, i))
+		}
+		if a.visarga {
+			out.WriteString("h")
+		}
+	}
+	return out.String()
+}
+
+// nasalBefore returns the romanization of the nasal sign of the akshara at
+// i, which assimilates to the labial consonant following it (संबंध sambandh)
+func nasalBefore(aksharas []akshara, i int) string {
+	if i+1 < len(aksharas) && len(aksharas[i+1].consonants) > 0 {
+		switch aksharas[i+1].consonants[0] {
+		case "प", "फ", "ब", "भ", "म":
+			return "m"
+		}
+	}
+	return "n"
+}
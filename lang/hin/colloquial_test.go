@@ -0,0 +1,50 @@
+package hin_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/hin"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := map[string]string{
+		"नमस्ते":  "namaste",
+		"करना":   "karna",
+		"कमरा":   "kamra",
+		"समझना":  "samajhna",
+		"बदलना":  "badalna",
+		"अपना":   "apna",
+		"भारत":   "bharat",
+		"कमल":    "kamal",
+		"मित्र":   "mitra",
+		"न":      "na",
+		"हिंदी":   "hindi",
+		"ज़िंदगी": "zindagi",
+		"ज्ञान":   "gyan",
+		"संबंध":   "sambandh",
+		"क्या":    "kya",
+		"लड़का":   "ladka",
+		"१२३।":   "123.",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, hin.Romanize(input), "input %q", input)
+	}
+	assert.Equal(t, "कर्ना", hin.DeleteSchwas("करना"))
+	assert.Equal(t, "समझ्ना भारत्", hin.DeleteSchwas("समझना भारत"))
+}
+
+func TestColloquialScheme(t *testing.T) {
+	m, err := common.GetSchemeModule(hin.Lang, "hindi-colloquial")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	roman, err := m.Roman("नमस्ते, आप कैसे हैं?")
+	require.NoError(t, err)
+	assert.Equal(t, "namaste, ap kaise hain?", strings.Join(strings.Fields(roman), " "))
+}
@@ -9,15 +9,34 @@ import (
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
 )
 
-func init() {	
+func init() {
+	hindiEntry := common.ProviderEntry{
+		Provider:     NewHindiProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, hindiEntry); err != nil {
+		panic(fmt.Sprintf("failed to register hindi provider: %v", err))
+	}
+
+	for _, scheme := range hindiSchemes {
+		scheme.Providers = []string{"hindi"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Hindi scheme")
+		}
+	}
+
 	defaultProviders := []common.ProviderEntry{
 		{
 			Provider:     &mul.UnisegProvider{},
-			Capabilities: []string{"tokenization"},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
 		},
 		{
 			Provider:     mul.NewAksharamukhaProvider(Lang),
-			Capabilities: []string{"transliteration"},
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
 		},
 	}
 
@@ -0,0 +1,59 @@
+package hye
+
+import (
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptArmenian = "Armn" // Armenian alphabet
+	ScriptLatin    = "Latn" // Romanized/Latin script
+)
+
+// Tkn extends the common Token with Armenian-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Orthographic features
+	IsClassical    bool // Classical (Mashtotsian) orthography, as used in Western Armenian and Iran
+	HasEmphasis    bool // Contains an emphasis, exclamation or question mark over a vowel (՛ ՜ ՞)
+	HasLigature    bool // Contains the ligature և
+
+	// Morphological features
+	Case           GramCase // 7 cases
+	Number         string   // Singular or plural
+	Definite       bool     // Definite article suffix (-ը/-ն)
+	Possessive     int      // Person of the possessive suffix (-ս, -դ), 0 if none
+
+	// Verb features
+	Tense          string // Present, past, future...
+	Mood           string // Indicative, subjunctive, conditional, imperative...
+	Voice          string // Active, passive (-վ-), causative (-ացն-)
+
+	// Variety
+	Western        bool // Western Armenian, where stops are pronounced with a shifted voicing
+}
+
+// GramCase is an Armenian grammatical case
+type GramCase string
+const (
+	Nominative   GramCase = "nom"
+	Accusative   GramCase = "acc"
+	Genitive     GramCase = "gen"
+	Dative       GramCase = "dat"
+	Ablative     GramCase = "abl"
+	Instrumental GramCase = "ins"
+	Locative     GramCase = "loc"
+)
+
+
+// SpacingRule is the spacing rule registered for Armenian. The Armenian full
+// stop (։) and comma (՝) stay attached to the word they follow, like their
+// Latin counterparts.
+func SpacingRule(prev, current string) bool {
+	if strings.HasPrefix(current, "։") || strings.HasPrefix(current, "՝") {
+		return false
+	}
+	return common.DefaultSpacingRule(prev, current)
+}
@@ -0,0 +1,48 @@
+package hye
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	armenianEntry := common.ProviderEntry{
+		Provider:     NewArmenianProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, armenianEntry); err != nil {
+		panic(fmt.Sprintf("failed to register armenian provider: %v", err))
+	}
+
+	for _, scheme := range armenianSchemes {
+		scheme.Providers = []string{"armenian"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Armenian scheme")
+		}
+	}
+
+	if err := common.RegisterSpacingRule(Lang, SpacingRule); err != nil {
+		panic(fmt.Sprintf("failed to register Armenian spacing rule: %v", err))
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     &mul.UnisegProvider{},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     NewArmenianProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
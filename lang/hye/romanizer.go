@@ -0,0 +1,323 @@
+package hye
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const defaultScheme = "national"
+
+// armenianSchemes lists the romanization schemes offered by the ArmenianProvider.
+var armenianSchemes = []common.TranslitScheme{
+	{Name: "national", Description: "Romanization used by Armenian passports and road signs, BGN/PCGN without the apostrophes (Yerevan, Gyumri)"},
+	{Name: "bgn_pcgn", Description: "Board on Geographic Names - Permanent Committee on Geographical Names (1981), aspirates marked with an apostrophe"},
+	{Name: "iso9985", Description: "ISO 9985:1996, one letter per character (ē, ë, ž, x, ġ, č, ṙ, ō...)"},
+}
+
+// armenianScheme holds the letter table of a scheme and whether ե, ո and և
+// are spelled contextually.
+type armenianScheme struct {
+	letters    map[rune]string
+	contextual bool
+}
+
+// Tables of the lowercase letters; letters missing from a table are kept as is.
+var schemes = map[string]armenianScheme{
+	"national": {contextual: true, letters: map[rune]string{
+		'ա': "a", 'բ': "b", 'գ': "g", 'դ': "d", 'ե': "e", 'զ': "z", 'է': "e",
+		'ը': "y", 'թ': "t", 'ժ': "zh", 'ի': "i", 'լ': "l", 'խ': "kh", 'ծ': "ts",
+		'կ': "k", 'հ': "h", 'ձ': "dz", 'ղ': "gh", 'ճ': "ch", 'մ': "m", 'յ': "y",
+		'ն': "n", 'շ': "sh", 'ո': "o", 'չ': "ch", 'պ': "p", 'ջ': "j", 'ռ': "r",
+		'ս': "s", 'վ': "v", 'տ': "t", 'ր': "r", 'ց': "ts", 'ւ': "v", 'փ': "p",
+		'ք': "k", 'և': "ev", 'օ': "o", 'ֆ': "f",
+	}},
+	"bgn_pcgn": {contextual: true, letters: map[rune]string{
+		'ա': "a", 'բ': "b", 'գ': "g", 'դ': "d", 'ե': "e", 'զ': "z", 'է': "e",
+		'ը': "y", 'թ': "t’", 'ժ': "zh", 'ի': "i", 'լ': "l", 'խ': "kh", 'ծ': "ts",
+		'կ': "k", 'հ': "h", 'ձ': "dz", 'ղ': "gh", 'ճ': "ch", 'մ': "m", 'յ': "y",
+		'ն': "n", 'շ': "sh", 'ո': "o", 'չ': "ch’", 'պ': "p", 'ջ': "j", 'ռ': "r",
+		'ս': "s", 'վ': "v", 'տ': "t", 'ր': "r", 'ց': "ts’", 'ւ': "w", 'փ': "p’",
+		'ք': "k’", 'և': "ev", 'օ': "o", 'ֆ': "f",
+	}},
+	"iso9985": {letters: map[rune]string{
+		'ա': "a", 'բ': "b", 'գ': "g", 'դ': "d", 'ե': "e", 'զ': "z", 'է': "ē",
+		'ը': "ë", 'թ': "tʼ", 'ժ': "ž", 'ի': "i", 'լ': "l", 'խ': "x", 'ծ': "c",
+		'կ': "k", 'հ': "h", 'ձ': "j", 'ղ': "ġ", 'ճ': "č", 'մ': "m", 'յ': "y",
+		'ն': "n", 'շ': "š", 'ո': "o", 'չ': "čʼ", 'պ': "p", 'ջ': "ǰ", 'ռ': "ṙ",
+		'ս': "s", 'վ': "v", 'տ': "t", 'ր': "r", 'ց': "cʼ", 'ւ': "w", 'փ': "pʼ",
+		'ք': "kʼ", 'և': "ew", 'օ': "ō", 'ֆ': "f",
+	}},
+}
+
+// Emphasis (՛), exclamation (՜) and question (՞) marks are written over the
+// stressed vowel, inside the word
+const (
+	emphasisMark    = '՛'
+	exclamationMark = '՜'
+	questionMark    = '՞'
+)
+
+// exceptions are the words whose initial ո isn't spelled vo
+var exceptions = map[string]string{
+	"ով":    "ov",
+	"ովքեր": "ovker",
+}
+
+// ArmenianProvider romanizes Armenian tokens in the reformed (Eastern)
+// orthography. Besides the letter tables, the national and BGN/PCGN schemes
+// spell ե and ո at the start of a word ye and vo (Yerevan, Vorotan) and the
+// digraph ու u.
+type ArmenianProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+	scheme           string
+}
+
+// NewArmenianProvider creates a new ArmenianProvider using the default scheme.
+func NewArmenianProvider() *ArmenianProvider {
+	return &ArmenianProvider{
+		scheme: defaultScheme,
+	}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *ArmenianProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Armenian romanizer).
+func (p *ArmenianProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Armenian romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+//
+// Returns an error if the configuration is invalid.
+func (p *ArmenianProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// ArmenianOptions are the typed options of ArmenianProvider, see common.Configure.
+type ArmenianOptions struct {
+	// Scheme is the name of a registered Armenian scheme. Defaults to defaultScheme.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *ArmenianProvider) ConfigureWith(opts ArmenianOptions) error {
+	if _, ok := schemes[opts.Scheme]; !ok && opts.Scheme != "" {
+		return fmt.Errorf("unsupported transliteration scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// InitWithContext initializes the provider with the given context.
+// This validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *ArmenianProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("armenian: context canceled during initialization: %w", err)
+	}
+
+	scheme, _ := p.config["scheme"].(string)
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if _, ok := schemes[scheme]; !ok {
+		return fmt.Errorf("armenian: unsupported transliteration scheme: %s", scheme)
+	}
+	p.scheme = scheme
+	return nil
+}
+
+// Init initializes the provider with a background context.
+//
+// Returns an error if initialization fails.
+func (p *ArmenianProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes the provider from scratch with the given context.
+// For the Armenian romanizer, this is equivalent to InitWithContext as there are no persistent resources.
+func (p *ArmenianProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *ArmenianProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *ArmenianProvider) Name() string {
+	return "armenian"
+}
+
+func (p *ArmenianProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *ArmenianProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *ArmenianProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *ArmenianProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController processes input tokens using the specified context,
+// adding romanization to Armenian tokens.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *ArmenianProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("armenian: context canceled during processing: %w", err)
+	}
+
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("armenian: raw input not accepted, a tokenizer must run first")
+	}
+
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("armenian: context canceled while processing token %d: %w", i, err)
+		}
+
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		roman, _ := Romanize(s, p.scheme)
+		tkn.SetRoman(roman)
+	}
+
+	return input, nil
+}
+
+// Romanize converts Armenian text to the given scheme ("national", "bgn_pcgn"
+// or "iso9985").
+//
+// Returns an error if the scheme is not supported.
+func Romanize(text, scheme string) (string, error) {
+	s, ok := schemes[scheme]
+	if !ok {
+		return "", fmt.Errorf("armenian: unsupported transliteration scheme: %s", scheme)
+	}
+	var out strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isArmenianLetter(runes[i]) {
+			out.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && (isArmenianLetter(runes[j]) || isWordMark(runes[j])) {
+			j++
+		}
+		out.WriteString(romanizeWord(runes[i:j], s))
+		i = j
+	}
+	return out.String(), nil
+}
+
+func isArmenianLetter(r rune) bool {
+	return (r >= 'Ա' && r <= 'Ֆ') || (r >= 'ա' && r <= 'և')
+}
+
+func isWordMark(r rune) bool {
+	return r == emphasisMark || r == exclamationMark || r == questionMark
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("աեէըիոօ", r)
+}
+
+// romanizeWord romanizes a word, restoring its capitalization.
+func romanizeWord(word []rune, s armenianScheme) string {
+	var letters []rune
+	upper := 0
+	for _, r := range word {
+		if isWordMark(r) {
+			continue
+		}
+		if unicode.IsUpper(r) {
+			upper++
+		}
+		letters = append(letters, unicode.ToLower(r))
+	}
+
+	var roman string
+	if exception, ok := exceptions[string(letters)]; ok && s.contextual {
+		roman = exception
+	} else {
+		var out strings.Builder
+		for i, r := range letters {
+			initial := i == 0
+			afterVowel := i > 0 && (isVowel(letters[i-1]) || (letters[i-1] == 'ւ' && i > 1 && letters[i-2] == 'ո'))
+			switch {
+			case r == 'ո' && i+1 < len(letters) && letters[i+1] == 'ւ' && s.contextual:
+				// ու is the vowel u, its ւ is skipped below
+				out.WriteString("u")
+			case r == 'ւ' && i > 0 && letters[i-1] == 'ո' && s.contextual:
+			case s.contextual && initial && r == 'ո':
+				out.WriteString("vo")
+			case s.contextual && (initial || afterVowel) && r == 'ե':
+				out.WriteString("ye")
+			case s.contextual && initial && r == 'և':
+				out.WriteString("yev")
+			default:
+				if l, ok := s.letters[r]; ok {
+					out.WriteString(l)
+				} else {
+					out.WriteRune(r)
+				}
+			}
+		}
+		roman = out.String()
+	}
+
+	switch {
+	case upper > 1 && upper == len(letters):
+		return strings.ToUpper(roman)
+	case upper > 0 && unicode.IsUpper(word[0]):
+		first := []rune(roman)
+		if len(first) > 0 {
+			first[0] = unicode.ToUpper(first[0])
+		}
+		return string(first)
+	}
+	return roman
+}
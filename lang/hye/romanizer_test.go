@@ -0,0 +1,37 @@
+package hye_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/hye"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, scheme, expected string
+	}{
+		{"Երևան", "national", "Yerevan"},
+		{"Գյումրի", "national", "Gyumri"},
+		{"Որոտան", "national", "Vorotan"},
+		{"ով", "national", "ov"},
+		{"և", "national", "yev"},
+		{"Էջմիածին", "national", "Ejmiatsin"},
+		{"Հայաստան", "bgn_pcgn", "Hayastan"},
+		{"Չարենց", "bgn_pcgn", "Ch’arents’"},
+		{"Երևան", "iso9985", "Erewan"},
+		{"Ժողովուրդ", "iso9985", "Žoġovowrd"},
+		{"ինչո՞ւ", "national", "inchu"}, // question mark over the vowel
+		{"ՀԱՅԱՍՏԱՆ", "national", "HAYASTAN"},
+	}
+	for _, c := range cases {
+		roman, err := hye.Romanize(c.input, c.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, roman, "input %q with scheme %s", c.input, c.scheme)
+	}
+
+	_, err := hye.Romanize("Երևան", "unknown")
+	assert.Error(t, err)
+}
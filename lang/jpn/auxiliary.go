@@ -0,0 +1,153 @@
+package jpn
+
+import (
+	"strings"
+)
+
+// subsidiaryVerbs are the verbs that attach to the te-form of a verb
+// (食べて+しまう, 読んで+いる...). Keys are dictionary forms in kana.
+var subsidiaryVerbs = map[string]bool{
+	"しまう": true, "いる": true, "おく": true, "みる": true, "いく": true,
+	"くる": true, "ある": true, "あげる": true, "くれる": true, "もらう": true,
+	"ください": true, "くださる": true, "いただく": true, "おる": true,
+	"ちゃう": true, "じゃう": true, "とく": true, "どく": true, "てる": true,
+}
+
+// boundAuxiliaries are the auxiliaries that attach directly to a verb stem or
+// another auxiliary (食べ+ます, 食べ+たい, 行か+ない, 食べ+た...).
+var boundAuxiliaries = map[string]bool{
+	"ます": true, "たい": true, "ない": true, "た": true, "だ": true,
+	"れる": true, "られる": true, "せる": true, "させる": true, "ぬ": true,
+	"まい": true, "たがる": true, "そう": true,
+}
+
+// MergeAuxiliaryChains reattaches auxiliary chains such as 食べて+しまった or
+// 行か+なかった into a single token. The merged token takes the surface, kana
+// and romanization of the whole chain, the dictionary form and glosses of its
+// head, and keeps the original tokens in its Components field.
+// Tokens that are not part of a chain are returned unchanged.
+func MergeAuxiliaryChains(tkns []*Tkn) []*Tkn {
+	merged := make([]*Tkn, 0, len(tkns))
+	for i := 0; i < len(tkns); i++ {
+		head := tkns[i]
+		if !head.IsLexical || !isConjugable(head) {
+			merged = append(merged, head)
+			continue
+		}
+		chain := []*Tkn{head}
+		for j := i + 1; j < len(tkns); j++ {
+			if !attachesTo(chain[len(chain)-1], tkns[j]) {
+				break
+			}
+			chain = append(chain, tkns[j])
+		}
+		if len(chain) == 1 {
+			merged = append(merged, head)
+			continue
+		}
+		merged = append(merged, mergeChain(chain))
+		i += len(chain) - 1
+	}
+	return merged
+}
+
+// mergeChain builds the token that represents a whole auxiliary chain.
+func mergeChain(chain []*Tkn) *Tkn {
+	head := chain[0]
+	last := chain[len(chain)-1]
+
+	out := &Tkn{Tkn: head.Tkn}
+	out.Kanji = head.Kanji
+	out.BaseForm = head.BaseForm
+	out.Inflection = head.Inflection
+	out.IsKango, out.IsWago, out.IsGairaigo = head.IsKango, head.IsWago, head.IsGairaigo
+	out.Position.End = last.Position.End
+
+	var surface, kana, roman strings.Builder
+	for i, tkn := range chain {
+		surface.WriteString(tkn.Surface)
+		kana.WriteString(tkn.Kana)
+		// Subsidiary verbs are written as separate words in Hepburn
+		// (tabete shimatta), bound auxiliaries are not (tabemashita).
+		if i > 0 && subsidiaryVerbs[dictionaryForm(tkn)] {
+			roman.WriteString(" ")
+		}
+		roman.WriteString(tkn.Romanization)
+
+		if i > 0 {
+			out.Inflection.Polite = out.Inflection.Polite || tkn.Inflection.Polite
+			out.Inflection.Negative = out.Inflection.Negative || tkn.Inflection.Negative
+			if tkn.Inflection.Form != "" {
+				out.Inflection.Form = tkn.Inflection.Form
+			}
+		}
+	}
+	out.Surface = surface.String()
+	out.Normalized = out.Surface
+	out.Kana = kana.String()
+	out.Romanization = roman.String()
+	out.Components = chain
+	return out
+}
+
+// attachesTo reports whether next is an auxiliary that continues the chain
+// ending with prev. Subsidiary verbs require prev to end in a te-form.
+func attachesTo(prev, next *Tkn) bool {
+	if !next.IsLexical || prev.Position.End != next.Position.Start {
+		return false
+	}
+	base := dictionaryForm(next)
+	if boundAuxiliaries[base] || strings.Contains(next.PartOfSpeech, "aux") {
+		return true
+	}
+	return subsidiaryVerbs[base] && isTeForm(prev)
+}
+
+// isConjugable reports whether the token is a verb or an i-adjective,
+// i.e. something an auxiliary chain can start from.
+func isConjugable(tkn *Tkn) bool {
+	pos := tkn.PartOfSpeech
+	return strings.Contains(pos, "v1") || strings.Contains(pos, "v5") ||
+		strings.Contains(pos, "vs") || strings.Contains(pos, "vk") ||
+		strings.Contains(pos, "adj-i")
+}
+
+func isTeForm(tkn *Tkn) bool {
+	return strings.HasSuffix(tkn.Surface, "て") || strings.HasSuffix(tkn.Surface, "で")
+}
+
+// headword returns the written form of a dictionary form formatted as
+// "仕舞う 【しまう】", i.e. without its reading.
+func headword(base string) string {
+	if idx := strings.Index(base, "【"); idx >= 0 {
+		base = base[:idx]
+	}
+	return strings.TrimSpace(base)
+}
+
+// dictionaryForm returns the kana dictionary form of the token, extracting it
+// from readings formatted as "仕舞う 【しまう】" when needed.
+func dictionaryForm(tkn *Tkn) string {
+	base := tkn.BaseForm
+	if base == "" {
+		base = tkn.Surface
+	}
+	if start := strings.Index(base, "【"); start >= 0 {
+		if end := strings.Index(base, "】"); end > start {
+			base = base[start+len("【") : end]
+		}
+	}
+	return toHiragana(strings.TrimSpace(base))
+}
+
+// WithAuxiliaryChaining enables or disables the merging of auxiliary chains
+// (食べて+しまった → 食べてしまった) in the tokens returned by ichiran.
+// See MergeAuxiliaryChains. Disabled by default.
+func (m *Module) WithAuxiliaryChaining(enable bool) *Module {
+	for _, provider := range m.Providers {
+		if p, ok := provider.(*IchiranProvider); ok {
+			p.mergeAuxiliaries = enable
+		}
+	}
+	return m
+}
@@ -0,0 +1,92 @@
+package jpn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func auxTkn(surface, kana, romaji, pos, base string, start int) *Tkn {
+	tkn := &Tkn{Tkn: common.Tkn{Surface: surface, IsLexical: true, Romanization: romaji, PartOfSpeech: pos}}
+	tkn.Kana = kana
+	tkn.BaseForm = base
+	tkn.Position.Start = start
+	tkn.Position.End = start + len(surface)
+	return tkn
+}
+
+func TestMergeAuxiliaryChains(t *testing.T) {
+	tabete := auxTkn("食べて", "たべて", "tabete", "[v1,vt]", "食べる 【たべる】", 0)
+	shimatta := auxTkn("しまった", "しまった", "shimatta", "[v5u,aux-v]", "しまう", tabete.Position.End)
+	period := &Tkn{Tkn: common.Tkn{Surface: "。"}}
+	period.Position.Start = shimatta.Position.End
+
+	merged := MergeAuxiliaryChains([]*Tkn{tabete, shimatta, period})
+	assert.Len(t, merged, 2)
+	assert.Equal(t, "食べてしまった", merged[0].Surface)
+	assert.Equal(t, "たべてしまった", merged[0].Kana)
+	assert.Equal(t, "tabete shimatta", merged[0].Romanization)
+	assert.Equal(t, "食べる 【たべる】", merged[0].BaseForm)
+	assert.Equal(t, []*Tkn{tabete, shimatta}, merged[0].Components)
+	assert.Equal(t, period, merged[1])
+
+	// Nouns don't start chains
+	hon := auxTkn("本", "ほん", "hon", "[n]", "", 0)
+	da := auxTkn("だ", "だ", "da", "[cop]", "", hon.Position.End)
+	assert.Len(t, MergeAuxiliaryChains([]*Tkn{hon, da}), 2)
+}
+
+func TestTagRegister(t *testing.T) {
+	ikimasu := auxTkn("行きます", "いきます", "ikimasu", "[v5k-s,vi]", "行く 【いく】", 0)
+	ikimasu.Inflection.Polite = true
+	yo := auxTkn("よ", "よ", "yo", "[prt]", "", ikimasu.Position.End)
+	period := &Tkn{Tkn: common.Tkn{Surface: "。"}}
+	irassharu := auxTkn("いらっしゃる", "いらっしゃる", "irassharu", "[v5aru,vi]", "いらっしゃる", 0)
+	zo := auxTkn("ぞ", "ぞ", "zo", "[prt]", "", irassharu.Position.End)
+	iku := auxTkn("行く", "いく", "iku", "[v5k-s,vi]", "行く 【いく】", 0)
+	ne := auxTkn("ね", "ね", "ne", "[prt]", "", iku.Position.End)
+	yo2 := auxTkn("よ", "よ", "yo", "[prt]", "", 0)
+	hon := auxTkn("本", "ほん", "hon", "[n]", "", 0)
+
+	TagRegister([]*Tkn{ikimasu, yo, period, irassharu, zo, period, iku, ne, period, yo2, hon})
+	assert.Equal(t, RegisterPolite, ikimasu.Register)
+	assert.True(t, ikimasu.IsKeigo)
+	assert.True(t, yo.IsSentenceFinal)
+	assert.Equal(t, RegisterPolite, yo.Register)
+	assert.True(t, irassharu.IsHonorific)
+	assert.Equal(t, RegisterCasual, zo.Register)
+	assert.Empty(t, iku.Register)
+	assert.Equal(t, RegisterCasual, ne.Register)
+	// よ followed by a noun doesn't end the sentence
+	assert.False(t, yo2.IsSentenceFinal)
+	assert.Empty(t, hon.Register)
+}
+
+func TestHeadword(t *testing.T) {
+	assert.Equal(t, "仕舞う", headword("仕舞う 【しまう】"))
+	assert.Equal(t, "する", headword("する"))
+}
+
+func TestTagLevels(t *testing.T) {
+	gakkou := auxTkn("学校", "がっこう", "gakkō", "[n]", "", 0)
+	benkyou := auxTkn("勉強", "べんきょう", "benkyō", "[n,vs]", "", 0)
+	shiken := auxTkn("試験", "しけん", "shiken", "[n,vs]", "", 0)
+	kanji := auxTkn("憂鬱", "ゆううつ", "yūutsu", "[n]", "", 0)
+	wa := auxTkn("は", "は", "wa", "[prt]", "", 0)
+
+	TagLevels([]*Tkn{gakkou, benkyou, shiken, kanji, wa})
+	assert.Equal(t, 5, gakkou.JLPTLevel)
+	assert.Equal(t, 1, gakkou.KanjiGrade)
+	assert.Equal(t, 4, benkyou.JLPTLevel, "the hardest kanji gives the level")
+	assert.Equal(t, 3, benkyou.KanjiGrade)
+	assert.Equal(t, 4, shiken.KanjiGrade)
+	assert.Equal(t, LevelBeyond, kanji.JLPTLevel)
+	assert.Equal(t, LevelBeyond, kanji.KanjiGrade)
+	assert.Zero(t, wa.JLPTLevel)
+
+	summary := TknSliceWrapper{NativeSlice: []*Tkn{gakkou, wa, benkyou, shiken, kanji}}.LevelSummary()
+	assert.Equal(t, 5, summary.Words)
+	assert.Equal(t, 4, summary.KanjiWords)
+	assert.Equal(t, map[int]int{5: 1, 4: 2, LevelBeyond: 1}, summary.JLPT)
+}
@@ -13,10 +13,21 @@ import (
 	"github.com/k0kubun/pp"
 )
 
+// ichiranImages are the Docker images pulled by go-ichiran
+var ichiranImages = []string{
+	"ghcr.io/tassa-yoniso-manasi-karoto/langkit-ichiran-main:latest",
+	"ghcr.io/tassa-yoniso-manasi-karoto/langkit-ichiran-pg:latest",
+}
+
+// ichiranDownloadSize is the approximate size of the ichiran images
+const ichiranDownloadSize int64 = 2 << 30
+
 // IchiranProvider satisfies the Provider interface
 type IchiranProvider struct {
 	config			map[string]interface{}
 	progressCallback	common.ProgressCallback
+	ipa			bool // the "ipa" scheme outputs IPA derived from the kana instead of romaji
+	mergeAuxiliaries	bool // reattach auxiliary chains, see MergeAuxiliaryChains
 }
 
 
@@ -32,12 +43,48 @@ func (p *IchiranProvider) WithDownloadProgressCallback(callback common.DownloadP
 }
 
 
-// SaveConfig merely stores the config to apply after init
+// SaveConfig merely stores the config to apply after init.
+// It fails if the "target_gloss_lang" key requests glosses in another
+// language than English, the only one of ichiran.
 func (p *IchiranProvider) SaveConfig(cfg map[string]interface{}) error {
+	if _, err := common.TargetGlossLang(cfg, p.GlossLanguages()...); err != nil {
+		return err
+	}
 	p.config = cfg
 	return nil
 }
 
+// GlossLanguages implements common.GlossLanguageReporter.
+func (p *IchiranProvider) GlossLanguages() []string {
+	return []string{common.DefaultGlossLang}
+}
+
+// IchiranOptions are the typed options of IchiranProvider, see common.Configure.
+type IchiranOptions struct {
+	// IPA outputs an IPA transcription derived from the kana instead of romaji,
+	// as the "ipa" scheme does.
+	IPA bool
+	// MergeAuxiliaries reattaches auxiliary verb chains to their head,
+	// see MergeAuxiliaryChains.
+	MergeAuxiliaries bool
+	// TargetGlossLang is the language of the glosses. Ichiran only has
+	// English ones, any other language is rejected.
+	TargetGlossLang string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *IchiranProvider) ConfigureWith(opts IchiranOptions) error {
+	cfg := make(map[string]interface{})
+	if opts.IPA {
+		cfg["scheme"] = "ipa"
+	}
+	if opts.TargetGlossLang != "" {
+		cfg["target_gloss_lang"] = opts.TargetGlossLang
+	}
+	p.mergeAuxiliaries = opts.MergeAuxiliaries
+	return p.SaveConfig(cfg)
+}
+
 // InitWithContext initializes the provider with the given context
 func (p *IchiranProvider) InitWithContext(ctx context.Context) (err error) {
 	if err = ichiran.InitWithContext(ctx); err != nil {
@@ -68,6 +115,8 @@ func (p *IchiranProvider) InitRecreate(noCache bool) (err error) {
 
 
 func (p *IchiranProvider) applyConfig() error {
+	scheme, _ := p.config["scheme"].(string)
+	p.ipa = strings.ToLower(scheme) == "ipa"
 	return nil
 }
 
@@ -84,6 +133,17 @@ func (p *IchiranProvider) GetMaxQueryLen() int {
 	return 0
 }
 
+// ResourceVersions returns the digests of ichiran's Docker images,
+// implementing common.VersionReporter.
+func (p *IchiranProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	return common.DockerImageVersions(ctx, ichiranImages...)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// ichiran runs in Docker containers.
+func (p *IchiranProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
 
 // CloseWithContext closes the provider with the given context
 func (p *IchiranProvider) CloseWithContext(ctx context.Context) error {
@@ -138,7 +198,7 @@ func (p *IchiranProvider) processChunks(ctx context.Context, chunks []string) (c
 		}
 
 		// Build a string slice of lexical surfaces from jTokens
-		// so that we can call IntegrateProviderTokens to preserve filler
+		// so that we can call IntegrateProviderTokensV2 to preserve filler
 		lexSurfaces := make([]string, len(*jTokens))
 		for i, jt := range *jTokens {
 			lexSurfaces[i] = jt.Surface
@@ -147,9 +207,13 @@ func (p *IchiranProvider) processChunks(ctx context.Context, chunks []string) (c
 		chunk = RemoveJapanesePunctuation(chunk)
 
 		// 2) Combine lexical tokens w/ filler
-		integrated := common.IntegrateProviderTokens(chunk, lexSurfaces)
+		integrated, err := common.IntegrateProviderTokensWithContext(ctx, chunk, lexSurfaces)
+		if err != nil {
+			return nil, fmt.Errorf("ichiran: chunk %d: %w", idx, err)
+		}
 
 		// We'll iterate integrated tokens, filling morphological data for lexical ones
+		var chunkTkns []*Tkn
 		lexCount := 0
 		for _, tkn := range integrated {
 			if tkn.IsLexical {
@@ -162,15 +226,25 @@ func (p *IchiranProvider) processChunks(ctx context.Context, chunks []string) (c
 				// We also preserve the tkn positions if needed:
 				jpnTkn.Position.Start = tkn.Position.Start
 				jpnTkn.Position.End = tkn.Position.End
-
-				tsw.Append(jpnTkn)
+				chunkTkns = append(chunkTkns, jpnTkn)
 			} else {
 				// 4) Non-lexical filler => just preserve as is
 				fillerTkn := &Tkn{
 					Tkn: *tkn, // embed the original Tkn fields
 				}
-				tsw.Append(fillerTkn)
+				chunkTkns = append(chunkTkns, fillerTkn)
+			}
+		}
+
+		// 5) Optionally reattach the auxiliary chains ichiran split apart
+		if p.mergeAuxiliaries {
+			chunkTkns = MergeAuxiliaryChains(chunkTkns)
+		}
+		for _, jpnTkn := range chunkTkns {
+			if p.ipa && jpnTkn.IsLexical {
+				jpnTkn.Romanization = KanaToIPA(jpnTkn.Kana)
 			}
+			tsw.Append(jpnTkn)
 		}
 	}
 	return tsw, nil
@@ -179,15 +253,15 @@ func (p *IchiranProvider) processChunks(ctx context.Context, chunks []string) (c
 func init() {
 	IchiranEntry := common.ProviderEntry{
 		Provider:     &IchiranProvider{},
-		Capabilities: []string{"tokenization", "transliteration", "romaji"},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityTransliteration, common.CapabilityLemmatization},
 	}
 	err := common.Register(Lang, IchiranEntry)
 	if err != nil {
-		panic(fmt.Sprintf("failed to register ichiran provider: %w", err))
+		panic(fmt.Sprintf("failed to register ichiran provider: %v", err))
 	}
 	err = common.SetDefault(Lang, []common.ProviderEntry{IchiranEntry})
 	if err != nil {
-		panic(fmt.Sprintf("failed to set ichiran as default: %w", err))
+		panic(fmt.Sprintf("failed to set ichiran as default: %v", err))
 	}
 	
 	ichiranScheme := common.TranslitScheme{
@@ -195,10 +269,29 @@ func init() {
 		Description: "Hepburn romanization",
 		Providers: []string{"ichiran"},
 		NeedsDocker: true,
+		Requirements: common.SchemeRequirements{DownloadSize: ichiranDownloadSize},
 	}
 	if err := common.RegisterScheme(Lang, ichiranScheme); err != nil {
 		common.Log.Warn().Msg("Failed to register scheme " + ichiranScheme.Name)
 	}
+
+	ipaScheme := common.TranslitScheme{
+		Name: "ipa",
+		Description: "IPA transcription derived from ichiran's kana readings",
+		Providers: []string{"ichiran"},
+		NeedsDocker: true,
+		IPA: true,
+		Requirements: common.SchemeRequirements{DownloadSize: ichiranDownloadSize},
+	}
+	if err := common.RegisterScheme(Lang, ipaScheme); err != nil {
+		common.Log.Warn().Msg("Failed to register scheme " + ipaScheme.Name)
+	}
+
+	// Japanese text commonly mixes full-width Latin letters and digits and
+	// half-width katakana
+	if err := common.RegisterNormalization(Lang, common.Normalization{Form: common.NFC, FoldWidth: true}); err != nil {
+		panic(fmt.Sprintf("failed to register Japanese normalization: %v", err))
+	}
 }
 
 // RemoveJapanesePunctuation removes all occurrences of Japanese punctuation characters
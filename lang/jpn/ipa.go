@@ -0,0 +1,203 @@
+package jpn
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// kanaIPA maps single hiragana to a broad IPA transcription.
+var kanaIPA = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "ɯ", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "kʲi", 'く': "kɯ", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gʲi", 'ぐ': "gɯ", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "ɕi", 'す': "sɯ", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "dʑi", 'ず': "zɯ", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "tɕi", 'つ': "tsɯ", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "dʑi", 'づ': "zɯ", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ɲi", 'ぬ': "nɯ", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "çi", 'ふ': "ɸɯ", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bʲi", 'ぶ': "bɯ", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pʲi", 'ぷ': "pɯ", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mʲi", 'む': "mɯ", 'め': "me", 'も': "mo",
+	'や': "ja", 'ゆ': "jɯ", 'よ': "jo",
+	'ら': "ɾa", 'り': "ɾʲi", 'る': "ɾɯ", 'れ': "ɾe", 'ろ': "ɾo",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ゔ': "vɯ",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "ɯ", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ja", 'ゅ': "jɯ", 'ょ': "jo", 'ゎ': "wa",
+}
+
+// palatalIPA maps the i-row kana that combine with a small ゃ/ゅ/ょ/ぇ to
+// the onset of the resulting mora, e.g. き+ゃ → kʲa.
+var palatalIPA = map[rune]string{
+	'き': "kʲ", 'ぎ': "gʲ", 'し': "ɕ", 'じ': "dʑ", 'ち': "tɕ", 'ぢ': "dʑ",
+	'に': "ɲ", 'ひ': "ç", 'び': "bʲ", 'ぴ': "pʲ", 'み': "mʲ", 'り': "ɾʲ",
+}
+
+// smallVowelIPA maps the small kana that modify the vowel of the preceding mora.
+var smallVowelIPA = map[rune]string{
+	'ゃ': "a", 'ゅ': "ɯ", 'ょ': "o", 'ぁ': "a", 'ぃ': "i", 'ぅ': "ɯ", 'ぇ': "e", 'ぉ': "o",
+}
+
+// foreignOnsetIPA maps the onsets used with small vowels in loanwords
+// (ファ, ティ, ウィ...) when they don't follow the palatal pattern.
+var foreignOnsetIPA = map[rune]string{
+	'ふ': "ɸ", 'て': "t", 'で': "d", 'と': "t", 'ど': "d", 'う': "w",
+	'つ': "ts", 'ゔ': "v", 'く': "kw", 'ぐ': "gw",
+}
+
+// particleIPA holds the particles whose pronunciation differs from their kana.
+var particleIPA = map[string]string{
+	"は": "wa",
+	"へ": "e",
+	"を": "o",
+}
+
+// KanaToIPA converts a kana string (hiragana and/or katakana) to a broad IPA
+// transcription. It handles yōon (きゃ), sokuon (っ), the moraic nasal (ん)
+// assimilation, long vowels (ー, おう, えい) and the particles は/へ/を when
+// they make up the whole input. Characters other than kana are kept as is.
+func KanaToIPA(kana string) string {
+	hira := toHiragana(kana)
+	if ipa, ok := particleIPA[hira]; ok {
+		return ipa
+	}
+
+	// First pass: split into morae transcribed in IPA
+	var morae []string
+	rs := []rune(hira)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		var next rune
+		if i+1 < len(rs) {
+			next = rs[i+1]
+		}
+
+		if v, ok := smallVowelIPA[next]; ok {
+			if onset, ok := palatalIPA[r]; ok && next != 'ぁ' && next != 'ぃ' && next != 'ぅ' && next != 'ぉ' {
+				morae = append(morae, onset+v)
+				i++
+				continue
+			}
+			if onset, ok := foreignOnsetIPA[r]; ok && next != 'ゃ' && next != 'ゅ' && next != 'ょ' {
+				morae = append(morae, onset+v)
+				i++
+				continue
+			}
+		}
+
+		switch r {
+		case 'っ', 'ん', 'ー':
+			morae = append(morae, string(r))
+		default:
+			if ipa, ok := kanaIPA[r]; ok {
+				morae = append(morae, ipa)
+			} else {
+				morae = append(morae, string(r))
+			}
+		}
+	}
+
+	// Second pass: resolve the morae whose value depends on their neighbours
+	var b strings.Builder
+	lastVowel := ""
+	for i, m := range morae {
+		next := ""
+		if i+1 < len(morae) {
+			next = morae[i+1]
+		}
+		switch {
+		case m == "っ":
+			b.WriteString(geminate(next))
+			lastVowel = ""
+			continue
+		case m == "ん":
+			b.WriteString(moraicNasal(next))
+			lastVowel = ""
+			continue
+		case m == "ー":
+			if lastVowel != "" {
+				b.WriteString("ː")
+			}
+			continue
+		case lastVowel != "" && isLengthening(lastVowel, m):
+			b.WriteString("ː")
+			lastVowel = ""
+			continue
+		}
+		b.WriteString(m)
+		lastVowel = finalVowel(m)
+	}
+	return b.String()
+}
+
+// toHiragana converts the katakana of a string to hiragana.
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+// geminate returns the first consonant of the given mora for a preceding っ,
+// or a glottal stop if no consonant follows.
+func geminate(next string) string {
+	switch {
+	case strings.HasPrefix(next, "tɕ"), strings.HasPrefix(next, "ts"):
+		return "t"
+	case strings.HasPrefix(next, "dʑ"):
+		return "d"
+	}
+	r, _ := utf8.DecodeRuneInString(next)
+	if next == "" || isVowel(string(r)) {
+		return "ʔ"
+	}
+	return string(r)
+}
+
+// moraicNasal returns the realization of ん before the given mora.
+func moraicNasal(next string) string {
+	if next == "" {
+		return "ɴ"
+	}
+	r, _ := utf8.DecodeRuneInString(next)
+	switch {
+	case strings.ContainsRune("pbm", r):
+		return "m"
+	case strings.ContainsRune("kg", r):
+		return "ŋ"
+	case strings.HasPrefix(next, "tɕ"), strings.HasPrefix(next, "dʑ"), r == 'ɲ':
+		return "ɲ"
+	case strings.ContainsRune("tdnɾz", r):
+		return "n"
+	}
+	return "ɰ̃"
+}
+
+// isLengthening reports whether a bare vowel mora lengthens the preceding vowel,
+// as in かあ, おう or えい.
+func isLengthening(prevVowel, mora string) bool {
+	if !isVowel(mora) {
+		return false
+	}
+	return mora == prevVowel || (prevVowel == "o" && mora == "ɯ") || (prevVowel == "e" && mora == "i")
+}
+
+// finalVowel returns the vowel a mora ends with, if any.
+func finalVowel(mora string) string {
+	for _, v := range []string{"a", "i", "ɯ", "e", "o"} {
+		if strings.HasSuffix(mora, v) {
+			return v
+		}
+	}
+	return ""
+}
+
+func isVowel(s string) bool {
+	switch s {
+	case "a", "i", "ɯ", "e", "o":
+		return true
+	}
+	return false
+}
@@ -42,54 +42,54 @@ type Tkn struct {
 	IsHumble    bool   // 謙譲語 (Humble form)
 	IsKeigo     bool   // General keigo flag
 	Register    string // Language register (formal, casual, etc.)
+	// IsSentenceFinal marks sentence-final particles (終助詞: よ, ね, ぞ...),
+	// see TagRegister
+	IsSentenceFinal bool
+
+	// Levels of the hardest kanji of the token, see TagLevels
+	JLPTLevel  int // 5 for N5 to 1 for N1
+	KanjiGrade int // jōyō grade, 1 to 6 in elementary school
+
+	// Components holds the original tokens of an auxiliary chain
+	// merged by MergeAuxiliaryChains (食べて+しまった → 食べてしまった)
+	Components []*Tkn
 }
 
 
 
-// TODO Maybe automatically return Katakana or Hiragana as fit
-/*
-// Returns a tokenized string of Hiragana readings
-func (m *Module) Kana(input string) (string, error) {
-	if m.Transliterator == nil && m.ProviderType != common.CombinedMode {
-		return "", fmt.Errorf("Kana requires either a transliterator or combined provider (got %s)", m.ProviderType)
-	}
-	tkns, err := m.Tokens(input)
-	if err != nil {
-		return "", err
-	}
-	return tkns.Kana(), nil
+// KanaParts returns the hiragana reading of each token of the input,
+// see TknSliceWrapper.KanaPartsWithOptions.
+func (m *Module) KanaParts(input string) ([]string, error) {
+	return m.KanaPartsWithOptions(input, KanaOptions{})
 }
 
-// Returns a slice of string of Hiragana readings
-func (m *Module) KanaParts(input string) ([]string, error) {
-	if m.Transliterator == nil && m.ProviderType != common.CombinedMode {
-		return []string{}, fmt.Errorf("KanaParts requires either a transliterator or combined provider (got %s)", m.ProviderType)
-	}
-	tkns, err := m.LexicalTokens(input)
+// KanaPartsWithOptions returns the kana reading of each token of the input,
+// see TknSliceWrapper.KanaPartsWithOptions.
+func (m *Module) KanaPartsWithOptions(input string, opts KanaOptions) ([]string, error) {
+	tkns, err := m.Tokens(input)
 	if err != nil {
-		return []string{}, err
+		return nil, err
 	}
-	return tkns.KanaParts(), nil
-}*/
-
+	return tkns.KanaPartsWithOptions(opts)
+}
 
 func (wrapper TknSliceWrapper) Kana() string {
 	return strings.Join(wrapper.KanaParts(), " ")
 }
 
+// KanaParts returns the hiragana reading of each token, falling back to the
+// surface of the tokens without one. See KanaPartsWithOptions.
 func (wrapper TknSliceWrapper) KanaParts() []string {
-	var parts []string
-	for _, token := range wrapper.NativeSlice {
-		if token.Tkn.IsLexical && token.Kana != "" {
-			parts = append(parts, token.Kana)
-		} else {
-			parts = append(parts, token.Tkn.Surface)
-		}
-	}
+	parts, _ := wrapper.KanaPartsWithOptions(KanaOptions{})
 	return parts
 }
 
 
+// ichiranHalfScore is the ichiran score given a confidence of 0.5: ichiran
+// scores grow with the length and the frequency of the words it finds, a
+// common particle scoring about 10 and a long compound several hundreds.
+const ichiranHalfScore = 50
+
 // ToJapaneseToken converts an JSONToken to a *Tkn
 func ToJapaneseToken(it *ichiran.JSONToken) *Tkn {
 	jt := &Tkn{ Tkn: common.Tkn {
@@ -109,7 +109,7 @@ func ToJapaneseToken(it *ichiran.JSONToken) *Tkn {
 	// Continue with Japanese-specific token processing
 	jt.Normalized = it.Surface // Could be enhanced with actual normalization
 	jt.Position.Start = it.Seq
-	jt.Confidence = float64(it.Score)
+	jt.Confidence = common.ScoreConfidence(float64(it.Score), ichiranHalfScore)
 	jt.Language = "jpn"
 	jt.Script = "Jpan"
 	jt.Romanization = it.Romaji
@@ -119,6 +119,7 @@ func ToJapaneseToken(it *ichiran.JSONToken) *Tkn {
 	if len(it.Gloss) > 0 {
 		// Set part of speech from first gloss FIXME
 		jt.PartOfSpeech = it.Gloss[0].Pos
+		jt.UPOS = common.ToUPOS("ichiran", jt.PartOfSpeech)
 
 		// Convert Ichiran glosses to common glosses
 		jt.Glosses = make([]common.Gloss, len(it.Gloss))
@@ -127,6 +128,7 @@ func ToJapaneseToken(it *ichiran.JSONToken) *Tkn {
 				PartOfSpeech: g.Pos,
 				Definition:   g.Gloss,
 				Info:        g.Info,
+				Language:     common.DefaultGlossLang,
 			}
 		}
 	}
@@ -153,6 +155,17 @@ func ToJapaneseToken(it *ichiran.JSONToken) *Tkn {
 		}
 	}
 
+	// Uninflected words are their own dictionary form
+	jt.Lemma = jt.Surface
+	if jt.BaseForm != "" {
+		jt.Lemma = headword(jt.BaseForm)
+	}
+
+	// Ichiran already returns some auxiliary chains as a single compound token
+	for i := range it.Components {
+		jt.Components = append(jt.Components, ToJapaneseToken(&it.Components[i]))
+	}
+
 	// Store original Ichiran data in metadata
 	jt.Metadata["ichiran"] = map[string]interface{}{
 		"score":       it.Score,
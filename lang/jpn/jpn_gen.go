@@ -3,6 +3,7 @@
 package jpn
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -34,7 +35,8 @@ type TknSliceWrapper struct {
 // Tokens returns the token slice wrapper without filtering out non-lexical tokens.
 func (m *Module) Tokens(input string) (*TknSliceWrapper, error) {
 	tsw, err := m.Module.Tokens(input)
-	if err != nil {
+	var partial *common.PartialResultsError
+	if err != nil && !errors.As(err, &partial) {
 		return &TknSliceWrapper{}, fmt.Errorf("lang/%s: %w", Lang, err)
 	}
 	customTsw, ok := tsw.(*TknSliceWrapper)
@@ -47,6 +49,9 @@ func (m *Module) Tokens(input string) (*TknSliceWrapper, error) {
 		return &TknSliceWrapper{}, fmt.Errorf("failed assertion of []%s.Tkn: %w", Lang, err)
 	}
 	customTsw.NativeSlice = tkns
+	if partial != nil {
+		return customTsw, fmt.Errorf("lang/%s: %w", Lang, partial)
+	}
 	return customTsw, nil
 }
 
@@ -55,17 +60,17 @@ func (m *Module) Tokens(input string) (*TknSliceWrapper, error) {
 // thereby avoiding re‑processing via additional module methods.
 func (m *Module) LexicalTokens(input string) (*TknSliceWrapper, error) {
 	raw, err := m.Tokens(input)
-	if err != nil {
+	if err != nil && !errors.As(err, new(*common.PartialResultsError)) {
 		return &TknSliceWrapper{}, fmt.Errorf("lang/%s: %w", Lang, err)
 	}
-	return raw.ToLexicalTokens(), nil
+	return raw.ToLexicalTokens(), err
 }
 
 // Filter returns a new TknSliceWrapper containing only tokens that have lexical content.
 // It processes the Tokens output without invoking further module-level processing.
 func (w *TknSliceWrapper) ToLexicalTokens() *TknSliceWrapper {
 	filtered := &TknSliceWrapper{
-		TknSliceWrapper: common.TknSliceWrapper{},
+		TknSliceWrapper: common.TknSliceWrapper{Chunks: w.Chunks, Versions: w.Versions},
 		NativeSlice: make([]*Tkn, 0, len(w.NativeSlice)),
 	}
 	// Iterate over the tokens using the common interface's methods.
@@ -93,3 +98,18 @@ func assertLangSpecificTokens(anyTokens []common.AnyToken) ([]*Tkn, error) {
 	return tokens, nil
 }
 
+func init() {
+	if err := common.RegisterTokenConverter(Lang, convertTokens); err != nil {
+		panic(fmt.Sprintf("failed to register the token converter: %v", err))
+	}
+}
+
+// convertTokens wraps common tokens in a TknSliceWrapper of Tkn.
+func convertTokens(tokens []*common.Tkn) common.AnyTokenSliceWrapper {
+	tsw := &TknSliceWrapper{}
+	for _, token := range tokens {
+		tsw.Append(&Tkn{Tkn: *token})
+	}
+	return tsw
+}
+
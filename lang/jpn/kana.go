@@ -0,0 +1,290 @@
+package jpn
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// KanaProvider is a pure Go alternative to ichiran that doesn't need Docker.
+// It segments text by script, reads kanji words from a small built-in lexicon
+// (see kanjiLexicon) and romanizes kana with KanaToRomaji. Kanji it doesn't
+// know are left as is. Its output is far less accurate than ichiran's and it
+// provides no morphological data, but it works offline and starts instantly.
+type KanaProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewKanaProvider creates a new kana provider
+func NewKanaProvider() *KanaProvider {
+	return &KanaProvider{}
+}
+
+func (p *KanaProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *KanaProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the kana provider doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration
+func (p *KanaProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+func (p *KanaProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *KanaProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *KanaProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *KanaProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *KanaProvider) Name() string {
+	return "kana"
+}
+
+func (p *KanaProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.CombinedMode, common.ReverseTransliteratorMode}
+}
+
+func (p *KanaProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+func (p *KanaProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *KanaProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// ProcessFlowController processes input with the given context and mode
+func (p *KanaProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("kana: empty input was passed to processor")
+	}
+	if mode != common.CombinedMode && mode != common.ReverseTransliteratorMode {
+		return nil, fmt.Errorf("kana: unsupported operating mode %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("kana: not implemented for pre-tokenized data (we are combined)")
+	}
+	if mode == common.ReverseTransliteratorMode {
+		return p.toKana(ctx, input)
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("kana: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		for _, tkn := range SegmentKana(chunk) {
+			tsw.Append(tkn)
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+// toKana converts the raw chunks of the input from Hepburn to hiragana, one
+// token per chunk.
+func (p *KanaProvider) toKana(ctx context.Context, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("kana: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tsw.Append(&Tkn{Tkn: common.Tkn{
+			Surface:      RomajiToKana(chunk),
+			Romanization: chunk,
+			IsLexical:    true,
+		}})
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+// kanaParticles are the particles split off the start of a hiragana run
+// following a kanji or katakana word.
+var kanaParticles = map[rune]bool{
+	'は': true, 'が': true, 'を': true, 'に': true, 'で': true, 'と': true,
+	'も': true, 'へ': true, 'の': true, 'や': true,
+}
+
+type kanaScript int
+
+const (
+	scriptOther kanaScript = iota
+	scriptHan
+	scriptHiragana
+	scriptKatakana
+)
+
+func scriptOf(r rune) kanaScript {
+	switch {
+	case unicode.Is(unicode.Han, r) || r == '々':
+		return scriptHan
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return scriptKatakana
+	}
+	return scriptOther
+}
+
+// SegmentKana splits Japanese text into tokens by script: kanji words found
+// in the built-in lexicon (with their okurigana), runs of unknown kanji, runs
+// of katakana, runs of hiragana (with leading particles split off) and
+// non-lexical filler. Lexical tokens get their kana reading and Hepburn
+// romanization when they can be determined.
+func SegmentKana(text string) []*Tkn {
+	var tkns []*Tkn
+	prevScript := scriptOther
+	for pos := 0; pos < len(text); {
+		r, _ := utf8.DecodeRuneInString(text[pos:])
+		script := scriptOf(r)
+
+		var surface, reading string
+		switch script {
+		case scriptHan:
+			if surface, reading = lookupKanjiLexicon(text[pos:]); surface == "" {
+				surface = scriptRun(text[pos:], scriptHan)
+			} else if strings.HasSuffix(surface, "っ") || strings.HasSuffix(surface, "ん") {
+				// Keep the te/ta ending of 行っ+た, 読ん+で with its stem
+				if next, _ := utf8.DecodeRuneInString(text[pos+len(surface):]); scriptOf(next) == scriptHiragana {
+					surface += string(next)
+					reading += string(next)
+				}
+			}
+		case scriptKatakana:
+			surface = scriptRun(text[pos:], scriptKatakana)
+			reading = surface
+		case scriptHiragana:
+			if kanaParticles[r] && (prevScript == scriptHan || prevScript == scriptKatakana) {
+				surface = string(r)
+			} else {
+				surface = scriptRun(text[pos:], scriptHiragana)
+			}
+			reading = surface
+		default:
+			surface = otherRun(text[pos:])
+		}
+
+		tkn := &Tkn{Tkn: common.Tkn{Surface: surface, IsLexical: script != scriptOther}}
+		tkn.Position.Start = pos
+		tkn.Position.End = pos + len(surface)
+		if tkn.IsLexical {
+			tkn.Normalized = surface
+			tkn.Language = Lang
+			tkn.Script = "Jpan"
+			if reading != "" {
+				tkn.Kana = reading
+				tkn.Hiragana = toHiragana(reading)
+				tkn.Romanization = KanaToRomaji(reading)
+			}
+			if script == scriptKatakana {
+				tkn.Katakana = surface
+				tkn.IsGairaigo = true
+			}
+		}
+		tkns = append(tkns, tkn)
+		prevScript = script
+		pos += len(surface)
+	}
+	return tkns
+}
+
+// lookupKanjiLexicon returns the longest lexicon entry text starts with.
+func lookupKanjiLexicon(text string) (surface, reading string) {
+	rs := []rune(text)
+	for n := min(kanjiLexiconMaxLen, len(rs)); n > 0; n-- {
+		candidate := string(rs[:n])
+		if r, ok := kanjiLexicon[candidate]; ok {
+			return candidate, r
+		}
+	}
+	return "", ""
+}
+
+// scriptRun returns the prefix of text made of characters of the given script.
+func scriptRun(text string, script kanaScript) string {
+	end := len(text)
+	for i, r := range text {
+		if scriptOf(r) != script {
+			end = i
+			break
+		}
+	}
+	return text[:end]
+}
+
+// otherRun returns the prefix of text made of non-Japanese characters.
+func otherRun(text string) string {
+	end := len(text)
+	for i, r := range text {
+		if scriptOf(r) != scriptOther {
+			end = i
+			break
+		}
+	}
+	return text[:end]
+}
+
+// UseKanaProviderAsDefault makes the Docker-free kana provider the default
+// provider for Japanese, so that DefaultModule("jpn") works offline.
+// It is already used automatically on platforms without Docker.
+func UseKanaProviderAsDefault() error {
+	return common.SetDefault(Lang, []common.ProviderEntry{kanaEntry})
+}
+
+var kanaEntry = common.ProviderEntry{
+	Provider:     NewKanaProvider(),
+	Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityTransliteration},
+}
+
+func init() {
+	if err := common.Register(Lang, kanaEntry); err != nil {
+		panic(fmt.Sprintf("failed to register kana provider: %v", err))
+	}
+	if err := common.SetPlatformFallback(Lang, []common.ProviderEntry{kanaEntry}); err != nil {
+		panic(fmt.Sprintf("failed to set kana provider as fallback: %v", err))
+	}
+
+	kanaScheme := common.TranslitScheme{
+		Name:        "kana-hepburn",
+		Description: "Hepburn romanization of kana with a built-in kanji lexicon (no Docker, lower accuracy)",
+		Providers:   []string{"kana"},
+	}
+	if err := common.RegisterScheme(Lang, kanaScheme); err != nil {
+		common.Log.Warn().Msg("Failed to register scheme " + kanaScheme.Name)
+	}
+	if err := common.RegisterReverseScheme(Lang, kanaScheme); err != nil {
+		common.Log.Warn().Msg("Failed to register reverse scheme " + kanaScheme.Name)
+	}
+}
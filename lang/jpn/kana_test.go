@@ -0,0 +1,244 @@
+package jpn
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common/testkit"
+)
+
+func TestKanaToRomaji(t *testing.T) {
+	cases := map[string]string{
+		"しんぶん":  "shinbun",
+		"がっこう":  "gakkō",
+		"まっちゃ":  "matcha",
+		"きょうと":  "kyōto",
+		"コーヒー":  "kōhī",
+		"ほんや":   "hon'ya",
+		"パーティー": "pātī",
+		"は":     "wa",
+		"ちょっと":  "chotto",
+	}
+	for kana, expected := range cases {
+		assert.Equal(t, expected, KanaToRomaji(kana), kana)
+	}
+}
+
+func TestRomajiToKana(t *testing.T) {
+	cases := map[string]string{
+		"shinbun":    "しんぶん",
+		"gakkō":      "がっこう",
+		"matcha":     "まっちゃ",
+		"Kyōto":      "きょうと",
+		"hon'ya":     "ほんや",
+		"chotto":     "ちょっと",
+		"konnichiwa": "こんにちわ",
+		"pātī":       "ぱあてぃい",
+	}
+	for romaji, expected := range cases {
+		assert.Equal(t, expected, RomajiToKana(romaji), romaji)
+	}
+	for _, kana := range []string{"しんぶん", "がっこう", "きょうと", "ほんや", "ちょっと", "まっちゃ"} {
+		assert.Equal(t, kana, RomajiToKana(KanaToRomaji(kana)), kana)
+	}
+}
+
+func TestToNative(t *testing.T) {
+	m, err := common.GetReverseModule(Lang, "kana-hepburn")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	native, err := m.ToNative("watashi wa gakkō ni ikimasu.")
+	require.NoError(t, err)
+	assert.Equal(t, "わたし わ がっこう に いきます.", native)
+
+	forward, err := common.GetSchemeModule(Lang, "kana-hepburn")
+	require.NoError(t, err)
+	_, err = forward.ToNative("gakkō")
+	assert.ErrorIs(t, err, common.ErrReverseUnsupported)
+}
+
+func TestSegmentKana(t *testing.T) {
+	var romaji []string
+	for _, tkn := range SegmentKana("私は東京でコーヒーを飲みました。昨日、学校に行った。") {
+		if tkn.IsLexical {
+			romaji = append(romaji, tkn.Romanization)
+		}
+	}
+	assert.Equal(t, []string{"watashi", "wa", "tōkyō", "de", "kōhī", "o", "nomi", "mashita", "kinō", "gakkō", "ni", "itta"}, romaji)
+}
+
+func TestKanaPlatformFallback(t *testing.T) {
+	common.SetPlatform(&common.Platform{OS: "windows", Arch: "arm64"})
+	defer common.SetPlatform(nil)
+
+	m, err := common.DefaultModule(Lang)
+	require.NoError(t, err)
+	assert.Equal(t, "kana", m.ProviderNames())
+
+	roman, err := m.Roman("日本語を勉強しています")
+	require.NoError(t, err)
+	assert.Contains(t, roman, "nihongo")
+}
+
+const testJMdict = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE JMdict [
+<!ENTITY n "noun (common) (futsuumeishi)">
+]>
+<JMdict>
+<entry>
+<ent_seq>1206730</ent_seq>
+<k_ele><keb>学校</keb></k_ele>
+<r_ele><reb>がっこう</reb></r_ele>
+<sense>
+<pos>&n;</pos>
+<gloss>school</gloss>
+<gloss xml:lang="fre">école</gloss>
+</sense>
+</entry>
+</JMdict>`
+
+func TestLoadJMdict(t *testing.T) {
+	jmdict, err := common.LoadJMdict(strings.NewReader(testJMdict), "")
+	require.NoError(t, err)
+
+	expected := []common.Gloss{{PartOfSpeech: "n", Definition: "school", Language: "eng"}}
+	assert.Equal(t, expected, jmdict.Lookup("学校", Lang))
+	assert.Equal(t, expected, jmdict.Lookup("がっこう", Lang))
+
+	tkns := SegmentKana("がっこう")
+	tsw := &TknSliceWrapper{}
+	tsw.Append(tkns[0])
+	_, err = common.NewGlossEnricher(jmdict).ProcessFlowController(context.Background(), common.EnricherMode, tsw)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tkns[0].Glosses)
+}
+
+func TestTargetGlossLang(t *testing.T) {
+	jmdict, err := common.LoadJMdict(strings.NewReader(testJMdict), "*")
+	require.NoError(t, err)
+	assert.Len(t, jmdict.Lookup("学校", Lang), 2)
+
+	enricher := common.NewGlossEnricher(jmdict)
+	enricher.TargetGlossLang = "fra"
+	tkns := SegmentKana("がっこう")
+	tsw := &TknSliceWrapper{}
+	tsw.Append(tkns[0])
+	_, err = enricher.ProcessFlowController(context.Background(), common.EnricherMode, tsw)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Gloss{{PartOfSpeech: "n", Definition: "école", Language: "fra"}}, tkns[0].Glosses)
+
+	ichiran := &IchiranProvider{}
+	assert.Equal(t, []string{"eng"}, common.GlossLanguagesOf(ichiran))
+	assert.NoError(t, common.Configure(ichiran, IchiranOptions{TargetGlossLang: "en"}))
+	assert.ErrorIs(t, common.Configure(ichiran, IchiranOptions{TargetGlossLang: "fr"}), common.ErrGlossLangUnsupported)
+}
+
+func TestSpellInteger(t *testing.T) {
+	cases := map[string]string{
+		"0":         "rei",
+		"2024":      "ni sen ni juu yon",
+		"300":       "san byaku",
+		"8600":      "hassen roppyaku",
+		"10000":     "ichi man",
+		"10000000":  "issen man",
+		"110000001": "ichi oku issen man ichi",
+	}
+	for digits, expected := range cases {
+		spoken, ok := SpellInteger(digits)
+		assert.True(t, ok, digits)
+		assert.Equal(t, expected, spoken, digits)
+	}
+}
+
+func TestNumberExpansion(t *testing.T) {
+	m, err := common.NewModule(Lang, "kana")
+	require.NoError(t, err)
+	(&Module{Module: m}).WithNumberExpansion()
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	roman, err := m.Roman("２０２４年に3.5キロ")
+	require.NoError(t, err)
+	assert.Contains(t, roman, "ni sen ni juu yon")
+	assert.Contains(t, roman, "san ten go")
+}
+
+func TestNormalization(t *testing.T) {
+	n := common.GetNormalization(Lang)
+	assert.Equal(t, "ガイド ABC123、", n.Apply("ｶﾞｲﾄﾞ ＡＢＣ１２３、"))
+	assert.Equal(t, "すごーい!!!", common.Normalization{MaxRepeat: 3}.Apply("すごーい!!!!!!"))
+	assert.Equal(t, "2000000", common.Normalization{MaxRepeat: 3}.Apply("2000000"))
+}
+
+func TestKanaPartsWithOptions(t *testing.T) {
+	tkn := func(surface, kana string, lexical bool) *Tkn {
+		return &Tkn{Tkn: common.Tkn{Surface: surface, IsLexical: lexical}, Kana: kana}
+	}
+	wrapper := TknSliceWrapper{NativeSlice: []*Tkn{
+		tkn("珈琲", "コーヒー", true),
+		tkn("を", "", true),
+		tkn("ティー", "", true),
+		tkn("、", "", false),
+		tkn("麤", "", true), // unknown kanji
+	}}
+
+	assert.Equal(t, []string{"こーひー", "を", "てぃー", "、", "麤"}, wrapper.KanaParts())
+
+	parts, err := wrapper.KanaPartsWithOptions(KanaOptions{ExpandLongVowels: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"こおひい", "を", "てぃい", "、", "麤"}, parts)
+
+	parts, err = wrapper.KanaPartsWithOptions(KanaOptions{Script: ReadingKatakana})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"コーヒー", "ヲ", "ティー", "、", "麤"}, parts)
+
+	_, err = wrapper.KanaPartsWithOptions(KanaOptions{Strict: true})
+	assert.ErrorIs(t, err, ErrMissingReading)
+	assert.Equal(t, "ちゃあ", expandLongVowels("ちゃー"))
+	assert.Equal(t, "っー", expandLongVowels("っー"))
+}
+
+func TestKanaCorpus(t *testing.T) {
+	m, err := common.GetSchemeModule(Lang, "kana-hepburn")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	testkit.AssertRomanization(t, m, "testdata/kana-hepburn.yaml")
+}
+
+func TestMergeWrappers(t *testing.T) {
+	m, err := common.GetSchemeModule(Lang, "kana-hepburn")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+
+	inputs := []string{"すしをたべた。 ", "みずをのむ。"}
+	var wrappers []common.AnyTokenSliceWrapper
+	for _, input := range inputs {
+		tsw, err := m.Tokens(input)
+		require.NoError(t, err)
+		wrappers = append(wrappers, tsw)
+	}
+	firstLen := wrappers[0].Len()
+
+	merged := common.MergeWrappers(wrappers[0], nil, wrappers[1])
+	native, ok := merged.(*TknSliceWrapper)
+	require.True(t, ok, "the language-specific type is preserved")
+	require.Len(t, native.NativeSlice, merged.Len())
+	assert.Equal(t, strings.Join(inputs, ""), native.ReconstructOriginal())
+
+	second := native.NativeSlice[firstLen]
+	assert.Equal(t, len(inputs[0]), second.Position.Start)
+	assert.Equal(t, 1, second.Position.Sentence)
+	chunk, ok := native.ChunkOf(second)
+	require.True(t, ok)
+	assert.Equal(t, "みずをのむ。", chunk.Text(strings.Join(inputs, "")))
+
+	// wrappers of different types fall back to the common type
+	_, ok = common.MergeWrappers(wrappers[0], &common.TknSliceWrapper{}).(*common.TknSliceWrapper)
+	assert.True(t, ok)
+}
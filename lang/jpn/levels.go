@@ -0,0 +1,154 @@
+package jpn
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+//go:embed data/kanji_levels.tsv
+var kanjiLevelsTSV string
+
+// LevelBeyond is the JLPTLevel and KanjiGrade of the tokens with a kanji
+// missing from the tables of data/kanji_levels.tsv, i.e. above N4 or taught
+// after the second grade of elementary school.
+const LevelBeyond = -1
+
+type kanjiLevel struct {
+	grade, jlpt int
+}
+
+var (
+	kanjiLevelsOnce sync.Once
+	kanjiLevels     map[rune]kanjiLevel
+)
+
+func loadKanjiLevels() map[rune]kanjiLevel {
+	kanjiLevelsOnce.Do(func() {
+		kanjiLevels = make(map[rune]kanjiLevel)
+		scanner := bufio.NewScanner(strings.NewReader(kanjiLevelsTSV))
+		for lineNum := 1; scanner.Scan(); lineNum++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.Split(line, "\t")
+			if len(fields) != 3 {
+				common.Log.Error().Int("line", lineNum).Msg("jpn: built-in kanji levels are invalid")
+				continue
+			}
+			grade, err1 := strconv.Atoi(fields[1])
+			jlpt, err2 := strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil {
+				common.Log.Error().Int("line", lineNum).Msg("jpn: built-in kanji levels are invalid")
+				continue
+			}
+			r, _ := utf8.DecodeRuneInString(fields[0])
+			kanjiLevels[r] = kanjiLevel{grade: grade, jlpt: jlpt}
+		}
+	})
+	return kanjiLevels
+}
+
+// KanjiLevels returns the jōyō grade (1 to 6) and the JLPT level (5 for N5,
+// 4 for N4, 0 above) of a kanji, if it is in the built-in tables.
+func KanjiLevels(kanji rune) (grade, jlpt int, ok bool) {
+	level, ok := loadKanjiLevels()[kanji]
+	return level.grade, level.jlpt, ok
+}
+
+// TagLevels sets the JLPTLevel and KanjiGrade of the lexical tokens to those
+// of their hardest kanji: the lowest JLPT level and the highest grade.
+// Tokens with a kanji missing from the tables, or without a JLPT level, get
+// LevelBeyond; tokens without kanji are left at 0.
+func TagLevels(tkns []*Tkn) {
+	for _, tkn := range tkns {
+		if tkn.IsLexical {
+			tkn.JLPTLevel, tkn.KanjiGrade = tokenLevels(tkn.Surface)
+		}
+	}
+}
+
+func tokenLevels(surface string) (jlpt, grade int) {
+	for _, r := range surface {
+		if !unicode.Is(unicode.Han, r) {
+			continue
+		}
+		g, j, ok := KanjiLevels(r)
+		if !ok {
+			return LevelBeyond, LevelBeyond
+		}
+		if j == 0 {
+			jlpt = LevelBeyond
+		} else if jlpt != LevelBeyond && (jlpt == 0 || j < jlpt) {
+			jlpt = j
+		}
+		grade = max(grade, g)
+	}
+	return jlpt, grade
+}
+
+// NewLevelTagger returns an enricher running TagLevels on the tokens.
+func NewLevelTagger() *common.FuncEnricher {
+	return common.NewFuncEnricher("jpn-levels", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		tkns := make([]*Tkn, 0, tsw.Len())
+		for i := 0; i < tsw.Len(); i++ {
+			if tkn, ok := tsw.GetIdx(i).(*Tkn); ok {
+				tkns = append(tkns, tkn)
+			}
+		}
+		TagLevels(tkns)
+		return nil
+	})
+}
+
+// WithLevelTagging appends the enricher tagging the tokens with the JLPT
+// level and the jōyō grade of their kanji, see TagLevels.
+func (m *Module) WithLevelTagging() *Module {
+	m.WithEnricher(NewLevelTagger())
+	return m
+}
+
+// LevelSummary counts the words of a text by level, to estimate its difficulty.
+type LevelSummary struct {
+	Words      int         // number of lexical tokens
+	KanjiWords int         // number of lexical tokens written with kanji
+	JLPT       map[int]int // number of words with kanji by JLPTLevel, LevelBeyond included
+	Grades     map[int]int // number of words with kanji by KanjiGrade, LevelBeyond included
+}
+
+// LevelSummary tags the tokens with their levels (see TagLevels) and counts them.
+func (wrapper TknSliceWrapper) LevelSummary() LevelSummary {
+	TagLevels(wrapper.NativeSlice)
+	summary := LevelSummary{JLPT: make(map[int]int), Grades: make(map[int]int)}
+	for _, tkn := range wrapper.NativeSlice {
+		if !tkn.IsLexical {
+			continue
+		}
+		summary.Words++
+		if tkn.JLPTLevel == 0 && tkn.KanjiGrade == 0 {
+			continue
+		}
+		summary.KanjiWords++
+		summary.JLPT[tkn.JLPTLevel]++
+		summary.Grades[tkn.KanjiGrade]++
+	}
+	return summary
+}
+
+// LevelSummary counts the words of the input by JLPT level and jōyō grade,
+// see TknSliceWrapper.LevelSummary.
+func (m *Module) LevelSummary(input string) (LevelSummary, error) {
+	tkns, err := m.Tokens(input)
+	if err != nil {
+		return LevelSummary{}, err
+	}
+	return tkns.LevelSummary(), nil
+}
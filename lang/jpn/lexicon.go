@@ -0,0 +1,85 @@
+package jpn
+
+// kanjiLexicon maps frequent words written with kanji to their reading in
+// hiragana. It lets the kana provider romanize common text without a
+// morphological analyzer; words that aren't listed keep their surface form.
+// Verbs and adjectives are listed by their stem with okurigana so that the
+// longest match picks the right reading (行っ → いっ, 行き → いき).
+var kanjiLexicon = map[string]string{
+	// Pronouns and people
+	"私": "わたし", "僕": "ぼく", "俺": "おれ", "彼": "かれ", "彼女": "かのじょ",
+	"自分": "じぶん", "人": "ひと", "日本人": "にほんじん", "友達": "ともだち",
+	"先生": "せんせい", "学生": "がくせい", "子供": "こども", "家族": "かぞく",
+	"父": "ちち", "母": "はは", "兄": "あに", "姉": "あね", "弟": "おとうと", "妹": "いもうと",
+	"男": "おとこ", "女": "おんな", "皆": "みんな", "誰": "だれ", "何": "なに",
+
+	// Places
+	"日本": "にほん", "東京": "とうきょう", "大阪": "おおさか", "京都": "きょうと",
+	"中国": "ちゅうごく", "韓国": "かんこく", "外国": "がいこく", "国": "くに",
+	"学校": "がっこう", "大学": "だいがく", "会社": "かいしゃ", "家": "いえ",
+	"駅": "えき", "店": "みせ", "町": "まち", "道": "みち", "部屋": "へや",
+	"病院": "びょういん", "図書館": "としょかん", "銀行": "ぎんこう", "山": "やま", "川": "かわ",
+	"海": "うみ", "空": "そら",
+
+	// Time
+	"今日": "きょう", "明日": "あした", "昨日": "きのう", "今": "いま", "時間": "じかん",
+	"毎日": "まいにち", "朝": "あさ", "昼": "ひる", "夜": "よる", "年": "とし",
+	"今年": "ことし", "去年": "きょねん", "来年": "らいねん", "時": "とき", "週末": "しゅうまつ",
+	"月曜日": "げつようび", "火曜日": "かようび", "水曜日": "すいようび", "木曜日": "もくようび",
+	"金曜日": "きんようび", "土曜日": "どようび", "日曜日": "にちようび",
+
+	// Things
+	"本": "ほん", "水": "みず", "車": "くるま", "電車": "でんしゃ", "電話": "でんわ",
+	"手紙": "てがみ", "お金": "おかね", "金": "かね", "名前": "なまえ", "言葉": "ことば",
+	"日本語": "にほんご", "英語": "えいご", "仕事": "しごと", "勉強": "べんきょう",
+	"映画": "えいが", "音楽": "おんがく", "天気": "てんき", "雨": "あめ", "雪": "ゆき",
+	"花": "はな", "犬": "いぬ", "猫": "ねこ", "魚": "さかな", "肉": "にく", "野菜": "やさい",
+	"ご飯": "ごはん", "朝ご飯": "あさごはん", "料理": "りょうり", "お茶": "おちゃ",
+	"気": "き", "心": "こころ", "目": "め", "手": "て", "足": "あし", "体": "からだ",
+	"頭": "あたま", "顔": "かお", "声": "こえ", "話": "はなし", "問題": "もんだい",
+	"大丈夫": "だいじょうぶ", "元気": "げんき", "好き": "すき", "嫌い": "きらい",
+	"上手": "じょうず", "下手": "へた", "本当": "ほんとう", "一緒": "いっしょ",
+
+	// Verb stems with okurigana
+	"食べ": "たべ", "飲ん": "のん", "飲み": "のみ", "飲む": "のむ",
+	"見": "み", "見る": "みる", "来": "き", "来る": "くる",
+	"行く": "いく", "行き": "いき", "行っ": "いっ", "帰る": "かえる", "帰り": "かえり", "帰っ": "かえっ",
+	"読む": "よむ", "読み": "よみ", "読ん": "よん", "書く": "かく", "書き": "かき", "書い": "かい",
+	"話す": "はなす", "話し": "はなし", "聞く": "きく", "聞き": "きき", "聞い": "きい",
+	"買う": "かう", "買い": "かい", "買っ": "かっ", "言う": "いう", "言い": "いい", "言っ": "いっ",
+	"思う": "おもう", "思い": "おもい", "思っ": "おもっ", "知る": "しる", "知り": "しり", "知っ": "しっ",
+	"分かる": "わかる", "分かり": "わかり", "分かっ": "わかっ", "待つ": "まつ", "待ち": "まち", "待っ": "まっ",
+	"会う": "あう", "会い": "あい", "会っ": "あっ", "使う": "つかう", "使い": "つかい", "使っ": "つかっ",
+	"作る": "つくる", "作り": "つくり", "作っ": "つくっ", "住む": "すむ", "住ん": "すん",
+	"出る": "でる", "出": "で", "入る": "はいる", "入り": "はいり", "入っ": "はいっ",
+	"寝る": "ねる", "寝": "ね", "起きる": "おきる", "起き": "おき", "教える": "おしえる", "教え": "おしえ",
+	"働く": "はたらく", "働き": "はたらき", "働い": "はたらい", "休む": "やすむ", "休み": "やすみ", "休ん": "やすん",
+	"持つ": "もつ", "持ち": "もち", "持っ": "もっ", "立つ": "たつ", "座る": "すわる",
+	"遊ぶ": "あそぶ", "遊び": "あそび", "遊ん": "あそん", "歩く": "あるく", "走る": "はしる",
+	"死ぬ": "しぬ", "生きる": "いきる", "生まれ": "うまれ", "始まる": "はじまる", "終わる": "おわる",
+	"開ける": "あける", "閉める": "しめる", "考える": "かんがえる", "考え": "かんがえ",
+
+	// Adjective stems
+	"大きい": "おおきい", "大き": "おおき", "小さい": "ちいさい", "小さ": "ちいさ",
+	"新しい": "あたらしい", "新し": "あたらし", "古い": "ふるい", "高い": "たかい", "高": "たか",
+	"安い": "やすい", "安": "やす", "良い": "よい", "悪い": "わるい", "早い": "はやい", "速い": "はやい",
+	"暑い": "あつい", "寒い": "さむい", "楽しい": "たのしい", "楽し": "たのし",
+	"難しい": "むずかしい", "易しい": "やさしい", "優しい": "やさしい", "多い": "おおい", "少ない": "すくない",
+	"長い": "ながい", "短い": "みじかい", "美味しい": "おいしい", "面白い": "おもしろい",
+
+	// Numbers and counters
+	"一": "いち", "二": "に", "三": "さん", "四": "よん", "五": "ご", "六": "ろく",
+	"七": "なな", "八": "はち", "九": "きゅう", "十": "じゅう", "百": "ひゃく", "千": "せん",
+	"万": "まん", "一人": "ひとり", "二人": "ふたり", "一つ": "ひとつ", "二つ": "ふたつ",
+}
+
+// kanjiLexiconMaxLen is the length in runes of the longest kanjiLexicon key.
+var kanjiLexiconMaxLen = func() int {
+	max := 0
+	for k := range kanjiLexicon {
+		if n := len([]rune(k)); n > max {
+			max = n
+		}
+	}
+	return max
+}()
@@ -0,0 +1,87 @@
+package jpn
+
+import (
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+var (
+	jpnDigits = []string{"rei", "ichi", "ni", "san", "yon", "go", "roku", "nana", "hachi", "kyuu"}
+	// units of the groups of four digits: man (10⁴), oku (10⁸), chou (10¹²), kei (10¹⁶)
+	jpnGroupUnits = []string{"", "man", "oku", "chou", "kei"}
+	// readings of digit × 1000 and digit × 100 that undergo sound changes
+	jpnThousands = map[byte]string{'1': "sen", '3': "san zen", '8': "hassen"}
+	jpnHundreds  = map[byte]string{'1': "hyaku", '3': "san byaku", '6': "roppyaku", '8': "happyaku"}
+)
+
+// NumberSpeller spells out numbers in Hepburn romaji, e.g. 2024 → "ni sen ni juu yon".
+var NumberSpeller = common.NumberSpeller{
+	Integer: SpellInteger,
+	Point:   "ten",
+}
+
+// SpellInteger returns the reading of a non-negative integer given as ASCII
+// digits in Hepburn romaji, and false beyond 10²⁰.
+func SpellInteger(digits string) (string, bool) {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return jpnDigits[0], true
+	}
+	if len(digits) > 4*len(jpnGroupUnits) {
+		return "", false
+	}
+	var words []string
+	for group := (len(digits) - 1) / 4; group >= 0; group-- {
+		end := len(digits) - 4*group
+		start := max(0, end-4)
+		g := strings.Repeat("0", 4-(end-start)) + digits[start:end]
+		if g == "0000" {
+			continue
+		}
+		words = append(words, spellJpnGroup(g, group > 0)...)
+		if unit := jpnGroupUnits[group]; unit != "" {
+			words = append(words, unit)
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+// spellJpnGroup reads a group of four digits. 1000 is read issen before a
+// unit of ten thousand and above (e.g. 一千万 issen man).
+func spellJpnGroup(g string, beforeUnit bool) (words []string) {
+	switch {
+	case g[0] == '0':
+	case g[0] == '1' && beforeUnit:
+		words = append(words, "issen")
+	case jpnThousands[g[0]] != "":
+		words = append(words, jpnThousands[g[0]])
+	default:
+		words = append(words, jpnDigits[g[0]-'0'], "sen")
+	}
+	switch {
+	case g[1] == '0':
+	case jpnHundreds[g[1]] != "":
+		words = append(words, jpnHundreds[g[1]])
+	default:
+		words = append(words, jpnDigits[g[1]-'0'], "hyaku")
+	}
+	switch g[2] {
+	case '0':
+	case '1':
+		words = append(words, "juu")
+	default:
+		words = append(words, jpnDigits[g[2]-'0'], "juu")
+	}
+	if g[3] != '0' {
+		words = append(words, jpnDigits[g[3]-'0'])
+	}
+	return words
+}
+
+// WithNumberExpansion appends the enricher spelling out the numbers of the
+// tokens in romaji, see common.NewNumberExpander.
+func (m *Module) WithNumberExpansion() *Module {
+	m.WithEnricher(common.NewNumberExpander(NumberSpeller))
+	return m
+}
@@ -0,0 +1,117 @@
+package jpn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrMissingReading is returned by KanaPartsWithOptions in strict mode when
+// a lexical token has no kana reading and isn't written in kana either.
+var ErrMissingReading = errors.New("missing kana reading")
+
+// KanaScript is the script of the readings output by KanaPartsWithOptions.
+type KanaScript int
+
+const (
+	// ReadingHiragana outputs the readings in hiragana, including the ones of
+	// loanwords that the providers give in katakana: コーヒー → こーひー.
+	ReadingHiragana KanaScript = iota
+	// ReadingKatakana outputs the readings in katakana: ひらがな → ヒラガナ.
+	ReadingKatakana
+)
+
+// KanaOptions configure KanaPartsWithOptions.
+type KanaOptions struct {
+	Script KanaScript
+
+	// ExpandLongVowels replaces the long vowel mark ー by the vowel it
+	// lengthens in hiragana output: こーひー → こおひい, てぃー → てぃい.
+	// It has no effect on katakana output, where ー is the norm.
+	ExpandLongVowels bool
+
+	// Strict makes KanaPartsWithOptions fail with ErrMissingReading instead
+	// of falling back to the surface of the lexical tokens that have no
+	// reading, e.g. kanji unknown to the provider.
+	Strict bool
+}
+
+// KanaPartsWithOptions returns the kana reading of each token. Non-lexical
+// tokens (punctuation, spaces, latin text...) are returned as is, as are the
+// lexical tokens without a reading unless opts.Strict is set; the reading of
+// a token written in kana only is its surface.
+func (wrapper TknSliceWrapper) KanaPartsWithOptions(opts KanaOptions) ([]string, error) {
+	parts := make([]string, 0, len(wrapper.NativeSlice))
+	for _, token := range wrapper.NativeSlice {
+		if !token.Tkn.IsLexical {
+			parts = append(parts, token.Tkn.Surface)
+			continue
+		}
+		reading := token.Kana
+		if reading == "" && isKana(token.Tkn.Surface) {
+			reading = token.Tkn.Surface
+		}
+		if reading == "" {
+			if opts.Strict {
+				return nil, fmt.Errorf("%w for %q", ErrMissingReading, token.Tkn.Surface)
+			}
+			parts = append(parts, token.Tkn.Surface)
+			continue
+		}
+		parts = append(parts, opts.convert(reading))
+	}
+	return parts, nil
+}
+
+func (opts KanaOptions) convert(reading string) string {
+	if opts.Script == ReadingKatakana {
+		return toKatakana(reading)
+	}
+	reading = toHiragana(reading)
+	if opts.ExpandLongVowels {
+		reading = expandLongVowels(reading)
+	}
+	return reading
+}
+
+// isKana reports whether s is made of kana and long vowel marks only.
+func isKana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.In(r, unicode.Hiragana, unicode.Katakana) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// toKatakana converts the hiragana of a string to katakana.
+func toKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r - 'ぁ' + 'ァ'
+		}
+		return r
+	}, s)
+}
+
+var vowelKana = map[byte]rune{'a': 'あ', 'i': 'い', 'u': 'う', 'e': 'え', 'o': 'お'}
+
+// expandLongVowels replaces each ー of a hiragana string by the vowel of the
+// kana before it. The small kana count as the end of the mora (ちゃー → ちゃあ);
+// a ー after っ, ん or a non-kana character is kept.
+func expandLongVowels(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if r != 'ー' || i == 0 {
+			continue
+		}
+		if romaji, ok := kanaRomaji[runes[i-1]]; ok {
+			runes[i] = vowelKana[romaji[len(romaji)-1]]
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,142 @@
+package jpn
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Registers set in Tkn.Register by TagRegister.
+const (
+	RegisterHonorific = "honorific" // 尊敬語
+	RegisterHumble    = "humble"    // 謙譲語
+	RegisterPolite    = "polite"    // 丁寧語 (です/ます)
+	RegisterCasual    = "casual"
+)
+
+// honorificVerbs are the special honorific verbs (尊敬語), by dictionary form in kana.
+var honorificVerbs = map[string]bool{
+	"いらっしゃる": true, "おっしゃる": true, "なさる": true, "くださる": true,
+	"めしあがる": true, "ごらんになる": true, "おいでになる": true, "おめしになる": true,
+	"おやすみになる": true,
+}
+
+// humbleVerbs are the special humble verbs (謙譲語), by dictionary form in kana.
+var humbleVerbs = map[string]bool{
+	"まいる": true, "もうす": true, "もうしあげる": true, "いただく": true,
+	"うかがう": true, "はいけんする": true, "おる": true, "ぞんじる": true,
+	"いたす": true, "さしあげる": true, "おめにかかる": true, "はいしゃくする": true,
+}
+
+// politeAuxiliaries are the auxiliaries of the polite style (丁寧語).
+var politeAuxiliaries = map[string]bool{
+	"です": true, "ます": true, "ござる": true, "ございます": true,
+}
+
+// sentenceFinalParticles are the particles (終助詞) that can end a sentence;
+// those mapped to true are only found in casual speech.
+var sentenceFinalParticles = map[string]bool{
+	"よ": false, "ね": false, "か": false, "よね": false, "な": false, "の": false,
+	"かな": false, "かしら": false,
+	"ぞ": true, "ぜ": true, "さ": true, "わ": true, "じゃん": true, "っけ": true,
+}
+
+// TagRegister sets the Register, IsKeigo, IsHonorific, IsHumble and
+// IsSentenceFinal fields of the tokens with rules on their dictionary form:
+//   - special honorific and humble verbs (いらっしゃる, 申す...) are keigo
+//     of the respective register, and polite inflections and auxiliaries
+//     (食べます, です) of the polite register;
+//   - particles ending a sentence (行きますよ, 行くぞ) are flagged as
+//     sentence-final; their register is casual for particles only found in
+//     casual speech (ぞ, ぜ...), otherwise that of the predicate they follow:
+//     polite after the polite style, casual after the plain style.
+//
+// Other tokens are left without a register.
+func TagRegister(tkns []*Tkn) {
+	for i, tkn := range tkns {
+		if !tkn.IsLexical {
+			continue
+		}
+		base := dictionaryForm(tkn)
+		switch {
+		case honorificVerbs[base]:
+			tkn.IsHonorific, tkn.IsKeigo = true, true
+			tkn.Register = RegisterHonorific
+		case humbleVerbs[base]:
+			tkn.IsHumble, tkn.IsKeigo = true, true
+			tkn.Register = RegisterHumble
+		case tkn.Inflection.Polite || politeAuxiliaries[base]:
+			tkn.IsKeigo = true
+			tkn.Register = RegisterPolite
+		}
+
+		casual, ok := sentenceFinalParticles[toHiragana(tkn.Surface)]
+		if !ok || !endsSentence(tkns, i) {
+			continue
+		}
+		tkn.IsSentenceFinal = true
+		switch prev := previousPredicate(tkns, i); {
+		case casual:
+			tkn.Register = RegisterCasual
+		case prev == nil:
+		case prev.Register == RegisterPolite || prev.Inflection.Polite:
+			tkn.Register = RegisterPolite
+		default:
+			tkn.Register = RegisterCasual
+		}
+	}
+}
+
+// endsSentence reports whether only sentence-final particles, sentence
+// punctuation or nothing follow the token at index i.
+func endsSentence(tkns []*Tkn, i int) bool {
+	for _, next := range tkns[i+1:] {
+		if next.IsLexical {
+			if _, ok := sentenceFinalParticles[toHiragana(next.Surface)]; !ok {
+				return false
+			}
+			continue
+		}
+		if strings.TrimSpace(next.Surface) == "" {
+			continue
+		}
+		return strings.ContainsAny(next.Surface, "。！？!?.\n")
+	}
+	return true
+}
+
+// previousPredicate returns the lexical token preceding the sentence-final
+// particles ending at index i.
+func previousPredicate(tkns []*Tkn, i int) *Tkn {
+	for j := i - 1; j >= 0; j-- {
+		if !tkns[j].IsLexical {
+			return nil
+		}
+		if _, ok := sentenceFinalParticles[toHiragana(tkns[j].Surface)]; !ok {
+			return tkns[j]
+		}
+	}
+	return nil
+}
+
+// NewRegisterTagger returns an enricher running TagRegister on the tokens.
+func NewRegisterTagger() *common.FuncEnricher {
+	return common.NewFuncEnricher("jpn-register", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		tkns := make([]*Tkn, 0, tsw.Len())
+		for i := 0; i < tsw.Len(); i++ {
+			if tkn, ok := tsw.GetIdx(i).(*Tkn); ok {
+				tkns = append(tkns, tkn)
+			}
+		}
+		TagRegister(tkns)
+		return nil
+	})
+}
+
+// WithRegisterTagging appends the enricher tagging the politeness register
+// and the sentence-final particles of the tokens, see TagRegister.
+func (m *Module) WithRegisterTagging() *Module {
+	m.WithEnricher(NewRegisterTagger())
+	return m
+}
@@ -0,0 +1,229 @@
+package jpn
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// kanaRomaji maps single hiragana to their Hepburn romanization.
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// palatalRomaji maps the i-row kana that combine with a small ゃ/ゅ/ょ
+// to the onset of the resulting mora, e.g. し+ょ → sho.
+var palatalRomaji = map[rune]string{
+	'き': "ky", 'ぎ': "gy", 'し': "sh", 'じ': "j", 'ち': "ch", 'ぢ': "j",
+	'に': "ny", 'ひ': "hy", 'び': "by", 'ぴ': "py", 'み': "my", 'り': "ry",
+}
+
+// foreignOnsetRomaji maps the onsets used with small vowels in loanwords
+// (ファ, ティ, ウィ, ヴァ...).
+var foreignOnsetRomaji = map[rune]string{
+	'ふ': "f", 'て': "t", 'で': "d", 'と': "t", 'ど': "d", 'う': "w",
+	'つ': "ts", 'ゔ': "v", 'し': "sh", 'じ': "j", 'ち': "ch",
+}
+
+// particleRomaji holds the particles whose pronunciation differs from their kana.
+var particleRomaji = map[string]string{
+	"は": "wa",
+	"へ": "e",
+	"を": "o",
+}
+
+var macrons = map[byte]string{'a': "ā", 'i': "ī", 'u': "ū", 'e': "ē", 'o': "ō"}
+
+// KanaToRomaji converts a kana string (hiragana and/or katakana) to modified
+// Hepburn romanization: しんぶん → shinbun, がっこう → gakkō, コーヒー → kōhī.
+// Long vowels written with ー, おう, おお and うう get a macron, ん is written n'
+// before a vowel or y, and the particles は/へ/を are read wa/e/o when they make
+// up the whole input. Characters other than kana are kept as is.
+func KanaToRomaji(kana string) string {
+	hira := toHiragana(kana)
+	if r, ok := particleRomaji[hira]; ok {
+		return r
+	}
+
+	var b strings.Builder
+	rs := []rune(hira)
+	geminate := false
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		var next rune
+		if i+1 < len(rs) {
+			next = rs[i+1]
+		}
+
+		var mora string
+		switch {
+		case r == 'っ':
+			geminate = true
+			continue
+		case r == 'ん':
+			mora = "n"
+			if n := kanaRomaji[next]; n != "" && (strings.ContainsAny(n[:1], "aiueo") || n[0] == 'y') {
+				mora = "n'"
+			}
+		case r == 'ー':
+			lengthen(&b)
+			continue
+		case (next == 'ゃ' || next == 'ゅ' || next == 'ょ') && palatalRomaji[r] != "":
+			mora = palatalRomaji[r] + kanaRomaji[next][1:]
+			i++
+		case (next == 'ぁ' || next == 'ぃ' || next == 'ぇ' || next == 'ぉ') && foreignOnsetRomaji[r] != "":
+			mora = foreignOnsetRomaji[r] + kanaRomaji[next]
+			i++
+		default:
+			var ok bool
+			if mora, ok = kanaRomaji[r]; !ok {
+				mora = string(r)
+			}
+		}
+
+		// Long vowels: おう, おお → ō and うう → ū. Without morphological analysis
+		// this can't tell apart a verb ending such as 思う (omou), which is
+		// accepted as the cost of a dictionary-free romanizer.
+		if (mora == "u" || mora == "o") && endsWithVowel(b.String(), 'o') ||
+			mora == "u" && endsWithVowel(b.String(), 'u') {
+			lengthen(&b)
+			continue
+		}
+
+		if geminate {
+			geminate = false
+			switch {
+			case strings.HasPrefix(mora, "ch"):
+				b.WriteString("t")
+			case mora != "" && !strings.ContainsAny(mora[:1], "aiueon"):
+				b.WriteString(mora[:1])
+			}
+		}
+		b.WriteString(mora)
+	}
+	return b.String()
+}
+
+// lengthen replaces the vowel the builder ends with by its macron form.
+func lengthen(b *strings.Builder) {
+	s := b.String()
+	if s == "" {
+		return
+	}
+	if m, ok := macrons[s[len(s)-1]]; ok {
+		b.Reset()
+		b.WriteString(s[:len(s)-1] + m)
+	}
+}
+
+func endsWithVowel(s string, v byte) bool {
+	return s != "" && s[len(s)-1] == v
+}
+
+// romajiKana maps the Hepburn romanization of a mora to its hiragana, see
+// RomajiToKana.
+var romajiKana = buildRomajiKana()
+
+func buildRomajiKana() map[string]string {
+	table := make(map[string]string)
+	for r, romaji := range kanaRomaji {
+		// small kana and the kana sharing their romanization with a more
+		// common one are never output
+		if strings.ContainsRune("ぁぃぅぇぉゃゅょゎゐゑをぢづ", r) {
+			continue
+		}
+		table[romaji] = string(r)
+	}
+	for r, onset := range palatalRomaji {
+		if r == 'ぢ' {
+			continue
+		}
+		for _, small := range "ゃゅょ" {
+			table[onset+kanaRomaji[small][1:]] = string(r) + string(small)
+		}
+	}
+	// the onsets of loanwords come last as they may clash with the above
+	// (し+ぃ is shi like し), sorted so that て/で win over と/ど for ti/di
+	onsets := make([]rune, 0, len(foreignOnsetRomaji))
+	for r := range foreignOnsetRomaji {
+		onsets = append(onsets, r)
+	}
+	slices.Sort(onsets)
+	for _, r := range onsets {
+		for _, small := range "ぁぃぇぉ" {
+			romaji := foreignOnsetRomaji[r] + kanaRomaji[small]
+			if _, ok := table[romaji]; !ok {
+				table[romaji] = string(r) + string(small)
+			}
+		}
+	}
+	return table
+}
+
+// macronVowels spells the long vowels of KanaToRomaji the way they are
+// most commonly written in hiragana: ō → ou, ū → uu...
+var macronVowels = strings.NewReplacer("ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "ou")
+
+// RomajiToKana converts modified Hepburn romanization back to hiragana:
+// shinbun → しんぶん, gakkō → がっこう, hon'ya → ほんや. It reverses
+// KanaToRomaji for hiragana, though what was written in katakana comes back
+// in hiragana and long vowels are spelled out (kōhī → こうひい). Characters
+// that aren't part of a mora are kept as is.
+func RomajiToKana(romaji string) string {
+	rs := []rune(macronVowels.Replace(strings.ToLower(norm.NFC.String(romaji))))
+	var b strings.Builder
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		var next rune
+		if i+1 < len(rs) {
+			next = rs[i+1]
+		}
+		switch {
+		case r == 'n' && next == '\'':
+			b.WriteRune('ん')
+			i += 2
+			continue
+		case r != 'n' && !strings.ContainsRune("aiueo", r) && r >= 'a' && r <= 'z' &&
+			(next == r || r == 't' && next == 'c'):
+			b.WriteRune('っ')
+			i++
+			continue
+		}
+
+		matched := false
+		for size := min(3, len(rs)-i); size > 0; size-- {
+			if kana, ok := romajiKana[string(rs[i:i+size])]; ok {
+				b.WriteString(kana)
+				i += size
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if r == 'n' {
+				b.WriteRune('ん')
+			} else {
+				b.WriteRune(r)
+			}
+			i++
+		}
+	}
+	return b.String()
+}
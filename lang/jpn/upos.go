@@ -0,0 +1,37 @@
+package jpn
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// IchiranPOS maps the JMdict part-of-speech entities returned by ichiran to
+// Universal POS tags. Subcategories not listed here (e.g. "v5k", "adj-na",
+// "adv-to") map to their category by prefix, see common.ToUPOS.
+var IchiranPOS = map[string]string{
+	"adj":    common.UPOSAdj,
+	"adj-pn": common.UPOSDet,   // pre-noun adjectival (連体詞): この, その...
+	"adv":    common.UPOSAdv,
+	"aux":    common.UPOSAux,
+	"conj":   common.UPOSCconj,
+	"cop":    common.UPOSAux,   // copula: だ, です
+	"ctr":    common.UPOSNoun,  // counter
+	"exp":    common.UPOSX,     // expression
+	"int":    common.UPOSIntj,
+	"n":      common.UPOSNoun,
+	"n-pr":   common.UPOSPropn,
+	"num":    common.UPOSNum,
+	"pn":     common.UPOSPron,
+	"pref":   common.UPOSX,
+	"prt":    common.UPOSAdp,   // particle, ADP as case particles in the UD Japanese treebanks
+	"suf":    common.UPOSX,
+	"unc":    common.UPOSX,     // unclassified
+	"v":      common.UPOSVerb,
+}
+
+func init() {
+	if err := common.RegisterPOSMapping("ichiran", IchiranPOS); err != nil {
+		panic(fmt.Sprintf("failed to register ichiran POS mapping: %v", err))
+	}
+}
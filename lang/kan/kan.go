@@ -0,0 +1,15 @@
+package kan
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Tkn extends common.Tkn with Kannada-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Script features
+	HasVattu     bool // ಒತ್ತಕ್ಷರ - Subjoined consonant of a cluster
+	HasHalant    bool // ಹಲಂತ - Virama marking a pure consonant
+	HasArkavattu bool // ಅರ್ಕಾವೊತ್ತು - r written above the following consonant
+}
@@ -0,0 +1,44 @@
+package kat
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	georgianEntry := common.ProviderEntry{
+		Provider:     NewGeorgianProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, georgianEntry); err != nil {
+		panic(fmt.Sprintf("failed to register georgian provider: %v", err))
+	}
+
+	for _, scheme := range georgianSchemes {
+		scheme.Providers = []string{"georgian"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Georgian scheme")
+		}
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     &mul.UnisegProvider{},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     NewGeorgianProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
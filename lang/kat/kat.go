@@ -0,0 +1,47 @@
+package kat
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptMkhedruli = "Geor" // Mkhedruli, the modern Georgian script (and its Mtavruli capitals)
+	ScriptKhutsuri  = "Geok" // Asomtavruli and Nuskhuri, the ecclesiastical scripts
+	ScriptLatin     = "Latn" // Romanized/Latin script
+)
+
+// Tkn extends the common Token with Georgian-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Orthographic features
+	IsMtavruli  bool // Written in Mtavruli capitals (headings, emphasis)
+	HasArchaic  bool // Contains letters no longer in use (ჱ, ჲ, ჳ, ჴ, ჵ, ჶ)
+
+	// Morphological features
+	Case        GramCase // 7 cases
+	Number      string   // Singular or plural
+	Screeve     string   // Tense-aspect-mood series of the verb (მწკრივი)
+	Preverb     string   // Directional/perfective preverb (მი-, მო-, და-...)
+	Version     string   // Neutral, subjective, objective or locative version vowel
+	Person      struct {
+		Subject        int // Person of the subject
+		DirectObject   int // Person of the direct object (polypersonal agreement)
+		IndirectObject int // Person of the indirect object
+	}
+
+	// Consonant clusters
+	HarmonicCluster bool // Contains a harmonic cluster (ბგ, თხ, წყ...)
+}
+
+// GramCase is a Georgian grammatical case
+type GramCase string
+const (
+	Nominative   GramCase = "nom"
+	Ergative     GramCase = "erg"
+	Dative       GramCase = "dat"
+	Genitive     GramCase = "gen"
+	Instrumental GramCase = "ins"
+	Adverbial    GramCase = "adv"
+	Vocative     GramCase = "voc"
+)
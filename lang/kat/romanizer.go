@@ -0,0 +1,231 @@
+package kat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const defaultScheme = "national"
+
+// georgianSchemes lists the romanization schemes offered by the GeorgianProvider.
+var georgianSchemes = []common.TranslitScheme{
+	{Name: "national", Description: "Georgian national system (2002), also adopted by BGN/PCGN: ejectives unmarked (Tbilisi, Kutaisi)"},
+	{Name: "iso9984", Description: "ISO 9984:1996, one letter per character with an apostrophe on aspirates (tʼ, pʼ, kʼ, cʼ, čʼ)"},
+}
+
+// schemeTables map the Mkhedruli letters to each scheme. Letters missing from
+// a table are kept as is.
+var schemeTables = map[string]map[rune]string{
+	"national": {
+		'ა': "a", 'ბ': "b", 'გ': "g", 'დ': "d", 'ე': "e", 'ვ': "v", 'ზ': "z",
+		'თ': "t", 'ი': "i", 'კ': "k", 'ლ': "l", 'მ': "m", 'ნ': "n", 'ო': "o",
+		'პ': "p", 'ჟ': "zh", 'რ': "r", 'ს': "s", 'ტ': "t", 'უ': "u", 'ფ': "p",
+		'ქ': "k", 'ღ': "gh", 'ყ': "q", 'შ': "sh", 'ჩ': "ch", 'ც': "ts", 'ძ': "dz",
+		'წ': "ts", 'ჭ': "ch", 'ხ': "kh", 'ჯ': "j", 'ჰ': "h",
+		// archaic letters
+		'ჱ': "e", 'ჲ': "y", 'ჳ': "w", 'ჴ': "kh", 'ჵ': "o", 'ჶ': "f",
+	},
+	"iso9984": {
+		'ა': "a", 'ბ': "b", 'გ': "g", 'დ': "d", 'ე': "e", 'ვ': "v", 'ზ': "z",
+		'თ': "tʼ", 'ი': "i", 'კ': "k", 'ლ': "l", 'მ': "m", 'ნ': "n", 'ო': "o",
+		'პ': "p", 'ჟ': "ž", 'რ': "r", 'ს': "s", 'ტ': "t", 'უ': "u", 'ფ': "pʼ",
+		'ქ': "kʼ", 'ღ': "ḡ", 'ყ': "q", 'შ': "š", 'ჩ': "čʼ", 'ც': "cʼ", 'ძ': "j",
+		'წ': "c", 'ჭ': "č", 'ხ': "x", 'ჯ': "ǰ", 'ჰ': "h",
+		'ჱ': "ē", 'ჲ': "y", 'ჳ': "w", 'ჴ': "q̌", 'ჵ': "ō", 'ჶ': "f",
+	},
+}
+
+// Mtavruli capitals (Ა...) mirror the Mkhedruli letters (ა...)
+const (
+	mtavruliStart  = 'Ა'
+	mtavruliEnd    = 'Ჿ'
+	mkhedruliStart = 'ა'
+)
+
+// GeorgianProvider romanizes Georgian tokens written in Mkhedruli, letter by
+// letter as Georgian spelling is phonemic. Mtavruli, the capitals used for
+// headings, is romanized in upper case.
+type GeorgianProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+	scheme           string
+}
+
+// NewGeorgianProvider creates a new GeorgianProvider using the default scheme.
+func NewGeorgianProvider() *GeorgianProvider {
+	return &GeorgianProvider{
+		scheme: defaultScheme,
+	}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *GeorgianProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Georgian romanizer).
+func (p *GeorgianProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Georgian romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+//
+// Returns an error if the configuration is invalid.
+func (p *GeorgianProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// GeorgianOptions are the typed options of GeorgianProvider, see common.Configure.
+type GeorgianOptions struct {
+	// Scheme is the name of a registered Georgian scheme. Defaults to defaultScheme.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *GeorgianProvider) ConfigureWith(opts GeorgianOptions) error {
+	if _, ok := schemeTables[opts.Scheme]; !ok && opts.Scheme != "" {
+		return fmt.Errorf("unsupported transliteration scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// InitWithContext initializes the provider with the given context.
+// This validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *GeorgianProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("georgian: context canceled during initialization: %w", err)
+	}
+
+	scheme, _ := p.config["scheme"].(string)
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if _, ok := schemeTables[scheme]; !ok {
+		return fmt.Errorf("georgian: unsupported transliteration scheme: %s", scheme)
+	}
+	p.scheme = scheme
+	return nil
+}
+
+// Init initializes the provider with a background context.
+//
+// Returns an error if initialization fails.
+func (p *GeorgianProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes the provider from scratch with the given context.
+// For the Georgian romanizer, this is equivalent to InitWithContext as there are no persistent resources.
+func (p *GeorgianProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *GeorgianProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *GeorgianProvider) Name() string {
+	return "georgian"
+}
+
+func (p *GeorgianProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *GeorgianProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *GeorgianProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *GeorgianProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController processes input tokens using the specified context,
+// adding romanization to Georgian tokens.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *GeorgianProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("georgian: context canceled during processing: %w", err)
+	}
+
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("georgian: raw input not accepted, a tokenizer must run first")
+	}
+
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("georgian: context canceled while processing token %d: %w", i, err)
+		}
+
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		roman, _ := Romanize(s, p.scheme)
+		tkn.SetRoman(roman)
+	}
+
+	return input, nil
+}
+
+// Romanize converts Georgian text to the given scheme ("national" or "iso9984").
+//
+// Returns an error if the scheme is not supported.
+func Romanize(text, scheme string) (string, error) {
+	table, ok := schemeTables[scheme]
+	if !ok {
+		return "", fmt.Errorf("georgian: unsupported transliteration scheme: %s", scheme)
+	}
+	var out strings.Builder
+	for _, r := range text {
+		upper := r >= mtavruliStart && r <= mtavruliEnd
+		if upper {
+			r = r - mtavruliStart + mkhedruliStart
+		}
+		roman, ok := table[r]
+		switch {
+		case !ok:
+			out.WriteRune(r)
+		case upper:
+			out.WriteString(strings.ToUpper(roman))
+		default:
+			out.WriteString(roman)
+		}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,32 @@
+package kat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/kat"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, scheme, expected string
+	}{
+		{"თბილისი", "national", "tbilisi"},
+		{"ქუთაისი", "national", "kutaisi"},
+		{"წყალტუბო", "national", "tsqaltubo"},
+		{"ჭიათურა", "national", "chiatura"},
+		{"ქართული ენა", "iso9984", "kʼartʼuli ena"},
+		{"ჯავახეთი", "iso9984", "ǰavaxetʼi"},
+		{"ᲡᲐᲥᲐᲠᲗᲕᲔᲚᲝ", "national", "SAKARTVELO"}, // Mtavruli
+	}
+	for _, c := range cases {
+		roman, err := kat.Romanize(c.input, c.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, roman, "input %q with scheme %s", c.input, c.scheme)
+	}
+
+	_, err := kat.Romanize("თბილისი", "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package khm
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func init() {
+	khmerNLTKEntry := common.ProviderEntry{
+		Provider:     NewKhmerNLTKProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization},
+	}
+	ungegnEntry := common.ProviderEntry{
+		Provider:     NewUNGEGNProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, khmerNLTKEntry); err != nil {
+		panic(fmt.Sprintf("failed to register khmer-nltk provider: %v", err))
+	}
+	if err := common.Register(Lang, ungegnEntry); err != nil {
+		panic(fmt.Sprintf("failed to register ungegn provider: %v", err))
+	}
+
+	for _, scheme := range khmerSchemes {
+		scheme.Providers = []string{"khmer-nltk", "ungegn"}
+		scheme.NeedsDocker = true
+		scheme.Requirements.DownloadSize = khmerNLTKDownloadSize
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Khmer scheme")
+		}
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		khmerNLTKEntry,
+		ungegnEntry,
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
@@ -0,0 +1,51 @@
+package khm
+
+import (
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptKhmer = "Khmr" // Khmer script
+	ScriptLatin = "Latn" // Romanized/Latin script (UNGEGN...)
+)
+
+// Tkn extends the common Token with Khmer-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Orthographic syllables of the word, in Khmer script (កម្ពុជា → កម, ពុ, ជា):
+	// a consonant written as a subscript often closes the syllable before it
+	Syllables []string
+
+	// Vocabulary
+	IsPaliSanskrit bool   // Learned word borrowed from Pali or Sanskrit
+	Register       string // Royal (រាជសព្ទ), monastic or common vocabulary
+}
+
+// isLexical reports whether a token contains letters or digits
+func isLexical(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// newKhmerToken wraps a token and splits its syllables
+func newKhmerToken(token *common.Tkn) *Tkn {
+	tkn := &Tkn{Tkn: *token}
+	tkn.IsLexical = isLexical(token.Surface)
+	if !tkn.IsLexical {
+		return tkn
+	}
+	for _, s := range parseSyllables([]rune(token.Surface)) {
+		tkn.Syllables = append(tkn.Syllables, s.text)
+	}
+	if len(tkn.Syllables) > 0 {
+		tkn.Script = ScriptKhmer
+	}
+	return tkn
+}
@@ -0,0 +1,360 @@
+package khm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	// khmerNLTKImage runs the word segmenter of khmer-nltk
+	// (https://github.com/VietHoang1512/khmer-nltk) behind the HTTP API
+	// described on KhmerNLTKProvider
+	khmerNLTKImage         = "ghcr.io/tassa-yoniso-manasi-karoto/langkit-khmer-nltk:latest"
+	khmerNLTKContainerName = "translitkit-khmer-nltk"
+	khmerNLTKHostPort      = "8087"
+	khmerNLTKStartTimeout  = 2 * time.Minute
+
+	// khmerNLTKDownloadSize is the approximate size in bytes of khmerNLTKImage
+	khmerNLTKDownloadSize int64 = 250 << 20
+)
+
+// KhmerNLTKProvider is a Khmer word segmenter backed by khmer-nltk, a CRF
+// model trained on a segmented corpus, running in a Docker container.
+// Khmer doesn't separate words with spaces: spaces mark phrase boundaries.
+//
+// Instead of managing its own container, it can use an existing server given
+// by the "endpoint" config key. The server answers
+//
+//	POST /tokenize {"text": "..."}
+//
+// with the words of the text, in order, spaces and punctuation included or not:
+//
+//	{"tokens": ["ខ្ញុំ", "ស្រលាញ់", "ប្រទេស", "កម្ពុជា"]}
+//
+// and GET /health with 200 once it is ready.
+type KhmerNLTKProvider struct {
+	config                   map[string]interface{}
+	endpoint                 string
+	httpClient               *http.Client
+	managed                  bool // the provider started the container itself
+	progressCallback         common.ProgressCallback
+	downloadProgressCallback common.DownloadProgressCallback
+}
+
+// NewKhmerNLTKProvider creates a new khmer-nltk tokenizer
+func NewKhmerNLTKProvider() *KhmerNLTKProvider {
+	return &KhmerNLTKProvider{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// SaveConfig stores the configuration. The recognized key is "endpoint", the
+// URL of an existing server (e.g. "http://localhost:8080").
+func (p *KhmerNLTKProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	if endpoint, ok := cfg["endpoint"].(string); ok {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+	return nil
+}
+
+// InitWithContext pulls the khmer-nltk image and starts its container unless
+// an endpoint was configured, then waits for the server to answer.
+//
+// Returns an error if Docker is unreachable, the server doesn't start or the context is canceled.
+func (p *KhmerNLTKProvider) InitWithContext(ctx context.Context) error {
+	if p.httpClient == nil {
+		p.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	if p.endpoint == "" {
+		if err := p.startContainer(ctx, false, false); err != nil {
+			return fmt.Errorf("khmer-nltk: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + khmerNLTKHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("khmer-nltk: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *KhmerNLTKProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext removes the container and starts a new one.
+// When noCache is true, the image is pulled again.
+func (p *KhmerNLTKProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	if p.managed || p.endpoint == "" {
+		if err := p.startContainer(ctx, true, noCache); err != nil {
+			return fmt.Errorf("khmer-nltk: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + khmerNLTKHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("khmer-nltk: %w", err)
+	}
+	return nil
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *KhmerNLTKProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// startContainer makes sure the khmer-nltk container is running. With
+// recreate, an existing container is removed first; with pull, the image is
+// pulled even if present.
+func (p *KhmerNLTKProvider) startContainer(ctx context.Context, recreate, pull bool) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	info, err := cli.ContainerInspect(ctx, khmerNLTKContainerName)
+	switch {
+	case err == nil && recreate:
+		if err := cli.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("failed to remove container: %w", err)
+		}
+	case err == nil && info.State != nil && info.State.Running:
+		return nil
+	case err == nil:
+		if err := cli.ContainerStart(ctx, info.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("failed to start container: %w", err)
+		}
+		return nil
+	case !cerrdefs.IsNotFound(err):
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if _, err := cli.ImageInspect(ctx, khmerNLTKImage); err != nil || pull {
+		if err := p.pullImage(ctx, cli); err != nil {
+			return err
+		}
+	}
+
+	port := nat.Port("8080/tcp")
+	created, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        khmerNLTKImage,
+			ExposedPorts: nat.PortSet{port: struct{}{}},
+		},
+		&container.HostConfig{
+			PortBindings:  nat.PortMap{port: []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: khmerNLTKHostPort}}},
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		nil, nil, khmerNLTKContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	return nil
+}
+
+// pullImage pulls khmerNLTKImage, reporting progress to the download progress callback.
+func (p *KhmerNLTKProvider) pullImage(ctx context.Context, cli *client.Client) error {
+	rc, err := cli.ImagePull(ctx, khmerNLTKImage, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", khmerNLTKImage, err)
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	for {
+		var msg struct {
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", khmerNLTKImage, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("failed to pull image %s: %s", khmerNLTKImage, msg.Error)
+		}
+		if p.downloadProgressCallback != nil && msg.ProgressDetail.Total > 0 {
+			p.downloadProgressCallback(p.Name(), msg.ProgressDetail.Current, msg.ProgressDetail.Total, msg.Status)
+		}
+	}
+}
+
+// waitReady polls the server until it reports being healthy.
+func (p *KhmerNLTKProvider) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, khmerNLTKStartTimeout)
+	defer cancel()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := p.httpClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server at %s not ready: %w", p.endpoint, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// ProcessFlowController segments the raw input chunks into words.
+func (p *KhmerNLTKProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("khmer-nltk: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("khmer-nltk only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("khmer-nltk: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("khmer-nltk: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		words, err := p.tokenize(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("khmer-nltk: chunk %d: %w", idx, err)
+		}
+		tokens, err := common.IntegrateProviderTokensWithContext(ctx, chunk, words)
+		if err != nil {
+			return nil, fmt.Errorf("khmer-nltk: chunk %d: %w", idx, err)
+		}
+		for _, token := range tokens {
+			tsw.Append(newKhmerToken(token))
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+// tokenize returns the words of the text, leaving out the whitespace and the
+// punctuation the server may return as tokens.
+func (p *KhmerNLTKProvider) tokenize(ctx context.Context, text string) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/tokenize", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, msg)
+	}
+	var result struct {
+		Tokens []string `json:"tokens"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	words := make([]string, 0, len(result.Tokens))
+	for _, token := range result.Tokens {
+		if isLexical(token) {
+			words = append(words, token)
+		}
+	}
+	return words, nil
+}
+
+// CloseWithContext stops the container if the provider started it.
+func (p *KhmerNLTKProvider) CloseWithContext(ctx context.Context) error {
+	if !p.managed {
+		return nil
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("khmer-nltk: failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+	if err := cli.ContainerStop(ctx, khmerNLTKContainerName, container.StopOptions{}); err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("khmer-nltk: failed to stop container: %w", err)
+	}
+	p.managed = false
+	p.endpoint = ""
+	return nil
+}
+
+// Close stops the container with a background context.
+func (p *KhmerNLTKProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *KhmerNLTKProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *KhmerNLTKProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+// ResourceVersions returns the digest of the khmer-nltk Docker image,
+// implementing common.VersionReporter.
+func (p *KhmerNLTKProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if !p.managed {
+		return nil, nil
+	}
+	return common.DockerImageVersions(ctx, khmerNLTKImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// khmer-nltk runs in a Docker container.
+func (p *KhmerNLTKProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
+func (p *KhmerNLTKProvider) Name() string {
+	return "khmer-nltk"
+}
+
+func (p *KhmerNLTKProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *KhmerNLTKProvider) GetMaxQueryLen() int {
+	// the CRF handles long texts, but we'll chunk for progress reporting
+	return 5000
+}
@@ -0,0 +1,54 @@
+package khm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestKhmerNLTKTokenizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		var req struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "ខ្ញុំស្រលាញ់ប្រទេសកម្ពុជា។", req.Text)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"tokens": []string{"ខ្ញុំ", "ស្រលាញ់", "ប្រទេស", "កម្ពុជា", "។"},
+		})
+	}))
+	defer server.Close()
+
+	p := NewKhmerNLTKProvider()
+	require.NoError(t, p.SaveConfig(map[string]interface{}{"endpoint": server.URL}))
+	require.NoError(t, p.Init())
+	defer p.Close()
+
+	input := &common.TknSliceWrapper{Raw: []string{"ខ្ញុំស្រលាញ់ប្រទេសកម្ពុជា។"}}
+	out, err := p.ProcessFlowController(context.Background(), common.TokenizerMode, input)
+	require.NoError(t, err)
+	out, err = NewUNGEGNProvider().ProcessFlowController(context.Background(), common.TransliteratorMode, out)
+	require.NoError(t, err)
+
+	var surfaces, romans []string
+	for i := 0; i < out.Len(); i++ {
+		surfaces = append(surfaces, out.GetIdx(i).GetSurface())
+		romans = append(romans, out.GetIdx(i).Roman())
+	}
+	assert.Equal(t, []string{"ខ្ញុំ", "ស្រលាញ់", "ប្រទេស", "កម្ពុជា", "។"}, surfaces)
+	assert.Equal(t, []string{"khnhom", "srâlŏănh", "brâtés", "kâmpŭchéa", ""}, romans)
+
+	tkn := out.GetIdx(3).(*Tkn)
+	assert.Equal(t, []string{"កម", "ពុ", "ជា"}, tkn.Syllables)
+	assert.Equal(t, ScriptKhmer, tkn.Script)
+	assert.False(t, out.GetIdx(4).IsLexicalContent())
+}
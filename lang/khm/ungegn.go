@@ -0,0 +1,431 @@
+package khm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const ungegnScheme = "ungegn"
+
+// khmerSchemes lists the romanization schemes offered by the UNGEGNProvider.
+var khmerSchemes = []common.TranslitScheme{
+	{Name: ungegnScheme, Description: "UNGEGN (BGN/PCGN 1972) romanization, as used for Cambodian place names (Kâmpŭchéa, Phnum Pénh)"},
+}
+
+const (
+	coeng        = '្'
+	nikahit      = 'ំ'
+	reahmuk      = 'ះ'
+	bantoc       = '់'
+	muusikatoan  = '៉'
+	triisap      = '៊'
+	robat        = '៌'
+	toandakhiat  = '៍'
+	lekTooNDaa   = 'ៗ'
+	firstSeries  = 0
+	secondSeries = 1
+)
+
+// consonant is the romanization of a consonant and its series, which
+// determines the reading of the vowels written on it
+type consonant struct {
+	roman  string
+	series int
+}
+
+var consonants = map[rune]consonant{
+	'ក': {"k", firstSeries}, 'ខ': {"kh", firstSeries}, 'គ': {"k", secondSeries}, 'ឃ': {"kh", secondSeries}, 'ង': {"ng", secondSeries},
+	'ច': {"ch", firstSeries}, 'ឆ': {"chh", firstSeries}, 'ជ': {"ch", secondSeries}, 'ឈ': {"chh", secondSeries}, 'ញ': {"nh", secondSeries},
+	'ដ': {"d", firstSeries}, 'ឋ': {"th", firstSeries}, 'ឌ': {"d", secondSeries}, 'ឍ': {"th", secondSeries}, 'ណ': {"n", firstSeries},
+	'ត': {"t", firstSeries}, 'ថ': {"th", firstSeries}, 'ទ': {"t", secondSeries}, 'ធ': {"th", secondSeries}, 'ន': {"n", secondSeries},
+	'ប': {"b", firstSeries}, 'ផ': {"ph", firstSeries}, 'ព': {"p", secondSeries}, 'ភ': {"ph", secondSeries}, 'ម': {"m", secondSeries},
+	'យ': {"y", secondSeries}, 'រ': {"r", secondSeries}, 'ល': {"l", secondSeries}, 'វ': {"v", secondSeries},
+	'ឝ': {"sh", firstSeries}, 'ឞ': {"ss", firstSeries}, 'ស': {"s", firstSeries}, 'ហ': {"h", firstSeries},
+	'ឡ': {"l", firstSeries}, 'អ': {"", firstSeries},
+}
+
+// sonorants are the consonants that take the series of the consonant they
+// are subscribed to
+const sonorants = "ងញនមយរលវ"
+
+// finals maps the romanization of the consonants closing a syllable, when it
+// differs from their initial one: aspiration is lost
+var finals = map[string]string{
+	"kh": "k", "chh": "ch", "th": "t", "ph": "p",
+}
+
+// vowels maps the dependent vowels, with the nikahit and reahmuk written
+// after them, to their reading in each series. The empty key is the inherent vowel.
+var vowels = map[string][2]string{
+	"":   {"â", "ô"},
+	"ា":  {"a", "éa"},
+	"ិ":  {"ĕ", "ĭ"},
+	"ី":  {"ei", "i"},
+	"ឹ":  {"œ̆", "œ̆"},
+	"ឺ":  {"œ", "œ"},
+	"ុ":  {"ŏ", "ŭ"},
+	"ូ":  {"o", "u"},
+	"ួ":  {"uŏ", "uŏ"},
+	"ើ":  {"aeu", "eu"},
+	"ឿ":  {"œă", "œă"},
+	"ៀ":  {"iĕ", "iĕ"},
+	"េ":  {"é", "é"},
+	"ែ":  {"ê", "ê"},
+	"ៃ":  {"ai", "ey"},
+	"ោ":  {"ao", "o"},
+	"ៅ":  {"au", "ŏv"},
+	"ំ":  {"âm", "um"},
+	"ាំ": {"ăm", "ŏâm"},
+	"ុំ": {"om", "um"},
+	"ះ":  {"ăh", "eăh"},
+	"ាះ": {"ăh", "eăh"},
+	"ិះ": {"ĕh", "ĭh"},
+	"ុះ": {"ŏh", "ŭh"},
+	"េះ": {"éh", "éh"},
+	"ោះ": {"aŏh", "uŏh"},
+}
+
+// shortVowels maps the vowels shortened by a bantoc on the final consonant
+var shortVowels = map[string][2]string{
+	"":  {"ă", "ŏ"},
+	"ា": {"ă", "ŏă"},
+}
+
+// independentVowels maps the vowels written as letters
+var independentVowels = map[rune]string{
+	'ឣ': "â", 'ឤ': "a", 'ឥ': "ĕ", 'ឦ': "ei", 'ឧ': "ŏ", 'ឩ': "u", 'ឪ': "âu",
+	'ឫ': "rœ̆", 'ឬ': "rœ", 'ឭ': "lœ̆", 'ឮ': "lœ", 'ឯ': "ê", 'ឰ': "ai",
+	'ឱ': "ao", 'ឲ': "ao", 'ឳ': "au",
+}
+
+// punctuation maps the punctuation marks and digits
+var punctuation = map[rune]string{
+	'។': ".", '៕': ".", '៖': ":", '៛': "riel",
+	'០': "0", '១': "1", '២': "2", '៣': "3", '៤': "4",
+	'៥': "5", '៦': "6", '៧': "7", '៨': "8", '៩': "9",
+}
+
+// cluster is a consonant with its subscripts, or an independent vowel, and
+// the signs written on it
+type cluster struct {
+	text        string
+	consonants  []rune // the base consonant then the subscript ones
+	independent rune   // independent vowel, 0 if none
+	vowel       string // dependent vowels, nikahit and reahmuk
+	shortened   bool   // bantoc: the vowel of the syllable is short
+	silent      bool   // toandakhiat or robat: the consonant isn't pronounced
+	series      int    // -1 unless set by a muusikatoan or a triisap
+}
+
+// syllable is a spoken syllable: an initial cluster, its vowel and the
+// consonant closing it
+type syllable struct {
+	text        string
+	initial     []rune
+	independent rune
+	series      int
+	vowel       string
+	final       rune
+	shortened   bool
+}
+
+func isKhmerLetter(r rune) bool {
+	return r >= 'ក' && r <= '៓' || r == '៝'
+}
+
+// parseClusters splits a Khmer word into clusters
+func parseClusters(word []rune) []cluster {
+	var clusters []cluster
+	for i := 0; i < len(word); {
+		c, next := parseCluster(word, i)
+		clusters = append(clusters, c)
+		i = next
+	}
+	return clusters
+}
+
+// parseCluster returns the cluster starting at start, and the index after it
+func parseCluster(word []rune, start int) (cluster, int) {
+	c := cluster{series: -1}
+	if _, ok := independentVowels[word[start]]; ok {
+		c.independent = word[start]
+	} else {
+		c.consonants = []rune{word[start]}
+	}
+	i := start + 1
+loop:
+	for ; i < len(word); i++ {
+		switch r := word[i]; {
+		case r == coeng && i+1 < len(word):
+			c.consonants = append(c.consonants, word[i+1])
+			i++
+		case r == bantoc:
+			c.shortened = true
+		case r == toandakhiat || r == robat:
+			c.silent = true
+		case r == muusikatoan:
+			c.series = firstSeries
+		case r == triisap:
+			c.series = secondSeries
+		case r >= 'ា' && r <= 'ៅ' || r == nikahit || r == reahmuk:
+			c.vowel += string(r)
+		case r >= 'ៈ' && r <= '៓':
+			// other signs
+		default:
+			break loop
+		}
+	}
+	c.text = string(word[start:i])
+	return c, i
+}
+
+// seriesOf returns the series of a consonant cluster: that of its last
+// subscript which isn't a sonorant, else that of its base consonant
+func seriesOf(cs []rune) int {
+	series := consonants[cs[0]].series
+	for _, c := range cs[1:] {
+		if !strings.ContainsRune(sonorants, c) {
+			series = consonants[c].series
+		}
+	}
+	return series
+}
+
+// parseSyllables splits a Khmer word into its spoken syllables. A consonant
+// without vowel closes the syllable before it; so does the base of a cluster
+// following an inherent vowel, its subscripts starting the next syllable
+// (កម្ពុជា kâm-pŭ-chéa).
+func parseSyllables(word []rune) []syllable {
+	clusters := parseClusters(word)
+	var syllables []syllable
+	for i, c := range clusters {
+		var prev *syllable
+		if len(syllables) > 0 {
+			prev = &syllables[len(syllables)-1]
+		}
+		open := prev != nil && prev.final == 0 && !strings.ContainsAny(prev.vowel, "ំះ")
+		switch {
+		case c.silent:
+			if prev != nil {
+				prev.text += c.text
+			} else {
+				syllables = append(syllables, syllable{text: c.text})
+			}
+			continue
+		case c.independent == 0 && c.vowel == "" && open && (len(c.consonants) == 1 || i == len(clusters)-1):
+			// a final consonant; a final cluster has its subscripts silent (មនុស្ស mônŭs)
+			prev.final = c.consonants[0]
+			prev.shortened = c.shortened
+			prev.text += c.text
+			continue
+		case c.independent == 0 && len(c.consonants) > 1 && open && prev.vowel == "" && prev.independent == 0:
+			prev.final = c.consonants[0]
+			prev.text += string(c.consonants[0])
+			c.consonants = c.consonants[1:]
+			c.text = strings.TrimPrefix(strings.TrimPrefix(c.text, string(prev.final)), string(coeng))
+		}
+		s := syllable{text: c.text, initial: c.consonants, independent: c.independent, vowel: c.vowel, shortened: c.shortened}
+		if c.independent == 0 {
+			s.series = seriesOf(c.consonants)
+			if c.series >= 0 {
+				s.series = c.series
+			}
+		}
+		syllables = append(syllables, s)
+	}
+	return syllables
+}
+
+// roman returns the UNGEGN romanization of a syllable
+func (s syllable) roman() string {
+	var out strings.Builder
+	if s.independent != 0 {
+		out.WriteString(independentVowels[s.independent])
+	} else if len(s.initial) > 0 {
+		for _, c := range s.initial {
+			out.WriteString(consonants[c].roman)
+		}
+		vowel, ok := vowels[s.vowel]
+		if short, isShort := shortVowels[s.vowel]; isShort && s.shortened {
+			vowel = short
+		}
+		if ok {
+			out.WriteString(vowel[s.series])
+		}
+	}
+	if s.final != 0 {
+		final := consonants[s.final].roman
+		if f, ok := finals[final]; ok {
+			final = f
+		}
+		out.WriteString(final)
+	}
+	return out.String()
+}
+
+// Romanize converts Khmer text to its UNGEGN romanization (កម្ពុជា
+// kâmpŭchéa, ភ្នំពេញ phnumpénh). Words aren't separated, as Khmer doesn't
+// separate them: segment the text first to get them apart. The repetition
+// mark ៗ repeats the word before it; characters outside of the Khmer block
+// are kept as is.
+//
+// The vowels are read according to the series of the consonant they are
+// written on. Syllables are found from the spelling alone, which misses some
+// irregular readings of Pali and Sanskrit loanwords.
+func Romanize(text string) string {
+	var out strings.Builder
+	var word []rune
+	var last string
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		var roman strings.Builder
+		for _, s := range parseSyllables(word) {
+			roman.WriteString(s.roman())
+		}
+		last = roman.String()
+		out.WriteString(last)
+		word = word[:0]
+	}
+	for _, r := range text {
+		if isKhmerLetter(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		switch {
+		case r == lekTooNDaa:
+			out.WriteString(" " + last)
+		case punctuation[r] != "":
+			out.WriteString(punctuation[r])
+		default:
+			out.WriteRune(r)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// UNGEGNProvider romanizes Khmer tokens with the UNGEGN system, see Romanize.
+type UNGEGNProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewUNGEGNProvider creates a new UNGEGNProvider.
+func NewUNGEGNProvider() *UNGEGNProvider {
+	return &UNGEGNProvider{}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *UNGEGNProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the UNGEGN romanizer).
+func (p *UNGEGNProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the UNGEGN romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *UNGEGNProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *UNGEGNProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("ungegn: context canceled during initialization: %w", err)
+	}
+	if scheme, _ := p.config["scheme"].(string); scheme != "" && scheme != ungegnScheme {
+		return fmt.Errorf("ungegn: unsupported transliteration scheme: %s", scheme)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *UNGEGNProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *UNGEGNProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *UNGEGNProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *UNGEGNProvider) Name() string {
+	return "ungegn"
+}
+
+func (p *UNGEGNProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *UNGEGNProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *UNGEGNProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *UNGEGNProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController romanizes the Khmer tokens of the input.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *UNGEGNProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("ungegn: context canceled during processing: %w", err)
+	}
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("ungegn: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("ungegn: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(Romanize(s))
+	}
+	return input, nil
+}
@@ -0,0 +1,29 @@
+package khm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/khm"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"កម្ពុជា", "kâmpŭchéa"}, // the base of ម្ព closes the first syllable
+		{"ភ្នំពេញ", "phnumpénh"}, // ន is a sonorant: ភ្ន is read in the second series
+		{"សៀមរាប", "siĕmréab"},
+		{"បាត់ដំបង", "bătdâmbâng"}, // bantoc shortens the vowel
+		{"អង្គរ", "ângkôr"},
+		{"ខ្មែរ", "khmêr"},
+		{"តាកែវ", "takêv"},
+		{"ផ្សេងៗ", "phséng phséng"}, // repetition mark
+		{"ព្រះ", "preăh"},
+		{"១២៣។", "123."},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, khm.Romanize(c.input), "input %q", c.input)
+	}
+}
@@ -0,0 +1,302 @@
+package lao
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const bgnScheme = "bgn_pcgn"
+
+// laoSchemes lists the romanization schemes offered by the LaoProvider.
+var laoSchemes = []common.TranslitScheme{
+	{Name: bgnScheme, Description: "BGN/PCGN 1966 romanization, as used for Lao place names (Viangchan, Louang Phabang, Savannakhét)"},
+}
+
+// initials maps Lao consonants to their romanization at the start of a syllable.
+var initials = map[rune]string{
+	'ກ': "k", 'ຂ': "kh", 'ຄ': "kh", 'ງ': "ng", 'ຈ': "ch", 'ສ': "s",
+	'ຊ': "x", 'ຍ': "gn", 'ດ': "d", 'ຕ': "t", 'ຖ': "th", 'ທ': "th",
+	'ນ': "n", 'ບ': "b", 'ປ': "p", 'ຜ': "ph", 'ຝ': "f", 'ພ': "ph",
+	'ຟ': "f", 'ມ': "m", 'ຢ': "y", 'ຣ': "r", 'ລ': "l", 'ວ': "v",
+	'ຫ': "h", 'ອ': "", 'ຮ': "h",
+	// Ligatures of ຫ with ນ and ມ
+	'ໜ': "n", 'ໝ': "m",
+}
+
+// finalConsonants maps the eight consonants allowed at the end of a syllable
+// to their romanization there.
+var finalConsonants = map[rune]string{
+	'ກ': "k", 'ງ': "ng", 'ດ': "t", 'ນ': "n", 'ບ': "p", 'ມ': "m", 'ຍ': "y", 'ວ': "o",
+}
+
+// openVowels maps the vowels of syllables without final consonant, written
+// as the leading vowel and the signs around the consonant "-", to their
+// romanization. Vowels ending with ຍ or ວ are listed whole.
+var openVowels = map[string]string{
+	"-": "o", "-ະ": "a", "-າ": "a", "-ິ": "i", "-ີ": "i", "-ຶ": "u", "-ື": "u",
+	"-ຸ": "ou", "-ູ": "ou", "ເ-ະ": "é", "ເ-": "é", "ແ-ະ": "è", "ແ-": "è",
+	"ໂ-ະ": "ô", "ໂ-": "ô", "ເ-າະ": "o", "-ໍ": "o", "ເ-ິ": "eu", "ເ-ີ": "eu",
+	"ເ-ັຍ": "ia", "ເ-ຍ": "ia", "ເ-ືອ": "ua", "-ົວ": "oua", "-ວ": "oua",
+	"ໄ-": "ai", "ໃ-": "ai", "ເ-ົາ": "ao", "-ຳ": "am",
+	"-າຍ": "ai", "-າວ": "ao", "-ິວ": "iou", "-ຸຍ": "oui", "-ອຍ": "oi",
+	"-ວຍ": "ouai", "ເ-ວ": "éo", "ແ-ວ": "èo", "ເ-ຍວ": "iou", "ໂ-ຍ": "ôi",
+}
+
+// closedVowels maps the vowels which are written differently when followed by
+// a final consonant (ກັນ, ເຂັນ, ກົດ, ຂອບ, ວຽງ). Other vowels keep the form
+// they have in openVowels.
+var closedVowels = map[string]string{
+	"-ັ": "a", "ເ-ັ": "é", "ແ-ັ": "è", "-ົ": "ô", "-ອ": "o", "-ວ": "oua",
+	"-ຽ": "ia", "-": "ô", "ເ-ິ": "eu",
+}
+
+// vowelSigns romanizes the signs of a vowel missing from the tables one by one.
+var vowelSigns = map[rune]string{
+	'ະ': "a", 'ັ': "a", 'າ': "a", 'ິ': "i", 'ີ': "i", 'ຶ': "u", 'ື': "u",
+	'ຸ': "ou", 'ູ': "ou", 'ົ': "ô", 'ຽ': "ia", 'ໍ': "o", 'ຳ': "am",
+	'ເ': "é", 'ແ': "è", 'ໂ': "ô", 'ໃ': "ai", 'ໄ': "ai",
+}
+
+// laoDigits maps Lao digits to ASCII digits.
+var laoDigits = map[rune]string{
+	'໐': "0", '໑': "1", '໒': "2", '໓': "3", '໔': "4",
+	'໕': "5", '໖': "6", '໗': "7", '໘': "8", '໙': "9",
+}
+
+// LaoProvider romanizes Lao tokens following the BGN/PCGN system in pure Go,
+// see Romanize.
+type LaoProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewLaoProvider creates a new LaoProvider.
+func NewLaoProvider() *LaoProvider {
+	return &LaoProvider{}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *LaoProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Lao romanizer).
+func (p *LaoProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Lao romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *LaoProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *LaoProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("lao: context canceled during initialization: %w", err)
+	}
+	if scheme, _ := p.config["scheme"].(string); scheme != "" && scheme != bgnScheme {
+		return fmt.Errorf("lao: unsupported romanization scheme: %s", scheme)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *LaoProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *LaoProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *LaoProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *LaoProvider) Name() string {
+	return "lao"
+}
+
+func (p *LaoProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *LaoProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *LaoProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *LaoProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController romanizes the Lao tokens of the input.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *LaoProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("lao: context canceled during processing: %w", err)
+	}
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("lao: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("lao: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(Romanize(s))
+	}
+	return input, nil
+}
+
+// Romanize romanizes Lao text following BGN/PCGN: the syllables of a run of
+// Lao letters are joined (ວຽງຈັນ → viangchan), tones are not marked and the
+// repetition mark ໆ repeats the syllable before it. Other characters are kept.
+func Romanize(text string) string {
+	var out strings.Builder
+	var run []rune
+	var last string
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		for _, s := range segmentLaoRun(run) {
+			last = romanizeSyllable(s)
+			out.WriteString(last)
+		}
+		run = run[:0]
+	}
+	for _, r := range text {
+		if isLaoLetter(r) {
+			run = append(run, r)
+			continue
+		}
+		flush()
+		switch {
+		case r == koLa:
+			out.WriteString(" " + last)
+		case laoDigits[r] != "":
+			out.WriteString(laoDigits[r])
+		case r == 'ຯ':
+			out.WriteString("...")
+		default:
+			out.WriteRune(r)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// romanizeSyllable romanizes a single Lao syllable.
+func romanizeSyllable(syllable string) string {
+	var rs []rune
+	for _, r := range syllable {
+		switch {
+		case isToneMark(r):
+		case r == cancelMark:
+			// the consonant under ໌ is silent
+			if len(rs) > 0 {
+				rs = rs[:len(rs)-1]
+			}
+		default:
+			rs = append(rs, r)
+		}
+	}
+
+	i := 0
+	var lead string
+	for i < len(rs) && isLeadingVowel(rs[i]) {
+		lead += string(rs[i])
+		i++
+	}
+	var initial string
+	if i < len(rs) {
+		// ຫ only gives its class to the sonorant following it: ຫວ່າງ vang
+		if rs[i] == hoSung && i+1 < len(rs) && (strings.ContainsRune(sonorants, rs[i+1]) || rs[i+1] == subscriptLo) {
+			i++
+			if rs[i] == subscriptLo {
+				initial = "l"
+				i++
+			}
+		}
+		if i < len(rs) && initial == "" {
+			initial = initials[rs[i]]
+			i++
+		}
+	}
+	for i < len(rs) {
+		if rs[i] == subscriptLo {
+			initial += "l"
+		} else if rs[i] == wo && lead == "" && i+1 < len(rs) && isVowelSign(rs[i+1]) {
+			// ກວ່າ, ຂວາ: ວ is part of the initial cluster
+			initial += "v"
+		} else {
+			break
+		}
+		i++
+	}
+
+	rest := rs[i:]
+	if v, ok := openVowels[lead+"-"+string(rest)]; ok {
+		return initial + v
+	}
+	if n := len(rest); n > 0 {
+		if final, ok := finalConsonants[rest[n-1]]; ok {
+			key := lead + "-" + string(rest[:n-1])
+			if v, ok := closedVowels[key]; ok {
+				return initial + v + final
+			}
+			if v, ok := openVowels[key]; ok {
+				return initial + v + final
+			}
+		}
+	}
+
+	roman := initial
+	for _, r := range lead + string(rest) {
+		if s, ok := vowelSigns[r]; ok {
+			roman += s
+		} else {
+			roman += finalConsonants[r]
+		}
+	}
+	return roman
+}
@@ -0,0 +1,62 @@
+package lao_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/lao"
+)
+
+func TestSegmentSyllables(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{"ສະຫວັນນະເຂດ", []string{"ສະ", "ຫວັນ", "ນະ", "ເຂດ"}},
+		{"ຂອບໃຈຫຼາຍໆ", []string{"ຂອບ", "ໃຈ", "ຫຼາຍໆ"}},
+		{"ເມືອງຫຼວງພະບາງ", []string{"ເມືອງ", "ຫຼວງ", "ພະ", "ບາງ"}},
+		{"ກວ່າ ປີ 2024", []string{"ກວ່າ", "ປີ", "2024"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, lao.SegmentSyllables(c.input), "input %q", c.input)
+	}
+}
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"ລາວ", "lao"},
+		{"ວຽງຈັນ", "viangchan"},
+		{"ຫຼວງພະບາງ", "louangphabang"},
+		{"ສະຫວັນນະເຂດ", "savannakhét"},
+		{"ຂອບໃຈ", "khopchai"},
+		{"ເມືອງ", "muang"},
+		{"ໄວໆ", "vai vai"},
+		{"ບໍ່", "bo"}, // tone marks are not romanized
+		{"໑໙໗໕", "1975"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, lao.Romanize(c.input), "input %q", c.input)
+	}
+}
+
+func TestModule(t *testing.T) {
+	m, err := lao.DefaultModule()
+	require.NoError(t, err)
+
+	roman, err := m.Roman("ສະບາຍດີ ວຽງຈັນ")
+	require.NoError(t, err)
+	assert.Equal(t, "sa bai di viang chan", roman)
+
+	tokens, err := m.Tokens("ໜ້າ")
+	require.NoError(t, err)
+	require.Len(t, tokens.Slice, 1)
+
+	tkn := tokens.Slice[0].(*lao.Tkn)
+	assert.Equal(t, "na", tkn.Roman())
+	assert.Equal(t, "high", tkn.ConsonantClass)
+	assert.Equal(t, "້", tkn.ToneMark)
+}
@@ -0,0 +1,48 @@
+package lao
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func init() {
+	syllablesEntry := common.ProviderEntry{
+		Provider:     NewSyllableTokenizerProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization},
+	}
+	laoEntry := common.ProviderEntry{
+		Provider:     NewLaoProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, syllablesEntry); err != nil {
+		panic(fmt.Sprintf("failed to register lao-syllables provider: %v", err))
+	}
+	if err := common.Register(Lang, laoEntry); err != nil {
+		panic(fmt.Sprintf("failed to register lao provider: %v", err))
+	}
+
+	for _, scheme := range laoSchemes {
+		scheme.Providers = []string{"lao-syllables", "lao"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Lao scheme")
+		}
+	}
+
+	if err := common.RegisterSpacingRule(Lang, SpacingRule); err != nil {
+		panic(fmt.Sprintf("failed to register Lao spacing rule: %v", err))
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		syllablesEntry,
+		laoEntry,
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
@@ -0,0 +1,31 @@
+package lao
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptLao   = "Laoo" // Lao script
+	ScriptLatin = "Latn" // Romanized/Latin script (BGN/PCGN...)
+)
+
+// Tkn extends the common Token with Lao-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Tones
+	ConsonantClass string // Class of the initial consonant (high, mid or low), ຫ leading a sonorant included
+	ToneMark       string // Tone mark written on the syllable (່ ້ ໊ ໋), empty if none
+}
+
+// SpacingRule is the spacing rule registered for Lao. The spaces found
+// between Lao phrases are kept as tokens, so no space is added around them.
+func SpacingRule(prev, current string) bool {
+	if strings.TrimRightFunc(prev, unicode.IsSpace) != prev || strings.TrimLeftFunc(current, unicode.IsSpace) != current {
+		return false
+	}
+	return common.DefaultSpacingRule(prev, current)
+}
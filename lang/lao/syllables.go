@@ -0,0 +1,306 @@
+package lao
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	hoSung      = 'ຫ' // high class ຫ, which gives its class to the sonorant following it
+	oAn         = 'ອ'
+	wo          = 'ວ'
+	subscriptLo = 'ຼ'
+	cancelMark  = '໌'
+	koLa        = 'ໆ' // repetition mark
+)
+
+var (
+	highClass = "ຂສຖຜຝຫໜໝ"
+	midClass  = "ກຈດຕບປຢອ"
+	sonorants = "ງຍນມຣລວ"
+	finals    = "ກງດນບມຍວ"
+)
+
+// SyllableTokenizerProvider is a pure Go tokenizer splitting Lao text into
+// syllables. Like Thai, Lao doesn't separate words, but its spelling is
+// phonetic enough for syllables to be found by rules: each consonant with the
+// vowels and marks written around it forms a cluster, and a cluster without
+// vowel closes the syllable before it when it can be a final consonant.
+type SyllableTokenizerProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewSyllableTokenizerProvider creates a new Lao syllable tokenizer
+func NewSyllableTokenizerProvider() *SyllableTokenizerProvider {
+	return &SyllableTokenizerProvider{}
+}
+
+func (p *SyllableTokenizerProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *SyllableTokenizerProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the tokenizer has no resources to download
+}
+
+// SaveConfig stores configuration for later application during initialization
+func (p *SyllableTokenizerProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *SyllableTokenizerProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *SyllableTokenizerProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *SyllableTokenizerProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// ProcessFlowController segments the raw input chunks into syllables
+func (p *SyllableTokenizerProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("lao-syllables: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("lao-syllables only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("lao-syllables: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("lao-syllables: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tokens, err := common.IntegrateProviderTokensV2(chunk, SegmentSyllables(chunk))
+		if err != nil {
+			common.Log.Debug().
+				Err(err).
+				Msg("Token integration had issues, continuing with partial results")
+		}
+		for _, token := range tokens {
+			tsw.Append(newSyllableToken(token))
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+func (p *SyllableTokenizerProvider) Name() string {
+	return "lao-syllables"
+}
+
+func (p *SyllableTokenizerProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *SyllableTokenizerProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// SegmentSyllables splits text into syllables: runs of Lao letters are split
+// into syllables, kept with the repetition mark ໆ following them, and runs of
+// other letters and digits are kept whole. Spaces and punctuation are left out.
+func SegmentSyllables(text string) []string {
+	var syllables []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch {
+		case isLaoLetter(rs[i]):
+			for j < len(rs) && isLaoLetter(rs[j]) {
+				j++
+			}
+			syllables = append(syllables, segmentLaoRun(rs[i:j])...)
+			for j < len(rs) && rs[j] == koLa {
+				syllables[len(syllables)-1] += string(koLa)
+				j++
+			}
+		case unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]):
+			for j < len(rs) && !isLaoLetter(rs[j]) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			syllables = append(syllables, string(rs[i:j]))
+		}
+		i = j
+	}
+	return syllables
+}
+
+// cluster is a consonant with the vowels written before it and the vowels
+// and marks written above, below and after it
+type cluster struct {
+	text      string
+	lead      rune // leading vowel (ເ ແ ໂ ໃ ໄ), 0 if none
+	consonant rune
+	hasVowel  bool
+}
+
+// laoSyllable is a syllable being built from clusters
+type laoSyllable struct {
+	text     string
+	hasVowel bool
+	closed   bool // the syllable can't take more letters
+	bareHo   bool // the syllable is a ຫ alone, which leads the sonorant following it
+}
+
+// segmentLaoRun splits a run of Lao letters into syllables.
+func segmentLaoRun(rs []rune) []string {
+	var syllables []laoSyllable
+	for _, c := range laoClusters(rs) {
+		bare := c.lead == 0 && !c.hasVowel
+		if n := len(syllables); n > 0 && !syllables[n-1].closed {
+			prev := &syllables[n-1]
+			merge := true
+			switch {
+			case prev.bareHo && strings.ContainsRune(sonorants, c.consonant),
+				// ກວ່າ, ຂວາ: ວ, ລ and ຣ following a consonant alone form a cluster with it
+				!prev.hasVowel && c.lead == 0 && c.hasVowel && strings.ContainsRune("ວລຣ", c.consonant):
+				prev.hasVowel = c.hasVowel
+				prev.closed = closes(c)
+			case bare && !prev.hasVowel && (c.consonant == wo || c.consonant == oAn):
+				// the vowels ົວ and ອ written without their first part (ກວນ, ຂອບ)
+				prev.hasVowel = true
+			case bare && c.consonant == oAn && strings.ContainsAny(prev.text, "ຶື"):
+				// ເ-ືອ
+			case bare && strings.ContainsRune(finals, c.consonant):
+				prev.closed = true
+			default:
+				merge = false
+			}
+			if merge {
+				prev.text += c.text
+				prev.bareHo = false
+				continue
+			}
+		}
+		syllables = append(syllables, laoSyllable{
+			text:     c.text,
+			hasVowel: !bare,
+			closed:   closes(c),
+			bareHo:   bare && c.consonant == hoSung,
+		})
+	}
+	texts := make([]string, len(syllables))
+	for i, s := range syllables {
+		texts[i] = s.text
+	}
+	return texts
+}
+
+// closes reports whether the vowel of a cluster can't be followed by a final
+// consonant: ະ, ຳ, ໍ, ໃ-, ໄ- and ເ-ົາ
+func closes(c cluster) bool {
+	return strings.ContainsAny(c.text, "ະຳໍ") || c.lead == 'ໃ' || c.lead == 'ໄ' ||
+		c.lead == 'ເ' && strings.Contains(c.text, "ົາ")
+}
+
+// laoClusters splits a run of Lao letters into clusters.
+func laoClusters(rs []rune) []cluster {
+	var clusters []cluster
+	for i := 0; i < len(rs); {
+		start := i
+		var c cluster
+		for i < len(rs) && isLeadingVowel(rs[i]) {
+			c.lead = rs[i]
+			i++
+		}
+		if i < len(rs) {
+			c.consonant = rs[i]
+			i++
+		}
+		for i < len(rs) && isDependent(rs[i]) {
+			if isVowelSign(rs[i]) {
+				c.hasVowel = true
+			}
+			i++
+		}
+		c.text = string(rs[start:i])
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+// isLaoLetter reports whether r is a Lao consonant, vowel or mark.
+func isLaoLetter(r rune) bool {
+	return r >= 0x0E80 && r <= 0x0EFF && (unicode.IsLetter(r) || unicode.IsMark(r)) && r != koLa
+}
+
+// isLeadingVowel reports whether r is one of ເ ແ ໂ ໃ ໄ, written before their consonant.
+func isLeadingVowel(r rune) bool {
+	return r >= 0x0EC0 && r <= 0x0EC4
+}
+
+// isDependent reports whether r can't start a cluster: vowels following a
+// consonant, the subscript ລ, the semivowel ຽ and the tone and other marks.
+func isDependent(r rune) bool {
+	return r >= 0x0EB0 && r <= 0x0EBD || r >= 0x0EC8 && r <= 0x0ECE
+}
+
+// isVowelSign reports whether r is a vowel following a consonant.
+func isVowelSign(r rune) bool {
+	return r >= 0x0EB0 && r <= 0x0EBB || r == 0x0EBD || r == 0x0ECD
+}
+
+// isToneMark reports whether r is one of the tone marks ່ ້ ໊ ໋.
+func isToneMark(r rune) bool {
+	return r >= 0x0EC8 && r <= 0x0ECB
+}
+
+// newSyllableToken wraps a token and finds the tone mark and the class of
+// the initial consonant of its syllable
+func newSyllableToken(token *common.Tkn) *Tkn {
+	tkn := &Tkn{Tkn: *token}
+	rs := []rune(token.Surface)
+	if !token.IsLexical || len(rs) == 0 || !isLaoLetter(rs[0]) {
+		return tkn
+	}
+	tkn.Script = ScriptLao
+	for i, r := range rs {
+		if isToneMark(r) {
+			tkn.ToneMark = string(r)
+		}
+		if tkn.ConsonantClass != "" || isLeadingVowel(r) {
+			continue
+		}
+		switch {
+		case r == hoSung && i+1 < len(rs) && (strings.ContainsRune(sonorants, rs[i+1]) || rs[i+1] == subscriptLo):
+			tkn.ConsonantClass = "high"
+		case strings.ContainsRune(highClass, r):
+			tkn.ConsonantClass = "high"
+		case strings.ContainsRune(midClass, r):
+			tkn.ConsonantClass = "mid"
+		default:
+			tkn.ConsonantClass = "low"
+		}
+	}
+	return tkn
+}
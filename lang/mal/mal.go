@@ -0,0 +1,15 @@
+package mal
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Tkn extends common.Tkn with Malayalam-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Script features
+	HasChillu         bool // ചില്ല് - Consonant without inherent vowel (ൻ ർ ൽ ൾ ൺ)
+	HasChandrakkala   bool // ചന്ദ്രക്കല - Virama, also marking the half-u (samvruthokaram)
+	HasSamvruthokaram bool // സംവൃതോകാരം - Word-final half-u
+}
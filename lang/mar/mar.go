@@ -1,6 +1,8 @@
 package mar
 
 import (
+	"strconv"
+
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
 )
 
@@ -134,7 +136,7 @@ func (t *Tkn) HasPostposition() bool {
 func (t *Tkn) GetVerbAgreement() string {
 	return t.VerbStructure.Gender + " " + 
 		t.VerbStructure.Number + " " + 
-		"Person-" + string(t.VerbStructure.Person)
+		"Person-" + strconv.Itoa(t.VerbStructure.Person)
 }
 
 // GetMorphologicalType returns the word's morphological classification
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"context"
+	"strings"
 
 	"github.com/tassa-yoniso-manasi-karoto/go-aksharamukha"
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
@@ -12,6 +13,12 @@ import (
 	"github.com/k0kubun/pp"
 )
 
+// aksharamukhaImage is the Docker image pulled by go-aksharamukha
+const aksharamukhaImage = "virtualvinodh/aksharamukha-back"
+
+// AksharamukhaDownloadSize is the approximate size in bytes of the aksharamukha image
+const AksharamukhaDownloadSize int64 = 1 << 30
+
 // AksharamukhaProvider satisfies the Provider interface
 type AksharamukhaProvider struct {
 	manager                  *aksharamukha.AksharamukhaManager
@@ -44,6 +51,27 @@ func (p *AksharamukhaProvider) SaveConfig(cfg map[string]interface{}) error {
 	return nil
 }
 
+// AksharamukhaOptions are the typed options of AksharamukhaProvider, see common.Configure.
+type AksharamukhaOptions struct {
+	// Lang is the ISO 639-3 code of the source language. Defaults to the provider's.
+	Lang string
+	// Scheme is the name of a target script registered for the language (e.g. "iast").
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *AksharamukhaProvider) ConfigureWith(opts AksharamukhaOptions) error {
+	lang := opts.Lang
+	if lang == "" {
+		lang = p.Lang
+	}
+	cfg := map[string]interface{}{"lang": lang}
+	if opts.Scheme != "" {
+		cfg["scheme"] = opts.Scheme
+	}
+	return p.SaveConfig(cfg)
+}
+
 // InitWithContext initializes the provider with the given context.
 // This sets up the aksharamukha library and applies any stored configuration.
 // The context is used for cancellation during initialization.
@@ -156,12 +184,24 @@ func (p *AksharamukhaProvider) applyConfig() error {
 }
 
 
+// ResourceVersions returns the digest of the aksharamukha Docker image,
+// implementing common.VersionReporter.
+func (p *AksharamukhaProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	return common.DockerImageVersions(ctx, aksharamukhaImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// aksharamukha runs in a Docker container.
+func (p *AksharamukhaProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
 func (p *AksharamukhaProvider) Name() string {
 	return "aksharamukha"
 }
 
 func (p *AksharamukhaProvider) SupportedModes() []common.OperatingMode {
-	return []common.OperatingMode{common.TransliteratorMode}
+	return []common.OperatingMode{common.TransliteratorMode, common.ReverseTransliteratorMode}
 }
 
 func (p *AksharamukhaProvider) GetMaxQueryLen() int {
@@ -216,6 +256,9 @@ func (p *AksharamukhaProvider) ProcessFlowController(ctx context.Context, mode c
 		return nil, fmt.Errorf("empty input was passed to processor")
 	}
 	if len(raw) != 0 {
+		if mode == common.ReverseTransliteratorMode {
+			return p.toNative(ctx, input)
+		}
 		//switch mode {
 		//case common.TransliteratorMode:
 		//	return p.process(ctx, raw)
@@ -235,7 +278,10 @@ func (p *AksharamukhaProvider) ProcessFlowController(ctx context.Context, mode c
 }
 
 // processTokens handles pre-tokenized input, adding romanization to tokens.
-// The context is used for cancellation during processing.
+// The lexical tokens are romanized in batches, see batchTokens, and the
+// batches concurrently if the module allows it, see
+// common.Module.WithTokenWorkers. The context is used for cancellation
+// during processing.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
@@ -245,36 +291,136 @@ func (p *AksharamukhaProvider) ProcessFlowController(ctx context.Context, mode c
 //   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
 //   - error: An error if processing fails or the context is canceled
 func (p *AksharamukhaProvider) processTokens(ctx context.Context, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
-	tokens := input.(*common.TknSliceWrapper).Slice
-	totalTokens := len(tokens)
-	
-	for idx, tkn := range tokens {
-		// Check for context cancellation
-		if err := ctx.Err(); err != nil {
-			return nil, fmt.Errorf("aksharamukha: context canceled while processing token %d: %w", idx, err)
-		}
-		
-		// Report progress if callback is set (throttler handles batching)
-		if p.progressCallback != nil {
-			p.progressCallback(idx, totalTokens)
+	batches := batchTokens(input)
+	err := common.ForEachIndex(ctx, len(batches), p.progressCallback, func(ctx context.Context, idx int) error {
+		return p.romanizeBatch(ctx, batches[idx])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// aksharamukhaBatchBytes is the maximum size of the text romanized in a
+// single request. The text is sent in the URL of a GET request, where each
+// byte of the Indic scripts takes 3 characters once escaped, so that the
+// batches stay under the 4 KB request line limit of common web servers.
+const aksharamukhaBatchBytes = 1024
+
+// aksharamukhaDelimiter separates the tokens of a batch: aksharamukha keeps
+// the line breaks of its input as is.
+const aksharamukhaDelimiter = "\n"
+
+// aksharamukhaTranslit calls the aksharamukha service, replaced in tests.
+var aksharamukhaTranslit = func(ctx context.Context, text string, from, to aksharamukha.Script) (string, error) {
+	return aksharamukha.TranslitWithContext(ctx, text, from, to, aksharamukha.DefaultOptions())
+}
+
+// batchTokens groups the lexical tokens of the input that aren't romanized
+// yet into batches of at most aksharamukhaBatchBytes, keeping their order.
+// The tokens containing the delimiter get a batch of their own.
+func batchTokens(input common.AnyTokenSliceWrapper) [][]common.AnyToken {
+	var batches [][]common.AnyToken
+	var batch []common.AnyToken
+	size := 0
+	flush := func() {
+		if len(batch) > 0 {
+			batches = append(batches, batch)
 		}
-		
+		batch, size = nil, 0
+	}
+	for idx := 0; idx < input.Len(); idx++ {
+		tkn := input.GetIdx(idx)
 		s := tkn.GetSurface()
 		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
 			continue
 		}
-		romanized, err := p.romanize(ctx, s)
+		if strings.Contains(s, aksharamukhaDelimiter) {
+			flush()
+			batches = append(batches, []common.AnyToken{tkn})
+			continue
+		}
+		if len(batch) > 0 && size+len(aksharamukhaDelimiter)+len(s) > aksharamukhaBatchBytes {
+			flush()
+		}
+		if len(batch) > 0 {
+			size += len(aksharamukhaDelimiter)
+		}
+		batch = append(batch, tkn)
+		size += len(s)
+	}
+	flush()
+	return batches
+}
+
+// romanizeBatch romanizes the tokens of a batch with a single request, or
+// token by token if the response doesn't have a line per token.
+func (p *AksharamukhaProvider) romanizeBatch(ctx context.Context, batch []common.AnyToken) error {
+	if len(batch) > 1 {
+		surfaces := make([]string, len(batch))
+		for i, tkn := range batch {
+			surfaces[i] = tkn.GetSurface()
+		}
+		romanized, err := p.romanize(ctx, strings.Join(surfaces, aksharamukhaDelimiter))
+		if err != nil {
+			return err
+		}
+		if parts := strings.Split(romanized, aksharamukhaDelimiter); len(parts) == len(batch) {
+			for i, tkn := range batch {
+				tkn.SetRoman(strings.TrimSpace(parts[i]))
+			}
+			return nil
+		}
+		common.Log.Debug().
+			Int("tokens", len(batch)).
+			Msg("aksharamukha: the romanized batch doesn't match its tokens, romanizing them one by one")
+	}
+	for _, tkn := range batch {
+		romanized, err := p.romanize(ctx, tkn.GetSurface())
 		if err != nil {
-			return nil, fmt.Errorf("romanization failed for token %s: %w", s, err)
+			return fmt.Errorf("romanization failed for token %s: %w", tkn.GetSurface(), err)
 		}
-		tkn.SetRoman(romanized)
+		tkn.SetRoman(strings.TrimSpace(romanized))
 	}
+	return nil
+}
 
-	return input, nil
+// toNative converts the raw chunks of the input from the configured scheme
+// back to the default script of the language, one token per chunk.
+func (p *AksharamukhaProvider) toNative(ctx context.Context, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if p.targetScheme == "" {
+		return nil, fmt.Errorf("aksharamukha: a scheme must be configured to convert text back to the native script")
+	}
+	script, err := defaultScriptFor(p.Lang)
+	if err != nil {
+		return nil, err
+	}
+	raw := input.GetRaw()
+	tsw := &common.TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("aksharamukha: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		native, err := aksharamukha.TranslitWithContext(ctx, chunk, p.targetScheme, script, aksharamukha.DefaultOptions())
+		if err != nil {
+			return nil, fmt.Errorf("conversion to %s failed for chunk %d with scheme %s: %w", script, idx, p.targetScheme, err)
+		}
+		tsw.Append(&common.Tkn{
+			Surface:      native,
+			Romanization: chunk,
+			IsLexical:    true,
+		})
+	}
+	input.ClearRaw()
+	return tsw, nil
 }
 
 // romanize converts text to a romanized form using the appropriate scheme.
-// It uses either the configured scheme or falls back to the default romanization.
+// It uses either the configured scheme or falls back to the default romanization
+// scheme of the language, see defaultRomanScheme.
 // Now accepts a context for cancellation.
 //
 // Parameters:
@@ -285,21 +431,63 @@ func (p *AksharamukhaProvider) processTokens(ctx context.Context, input common.A
 //   - string: The romanized text
 //   - error: An error if romanization fails
 func (p *AksharamukhaProvider) romanize(ctx context.Context, text string) (string, error) {
-	if p.targetScheme != "" {
-		script, err := aksharamukha.DefaultScriptFor(p.Lang)
-		if err != nil {
-			return "", fmt.Errorf("DefaultScriptFor failed for lang \"%s\": %w", p.Lang, err)
-		}
-		
-		// Use the context-aware version
-		romanized, err := aksharamukha.TranslitWithContext(ctx, text, script, p.targetScheme, aksharamukha.DefaultOptions())
-		if err != nil {
-			return "", fmt.Errorf("romanization failed for token \"%s\" with scheme %s: %w", text, p.targetScheme, err)
+	script, err := defaultScriptFor(p.Lang)
+	if err != nil {
+		return "", err
+	}
+	scheme := p.targetScheme
+	if scheme == "" {
+		if scheme, err = defaultRomanScheme(p.Lang, script); err != nil {
+			return "", err
 		}
-		return romanized, err
 	}
-	// Use the context-aware version for default romanization
-	return aksharamukha.RomanWithContext(ctx, text, p.Lang, aksharamukha.DefaultOptions())
+
+	// Use the context-aware version
+	romanized, err := aksharamukhaTranslit(ctx, text, script, scheme)
+	if err != nil {
+		return "", fmt.Errorf("romanization failed for token \"%s\" with scheme %s: %w", text, scheme, err)
+	}
+	return romanized, nil
+}
+
+// languageScripts are the scripts of the languages that go-aksharamukha
+// doesn't map to a script
+var languageScripts = map[string]aksharamukha.Script{
+	"kan": aksharamukha.Kannada,
+	"ori": aksharamukha.Oriya,
+}
+
+// defaultRomanSchemes are the schemes used when none is configured, for the
+// languages whose readers are better served by a practical romanization than
+// by ISO 15919
+var defaultRomanSchemes = map[string]aksharamukha.Script{
+	"ben": aksharamukha.RomanColloquial,
+	"ori": aksharamukha.RomanColloquial,
+}
+
+// defaultRomanScheme returns the scheme a language written in script is
+// romanized with when no scheme is configured.
+func defaultRomanScheme(lang string, script aksharamukha.Script) (aksharamukha.Script, error) {
+	if scheme, ok := defaultRomanSchemes[lang]; ok {
+		return scheme, nil
+	}
+	roman, ok := aksharamukha.Script2RomanScheme[string(script)]
+	if !ok {
+		return "", fmt.Errorf("no default romanization scheme for script %s", script)
+	}
+	return aksharamukha.Script(roman), nil
+}
+
+// defaultScriptFor returns the script a language is written in.
+func defaultScriptFor(lang string) (aksharamukha.Script, error) {
+	if script, ok := languageScripts[lang]; ok {
+		return script, nil
+	}
+	script, err := aksharamukha.DefaultScriptFor(lang)
+	if err != nil {
+		return "", fmt.Errorf("DefaultScriptFor failed for lang \"%s\": %w", lang, err)
+	}
+	return script, nil
 }
 
 
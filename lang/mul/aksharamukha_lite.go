@@ -0,0 +1,299 @@
+package mul
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The Devanagari, Bengali, Oriya, Tamil, Telugu, Kannada and Malayalam blocks
+// share the layout of ISCII: a letter is at the same offset from the start of the
+// block in all these scripts, so the tables below are indexed by that offset.
+const (
+	devanagariBlock = 0x0900
+	bengaliBlock    = 0x0980
+	oriyaBlock      = 0x0B00
+	tamilBlock      = 0x0B80
+	teluguBlock     = 0x0C00
+	kannadaBlock    = 0x0C80
+	malayalamBlock  = 0x0D00
+	indicBlockSize  = 0x80
+)
+
+// liteBlocks are the blocks of the scripts known to aksharamukha-lite
+var liteBlocks = []rune{devanagariBlock, bengaliBlock, oriyaBlock, tamilBlock, teluguBlock, kannadaBlock, malayalamBlock}
+
+const (
+	liteNukta  = 0x3C
+	liteVirama = 0x4D
+)
+
+// liteConsonants are romanized without their inherent vowel
+var liteConsonants = map[rune]string{
+	0x15: "k", 0x16: "kh", 0x17: "g", 0x18: "gh", 0x19: "ṅ",
+	0x1A: "c", 0x1B: "ch", 0x1C: "j", 0x1D: "jh", 0x1E: "ñ",
+	0x1F: "ṭ", 0x20: "ṭh", 0x21: "ḍ", 0x22: "ḍh", 0x23: "ṇ",
+	0x24: "t", 0x25: "th", 0x26: "d", 0x27: "dh", 0x28: "n", 0x29: "ṉ",
+	0x2A: "p", 0x2B: "ph", 0x2C: "b", 0x2D: "bh", 0x2E: "m",
+	0x2F: "y", 0x30: "r", 0x31: "ṟ", 0x32: "l", 0x33: "ḷ", 0x34: "ḻ", 0x35: "v",
+	0x36: "ś", 0x37: "ṣ", 0x38: "s", 0x39: "h",
+	0x5F: "ẏ", // Oriya yya, the y of Odia (ଯ is pronounced j)
+}
+
+// liteNuktaConsonants are the consonants followed by a nukta, found in
+// loanwords (क़ for the q of Arabic, फ़ for f...). NFC decomposes the
+// precomposed forms (U+0958...) into consonant and nukta.
+var liteNuktaConsonants = map[rune]string{
+	0x15: "q", 0x16: "k͟h", 0x17: "ġ", 0x1C: "z",
+	0x21: "ṛ", 0x22: "ṛh", 0x2B: "f", 0x2F: "ẏ",
+}
+
+// liteMatras are the vowel signs replacing the inherent vowel of a consonant
+var liteMatras = map[rune]string{
+	0x3E: "ā", 0x3F: "i", 0x40: "ī", 0x41: "u", 0x42: "ū",
+	0x43: "ṛ", 0x44: "ṝ", 0x45: "ê", 0x46: "e", 0x47: "e", 0x48: "ai",
+	0x49: "ô", 0x4A: "o", 0x4B: "o", 0x4C: "au", 0x57: "au", 0x62: "ḷ", 0x63: "ḹ",
+}
+
+// liteLetters are the independent vowels, signs, digits and punctuation
+var liteLetters = map[rune]string{
+	0x01: "m̐", 0x02: "ṃ", 0x03: "ḥ",
+	0x05: "a", 0x06: "ā", 0x07: "i", 0x08: "ī", 0x09: "u", 0x0A: "ū",
+	0x0B: "ṛ", 0x0C: "ḷ", 0x0D: "ê", 0x0E: "e", 0x0F: "e", 0x10: "ai",
+	0x11: "ô", 0x12: "o", 0x13: "o", 0x14: "au",
+	0x3D: "'", 0x50: "oṃ", 0x60: "ṝ", 0x61: "ḹ", 0x64: ".", 0x65: "..",
+	0x66: "0", 0x67: "1", 0x68: "2", 0x69: "3", 0x6A: "4",
+	0x6B: "5", 0x6C: "6", 0x6D: "7", 0x6E: "8", 0x6F: "9",
+}
+
+// liteISO are the letters romanized differently in ISO 15919 than in IAST:
+// vocalic r and l get a ring below, so that ṛ can stand for ड़, and e and o
+// are marked long, to tell them from the short e and o of Tamil.
+var liteISO = map[rune]string{
+	0x02: "ṁ", 0x0B: "r̥", 0x0C: "l̥", 0x0F: "ē", 0x13: "ō", 0x60: "r̥̄", 0x61: "l̥̄",
+	0x43: "r̥", 0x44: "r̥̄", 0x47: "ē", 0x4B: "ō", 0x62: "l̥", 0x63: "l̥̄",
+}
+
+// liteScriptLetters are the letters peculiar to a script
+var liteScriptLetters = map[rune]map[rune]string{
+	bengaliBlock: {0x4E: "t"}, // khanda ta, a t without inherent vowel
+	tamilBlock:   {0x03: "ḵ"}, // aytam
+	// dot reph, then the chillus: consonants without inherent vowel
+	malayalamBlock: {
+		0x4E: "r", 0x54: "m", 0x55: "y", 0x56: "ḻ",
+		0x7A: "ṇ", 0x7B: "n", 0x7C: "r", 0x7D: "l", 0x7E: "ḷ", 0x7F: "k",
+	},
+}
+
+// AksharamukhaLiteProvider is a pure Go transliterator of Devanagari, Bengali,
+// Oriya and the scripts of South India into IAST or ISO 15919, for the hosts where aksharamukha can't run
+// for lack of Docker. It is a letter by letter conversion: unlike aksharamukha,
+// it doesn't know the conventions of the individual languages (no schwa
+// deletion, no Tamil voicing rules...) and leaves the other scripts as is.
+type AksharamukhaLiteProvider struct {
+	config           map[string]interface{}
+	iso              bool
+	progressCallback common.ProgressCallback
+}
+
+// NewAksharamukhaLiteProvider creates a provider transliterating into IAST
+// until configured otherwise.
+func NewAksharamukhaLiteProvider() *AksharamukhaLiteProvider {
+	return &AksharamukhaLiteProvider{}
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+// The "scheme" key selects the output: "IAST" (default) or "ISO", with or
+// without the "-lite" suffix of the schemes registered for the provider.
+//
+// Returns an error if the scheme isn't supported.
+func (p *AksharamukhaLiteProvider) SaveConfig(cfg map[string]interface{}) error {
+	scheme, _ := cfg["scheme"].(string)
+	switch strings.TrimSuffix(scheme, "-lite") {
+	case "", "IAST":
+		p.iso = false
+	case "ISO":
+		p.iso = true
+	default:
+		return fmt.Errorf("unsupported transliteration scheme: %s", scheme)
+	}
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext initializes the provider with the given context.
+// The tables are built in, so there is nothing to initialize.
+func (p *AksharamukhaLiteProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("aksharamukha-lite: context canceled during initialization: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *AksharamukhaLiteProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as the provider
+// holds no resources.
+func (p *AksharamukhaLiteProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *AksharamukhaLiteProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// CloseWithContext is a no-op as the provider holds no resources.
+func (p *AksharamukhaLiteProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as the provider holds no resources.
+func (p *AksharamukhaLiteProvider) Close() error {
+	return nil
+}
+
+func (p *AksharamukhaLiteProvider) Name() string {
+	return "aksharamukha-lite"
+}
+
+func (p *AksharamukhaLiteProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *AksharamukhaLiteProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *AksharamukhaLiteProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback is a no-op: the provider downloads nothing.
+func (p *AksharamukhaLiteProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+}
+
+// ProcessFlowController romanizes the tokens of a tokenized input.
+func (p *AksharamukhaLiteProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if input.Len() == 0 && len(input.GetRaw()) == 0 {
+		return nil, fmt.Errorf("empty input was passed to processor")
+	}
+	if mode != common.TransliteratorMode || len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+
+	totalTokens := input.Len()
+	for idx := 0; idx < totalTokens; idx++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("aksharamukha-lite: context canceled while processing token %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, totalTokens)
+		}
+		tkn := input.GetIdx(idx)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(p.Transliterate(s))
+	}
+	return input, nil
+}
+
+// Transliterate romanizes the letters of text written in a script listed in
+// liteBlocks in the configured scheme, leaving the other characters as they are.
+func (p *AksharamukhaLiteProvider) Transliterate(text string) string {
+	runes := []rune(norm.NFC.String(text))
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		block, offset, ok := indicOffset(runes[i])
+		if !ok {
+			b.WriteRune(runes[i])
+			continue
+		}
+		consonant, isConsonant := liteConsonants[offset]
+		if !isConsonant {
+			if s, ok := p.letter(block, offset); ok {
+				b.WriteString(s)
+			} else if _, isMatra := liteMatras[offset]; !isMatra && offset != liteVirama && offset != liteNukta {
+				b.WriteRune(runes[i])
+			}
+			continue
+		}
+
+		if next, ok := sameBlockOffset(runes, i+1, block); ok && next == liteNukta {
+			if s, ok := liteNuktaConsonants[offset]; ok {
+				consonant = s
+			}
+			i++
+		}
+		b.WriteString(consonant)
+
+		// the inherent vowel is replaced by a matra and dropped by the virama
+		next, ok := sameBlockOffset(runes, i+1, block)
+		switch {
+		case ok && next == liteVirama:
+			i++
+		case ok && liteMatras[next] != "":
+			b.WriteString(p.pick(next, liteMatras[next]))
+			i++
+		default:
+			b.WriteString("a")
+		}
+	}
+	return b.String()
+}
+
+// letter returns the romanization of the letter that isn't a consonant nor a matra.
+func (p *AksharamukhaLiteProvider) letter(block, offset rune) (string, bool) {
+	if s, ok := liteScriptLetters[block][offset]; ok {
+		return s, true
+	}
+	s, ok := liteLetters[offset]
+	if !ok {
+		return "", false
+	}
+	return p.pick(offset, s), true
+}
+
+// pick returns the ISO 15919 romanization of the letter at offset when it
+// differs from its IAST one and ISO is configured.
+func (p *AksharamukhaLiteProvider) pick(offset rune, iast string) string {
+	if iso, ok := liteISO[offset]; ok && p.iso {
+		return iso
+	}
+	return iast
+}
+
+// indicOffset returns the start of the block of r and the offset of r in it,
+// if r belongs to one of liteBlocks.
+func indicOffset(r rune) (block, offset rune, ok bool) {
+	for _, block := range liteBlocks {
+		if r >= block && r < block+indicBlockSize {
+			return block, r - block, true
+		}
+	}
+	return 0, 0, false
+}
+
+// sameBlockOffset returns the offset of runes[i] if it belongs to block.
+func sameBlockOffset(runes []rune, i int, block rune) (rune, bool) {
+	if i >= len(runes) {
+		return 0, false
+	}
+	b, offset, ok := indicOffset(runes[i])
+	if !ok || b != block {
+		return 0, false
+	}
+	return offset, true
+}
+
+var _ common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] = (*AksharamukhaLiteProvider)(nil)
@@ -0,0 +1,131 @@
+package mul
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common/testkit"
+)
+
+func TestAksharamukhaLite(t *testing.T) {
+	iast := NewAksharamukhaLiteProvider()
+	iso := NewAksharamukhaLiteProvider()
+	require.NoError(t, iso.SaveConfig(map[string]interface{}{"lang": "hin", "scheme": "ISO-lite"}))
+
+	cases := []struct{ native, iast, iso string }{
+		{"संस्कृतम्", "saṃskṛtam", "saṁskr̥tam"},
+		{"नमस्ते", "namaste", "namastē"},
+		{"क़िला", "qilā", "qilā"}, // nukta
+		{"ज़िंदगी", "ziṃdagī", "ziṁdagī"},
+		{"হৃৎপিণ্ড", "hṛtpiṇḍa", "hr̥tpiṇḍa"}, // khanda ta
+		{"তামিল নাড়ু", "tāmila nāṛu", "tāmila nāṛu"},
+		{"ଓଡ଼ିଆ ଭାଷା", "oṛiā bhāṣā", "ōṛiā bhāṣā"},
+		{"தமிழ்", "tamiḻ", "tamiḻ"},
+		{"கோயில்", "koyil", "kōyil"},
+		{"ஃபோன்", "ḵpoṉ", "ḵpōṉ"},
+		{"తెలుగు", "telugu", "telugu"},
+		{"ಕನ್ನಡ", "kannaḍa", "kannaḍa"},
+		{"കേരളം", "keraḷaṃ", "kēraḷaṁ"},
+		{"അവൻ വന്നു", "avan vannu", "avan vannu"}, // chillu
+		{"राम 2", "rāma 2", "rāma 2"},
+		{"१९४७।", "1947.", "1947."},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.iast, iast.Transliterate(c.native), c.native)
+		assert.Equal(t, c.iso, iso.Transliterate(c.native), c.native)
+	}
+
+	assert.Error(t, iast.SaveConfig(map[string]interface{}{"scheme": "ITRANS"}))
+}
+
+func TestAksharamukhaLitePlatformFallback(t *testing.T) {
+	common.SetPlatform(&common.Platform{OS: "windows", Arch: "arm64"})
+	defer common.SetPlatform(nil)
+
+	m, err := common.DefaultModule("hin")
+	require.NoError(t, err)
+	assert.Equal(t, "uniseg→aksharamukha-lite", m.ProviderNames())
+
+	parts, err := m.RomanParts("मेरा नाम")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"merā", "nāma"}, parts)
+}
+
+func TestInitProgressCallback(t *testing.T) {
+	m, err := common.GetSchemeModule("hin", "IAST-lite")
+	require.NoError(t, err)
+
+	var events []string
+	m.WithInitProgressCallback(func(event common.InitEvent) {
+		events = append(events, event.Provider+" "+string(event.Phase))
+	})
+	require.NoError(t, m.Init())
+	assert.Equal(t, []string{
+		"uniseg starting", "uniseg ready",
+		"aksharamukha-lite starting", "aksharamukha-lite ready",
+	}, events)
+}
+
+func TestAksharamukhaLiteCorpus(t *testing.T) {
+	m, err := common.GetSchemeModule("hin", "IAST-lite")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	testkit.AssertRomanization(t, m, "testdata/iast-lite.yaml")
+}
+
+func TestFindProvidersByCapability(t *testing.T) {
+	// hin has no providers of its own in this package, only the multilingual ones
+	entries, err := common.FindProvidersByCapability("hin", common.CapabilityTransliteration)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Provider.Name())
+	}
+	assert.Contains(t, names, "aksharamukha")
+	assert.Contains(t, names, "aksharamukha-lite")
+	assert.NotContains(t, names, "uniseg")
+}
+
+func TestRegisterLanguageDefaults(t *testing.T) {
+	// Nepali is written in Devanagari, a language pack can set it up with
+	// the providers of mul
+	_, err := common.DefaultModule("nep")
+	require.ErrorIs(t, err, common.ErrLanguageNotRegistered)
+	assert.Contains(t, err.Error(), "hin", "the error should list the registered languages")
+	_, err = common.NewModule("nep", "uniseg", "missing")
+	require.ErrorIs(t, err, common.ErrLanguageNotRegistered)
+
+	require.NoError(t, common.AddRegistrationValidator(func(lang string, entry common.ProviderEntry) error {
+		if lang == "nep" && common.RequirementsOf(entry.Provider).Docker {
+			return errors.New("no Docker for Nepali")
+		}
+		return nil
+	}))
+	err = common.Register("nep", common.ProviderEntry{
+		Provider:     NewAksharamukhaProvider("nep"),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Docker for Nepali")
+
+	require.Error(t, common.RegisterLanguageDefaults("nep", common.LanguageDefaults{Providers: []string{"uniseg", "missing"}}))
+	require.NoError(t, common.RegisterLanguageDefaults("nep", common.LanguageDefaults{
+		Providers:            []string{"uniseg", "Aksharamukha-Lite"},
+		NeedsTransliteration: true,
+	}))
+	needed, err := common.NeedsTransliteration("ne")
+	require.NoError(t, err)
+	assert.True(t, needed)
+	assert.Contains(t, common.Languages(), "nep")
+
+	m, err := common.DefaultModule("nep")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+	parts, err := m.RomanParts("मेरो नाम")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mero", "nāma"}, parts)
+}
@@ -0,0 +1,103 @@
+package mul
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/go-aksharamukha"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestAksharamukhaDefaultScheme(t *testing.T) {
+	cases := map[string]aksharamukha.Script{
+		"hin": aksharamukha.ISO,
+		"kan": aksharamukha.ISO,
+		"ben": aksharamukha.RomanColloquial,
+		"ori": aksharamukha.RomanColloquial,
+	}
+	for lang, expected := range cases {
+		script, err := defaultScriptFor(lang)
+		require.NoError(t, err, lang)
+		scheme, err := defaultRomanScheme(lang, script)
+		require.NoError(t, err, lang)
+		assert.Equal(t, expected, scheme, lang)
+	}
+	script, err := defaultScriptFor("ori")
+	require.NoError(t, err)
+	assert.Equal(t, aksharamukha.Oriya, script)
+
+	// the two-letter codes resolve to the default pipelines too
+	for _, code := range []string{"bn", "or"} {
+		m, err := common.DefaultModule(code)
+		require.NoError(t, err, code)
+		assert.Contains(t, m.ProviderNames(), "uniseg→aksharamukha", code) // -lite without Docker
+		_, err = common.GetSchemeModule(code, "IAST")
+		assert.NoError(t, err, code)
+	}
+}
+
+func TestAksharamukhaBatches(t *testing.T) {
+	var requests []string
+	translit := func(ctx context.Context, text string, from, to aksharamukha.Script) (string, error) {
+		requests = append(requests, text)
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			lines[i] = "r(" + line + ")"
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+	defer func(orig func(context.Context, string, aksharamukha.Script, aksharamukha.Script) (string, error)) {
+		aksharamukhaTranslit = orig
+	}(aksharamukhaTranslit)
+	aksharamukhaTranslit = translit
+
+	p := NewAksharamukhaProvider("hin")
+	tokens := func(words ...string) *common.TknSliceWrapper {
+		tsw := &common.TknSliceWrapper{}
+		for _, w := range words {
+			tsw.Append(&common.Tkn{Surface: w, IsLexical: w != " "})
+		}
+		return tsw
+	}
+	romans := func(tsw common.AnyTokenSliceWrapper) []string {
+		var r []string
+		for i := 0; i < tsw.Len(); i++ {
+			r = append(r, tsw.GetIdx(i).Roman())
+		}
+		return r
+	}
+
+	out, err := p.ProcessFlowController(context.Background(), common.TransliteratorMode, tokens("नमस्ते", " ", "दुनिया"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r(नमस्ते)", "", "r(दुनिया)"}, romans(out))
+	assert.Equal(t, []string{"नमस्ते\nदुनिया"}, requests, "the tokens are romanized in a single request")
+
+	// the batches are bounded in size
+	requests = nil
+	words := make([]string, 200)
+	for i := range words {
+		words[i] = "नमस्ते"
+	}
+	out, err = p.ProcessFlowController(context.Background(), common.TransliteratorMode, tokens(words...))
+	require.NoError(t, err)
+	assert.Greater(t, len(requests), 1)
+	for _, r := range requests {
+		assert.LessOrEqual(t, len(r), aksharamukhaBatchBytes)
+	}
+	assert.Equal(t, "r(नमस्ते)", out.GetIdx(199).Roman())
+
+	// a response that doesn't match the batch falls back to one request per
+	// token, whose romanization is trimmed the same way
+	requests = nil
+	aksharamukhaTranslit = func(ctx context.Context, text string, from, to aksharamukha.Script) (string, error) {
+		requests = append(requests, text)
+		return "r(" + strings.ReplaceAll(text, "\n", " ") + ") ", nil
+	}
+	out, err = p.ProcessFlowController(context.Background(), common.TransliteratorMode, tokens("नमस्ते", "दुनिया"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r(नमस्ते)", "r(दुनिया)"}, romans(out))
+	assert.Len(t, requests, 3)
+}
@@ -0,0 +1,168 @@
+package mul
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/go-aksharamukha"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+// AksharamukhaConverter is the common.ScriptConverter backed by aksharamukha,
+// converting between any two of the scripts it supports (see
+// aksharamukha.Script): "Devanagari", "Tamil", "Thai", "IAST"... The Docker
+// container of aksharamukha is pulled and started on the first conversion.
+type AksharamukhaConverter struct {
+	mu          sync.Mutex
+	initialized bool
+}
+
+func (c *AksharamukhaConverter) Name() string {
+	return "aksharamukha"
+}
+
+// Supports implements common.ScriptConverter.
+func (c *AksharamukhaConverter) Supports(fromScript, toScript string) bool {
+	return aksharamukha.IsValidScript(aksharamukha.Script(fromScript)) &&
+		aksharamukha.IsValidScript(aksharamukha.Script(toScript))
+}
+
+// ConvertScript implements common.ScriptConverter.
+func (c *AksharamukhaConverter) ConvertScript(ctx context.Context, text, fromScript, toScript string) (string, error) {
+	if err := c.init(ctx); err != nil {
+		return "", err
+	}
+	return aksharamukha.TranslitWithContext(ctx, text, aksharamukha.Script(fromScript), aksharamukha.Script(toScript), aksharamukha.DefaultOptions())
+}
+
+// init starts the default aksharamukha container once.
+func (c *AksharamukhaConverter) init(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.initialized {
+		return nil
+	}
+	if err := aksharamukha.PullImagesWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to pull aksharamukha images: %w", err)
+	}
+	if err := aksharamukha.InitWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to initialize aksharamukha: %w", err)
+	}
+	c.initialized = true
+	return nil
+}
+
+// Serbian is written in both Cyrillic and Latin (Gaj's alphabet), which map
+// to each other letter by letter, the Latin digraphs lj, nj and dž standing
+// for a single Cyrillic letter.
+const (
+	SerbianCyrillic = "SerbianCyrillic"
+	SerbianLatin    = "SerbianLatin"
+)
+
+var serbianCyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'ђ': "đ", 'е': "e",
+	'ж': "ž", 'з': "z", 'и': "i", 'ј': "j", 'к': "k", 'л': "l", 'љ': "lj",
+	'м': "m", 'н': "n", 'њ': "nj", 'о': "o", 'п': "p", 'р': "r", 'с': "s",
+	'т': "t", 'ћ': "ć", 'у': "u", 'ф': "f", 'х': "h", 'ц': "c", 'ч': "č",
+	'џ': "dž", 'ш': "š",
+}
+
+// serbianLatinToCyrillic is the reverse of serbianCyrillicToLatin
+var serbianLatinToCyrillic = func() map[string]rune {
+	m := make(map[string]rune, len(serbianCyrillicToLatin))
+	for cyr, lat := range serbianCyrillicToLatin {
+		m[lat] = cyr
+	}
+	return m
+}()
+
+// SerbianConverter is the common.ScriptConverter between the Cyrillic and
+// Latin alphabets of Serbian. Converting from Latin reads lj, nj and dž as
+// digraphs, which is wrong for the few compounds where they span two
+// morphemes (nadživeti is надживети, not наџивети).
+type SerbianConverter struct{}
+
+func (SerbianConverter) Name() string {
+	return "serbian"
+}
+
+// Supports implements common.ScriptConverter.
+func (SerbianConverter) Supports(fromScript, toScript string) bool {
+	return fromScript == SerbianCyrillic && toScript == SerbianLatin ||
+		fromScript == SerbianLatin && toScript == SerbianCyrillic
+}
+
+// ConvertScript implements common.ScriptConverter.
+func (SerbianConverter) ConvertScript(ctx context.Context, text, fromScript, toScript string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if fromScript == SerbianCyrillic {
+		return serbianToLatin(text), nil
+	}
+	return serbianToCyrillic(text), nil
+}
+
+func serbianToLatin(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	for i, r := range runes {
+		lat, ok := serbianCyrillicToLatin[unicode.ToLower(r)]
+		switch {
+		case !ok:
+			b.WriteRune(r)
+		case !unicode.IsUpper(r):
+			b.WriteString(lat)
+		case inCapitals(runes, i):
+			b.WriteString(strings.ToUpper(lat))
+		default:
+			upper := []rune(lat)
+			upper[0] = unicode.ToUpper(upper[0])
+			b.WriteString(string(upper))
+		}
+	}
+	return b.String()
+}
+
+// inCapitals reports whether the capital letter at index i is part of a word
+// written in capitals, so that Љ is written LJ in ЉУБАВ and Lj in Љубав.
+func inCapitals(runes []rune, i int) bool {
+	if i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+		return unicode.IsUpper(runes[i+1])
+	}
+	return i > 0 && unicode.IsUpper(runes[i-1])
+}
+
+func serbianToCyrillic(text string) string {
+	runes := []rune(norm.NFC.String(text))
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		matched := false
+		for size := min(2, len(runes)-i); size > 0; size-- {
+			cyr, ok := serbianLatinToCyrillic[strings.ToLower(string(runes[i:i+size]))]
+			if !ok {
+				continue
+			}
+			if unicode.IsUpper(runes[i]) {
+				cyr = unicode.ToUpper(cyr)
+			}
+			b.WriteRune(cyr)
+			i += size
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+var _ common.ScriptConverter = (*AksharamukhaConverter)(nil)
+var _ common.ScriptConverter = SerbianConverter{}
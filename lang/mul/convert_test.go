@@ -0,0 +1,30 @@
+package mul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestConvertSerbian(t *testing.T) {
+	cases := map[string]string{
+		"Београд":         "Beograd",
+		"Љубав и њива":    "Ljubav i njiva",
+		"ЏЕП, Ђорђе, ЉУТ": "DŽEP, Đorđe, LJUT",
+		"Ћирилица 2024.":  "Ćirilica 2024.",
+	}
+	for cyrillic, latin := range cases {
+		converted, err := common.Convert(cyrillic, SerbianCyrillic, SerbianLatin)
+		require.NoError(t, err)
+		assert.Equal(t, latin, converted, cyrillic)
+
+		converted, err = common.Convert(latin, SerbianLatin, SerbianCyrillic)
+		require.NoError(t, err)
+		assert.Equal(t, cyrillic, converted, latin)
+	}
+
+	_, err := common.Convert("Београд", SerbianCyrillic, "Klingon")
+	assert.ErrorIs(t, err, common.ErrScriptConversionUnsupported)
+}
@@ -0,0 +1,163 @@
+package mul
+
+import (
+	"strings"
+	"unicode"
+
+	iuliia "github.com/mehanizm/iuliia-go"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// iuliia only ships Russian and Uzbek schemas: the Ukrainian and Belarusian
+// ones are defined here in its format. Mapping applies to a letter, PrevMapping
+// to a letter given the previous one (or to a word-initial letter when the key
+// is a single letter) and NextMapping to a letter given the next one.
+
+var ukrainianSchemes = []common.TranslitScheme{
+	{Name: "kmu_2010", Description: "Resolution of the Cabinet of Ministers of Ukraine No. 55 (2010) - Ukrainian National Standard, also adopted by BGN/PCGN and UNGEGN"},
+	{Name: "bgn_pcgn", Description: "Board on Geographic Names - Permanent Committee on Geographical Names (1965)"},
+}
+
+var belarusianSchemes = []common.TranslitScheme{
+	{Name: "national", Description: "Instruction on the transliteration of Belarusian geographic names (2007) - Belarusian National Standard, adopted by UNGEGN"},
+	{Name: "bgn_pcgn", Description: "Board on Geographic Names - Permanent Committee on Geographical Names (1979)"},
+}
+
+// Ukrainian national system
+// https://zakon.rada.gov.ua/laws/show/55-2010-п
+var ukrKMU2010 = &iuliia.Schema{
+	Name: "kmu_2010",
+	Desc: "Ukrainian National Standard (2010)",
+	Mapping: map[string]string{
+		"г": "h", "ґ": "g", "е": "e", "є": "ie", "ж": "zh", "и": "y", "і": "i",
+		"ї": "i", "й": "i", "х": "kh", "ц": "ts", "ч": "ch", "ш": "sh", "щ": "shch",
+		"ь": "", "ю": "iu", "я": "ia",
+	},
+	PrevMapping: map[string]string{
+		"є": "ye", "ї": "yi", "й": "y", "ю": "yu", "я": "ya",
+		// зг is spelled zgh to tell it from ж (Згорани → Zghorany)
+		"зг": "gh",
+	},
+	NextMapping:   map[string]string{},
+	EndingMapping: map[string]string{},
+}
+
+var ukrBGNPCGN = &iuliia.Schema{
+	Name: "bgn_pcgn",
+	Desc: "BGN/PCGN romanization of Ukrainian (1965)",
+	Mapping: map[string]string{
+		"г": "h", "ґ": "g", "е": "e", "є": "ye", "ж": "zh", "и": "y", "і": "i",
+		"ї": "yi", "й": "y", "х": "kh", "ц": "ts", "ч": "ch", "ш": "sh", "щ": "shch",
+		"ь": "’", "ю": "yu", "я": "ya",
+	},
+	PrevMapping:   map[string]string{},
+	NextMapping:   map[string]string{},
+	EndingMapping: map[string]string{},
+}
+
+// Belarusian national system
+var belNational = &iuliia.Schema{
+	Name: "national",
+	Desc: "Belarusian National Standard (2007)",
+	Mapping: map[string]string{
+		"г": "h", "ґ": "g", "е": "ie", "ё": "io", "ж": "ž", "і": "i", "й": "j",
+		"ў": "ŭ", "х": "ch", "ц": "c", "ч": "č", "ш": "š", "ы": "y", "ь": "",
+		"э": "e", "ю": "iu", "я": "ia",
+	},
+	PrevMapping: iotatedAfter("аеёіоуыэюяўь", map[string]string{
+		"е": "je", "ё": "jo", "ю": "ju", "я": "ja",
+	}),
+	// the soft sign is marked with an acute on the consonant it softens
+	NextMapping: map[string]string{
+		"зь": "ź", "ль": "ĺ", "нь": "ń", "сь": "ś", "ць": "ć",
+	},
+	EndingMapping: map[string]string{},
+}
+
+var belBGNPCGN = &iuliia.Schema{
+	Name: "bgn_pcgn",
+	Desc: "BGN/PCGN romanization of Belarusian (1979)",
+	Mapping: map[string]string{
+		"г": "h", "ґ": "g", "е": "e", "ё": "ë", "ж": "zh", "і": "i", "й": "y",
+		"ў": "w", "х": "kh", "ц": "ts", "ч": "ch", "ш": "sh", "ы": "y", "ь": "’",
+		"э": "e", "ю": "yu", "я": "ya",
+	},
+	PrevMapping: iotatedAfter("аеёіоуыэюяйўь", map[string]string{
+		"е": "ye", "ё": "yo",
+	}),
+	NextMapping:   map[string]string{},
+	EndingMapping: map[string]string{},
+}
+
+// iotatedAfter returns the PrevMapping spelling the given vowels as iotated
+// at the start of a word and after the letters of after.
+func iotatedAfter(after string, vowels map[string]string) map[string]string {
+	mapping := make(map[string]string)
+	for vowel, roman := range vowels {
+		mapping[vowel] = roman
+		for _, prev := range after {
+			mapping[string(prev)+vowel] = roman
+		}
+	}
+	return mapping
+}
+
+var ukrainianSchemesToScript = map[string]*iuliia.Schema{
+	"kmu_2010": ukrKMU2010,
+	"bgn_pcgn": ukrBGNPCGN,
+}
+
+var belarusianSchemesToScript = map[string]*iuliia.Schema{
+	"national": belNational,
+	"bgn_pcgn": belBGNPCGN,
+}
+
+// apostropheRule is how a schema deals with the apostrophe separating a
+// consonant from an iotated vowel (м'ясо, сям'я)
+type apostropheRule int
+
+const (
+	// keepApostrophe romanizes the apostrophe as is
+	keepApostrophe apostropheRule = iota
+	// ignoreApostrophe romanizes the word as if it had no apostrophe
+	// (Знам'янка → Znamianka)
+	ignoreApostrophe
+	// dropApostrophe romanizes the word as two, iotating the vowel after
+	// the apostrophe, and leaves the apostrophe out (сям'я → siamja)
+	dropApostrophe
+)
+
+var apostropheRules = map[*iuliia.Schema]apostropheRule{
+	ukrKMU2010:  ignoreApostrophe,
+	belNational: dropApostrophe,
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// translateWithApostrophes translates text with schema, applying the
+// apostrophe rule of the schema to apostrophes between Cyrillic letters.
+func translateWithApostrophes(schema *iuliia.Schema, text string) string {
+	rule := apostropheRules[schema]
+	if rule == keepApostrophe || !strings.ContainsFunc(text, isApostrophe) {
+		return schema.Translate(text)
+	}
+	runes := []rune(text)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes)-1; i++ {
+		if isApostrophe(runes[i]) && unicode.Is(unicode.Cyrillic, runes[i-1]) && unicode.Is(unicode.Cyrillic, runes[i+1]) {
+			parts = append(parts, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+	parts = append(parts, string(runes[start:]))
+	if rule == ignoreApostrophe {
+		return schema.Translate(strings.Join(parts, ""))
+	}
+	for i, part := range parts {
+		parts[i] = schema.Translate(part)
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,405 @@
+package mul
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+// dravidianScripts are the blocks of the scripts of the Dravidian languages,
+// which share the ISCII layout of the tables of aksharamukha-lite
+var dravidianScripts = map[string]rune{
+	"tam": tamilBlock,
+	"tel": teluguBlock,
+	"kan": kannadaBlock,
+	"mal": malayalamBlock,
+}
+
+// dravidianSchemes are the colloquial schemes of the DravidianProvider, by language
+var dravidianSchemes = map[string]common.TranslitScheme{
+	"tam": {Name: "tamil-colloquial", Description: "Colloquial romanization without diacritics, following the Tamil voicing rules (vanakkam, thamizh, nandri)"},
+	"tel": {Name: "telugu-colloquial", Description: "Colloquial romanization without diacritics (telugu, namaskaaram)"},
+	"kan": {Name: "kannada-colloquial", Description: "Colloquial romanization without diacritics (kannada, namaskaara)"},
+	"mal": {Name: "malayalam-colloquial", Description: "Colloquial romanization without diacritics (malayaalam, njaan, enthu)"},
+}
+
+// colloquialConsonants are the colloquial romanizations of the consonants,
+// without their inherent vowel
+var colloquialConsonants = map[rune]string{
+	0x15: "k", 0x16: "kh", 0x17: "g", 0x18: "gh", 0x19: "n",
+	0x1A: "ch", 0x1B: "chh", 0x1C: "j", 0x1D: "jh", 0x1E: "n",
+	0x1F: "t", 0x20: "th", 0x21: "d", 0x22: "dh", 0x23: "n",
+	0x24: "t", 0x25: "th", 0x26: "d", 0x27: "dh", 0x28: "n", 0x29: "n",
+	0x2A: "p", 0x2B: "ph", 0x2C: "b", 0x2D: "bh", 0x2E: "m",
+	0x2F: "y", 0x30: "r", 0x31: "r", 0x32: "l", 0x33: "l", 0x34: "zh", 0x35: "v",
+	0x36: "sh", 0x37: "sh", 0x38: "s", 0x39: "h",
+}
+
+// colloquialOverrides are the consonants romanized differently in a language:
+// the dental t of Tamil and Malayalam is written th (thamizh, thrissur), as
+// these languages have no aspirated t to tell it from.
+var colloquialOverrides = map[string]map[rune]string{
+	"tam": {0x24: "th"},
+	"mal": {0x24: "th", 0x19: "ng", 0x1E: "nj"},
+}
+
+// colloquialVowels are the independent vowels and the vowel signs: the long
+// vowels are doubled, except e and o, written alike whether short or long.
+var colloquialVowels = map[rune]string{
+	0x05: "a", 0x06: "aa", 0x07: "i", 0x08: "ee", 0x09: "u", 0x0A: "oo",
+	0x0B: "ri", 0x0E: "e", 0x0F: "e", 0x10: "ai", 0x12: "o", 0x13: "o", 0x14: "au",
+	0x3E: "aa", 0x3F: "i", 0x40: "ee", 0x41: "u", 0x42: "oo", 0x43: "ri",
+	0x46: "e", 0x47: "e", 0x48: "ai", 0x4A: "o", 0x4B: "o", 0x4C: "au", 0x57: "au",
+}
+
+// colloquialSigns are the other letters of the blocks
+var colloquialSigns = map[rune]string{
+	0x01: "", 0x03: "h", 0x3D: "", 0x50: "om",
+	0x66: "0", 0x67: "1", 0x68: "2", 0x69: "3", 0x6A: "4",
+	0x6B: "5", 0x6C: "6", 0x6D: "7", 0x6E: "8", 0x6F: "9",
+}
+
+// colloquialChillus are the Malayalam consonants without inherent vowel
+var colloquialChillus = map[rune]string{
+	0x4E: "r", 0x54: "m", 0x55: "y", 0x56: "zh",
+	0x7A: "n", 0x7B: "n", 0x7C: "r", 0x7D: "l", 0x7E: "l", 0x7F: "k",
+}
+
+const liteAnusvara = 0x02
+
+// tamilStops are the Tamil stops, which stand for a voiceless or a voiced
+// sound depending on their position in the word
+var tamilStops = map[rune]struct{ voiceless, voiced string }{
+	0x15: {"k", "g"},
+	0x1A: {"ch", "s"},
+	0x1F: {"t", "d"},
+	0x24: {"th", "dh"},
+	0x2A: {"p", "b"},
+}
+
+// nasals are the nasal consonants, after which a Tamil stop is voiced
+var nasals = map[rune]bool{0x19: true, 0x1E: true, 0x23: true, 0x28: true, 0x29: true, 0x2E: true}
+
+// dravidianLetter is a letter of a word: a consonant with its vowel, an
+// independent vowel or a sign, or a character of another script.
+type dravidianLetter struct {
+	offset    rune   // offset of the letter in the block of its script
+	consonant bool   // the letter is a consonant
+	virama    bool   // the consonant has no vowel
+	vowel     string // romanized vowel of the consonant
+	text      string // romanization of the letter that isn't a consonant
+	native    bool   // the letter belongs to the script of the language
+}
+
+// DravidianProvider romanizes Tamil, Telugu, Kannada and Malayalam the way
+// they are commonly written in Latin letters, without diacritics: the long
+// vowels are doubled (aa, ee, oo), the retroflex consonants are written like
+// the dental ones and ழ/ഴ is zh. The conventions of each language are then
+// applied, see Romanize.
+type DravidianProvider struct {
+	Lang             string
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewDravidianProvider creates a colloquial romanizer of lang, which must be
+// "tam", "tel", "kan" or "mal".
+func NewDravidianProvider(lang string) *DravidianProvider {
+	return &DravidianProvider{Lang: lang}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *DravidianProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback is a no-op: the provider downloads nothing.
+func (p *DravidianProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *DravidianProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the language and the scheme found in the stored configuration.
+//
+// Returns an error if either is not supported or the context is canceled.
+func (p *DravidianProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("dravidian-colloquial: context canceled during initialization: %w", err)
+	}
+	scheme, ok := dravidianSchemes[p.Lang]
+	if !ok {
+		return fmt.Errorf("dravidian-colloquial: unsupported language: %s", p.Lang)
+	}
+	if s, _ := p.config["scheme"].(string); s != "" && s != scheme.Name {
+		return fmt.Errorf("dravidian-colloquial: unsupported transliteration scheme: %s", s)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *DravidianProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *DravidianProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *DravidianProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *DravidianProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *DravidianProvider) Close() error {
+	return nil
+}
+
+func (p *DravidianProvider) Name() string {
+	return "dravidian-colloquial"
+}
+
+func (p *DravidianProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *DravidianProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// ProcessFlowController romanizes the tokens of a tokenized input.
+func (p *DravidianProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("dravidian-colloquial: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("dravidian-colloquial: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(p.Romanize(s))
+	}
+	return input, nil
+}
+
+// Romanize converts text written in the script of the language of the
+// provider to its colloquial romanization. Characters of other scripts are
+// kept as is.
+//
+// Besides the common conventions, each language has its own:
+//   - Tamil: the stops are voiceless at the start of a word, when doubled
+//     and before a pulli, and voiced between vowels and after a nasal
+//     (கடல் kadal, தங்கம் thangam, பட்டம் pattam); ச is s between vowels
+//     and j after ஞ (பஞ்சு panju); ற்ற is tr and ன்ற ndr (நன்றி nandri).
+//     The grantha consonants ஜ ஷ ஸ ஹ keep their sound (க்ஷ ksh), and ஸ்ரீ is sri.
+//   - Malayalam: the chillus are written as their consonant, റ്റ is tt and
+//     ന്റ nt (എന്റെ ente), ഞ nj and ങ്ങ ng; the chandrakkala at the end of a
+//     word is the half-u (എന്ത് enthu).
+func (p *DravidianProvider) Romanize(text string) string {
+	block, ok := dravidianScripts[p.Lang]
+	if !ok {
+		return text
+	}
+	text = norm.NFC.String(text)
+	if p.Lang == "tam" {
+		text = strings.ReplaceAll(text, "ஸ்ரீ", "sri")
+	}
+	letters := parseDravidianLetters(block, text)
+	var b strings.Builder
+	for i, l := range letters {
+		switch {
+		case l.consonant:
+			b.WriteString(p.consonant(letters, i))
+			b.WriteString(l.vowel)
+			if p.Lang == "mal" && l.virama && !nativeAt(letters, i+1) {
+				b.WriteString("u")
+			}
+		case l.native && l.offset == liteAnusvara:
+			b.WriteString(anusvaraBefore(letters, i))
+		default:
+			b.WriteString(l.text)
+		}
+	}
+	return b.String()
+}
+
+// parseDravidianLetters splits text into the letters of the script at block.
+func parseDravidianLetters(block rune, text string) []dravidianLetter {
+	runes := []rune(text)
+	var letters []dravidianLetter
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r < block || r >= block+indicBlockSize {
+			letters = append(letters, dravidianLetter{text: string(r)})
+			continue
+		}
+		offset := r - block
+		if _, ok := colloquialConsonants[offset]; !ok {
+			l := dravidianLetter{offset: offset, native: true}
+			switch {
+			case offset >= 0x05 && offset <= 0x14: // independent vowels
+				l.text = colloquialVowels[offset]
+			case block == malayalamBlock && colloquialChillus[offset] != "":
+				l.text = colloquialChillus[offset]
+			case offset == liteAnusvara:
+			default:
+				s, ok := colloquialSigns[offset]
+				if !ok {
+					continue // a vowel sign or a virama without consonant
+				}
+				l.text = s
+			}
+			letters = append(letters, l)
+			continue
+		}
+
+		l := dravidianLetter{offset: offset, consonant: true, native: true, vowel: "a"}
+		if next, ok := sameBlockOffset(runes, i+1, block); ok && next == liteNukta {
+			i++
+		}
+		// the inherent vowel is replaced by a vowel sign and dropped by the virama
+		if next, ok := sameBlockOffset(runes, i+1, block); ok {
+			if next == liteVirama {
+				l.virama, l.vowel = true, ""
+				i++
+			} else if next >= 0x3E && colloquialVowels[next] != "" {
+				l.vowel = colloquialVowels[next]
+				i++
+			}
+		}
+		letters = append(letters, l)
+	}
+	return letters
+}
+
+// consonant returns the romanization of the consonant at i.
+func (p *DravidianProvider) consonant(letters []dravidianLetter, i int) string {
+	var s string
+	var ok bool
+	switch p.Lang {
+	case "tam":
+		s, ok = tamilConsonant(letters, i)
+	case "mal":
+		s, ok = malayalamConsonant(letters, i)
+	}
+	if ok {
+		return s
+	}
+	if s, ok := colloquialOverrides[p.Lang][letters[i].offset]; ok {
+		return s
+	}
+	return colloquialConsonants[letters[i].offset]
+}
+
+// tamilConsonant applies the voicing rules of Tamil to the consonant at i.
+func tamilConsonant(letters []dravidianLetter, i int) (string, bool) {
+	l := letters[i]
+	prev, hasPrev := consonantAt(letters, i-1)
+	next, hasNext := consonantAt(letters, i+1)
+	if l.offset == 0x31 { // ற
+		switch {
+		case l.virama && hasNext && next.offset == 0x31:
+			return "t", true
+		case hasPrev && prev.virama && prev.offset == 0x29:
+			return "dr", true
+		}
+		return "", false
+	}
+
+	stop, isStop := tamilStops[l.offset]
+	if !isStop {
+		return "", false
+	}
+	switch {
+	case l.virama && hasNext && next.offset == l.offset:
+		// the first of a doubled stop, the second is voiceless (அச்சம் accham)
+		return strings.TrimSuffix(stop.voiceless, "h"), true
+	case l.virama:
+		return stop.voiceless, true
+	case !nativeAt(letters, i-1):
+		if l.offset == 0x1A {
+			return "s", true
+		}
+		return stop.voiceless, true
+	case hasPrev && prev.virama && nasals[prev.offset], !letters[i-1].consonant && letters[i-1].offset == liteAnusvara:
+		if l.offset == 0x1A {
+			return "j", true
+		}
+		return stop.voiced, true
+	case hasPrev && prev.virama:
+		return stop.voiceless, true
+	}
+	return stop.voiced, true
+}
+
+// malayalamConsonant applies the conventions of Malayalam to the consonant at i.
+func malayalamConsonant(letters []dravidianLetter, i int) (string, bool) {
+	l := letters[i]
+	prev, hasPrev := consonantAt(letters, i-1)
+	next, hasNext := consonantAt(letters, i+1)
+	switch {
+	case l.offset == 0x31 && l.virama && hasNext && next.offset == 0x31:
+		return "t", true
+	case l.offset == 0x31 && hasPrev && prev.virama && (prev.offset == 0x31 || prev.offset == 0x28):
+		return "t", true
+	case (l.offset == 0x19 || l.offset == 0x1E) && l.virama && hasNext && next.offset == l.offset:
+		return "", true // ങ്ങ ng, ഞ്ഞ nj
+	case (l.offset == 0x19 || l.offset == 0x1E) && l.virama:
+		return "n", true
+	}
+	return "", false
+}
+
+// consonantAt returns the letter at i if it is a consonant of the script.
+func consonantAt(letters []dravidianLetter, i int) (dravidianLetter, bool) {
+	if i < 0 || i >= len(letters) || !letters[i].consonant {
+		return dravidianLetter{}, false
+	}
+	return letters[i], true
+}
+
+// nativeAt reports whether the letter at i belongs to the script.
+func nativeAt(letters []dravidianLetter, i int) bool {
+	return i >= 0 && i < len(letters) && letters[i].native
+}
+
+// anusvaraBefore returns the romanization of the anusvara at i: m before a
+// labial consonant and at the end of a word (వందనం vandanam), n otherwise.
+func anusvaraBefore(letters []dravidianLetter, i int) string {
+	next, ok := consonantAt(letters, i+1)
+	if !ok || (next.offset >= 0x2A && next.offset <= 0x2E) {
+		return "m"
+	}
+	return "n"
+}
+
+var _ common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] = (*DravidianProvider)(nil)
@@ -0,0 +1,79 @@
+package mul
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestDravidianRomanize(t *testing.T) {
+	cases := map[string]map[string]string{
+		"tam": {
+			"வணக்கம்":    "vanakkam",
+			"தமிழ்":      "thamizh",
+			"நன்றி":      "nandri",
+			"காற்று":     "kaatru",
+			"கடல்":       "kadal",
+			"அது":        "adhu",
+			"தங்கம்":     "thangam",
+			"பஞ்சு":      "panju",
+			"பேசு":       "pesu",
+			"பட்டம்":     "pattam",
+			"அம்பு":      "ambu",
+			"ஜன்னல்":     "jannal", // grantha
+			"லக்ஷ்மி":    "lakshmi",
+			"ஸ்ரீகாந்த்": "srikaanth",
+			"௨௦௨௪":       "2024",
+		},
+		"tel": {
+			"తెలుగు":   "telugu",
+			"నమస్కారం": "namaskaaram",
+			"వందనం":    "vandanam",
+		},
+		"kan": {
+			"ಕನ್ನಡ":    "kannada",
+			"ನಮಸ್ಕಾರ":  "namaskaara",
+			"ಬೆಂಗಳೂರು": "bengalooru",
+		},
+		"mal": {
+			"മലയാളം":     "malayaalam",
+			"ഞാൻ":        "njaan",
+			"എന്ത്":      "enthu",
+			"എന്റെ":      "ente",
+			"കുട്ടി":     "kutti",
+			"നിങ്ങൾ":     "ningal",
+			"കോഴിക്കോട്": "kozhikkotu",
+		},
+	}
+	for lang, words := range cases {
+		p := NewDravidianProvider(lang)
+		for native, roman := range words {
+			assert.Equal(t, roman, p.Romanize(native), "%s: %s", lang, native)
+		}
+	}
+}
+
+func TestDravidianSchemes(t *testing.T) {
+	for lang, scheme := range dravidianSchemes {
+		m, err := common.GetSchemeModule(lang, scheme.Name)
+		require.NoError(t, err, lang)
+		require.NoError(t, m.Init())
+		assert.Equal(t, "dravidian-colloquial", m.ProviderRoles[common.TransliteratorMode].Name())
+		m.Close()
+	}
+
+	m, err := common.GetSchemeModule("tam", "tamil-colloquial")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+	roman, err := m.Roman("வணக்கம், தமிழ் நாடு!")
+	require.NoError(t, err)
+	assert.Equal(t, "vanakkam, thamizh naadu!", strings.Join(strings.Fields(roman), " "))
+
+	p := NewDravidianProvider("tam")
+	require.NoError(t, p.SaveConfig(map[string]interface{}{"scheme": "ISO"}))
+	assert.Error(t, p.Init())
+}
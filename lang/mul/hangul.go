@@ -0,0 +1,328 @@
+package mul
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// HangulProvider is an enricher provider romanizing the Hangul found in the
+// tokens of any language with the Revised Romanization of Korean (2000), e.g.
+// Korean names in Japanese subtitles. It can be appended to any module:
+//
+//	m.WithEnricher(mul.NewHangulRomanizer())
+//
+// Tokens containing Hangul syllables are given a romanization, in which the
+// rest of their surface is kept, and are made lexical.
+type HangulProvider struct {
+	opts HangulOptions
+}
+
+// HangulOptions are the typed options of HangulProvider, see common.Configure.
+type HangulOptions struct {
+	// Words romanizes Hangul as common words, applying the sound changes
+	// between syllables (한국말 → hangungmal). By default Hangul is romanized
+	// as personal names: without the sound changes, as the Revised
+	// Romanization requires for names, capitalized and with the family name
+	// of three-syllable names (or four with a two-syllable family name) set
+	// apart (김민수 → Gim Minsu).
+	Words bool
+}
+
+// NewHangulRomanizer creates a new HangulProvider romanizing names.
+func NewHangulRomanizer() *HangulProvider {
+	return &HangulProvider{}
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *HangulProvider) ConfigureWith(opts HangulOptions) error {
+	p.opts = opts
+	return nil
+}
+
+// SaveConfig stores the configuration. The "words" key sets HangulOptions.Words.
+func (p *HangulProvider) SaveConfig(cfg map[string]interface{}) error {
+	if words, ok := cfg["words"].(bool); ok {
+		p.opts.Words = words
+	}
+	return nil
+}
+
+func (p *HangulProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *HangulProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *HangulProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *HangulProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *HangulProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *HangulProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *HangulProvider) WithProgressCallback(callback common.ProgressCallback) {
+	// No-op: table lookups are too fast to be worth reporting
+}
+
+func (p *HangulProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: nothing to download
+}
+
+func (p *HangulProvider) Name() string {
+	return "hangul-romanizer"
+}
+
+func (p *HangulProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.EnricherMode}
+}
+
+func (p *HangulProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// ProcessFlowController romanizes the tokens of the input containing Hangul,
+// unless a previous provider already romanized them.
+func (p *HangulProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if mode != common.EnricherMode {
+		return nil, fmt.Errorf("hangul-romanizer only supports enricher mode, got %s", mode)
+	}
+	for i := 0; i < input.Len(); i++ {
+		if i%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("hangul-romanizer: context canceled: %w", err)
+			}
+		}
+		token := input.GetIdx(i)
+		surface := token.GetSurface()
+		if !strings.ContainsFunc(surface, isHangulSyllable) {
+			continue
+		}
+		if roman := token.Roman(); roman != "" && !strings.ContainsFunc(roman, isHangulSyllable) {
+			continue
+		}
+		tkn := common.BaseToken(token)
+		if tkn == nil {
+			continue
+		}
+		tkn.IsLexical = true
+		tkn.Romanization = RomanizeHangul(surface, !p.opts.Words)
+		if strings.IndexFunc(surface, func(r rune) bool { return !isHangulSyllable(r) }) < 0 {
+			tkn.Language = "kor"
+			tkn.Script = "Hang"
+		}
+	}
+	return input, nil
+}
+
+const (
+	hangulBase  = 0xAC00
+	hangulLast  = 0xD7A3
+	jungseongs  = 21
+	jongseongs  = 28
+	silentIeung = 11 // index of ㅇ among the initials
+)
+
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+// Revised Romanization of the initials (ㄱ ㄲ ㄴ ㄷ ㄸ ㄹ ㅁ ㅂ ㅃ ㅅ ㅆ ㅇ ㅈ ㅉ ㅊ ㅋ ㅌ ㅍ ㅎ)
+var rrInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+
+// Revised Romanization of the vowels (ㅏ ㅐ ㅑ ㅒ ㅓ ㅔ ㅕ ㅖ ㅗ ㅘ ㅙ ㅚ ㅛ ㅜ ㅝ ㅞ ㅟ ㅠ ㅡ ㅢ ㅣ)
+var rrVowels = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+
+// Revised Romanization of the finals before a consonant or a pause
+// (none ㄱ ㄲ ㄳ ㄴ ㄵ ㄶ ㄷ ㄹ ㄺ ㄻ ㄼ ㄽ ㄾ ㄿ ㅀ ㅁ ㅂ ㅄ ㅅ ㅆ ㅇ ㅈ ㅊ ㅋ ㅌ ㅍ ㅎ)
+var rrFinals = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+
+// rrLiaison splits each final before a vowel into the part staying in its
+// syllable and the part carried over to the next one (한국어 → hangugeo).
+var rrLiaison = [][2]string{
+	{"", ""}, {"", "g"}, {"", "kk"}, {"k", "s"}, {"", "n"}, {"n", "j"}, {"n", ""}, {"", "d"},
+	{"", "r"}, {"l", "g"}, {"l", "m"}, {"l", "b"}, {"l", "s"}, {"l", "t"}, {"l", "p"}, {"", "r"},
+	{"", "m"}, {"", "b"}, {"p", "s"}, {"", "s"}, {"", "ss"}, {"ng", ""}, {"", "j"}, {"", "ch"},
+	{"", "k"}, {"", "t"}, {"", "p"}, {"", ""},
+}
+
+// Initials and finals affected by the sound changes between syllables
+const (
+	initGiyeok = 0
+	initNieun  = 2
+	initDigeut = 3
+	initRieul  = 5
+	initMieum  = 6
+	initJieut  = 12
+
+	finalNieunHieut = 6
+	finalRieulHieut = 15
+	finalHieut      = 27
+)
+
+// Common family names, set apart from the given name in name mode
+var (
+	surnames = map[string]bool{
+		"김": true, "이": true, "박": true, "최": true, "정": true, "강": true, "조": true,
+		"윤": true, "장": true, "임": true, "한": true, "오": true, "서": true, "신": true,
+		"권": true, "황": true, "안": true, "송": true, "류": true, "유": true, "전": true,
+		"홍": true, "고": true, "문": true, "양": true, "손": true, "배": true, "백": true,
+		"허": true, "남": true, "심": true, "노": true, "하": true, "곽": true, "성": true,
+		"차": true, "주": true, "우": true, "구": true, "민": true, "진": true, "나": true,
+		"지": true, "엄": true, "채": true, "원": true, "천": true, "방": true, "공": true,
+		"현": true, "함": true, "변": true, "염": true, "여": true, "추": true, "도": true,
+		"소": true, "석": true, "선": true, "설": true, "마": true, "길": true, "연": true,
+		"위": true, "표": true, "명": true, "기": true, "반": true, "왕": true, "금": true,
+		"옥": true, "육": true, "인": true, "맹": true, "제": true, "모": true, "탁": true,
+		"국": true, "어": true, "은": true, "편": true, "용": true, "예": true, "경": true,
+	}
+	compoundSurnames = map[string]bool{
+		"남궁": true, "황보": true, "제갈": true, "선우": true, "독고": true, "사공": true, "서문": true,
+	}
+)
+
+// RomanizeHangul romanizes the Hangul syllables of text with the Revised
+// Romanization of Korean, keeping the other characters. With names, each run
+// of syllables is romanized as a personal name (see HangulOptions).
+func RomanizeHangul(text string, names bool) string {
+	var out strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isHangulSyllable(runes[i]) {
+			out.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isHangulSyllable(runes[j]) {
+			j++
+		}
+		if names {
+			out.WriteString(romanizeName(runes[i:j]))
+		} else {
+			out.WriteString(romanizeWord(runes[i:j], true))
+		}
+		i = j
+	}
+	return out.String()
+}
+
+// romanizeName romanizes a run of syllables as a personal name
+func romanizeName(syllables []rune) string {
+	split := 0
+	switch {
+	case len(syllables) >= 3 && len(syllables) <= 4 && compoundSurnames[string(syllables[:2])]:
+		split = 2
+	case len(syllables) == 3 && surnames[string(syllables[:1])]:
+		split = 1
+	}
+	if split == 0 {
+		return capitalize(romanizeWord(syllables, false))
+	}
+	return capitalize(romanizeWord(syllables[:split], false)) + " " + capitalize(romanizeWord(syllables[split:], false))
+}
+
+// romanizeWord romanizes a run of syllables. Liaison and ㄹㄹ → ll always
+// apply; with assimilation, so do the other sound changes between syllables.
+func romanizeWord(syllables []rune, assimilation bool) string {
+	var out strings.Builder
+	nextInitial := ""
+	for i, r := range syllables {
+		s := int(r - hangulBase)
+		initial, vowel, final := s/(jungseongs*jongseongs), (s%(jungseongs*jongseongs))/jongseongs, s%jongseongs
+
+		if i == 0 {
+			nextInitial = rrInitials[initial]
+		}
+		out.WriteString(nextInitial)
+		out.WriteString(rrVowels[vowel])
+
+		if i == len(syllables)-1 {
+			out.WriteString(rrFinals[final])
+			break
+		}
+		s = int(syllables[i+1] - hangulBase)
+		next := s / (jungseongs * jongseongs)
+		finalRoman, initialRoman := rrFinals[final], rrInitials[next]
+		switch {
+		case final == 0:
+		case next == silentIeung:
+			finalRoman, initialRoman = rrLiaison[final][0], rrLiaison[final][1]
+		case next == initRieul && rrFinals[final] == "l":
+			initialRoman = "l"
+		case assimilation:
+			finalRoman, initialRoman = assimilate(final, next)
+		}
+		out.WriteString(finalRoman)
+		nextInitial = initialRoman
+	}
+	return out.String()
+}
+
+// assimilate applies the consonant assimilations between a final and the
+// initial of the next syllable: nasalization (백마 → baengma), lateralization
+// (신라 → silla), ㄹ → n (종로 → jongno) and aspiration after ㅎ (좋고 → joko).
+// Aspiration of ㄱ, ㄷ, ㅂ before ㅎ is not transcribed, as in nouns (묵호 → mukho).
+func assimilate(final, next int) (string, string) {
+	finalRoman, initialRoman := rrFinals[final], rrInitials[next]
+	switch final {
+	case finalHieut, finalNieunHieut, finalRieulHieut:
+		if aspirated, ok := map[int]string{initGiyeok: "k", initDigeut: "t", initJieut: "ch"}[next]; ok {
+			if final == finalHieut {
+				finalRoman = ""
+			}
+			return finalRoman, aspirated
+		}
+	}
+	switch next {
+	case initNieun, initMieum:
+		switch finalRoman {
+		case "k":
+			return "ng", initialRoman
+		case "t":
+			return "n", initialRoman
+		case "p":
+			return "m", initialRoman
+		case "l":
+			if next == initNieun {
+				return "l", "l"
+			}
+		}
+	case initRieul:
+		switch finalRoman {
+		case "n":
+			return "l", "l"
+		case "m", "ng":
+			return finalRoman, "n"
+		case "k":
+			return "ng", "n"
+		case "t":
+			return "n", "n"
+		case "p":
+			return "m", "n"
+		}
+	}
+	return finalRoman, initialRoman
+}
+
+func capitalize(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
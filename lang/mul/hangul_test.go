@@ -0,0 +1,59 @@
+package mul
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestRomanizeHangul(t *testing.T) {
+	for hangul, roman := range map[string]string{
+		"한국어": "hangugeo",
+		"한국말": "hangungmal",
+		"신라":  "silla",
+		"종로":  "jongno",
+		"백마":  "baengma",
+		"독립":  "dongnip",
+		"울릉":  "ulleung",
+		"좋고":  "joko",
+		"묵호":  "mukho",
+	} {
+		assert.Equal(t, roman, RomanizeHangul(hangul, false), hangul)
+	}
+	for hangul, roman := range map[string]string{
+		"김민수":  "Gim Minsu",
+		"한복남":  "Han Boknam",
+		"남궁민수": "Namgung Minsu",
+		"서울":   "Seoul",
+		"김민수 씨": "Gim Minsu Ssi",
+	} {
+		assert.Equal(t, roman, RomanizeHangul(hangul, true), hangul)
+	}
+}
+
+func TestHangulProvider(t *testing.T) {
+	tsw := &common.TknSliceWrapper{}
+	tsw.Append(
+		&common.Tkn{Surface: "김민수"},
+		&common.Tkn{Surface: "さん", IsLexical: true, Romanization: "san"},
+		&common.Tkn{Surface: "、"},
+	)
+	out, err := NewHangulRomanizer().ProcessFlowController(context.Background(), common.EnricherMode, tsw)
+	require.NoError(t, err)
+	tkn := common.BaseToken(out.GetIdx(0))
+	assert.True(t, tkn.IsLexical)
+	assert.Equal(t, "Gim Minsu", tkn.Roman())
+	assert.Equal(t, "kor", tkn.Language)
+	assert.Equal(t, "san", out.GetIdx(1).Roman())
+
+	p := NewHangulRomanizer()
+	require.NoError(t, common.Configure(p, HangulOptions{Words: true}))
+	tsw = &common.TknSliceWrapper{}
+	tsw.Append(&common.Tkn{Surface: "한국말"})
+	_, err = p.ProcessFlowController(context.Background(), common.EnricherMode, tsw)
+	require.NoError(t, err)
+	assert.Equal(t, "hangungmal", tsw.GetIdx(0).Roman())
+}
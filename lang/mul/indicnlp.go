@@ -0,0 +1,445 @@
+package mul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	// indicNLPImage runs the Stanza pipelines of the Devanagari languages
+	// (https://stanfordnlp.github.io/stanza/) behind the HTTP API described
+	// on IndicNLPProvider
+	indicNLPImage         = "ghcr.io/tassa-yoniso-manasi-karoto/langkit-indic-nlp:latest"
+	indicNLPContainerName = "translitkit-indic-nlp"
+	indicNLPHostPort      = "8088"
+	indicNLPStartTimeout  = 3 * time.Minute
+
+	// IndicNLPDownloadSize is the approximate size in bytes of indicNLPImage
+	IndicNLPDownloadSize int64 = 1500 << 20
+)
+
+// indicNLPModels maps the languages supported by IndicNLPProvider to the
+// code of their Stanza model.
+var indicNLPModels = map[string]string{
+	"hin": "hi",
+	"mar": "mr",
+}
+
+// IndicNLPProvider is a tokenizer for Hindi and Marathi backed by Stanza,
+// running in a Docker container. Unlike uniseg, which splits the text on
+// Unicode word boundaries, it segments the postpositions and clitics written
+// together with their word and gives each token its lemma, its part of speech
+// (Tkn.PartOfSpeech, as a Universal POS tag in Tkn.UPOS) and its
+// morphological features (Tkn.MorphFeatures, e.g. "Case": "Nom").
+//
+// It is registered for Hindi and Marathi as an alternative to uniseg:
+//
+//	m, err := common.NewModule("hin", "indic-nlp", "hindi")
+//
+// Instead of managing its own container, it can use an existing server given
+// by the "endpoint" config key. The server answers
+//
+//	POST /analyze {"text": "...", "lang": "hi"}
+//
+// with the words of the text, in order, punctuation included or not:
+//
+//	{"tokens": [{"text": "लड़कों", "lemma": "लड़का", "upos": "NOUN", "xpos": "NN", "feats": "Case=Acc|Gender=Masc|Number=Plur"}, ...]}
+//
+// and GET /health with 200 once it is ready.
+type IndicNLPProvider struct {
+	config                   map[string]interface{}
+	Lang                     string // ISO 639-3 language code
+	endpoint                 string
+	httpClient               *http.Client
+	managed                  bool // the provider started the container itself
+	progressCallback         common.ProgressCallback
+	downloadProgressCallback common.DownloadProgressCallback
+}
+
+// indicNLPToken is a word of the response of the server.
+type indicNLPToken struct {
+	Text  string `json:"text"`
+	Lemma string `json:"lemma"`
+	UPOS  string `json:"upos"`
+	XPOS  string `json:"xpos"`
+	Feats string `json:"feats"`
+}
+
+// NewIndicNLPProvider creates a new Stanza tokenizer for the language
+// (ISO 639-3), "hin" or "mar".
+func NewIndicNLPProvider(lang string) *IndicNLPProvider {
+	return &IndicNLPProvider{
+		Lang:       lang,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// SaveConfig stores the configuration. Recognized keys are "lang", the
+// language of the text, and "endpoint", the URL of an existing server
+// (e.g. "http://localhost:8080").
+//
+// Returns an error if the language isn't supported.
+func (p *IndicNLPProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	if lang, ok := cfg["lang"].(string); ok {
+		if _, ok := indicNLPModels[lang]; !ok {
+			return fmt.Errorf("indic-nlp: no model for language %s", lang)
+		}
+		p.Lang = lang
+	}
+	if endpoint, ok := cfg["endpoint"].(string); ok {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+	return nil
+}
+
+// InitWithContext pulls the Indic NLP image and starts its container unless
+// an endpoint was configured, then waits for the server to answer.
+//
+// Returns an error if Docker is unreachable, the server doesn't start or the context is canceled.
+func (p *IndicNLPProvider) InitWithContext(ctx context.Context) error {
+	if p.httpClient == nil {
+		p.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	if p.endpoint == "" {
+		if err := p.startContainer(ctx, false, false); err != nil {
+			return fmt.Errorf("indic-nlp: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + indicNLPHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("indic-nlp: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *IndicNLPProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext removes the container and starts a new one.
+// When noCache is true, the image is pulled again.
+func (p *IndicNLPProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	if p.managed || p.endpoint == "" {
+		if err := p.startContainer(ctx, true, noCache); err != nil {
+			return fmt.Errorf("indic-nlp: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + indicNLPHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("indic-nlp: %w", err)
+	}
+	return nil
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *IndicNLPProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// startContainer makes sure the Indic NLP container is running. With
+// recreate, an existing container is removed first; with pull, the image is
+// pulled even if present.
+func (p *IndicNLPProvider) startContainer(ctx context.Context, recreate, pull bool) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	info, err := cli.ContainerInspect(ctx, indicNLPContainerName)
+	switch {
+	case err == nil && recreate:
+		if err := cli.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("failed to remove container: %w", err)
+		}
+	case err == nil && info.State != nil && info.State.Running:
+		return nil
+	case err == nil:
+		if err := cli.ContainerStart(ctx, info.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("failed to start container: %w", err)
+		}
+		return nil
+	case !cerrdefs.IsNotFound(err):
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if _, err := cli.ImageInspect(ctx, indicNLPImage); err != nil || pull {
+		if err := p.pullImage(ctx, cli); err != nil {
+			return err
+		}
+	}
+
+	port := nat.Port("8080/tcp")
+	created, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        indicNLPImage,
+			ExposedPorts: nat.PortSet{port: struct{}{}},
+		},
+		&container.HostConfig{
+			PortBindings:  nat.PortMap{port: []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: indicNLPHostPort}}},
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		nil, nil, indicNLPContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	return nil
+}
+
+// pullImage pulls indicNLPImage, reporting progress to the download progress callback.
+func (p *IndicNLPProvider) pullImage(ctx context.Context, cli *client.Client) error {
+	rc, err := cli.ImagePull(ctx, indicNLPImage, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", indicNLPImage, err)
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	for {
+		var msg struct {
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", indicNLPImage, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("failed to pull image %s: %s", indicNLPImage, msg.Error)
+		}
+		if p.downloadProgressCallback != nil && msg.ProgressDetail.Total > 0 {
+			p.downloadProgressCallback(p.Name(), msg.ProgressDetail.Current, msg.ProgressDetail.Total, msg.Status)
+		}
+	}
+}
+
+// waitReady polls the server until it reports being healthy.
+func (p *IndicNLPProvider) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, indicNLPStartTimeout)
+	defer cancel()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := p.httpClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server at %s not ready: %w", p.endpoint, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// ProcessFlowController segments the raw input chunks into analysed words.
+func (p *IndicNLPProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("indic-nlp: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("indic-nlp only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("indic-nlp: provider requires raw text input")
+	}
+	model, ok := indicNLPModels[p.Lang]
+	if !ok {
+		return nil, fmt.Errorf("indic-nlp: no model for language %q", p.Lang)
+	}
+
+	tsw := &common.TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("indic-nlp: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		words, err := p.analyze(ctx, chunk, model)
+		if err != nil {
+			return nil, fmt.Errorf("indic-nlp: chunk %d: %w", idx, err)
+		}
+		for _, tkn := range integrateIndicNLPTokens(chunk, words) {
+			tsw.Append(tkn)
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+// analyze queries the server for the words of text.
+func (p *IndicNLPProvider) analyze(ctx context.Context, text, model string) ([]indicNLPToken, error) {
+	body, err := json.Marshal(map[string]string{"text": text, "lang": model})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/analyze", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, msg)
+	}
+	var result struct {
+		Tokens []indicNLPToken `json:"tokens"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return result.Tokens, nil
+}
+
+// integrateIndicNLPTokens locates the words in the chunk and returns them as
+// tokens along with the text between them. Words that can't be found in the
+// chunk, e.g. normalized by the server, are skipped.
+func integrateIndicNLPTokens(chunk string, words []indicNLPToken) []*common.Tkn {
+	var tokens []*common.Tkn
+	pos := 0
+	for _, word := range words {
+		if word.Text == "" {
+			continue
+		}
+		idx := strings.Index(chunk[pos:], word.Text)
+		if idx < 0 {
+			common.Log.Debug().
+				Str("token", word.Text).
+				Int("position", pos).
+				Msg("indic-nlp: token not found in original text, skipping")
+			continue
+		}
+		if idx > 0 {
+			tokens = append(tokens, &common.Tkn{Surface: chunk[pos : pos+idx]})
+		}
+		pos += idx + len(word.Text)
+		tokens = append(tokens, newIndicNLPToken(word))
+	}
+	if pos < len(chunk) {
+		tokens = append(tokens, &common.Tkn{Surface: chunk[pos:]})
+	}
+	return tokens
+}
+
+// newIndicNLPToken converts a word of the server to a token.
+func newIndicNLPToken(word indicNLPToken) *common.Tkn {
+	tkn := &common.Tkn{
+		Surface:      word.Text,
+		Lemma:        word.Lemma,
+		PartOfSpeech: word.XPOS,
+		IsLexical:    word.UPOS != common.UPOSPunct && word.UPOS != common.UPOSSym && strings.IndexFunc(word.Text, unicode.IsLetter) >= 0,
+	}
+	if tkn.PartOfSpeech == "" {
+		tkn.PartOfSpeech = word.UPOS
+	}
+	if common.IsUPOS(word.UPOS) {
+		tkn.UPOS = word.UPOS
+	}
+	// Universal Dependencies features: "Case=Nom|Gender=Masc", "_" if none
+	for _, feat := range strings.Split(word.Feats, "|") {
+		if key, value, ok := strings.Cut(feat, "="); ok {
+			if tkn.MorphFeatures == nil {
+				tkn.MorphFeatures = make(map[string]string)
+			}
+			tkn.MorphFeatures[key] = value
+		}
+	}
+	return tkn
+}
+
+// CloseWithContext stops the container if the provider started it.
+func (p *IndicNLPProvider) CloseWithContext(ctx context.Context) error {
+	if !p.managed {
+		return nil
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("indic-nlp: failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+	if err := cli.ContainerStop(ctx, indicNLPContainerName, container.StopOptions{}); err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("indic-nlp: failed to stop container: %w", err)
+	}
+	p.managed = false
+	p.endpoint = ""
+	return nil
+}
+
+// Close stops the container with a background context.
+func (p *IndicNLPProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *IndicNLPProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *IndicNLPProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+// ResourceVersions returns the digest of the Indic NLP Docker image,
+// implementing common.VersionReporter.
+func (p *IndicNLPProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if !p.managed {
+		return nil, nil
+	}
+	return common.DockerImageVersions(ctx, indicNLPImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// Stanza runs in a Docker container.
+func (p *IndicNLPProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
+func (p *IndicNLPProvider) Name() string {
+	return "indic-nlp"
+}
+
+func (p *IndicNLPProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *IndicNLPProvider) GetMaxQueryLen() int {
+	// Stanza handles long texts, but we'll chunk for progress reporting
+	return 5000
+}
@@ -0,0 +1,70 @@
+package mul
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestIndicNLPProvider(t *testing.T) {
+	text := "लड़कों ने खाना खाया।"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, text, req["text"])
+		assert.Equal(t, "hi", req["lang"])
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"tokens": []map[string]string{
+				{"text": "लड़कों", "lemma": "लड़का", "upos": "NOUN", "xpos": "NN", "feats": "Case=Acc|Gender=Masc|Number=Plur"},
+				{"text": "ने", "lemma": "ने", "upos": "ADP", "xpos": "PSP", "feats": "AdpType=Post"},
+				{"text": "खाना", "lemma": "खाना", "upos": "NOUN", "xpos": "NN", "feats": "_"},
+				{"text": "खाया", "lemma": "खा", "upos": "VERB", "xpos": "VM", "feats": "Aspect=Perf|Gender=Masc"},
+				{"text": "।", "lemma": "।", "upos": "PUNCT", "xpos": "SYM", "feats": "_"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := NewIndicNLPProvider("hin")
+	require.NoError(t, p.SaveConfig(map[string]interface{}{"lang": "hin", "endpoint": server.URL}))
+	require.NoError(t, p.Init())
+	defer p.Close()
+	assert.Error(t, p.SaveConfig(map[string]interface{}{"lang": "tha"}))
+
+	input := &common.TknSliceWrapper{Raw: []string{text}}
+	out, err := p.ProcessFlowController(context.Background(), common.TokenizerMode, input)
+	require.NoError(t, err)
+
+	var surfaces, lemmas []string
+	for i := 0; i < out.Len(); i++ {
+		if tkn := out.GetIdx(i).(*common.Tkn); tkn.IsLexical {
+			surfaces = append(surfaces, tkn.Surface)
+			lemmas = append(lemmas, tkn.Lemma)
+		}
+	}
+	assert.Equal(t, []string{"लड़कों", "ने", "खाना", "खाया"}, surfaces)
+	assert.Equal(t, []string{"लड़का", "ने", "खाना", "खा"}, lemmas)
+
+	tkn := out.GetIdx(0).(*common.Tkn)
+	assert.Equal(t, "NN", tkn.PartOfSpeech)
+	assert.Equal(t, common.UPOSNoun, tkn.UPOS)
+	assert.Equal(t, map[string]string{"Case": "Acc", "Gender": "Masc", "Number": "Plur"}, tkn.MorphFeatures)
+	assert.Nil(t, out.GetIdx(4).(*common.Tkn).MorphFeatures, `"_" means no features`)
+	assert.False(t, out.GetIdx(out.Len()-1).IsLexicalContent(), "punctuation isn't lexical")
+
+	// registered as an alternative tokenizer of Hindi and Marathi
+	for _, lang := range []string{"hin", "mar"} {
+		m, err := common.NewModule(lang, "stanza", "aksharamukha-lite")
+		require.NoError(t, err, lang)
+		assert.Equal(t, "indic-nlp", m.ProviderRoles[common.TokenizerMode].Name())
+	}
+}
@@ -10,51 +10,141 @@ import (
 const Lang = "mul"
 
 var indicLangs = []string{
-	"hin", "ben", "fas", "guj", "mar", "pan", "sin", "urd", "tam", "tel",
+	"hin", "ben", "fas", "guj", "mar", "pan", "sin", "urd", "tam", "tel", "kan", "mal", "ori",
 }
 
 func init() {
 	unisegEntry := common.ProviderEntry{
 		Provider:     &UnisegProvider{},
-		Capabilities: []string{"tokenization"},
+		Capabilities: []common.Capability{common.CapabilityTokenization},
 	}
 	aksharamukhaEntry := common.ProviderEntry{
 		Provider:     &AksharamukhaProvider{},
-		Capabilities: []string{"transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
 	}
 	iuliiaEntry := common.ProviderEntry{
 		Provider:     NewIuliiaProvider("rus"),
-		Capabilities: []string{"transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+	spacyEntry := common.ProviderEntry{
+		Provider:     NewSpacyProvider(),
+		Capabilities: []common.Capability{common.CapabilityNER},
+	}
+	hangulEntry := common.ProviderEntry{
+		Provider:     NewHangulRomanizer(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+	aksharamukhaLiteEntry := common.ProviderEntry{
+		Provider:     NewAksharamukhaLiteProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
 	}
 	
 
 	err := common.Register("mul", unisegEntry)
 	if err != nil {
-		panic(fmt.Sprintf("failed to register uniseg provider: %w", err))
+		panic(fmt.Sprintf("failed to register uniseg provider: %v", err))
 	}
 	
 	err = common.Register("mul", aksharamukhaEntry)
 	if err != nil {
-		panic(fmt.Sprintf("failed to register aksharamukha provider: %w", err))
+		panic(fmt.Sprintf("failed to register aksharamukha provider: %v", err))
 	}
 	
 	err = common.Register("mul", iuliiaEntry)
 	if err != nil {
-		panic(fmt.Sprintf("failed to register iuliia provider: %w", err))
+		panic(fmt.Sprintf("failed to register iuliia provider: %v", err))
+	}
+
+	err = common.Register("mul", spacyEntry)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register spacy provider: %v", err))
+	}
+
+	err = common.Register("mul", hangulEntry)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register hangul-romanizer provider: %v", err))
+	}
+
+	err = common.Register("mul", aksharamukhaLiteEntry)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register aksharamukha-lite provider: %v", err))
 	}
 	
+	// Stanza is an alternative tokenizer of the languages it has a model
+	// for, each with its own instance since the language is its config
+	for lang := range indicNLPModels {
+		indicNLPEntry := common.ProviderEntry{
+			Provider:     NewIndicNLPProvider(lang),
+			Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+			Aliases:      []string{"stanza"},
+		}
+		if err := common.Register(lang, indicNLPEntry); err != nil {
+			panic(fmt.Sprintf("failed to register indic-nlp provider for %s: %v", lang, err))
+		}
+	}
+
+	// The colloquial romanizers of the Dravidian languages, each with its
+	// own instance since the conventions depend on the language
+	for lang, scheme := range dravidianSchemes {
+		dravidianEntry := common.ProviderEntry{
+			Provider:     NewDravidianProvider(lang),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		}
+		if err := common.Register(lang, dravidianEntry); err != nil {
+			panic(fmt.Sprintf("failed to register dravidian-colloquial provider for %s: %v", lang, err))
+		}
+		scheme.Providers = []string{"dravidian-colloquial"}
+		if err := common.RegisterScheme(lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("lang", lang).
+				Msg("Failed to register scheme " + scheme.Name)
+		}
+	}
+
+	common.RegisterScriptConverter(&AksharamukhaConverter{})
+	common.RegisterScriptConverter(SerbianConverter{})
+
 	// #### Schemes registration ####
 
 	for _, indicLang := range indicLangs {
 		for _, scheme := range indicSchemes {
 			scheme.Providers = []string{"aksharamukha"}
 			scheme.NeedsDocker = true
+			scheme.Requirements.DownloadSize = AksharamukhaDownloadSize
 			if err := common.RegisterScheme(indicLang, scheme); err != nil {
 				common.Log.Warn().
 					Str("pkg", Lang).
 					Str("lang", indicLang).
 					Msg("Failed to register scheme " + scheme.Name)
 			}
+			if err := common.RegisterReverseScheme(indicLang, scheme); err != nil {
+				common.Log.Warn().
+					Str("pkg", Lang).
+					Str("lang", indicLang).
+					Msg("Failed to register reverse scheme " + scheme.Name)
+			}
+		}
+	}
+	
+	// Without Docker, the languages written in the scripts aksharamukha-lite
+	// knows fall back to it
+	for _, liteLang := range liteLangs {
+		for _, scheme := range liteSchemes {
+			scheme.Providers = []string{"aksharamukha-lite"}
+			if err := common.RegisterScheme(liteLang, scheme); err != nil {
+				common.Log.Warn().
+					Str("pkg", Lang).
+					Str("lang", liteLang).
+					Msg("Failed to register scheme " + scheme.Name)
+			}
+		}
+		fallback := []common.ProviderEntry{unisegEntry, aksharamukhaLiteEntry}
+		if err := common.SetPlatformFallback(liteLang, fallback); err != nil {
+			common.Log.Warn().Err(err).
+				Str("pkg", Lang).
+				Str("lang", liteLang).
+				Msg("Failed to set aksharamukha-lite as fallback")
 		}
 	}
 	
@@ -66,6 +156,27 @@ func init() {
 				Str("lang", "rus").
 				Msg("Failed to register scheme " + scheme.Name)
 		}
+		if reverse, ok := newIuliiaReverse(russianSchemesToScript[scheme.Name]); ok {
+			reversibleSchemas[russianSchemesToScript[scheme.Name]] = reverse
+			if err := common.RegisterReverseScheme("rus", scheme); err != nil {
+				common.Log.Warn().
+					Str("pkg", Lang).
+					Str("lang", "rus").
+					Msg("Failed to register reverse scheme " + scheme.Name)
+			}
+		}
+	}
+
+	for lang, schemes := range map[string][]common.TranslitScheme{"ukr": ukrainianSchemes, "bel": belarusianSchemes} {
+		for _, scheme := range schemes {
+			scheme.Providers = []string{"iuliia"}
+			if err := common.RegisterScheme(lang, scheme); err != nil {
+				common.Log.Warn().
+					Str("pkg", Lang).
+					Str("lang", lang).
+					Msg("Failed to register scheme " + scheme.Name)
+			}
+		}
 	}
 
 	if err := common.RegisterScheme("uzb", uzbekScheme); err != nil {
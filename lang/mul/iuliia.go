@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"math"
 	"context"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 
 	iuliia "github.com/mehanizm/iuliia-go"
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
@@ -50,6 +54,27 @@ func (p *IuliiaProvider) SaveConfig(cfg map[string]interface{}) error {
 	return nil
 }
 
+// IuliiaOptions are the typed options of IuliiaProvider, see common.Configure.
+type IuliiaOptions struct {
+	// Lang is the ISO 639-3 code of the source language. Defaults to the provider's.
+	Lang string
+	// Scheme is the name of an iuliia schema registered for the language.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *IuliiaProvider) ConfigureWith(opts IuliiaOptions) error {
+	lang := opts.Lang
+	if lang == "" {
+		lang = p.Lang
+	}
+	cfg := map[string]interface{}{"lang": lang}
+	if opts.Scheme != "" {
+		cfg["scheme"] = opts.Scheme
+	}
+	return p.SaveConfig(cfg)
+}
+
 // InitWithContext initializes the provider with the given context.
 // For Iuliia, this validates the language setting and applies any stored configuration.
 // The context can be used for cancellation, though initialization is typically quick.
@@ -61,11 +86,10 @@ func (p *IuliiaProvider) InitWithContext(ctx context.Context) error {
 		return fmt.Errorf("iuliia: context canceled during initialization: %w", err)
 	}
 	
-	switch p.Lang {
-	case "rus", "uzb":
-	case "":
+	if p.Lang == "" {
 		return fmt.Errorf("language code must be set before initialization")
-	default:
+	}
+	if _, ok := iuliiaSchemas[p.Lang]; !ok {
 		return fmt.Errorf("\"%s\" is not a language code supported by Iuliia", p.Lang)
 	}
 	return p.applyConfig()
@@ -105,9 +129,9 @@ func (p *IuliiaProvider) applyConfig() error {
 		return fmt.Errorf("scheme name not provided in config")
 	}
 	
-	targetScheme, ok := russianSchemesToScript[schemeName]
+	targetScheme, ok := iuliiaSchemas[p.Lang][schemeName]
 	if !ok {
-		return fmt.Errorf("unsupported transliteration scheme: %s", schemeName)
+		return fmt.Errorf("unsupported transliteration scheme for %s: %s", p.Lang, schemeName)
 	}
 
 	p.targetScheme = targetScheme
@@ -119,7 +143,7 @@ func (p *IuliiaProvider) Name() string {
 }
 
 func (p *IuliiaProvider) SupportedModes() []common.OperatingMode {
-	return []common.OperatingMode{common.TransliteratorMode}
+	return []common.OperatingMode{common.TransliteratorMode, common.ReverseTransliteratorMode}
 }
 
 func (p *IuliiaProvider) GetMaxQueryLen() int {
@@ -165,6 +189,9 @@ func (p *IuliiaProvider) ProcessFlowController(ctx context.Context, mode common.
 	}
 
 	if len(raw) != 0 {
+		if mode == common.ReverseTransliteratorMode {
+			return p.toNative(ctx, input)
+		}
 		// switch mode {
 		// case common.TransliteratorMode:
 		// 	return p.process(ctx, raw)
@@ -263,6 +290,36 @@ func (p *IuliiaProvider) processTokens(ctx context.Context, input common.AnyToke
 
 
 
+// toNative converts the raw chunks of the input back to Cyrillic, one token per
+// chunk, for the schemas that are reversible (see reversibleSchemas).
+func (p *IuliiaProvider) toNative(ctx context.Context, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	schema := p.targetScheme
+	if schema == nil {
+		schema = defaultIuliiaSchemas[p.Lang]
+	}
+	reverse, ok := reversibleSchemas[schema]
+	if !ok {
+		return nil, fmt.Errorf("iuliia: %w: scheme %s isn't reversible", common.ErrReverseUnsupported, schema.Name)
+	}
+	raw := input.GetRaw()
+	tsw := &common.TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("iuliia: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tsw.Append(&common.Tkn{
+			Surface:      reverse.translate(chunk),
+			Romanization: chunk,
+			IsLexical:    true,
+		})
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
 // romanize converts text to a romanized form using the appropriate scheme.
 // It uses either the configured scheme or falls back to a default scheme based on the language.
 //
@@ -272,13 +329,91 @@ func (p *IuliiaProvider) processTokens(ctx context.Context, input common.AnyToke
 // Returns:
 //   - string: The romanized text
 func (p *IuliiaProvider) romanize(text string) string {
-	if p.targetScheme != nil {
-		return p.targetScheme.Translate(text)
+	schema := p.targetScheme
+	if schema == nil {
+		// otherwise use default romanization
+		schema = defaultIuliiaSchemas[p.Lang]
+	}
+	if schema == nil {
+		schema = iuliia.Gost_779
+	}
+	return translateWithApostrophes(schema, text)
+}
+
+// reversibleSchemas are the reverse mappings of the iuliia schemas whose
+// romanization can be converted back to Cyrillic, see newIuliiaReverse.
+var reversibleSchemas = make(map[*iuliia.Schema]*iuliiaReverse)
+
+// iuliiaReverse converts text romanized with an iuliia schema back to Cyrillic.
+type iuliiaReverse struct {
+	letters map[string]string // lowercase romanization (NFC) → lowercase Cyrillic letter
+	maxLen  int               // length in runes of the longest romanization
+}
+
+// newIuliiaReverse returns the reverse of schema, or false if its
+// romanization can't be converted back unambiguously: a schema is reversible
+// when it maps each letter on its own (without the context mappings of
+// iuliia) and no romanization is empty or the prefix of another one. This
+// holds for GOST 7.79-2000 (ISO 9) for instance, while the schemas writing
+// ш as "sh" can't tell it apart from сх.
+func newIuliiaReverse(schema *iuliia.Schema) (*iuliiaReverse, bool) {
+	if len(schema.PrevMapping) > 0 || len(schema.NextMapping) > 0 || len(schema.EndingMapping) > 0 {
+		return nil, false
+	}
+	reverse := &iuliiaReverse{letters: make(map[string]string)}
+	for letter, roman := range schema.Mapping {
+		// iuliia adds the capitalized letters to the mapping when building the schema
+		if letter != strings.ToLower(letter) {
+			continue
+		}
+		roman = norm.NFC.String(roman)
+		if roman == "" {
+			return nil, false
+		}
+		if other, ok := reverse.letters[roman]; ok && other != letter {
+			return nil, false
+		}
+		reverse.letters[roman] = letter
+		reverse.maxLen = max(reverse.maxLen, len([]rune(roman)))
 	}
-	// otherwise use default romanization
-	if p.Lang == "uzb" {
-		return iuliia.Uz.Translate(text)
+	for a := range reverse.letters {
+		for b := range reverse.letters {
+			if a != b && strings.HasPrefix(b, a) {
+				return nil, false
+			}
+		}
 	}
-	return iuliia.Gost_779.Translate(text)
+	return reverse, true
 }
 
+// translate converts romanized text to Cyrillic. Characters that aren't part
+// of a romanization are kept as is.
+func (r *iuliiaReverse) translate(text string) string {
+	runes := []rune(norm.NFC.String(text))
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		matched := false
+		for size := min(r.maxLen, len(runes)-i); size > 0; size-- {
+			letter, ok := r.letters[string(lower[i:i+size])]
+			if !ok {
+				continue
+			}
+			if unicode.IsUpper(runes[i]) {
+				letter = strings.ToUpper(letter)
+			}
+			b.WriteString(letter)
+			i += size
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+	return b.String()
+}
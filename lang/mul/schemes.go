@@ -21,6 +21,21 @@ var indicSchemes = []common.TranslitScheme{
 	{Name: "Titus", Description: "TITUS transliteration system"},
 }
 
+// IndicSchemes returns the schemes of the aksharamukha provider, for the
+// language packages registering them with their own tokenizer.
+func IndicSchemes() []common.TranslitScheme {
+	return append([]common.TranslitScheme(nil), indicSchemes...)
+}
+
+// liteSchemes are the schemes of aksharamukha-lite, registered for liteLangs
+var liteSchemes = []common.TranslitScheme{
+	{Name: "IAST-lite", Description: "IAST, letter by letter without Docker (no schwa deletion)"},
+	{Name: "ISO-lite", Description: "ISO 15919, letter by letter without Docker (no schwa deletion)"},
+}
+
+// liteLangs are the languages written in a script known to aksharamukha-lite
+var liteLangs = []string{"hin", "mar", "san", "ben", "ori", "tam", "tel", "kan", "mal"}
+
 var indicSchemesToScript = map[string]aksharamukha.Script{
 	"Harvard-Kyoto":    aksharamukha.HK,
 	"IAST":             aksharamukha.IAST,
@@ -94,6 +109,22 @@ var russianSchemesToScript = map[string]*iuliia.Schema{
 	"yandex_money":   iuliia.Yandex_money,
 }
 
+// iuliiaSchemas are the schemas of each language supported by iuliia, by scheme name
+var iuliiaSchemas = map[string]map[string]*iuliia.Schema{
+	"rus": russianSchemesToScript,
+	"uzb": {"uz": iuliia.Uz},
+	"ukr": ukrainianSchemesToScript,
+	"bel": belarusianSchemesToScript,
+}
+
+// defaultIuliiaSchemas are the schemas used when no scheme is configured
+var defaultIuliiaSchemas = map[string]*iuliia.Schema{
+	"rus": iuliia.Gost_779,
+	"uzb": iuliia.Uz,
+	"ukr": ukrKMU2010,
+	"bel": belNational,
+}
+
 
 var uzbekScheme = common.TranslitScheme{ Name: "uz", Description: "Uzbekistan cyr-lat transliteration schema", Providers: []string{"iuliia"} }
 
@@ -0,0 +1,369 @@
+package mul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	// spacyImage serves spaCy's models over HTTP (github.com/jgontrum/spacy-api-docker)
+	spacyImage         = "jgontrum/spacyapi:all_v2"
+	spacyContainerName = "translitkit-spacy"
+	spacyHostPort      = "8085"
+	spacyStartTimeout  = 2 * time.Minute
+)
+
+// spacyModels maps the languages with a NER model in spacyImage to the model name.
+var spacyModels = map[string]string{
+	"eng": "en",
+	"deu": "de",
+	"spa": "es",
+	"fra": "fr",
+	"por": "pt",
+	"ita": "it",
+	"nld": "nl",
+}
+
+// SpacyProvider is a named-entity recognition provider (NERMode) backed by
+// spaCy running in a Docker container. It labels the tokens produced by any
+// tokenizer, so it can be appended to any module with Module.WithNER, NER
+// being experimental:
+//
+//	common.EnableExperimental(common.ExperimentalNER)
+//	m.WithNER(mul.NewSpacyProvider())
+//
+// Instead of managing its own container, it can use an existing spacy-api
+// server given by the "endpoint" config key (e.g. "http://localhost:8080").
+type SpacyProvider struct {
+	config                   map[string]interface{}
+	Lang                     string // ISO 639-3 language code
+	endpoint                 string
+	httpClient               *http.Client
+	managed                  bool // the provider started the container itself
+	progressCallback         common.ProgressCallback
+	downloadProgressCallback common.DownloadProgressCallback
+}
+
+// NewSpacyProvider creates a new spaCy NER provider
+func NewSpacyProvider() *SpacyProvider {
+	return &SpacyProvider{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// SaveConfig stores the configuration. Recognized keys are "lang", the
+// language of the text, and "endpoint", the URL of an existing spacy-api server.
+//
+// Returns an error if the language has no spaCy NER model.
+func (p *SpacyProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	if lang, ok := cfg["lang"].(string); ok {
+		if _, ok := spacyModels[lang]; !ok {
+			return fmt.Errorf("spacy: no NER model for language %s", lang)
+		}
+		p.Lang = lang
+	}
+	if endpoint, ok := cfg["endpoint"].(string); ok {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+	return nil
+}
+
+// InitWithContext pulls the spaCy image and starts its container unless an
+// endpoint was configured, then waits for the server to answer.
+//
+// Returns an error if Docker is unreachable, the server doesn't start or the context is canceled.
+func (p *SpacyProvider) InitWithContext(ctx context.Context) error {
+	if p.httpClient == nil {
+		p.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	if p.endpoint == "" {
+		if err := p.startContainer(ctx, false, false); err != nil {
+			return fmt.Errorf("spacy: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + spacyHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("spacy: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *SpacyProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext removes the container and starts a new one.
+// When noCache is true, the image is pulled again.
+func (p *SpacyProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	if p.managed || p.endpoint == "" {
+		if err := p.startContainer(ctx, true, noCache); err != nil {
+			return fmt.Errorf("spacy: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + spacyHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("spacy: %w", err)
+	}
+	return nil
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *SpacyProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// startContainer makes sure the spaCy container is running. With recreate,
+// an existing container is removed first; with pull, the image is pulled
+// even if present.
+func (p *SpacyProvider) startContainer(ctx context.Context, recreate, pull bool) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	info, err := cli.ContainerInspect(ctx, spacyContainerName)
+	switch {
+	case err == nil && recreate:
+		if err := cli.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("failed to remove container: %w", err)
+		}
+	case err == nil && info.State != nil && info.State.Running:
+		return nil
+	case err == nil:
+		if err := cli.ContainerStart(ctx, info.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("failed to start container: %w", err)
+		}
+		return nil
+	case !cerrdefs.IsNotFound(err):
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if _, err := cli.ImageInspect(ctx, spacyImage); err != nil || pull {
+		if err := p.pullImage(ctx, cli); err != nil {
+			return err
+		}
+	}
+
+	port := nat.Port("80/tcp")
+	created, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        spacyImage,
+			ExposedPorts: nat.PortSet{port: struct{}{}},
+		},
+		&container.HostConfig{
+			PortBindings:  nat.PortMap{port: []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: spacyHostPort}}},
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		nil, nil, spacyContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	return nil
+}
+
+// pullImage pulls spacyImage, reporting progress to the download progress callback.
+func (p *SpacyProvider) pullImage(ctx context.Context, cli *client.Client) error {
+	rc, err := cli.ImagePull(ctx, spacyImage, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", spacyImage, err)
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	for {
+		var msg struct {
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", spacyImage, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("failed to pull image %s: %s", spacyImage, msg.Error)
+		}
+		if p.downloadProgressCallback != nil && msg.ProgressDetail.Total > 0 {
+			p.downloadProgressCallback(p.Name(), msg.ProgressDetail.Current, msg.ProgressDetail.Total, msg.Status)
+		}
+	}
+}
+
+// waitReady polls the server until it lists its models.
+func (p *SpacyProvider) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, spacyStartTimeout)
+	defer cancel()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/models", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := p.httpClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server at %s not ready: %w", p.endpoint, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// ProcessFlowController labels the named entities of the tokens of the input.
+func (p *SpacyProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if mode != common.NERMode {
+		return nil, fmt.Errorf("spacy only supports NER mode, got %s", mode)
+	}
+	model, ok := spacyModels[p.Lang]
+	if !ok {
+		return nil, fmt.Errorf("spacy: no NER model for language %q", p.Lang)
+	}
+	if p.progressCallback != nil {
+		p.progressCallback(0, 1)
+	}
+	text := common.SurfaceText(input)
+	if strings.TrimSpace(text) == "" {
+		return input, nil
+	}
+	spans, err := p.entities(ctx, text, model)
+	if err != nil {
+		return nil, fmt.Errorf("spacy: %w", err)
+	}
+	common.AnnotateEntities(input, spans)
+	return input, nil
+}
+
+// entities queries the server for the entities of text. spacy-api returns
+// offsets in characters, converted here to byte offsets.
+func (p *SpacyProvider) entities(ctx context.Context, text, model string) ([]common.EntitySpan, error) {
+	body, err := json.Marshal(map[string]string{"text": text, "model": model})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/ent", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, msg)
+	}
+	var ents []struct {
+		Start int    `json:"start"`
+		End   int    `json:"end"`
+		Type  string `json:"type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ents); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	// Byte offset of each character offset
+	offsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+
+	spans := make([]common.EntitySpan, 0, len(ents))
+	for _, e := range ents {
+		if e.Start < 0 || e.End > len(offsets)-1 || e.Start >= e.End {
+			continue
+		}
+		spans = append(spans, common.EntitySpan{Start: offsets[e.Start], End: offsets[e.End], Label: e.Type})
+	}
+	return spans, nil
+}
+
+// CloseWithContext stops the container if the provider started it.
+func (p *SpacyProvider) CloseWithContext(ctx context.Context) error {
+	if !p.managed {
+		return nil
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("spacy: failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+	if err := cli.ContainerStop(ctx, spacyContainerName, container.StopOptions{}); err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("spacy: failed to stop container: %w", err)
+	}
+	p.managed = false
+	p.endpoint = ""
+	return nil
+}
+
+// Close stops the container with a background context.
+func (p *SpacyProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *SpacyProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *SpacyProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+// ResourceVersions returns the digest of the spaCy Docker image,
+// implementing common.VersionReporter.
+func (p *SpacyProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if !p.managed {
+		return nil, nil
+	}
+	return common.DockerImageVersions(ctx, spacyImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// spaCy runs in a Docker container.
+func (p *SpacyProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
+func (p *SpacyProvider) Name() string {
+	return "spacy"
+}
+
+func (p *SpacyProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.NERMode}
+}
+
+func (p *SpacyProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
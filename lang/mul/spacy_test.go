@@ -0,0 +1,55 @@
+package mul
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestSpacyProvider_NER(t *testing.T) {
+	text := "Émile Zola visited New York and the UN."
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/models":
+			json.NewEncoder(w).Encode([]string{"en"})
+		case "/ent":
+			var req map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, text, req["text"])
+			assert.Equal(t, "en", req["model"])
+			// Offsets in characters, as spaCy returns them
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"start": 0, "end": 10, "type": "PERSON"},
+				{"start": 19, "end": 27, "type": "GPE"},
+				{"start": 36, "end": 38, "type": "ORG"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	p := NewSpacyProvider()
+	require.NoError(t, p.SaveConfig(map[string]interface{}{"lang": "eng", "endpoint": server.URL}))
+	require.NoError(t, p.Init())
+	assert.Error(t, p.SaveConfig(map[string]interface{}{"lang": "tha"}))
+
+	tkns, err := common.IntegrateProviderTokensV2(text, []string{"Émile", "Zola", "visited", "New", "York", "and", "the", "UN"})
+	require.NoError(t, err)
+	tsw := &common.TknSliceWrapper{}
+	for _, tkn := range tkns {
+		tsw.Append(tkn)
+	}
+	_, err = p.ProcessFlowController(context.Background(), common.NERMode, tsw)
+	require.NoError(t, err)
+
+	assert.Equal(t, []common.Entity{
+		{Text: "Émile Zola", Label: common.EntityPerson, Tokens: []int{0, 2}},
+		{Text: "New York", Label: common.EntityLocation, Tokens: []int{6, 8}},
+		{Text: "UN", Label: common.EntityOrganization, Tokens: []int{14}},
+	}, common.Entities(tsw))
+}
@@ -189,18 +189,11 @@ func (p *UnisegProvider) process(ctx context.Context, chunks []string) (common.A
 			if word != "" {
 				token := common.Tkn{
 					Surface: word,
-					Position: struct {
-						Start     int
-						End       int
-						Sentence  int
-						Paragraph int
-					}{
-						Start: len(trimmed) - len(remaining),
-						End:   len(trimmed) - len(rest),
-					},
 					// We decide lexical vs. non-lexical inside isLexical() helper
 					IsLexical: p.isLexical(word),
 				}
+				token.Position.Start = len(trimmed) - len(remaining)
+				token.Position.End = len(trimmed) - len(rest)
 
 				tsw.Append(&token)
 			}
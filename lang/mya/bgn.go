@@ -0,0 +1,339 @@
+package mya
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const bgnScheme = "bgn_pcgn"
+
+const (
+	medialY = 'ျ'
+	medialR = 'ြ'
+	medialW = 'ွ'
+	medialH = 'ှ'
+)
+
+// burmeseSchemes lists the romanization schemes offered by the BurmeseProvider.
+var burmeseSchemes = []common.TranslitScheme{
+	{Name: bgnScheme, Description: "BGN/PCGN 1970 romanization, as used for Burmese place names (Myanma, Yankon, Mandale)"},
+}
+
+// consonants maps Burmese consonants to their romanization as initials.
+var consonants = map[rune]string{
+	'က': "k", 'ခ': "hk", 'ဂ': "g", 'ဃ': "g", 'င': "ng",
+	'စ': "s", 'ဆ': "hs", 'ဇ': "z", 'ဈ': "z", 'ည': "ny", 'ဉ': "ny",
+	'ဋ': "t", 'ဌ': "ht", 'ဍ': "d", 'ဎ': "d", 'ဏ': "n",
+	'တ': "t", 'ထ': "ht", 'ဒ': "d", 'ဓ': "d", 'န': "n",
+	'ပ': "p", 'ဖ': "hp", 'ဗ': "b", 'ဘ': "b", 'မ': "m",
+	'ယ': "y", 'ရ': "y", 'လ': "l", 'ဝ': "w", 'သ': "th",
+	'ဟ': "h", 'ဠ': "l", 'အ': "", 'ဿ': "th",
+}
+
+// independentVowels maps the vowels written as letters of their own.
+var independentVowels = map[rune]string{
+	'ဣ': "i", 'ဤ': "i", 'ဥ': "u", 'ဦ': "u", 'ဧ': "e", 'ဩ': "aw", 'ဪ': "aw",
+}
+
+// symbols maps the symbols standing for a whole word.
+var symbols = map[rune]string{
+	'၌': "hnaik", '၍': "ywe", '၎': "lagaung", '၏': "i",
+}
+
+// rimes maps the vowels and finals following the initial of a syllable, tone
+// marks removed, to their romanization.
+var rimes = map[string]string{
+	"": "a", "ာ": "a", "ါ": "a", "ိ": "i", "ီ": "i", "ု": "u", "ူ": "u",
+	"ေ": "e", "ဲ": "è", "ော": "aw", "ေါ": "aw", "ော်": "aw", "ေါ်": "aw",
+	"ို": "o", "ံ": "an", "ုံ": "on", "ိံ": "ein",
+	"က်": "et", "င်": "in", "စ်": "it", "ည်": "i", "ဉ်": "in", "ဏ်": "an",
+	"တ်": "at", "န်": "an", "ပ်": "at", "မ်": "an", "ယ်": "è",
+	"ောက်": "auk", "ောင်": "aung", "ေါက်": "auk", "ေါင်": "aung",
+	"ိုက်": "aik", "ိုင်": "aing", "ိတ်": "eik", "ိပ်": "eik",
+	"ိန်": "ein", "ိမ်": "ein", "ုတ်": "ok", "ုပ်": "ok", "ုန်": "on", "ုမ်": "on",
+}
+
+// wRimes maps the finals which change the vowel of a syllable with medial ွ:
+// ကျွန် kyun, လွတ် lut.
+var wRimes = map[string]string{
+	"တ်": "ut", "ပ်": "ut", "န်": "un", "မ်": "un", "ံ": "un",
+}
+
+// vowelSigns romanizes the signs of a rime missing from the tables one by one.
+var vowelSigns = map[rune]string{
+	'ာ': "a", 'ါ': "a", 'ိ': "i", 'ီ': "i", 'ု': "u", 'ူ': "u", 'ေ': "e", 'ဲ': "è", 'ံ': "n",
+}
+
+// burmeseDigits maps Burmese digits to ASCII digits.
+var burmeseDigits = map[rune]string{
+	'၀': "0", '၁': "1", '၂': "2", '၃': "3", '၄': "4",
+	'၅': "5", '၆': "6", '၇': "7", '၈': "8", '၉': "9",
+}
+
+// BurmeseProvider romanizes Burmese tokens following the BGN/PCGN system in
+// pure Go, see Romanize.
+type BurmeseProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewBurmeseProvider creates a new BurmeseProvider.
+func NewBurmeseProvider() *BurmeseProvider {
+	return &BurmeseProvider{}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *BurmeseProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Burmese romanizer).
+func (p *BurmeseProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Burmese romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *BurmeseProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *BurmeseProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("burmese: context canceled during initialization: %w", err)
+	}
+	if scheme, _ := p.config["scheme"].(string); scheme != "" && scheme != bgnScheme {
+		return fmt.Errorf("burmese: unsupported romanization scheme: %s", scheme)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *BurmeseProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext is equivalent to InitWithContext as there are no persistent resources.
+func (p *BurmeseProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *BurmeseProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *BurmeseProvider) Name() string {
+	return "burmese"
+}
+
+func (p *BurmeseProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *BurmeseProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *BurmeseProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *BurmeseProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController romanizes the Burmese tokens of the input.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *BurmeseProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("burmese: context canceled during processing: %w", err)
+	}
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("burmese: raw input not accepted, a tokenizer must run first")
+	}
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("burmese: context canceled while processing token %d: %w", i, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		tkn.SetRoman(Romanize(s))
+	}
+	return input, nil
+}
+
+// Romanize romanizes Burmese text following BGN/PCGN: the syllables of a run
+// of Burmese letters are joined (မြန်မာ → myanma) and tones are not marked.
+// Other characters are kept, save for digits and the section marks ၊ and ။
+// which are converted to their ASCII counterparts.
+func Romanize(text string) string {
+	var out strings.Builder
+	for _, s := range splitRuns(text) {
+		rs := []rune(s)
+		if !isBurmeseLetter(rs[0]) {
+			for _, r := range rs {
+				switch {
+				case burmeseDigits[r] != "":
+					out.WriteString(burmeseDigits[r])
+				case r == '၊':
+					out.WriteString(",")
+				case r == '။':
+					out.WriteString(".")
+				default:
+					out.WriteRune(r)
+				}
+			}
+			continue
+		}
+		start := 0
+		for i := 1; i <= len(rs); i++ {
+			if i == len(rs) || startsSyllable(rs, i) {
+				out.WriteString(romanizeSyllable(rs[start:i]))
+				start = i
+			}
+		}
+	}
+	return out.String()
+}
+
+// splitRuns splits text into runs of Burmese letters and runs of other characters.
+func splitRuns(text string) []string {
+	var runs []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		for j < len(rs) && isBurmeseLetter(rs[j]) == isBurmeseLetter(rs[i]) {
+			j++
+		}
+		runs = append(runs, string(rs[i:j]))
+		i = j
+	}
+	return runs
+}
+
+// romanizeSyllable romanizes a single Burmese syllable. A consonant stacked
+// with a virama closes the part before it: မန္တ is read as မန် + တ.
+func romanizeSyllable(syllable []rune) string {
+	var roman string
+	part := make([]rune, 0, len(syllable))
+	for _, r := range syllable {
+		switch r {
+		case dotBelow, visarga:
+		case virama:
+			if n := len(part); n > 0 && part[n-1] != asat {
+				part = append(part, asat)
+			}
+			roman += romanizePart(part)
+			part = part[:0]
+		default:
+			part = append(part, r)
+		}
+	}
+	return roman + romanizePart(part)
+}
+
+// romanizePart romanizes an initial with its medials, vowels and final.
+func romanizePart(rs []rune) string {
+	if len(rs) == 0 {
+		return ""
+	}
+	if s, ok := symbols[rs[0]]; ok {
+		return s
+	}
+	if s, ok := independentVowels[rs[0]]; ok {
+		return s + romanizeRime(rs[1:], false)
+	}
+
+	base := rs[0]
+	initial := consonants[base]
+	var hasY, hasW, hasH bool
+	i := 1
+loop:
+	for ; i < len(rs); i++ {
+		switch rs[i] {
+		case medialY, medialR:
+			hasY = true
+		case medialW:
+			hasW = true
+		case medialH:
+			hasH = true
+		default:
+			break loop
+		}
+	}
+	switch {
+	case hasH && (hasY || base == 'ရ' || base == 'ယ'):
+		initial = "sh"
+	case hasH && base == 'လ':
+		initial = "hl"
+	case hasH:
+		initial = "h" + initial
+	case hasY && base == 'က':
+		initial = "ky"
+	case hasY && base == 'ခ':
+		initial = "ch"
+	case hasY && base == 'ဂ':
+		initial = "gy"
+	case hasY:
+		initial += "y"
+	}
+	if hasW {
+		if s, ok := wRimes[string(rs[i:])]; ok {
+			return initial + s
+		}
+		initial += "w"
+	}
+	return initial + romanizeRime(rs[i:], true)
+}
+
+// romanizeRime romanizes the vowels and final of a syllable. withInherent
+// tells whether an empty rime stands for the inherent vowel a.
+func romanizeRime(rs []rune, withInherent bool) string {
+	if len(rs) == 0 && !withInherent {
+		return ""
+	}
+	if s, ok := rimes[string(rs)]; ok {
+		return s
+	}
+	var roman string
+	for _, r := range rs {
+		if s, ok := vowelSigns[r]; ok {
+			roman += s
+		} else if s, ok := consonants[r]; ok {
+			roman += s
+		}
+	}
+	return roman
+}
@@ -0,0 +1,62 @@
+package mya_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mya"
+)
+
+func TestSegmentSyllables(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{"မြန်မာ", []string{"မြန်", "မာ"}},
+		{"မင်္ဂလာပါ", []string{"မင်္ဂ", "လာ", "ပါ"}}, // kinzi
+		{"မန္တလေး", []string{"မန္တ", "လေး"}},         // stacked consonant
+		{"ကျေးဇူးတင်ပါတယ်။", []string{"ကျေး", "ဇူး", "တင်", "ပါ", "တယ်"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, mya.SegmentSyllables(c.input), "input %q", c.input)
+	}
+}
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"မြန်မာ", "myanma"},
+		{"ရန်ကုန်", "yankon"},
+		{"မန္တလေး", "mantale"},
+		{"မင်္ဂလာပါ", "mingalapa"},
+		{"ကျွန်တော်", "kyuntaw"},
+		{"ရှမ်း", "shan"},
+		{"ဘောင်းဘီ", "baungbi"},
+		{"နေကောင်းလား။", "nekaungla."},
+		{"၁၉၄၈", "1948"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, mya.Romanize(c.input), "input %q", c.input)
+	}
+}
+
+func TestModule(t *testing.T) {
+	m, err := mya.DefaultModule()
+	require.NoError(t, err)
+
+	roman, err := m.Roman("မင်္ဂလာပါ။ နေကောင်းလား။")
+	require.NoError(t, err)
+	assert.Equal(t, "minga la pa။ ne kaung la။", roman)
+
+	tokens, err := m.Tokens("ရှမ်း")
+	require.NoError(t, err)
+	require.Len(t, tokens.Slice, 1)
+
+	tkn := tokens.Slice[0].(*mya.Tkn)
+	assert.Equal(t, "shan", tkn.Roman())
+	assert.Equal(t, "high", tkn.Tone)
+	assert.False(t, tkn.Stacked)
+}
@@ -0,0 +1,48 @@
+package mya
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func init() {
+	syllablesEntry := common.ProviderEntry{
+		Provider:     NewSyllableTokenizerProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization},
+	}
+	burmeseEntry := common.ProviderEntry{
+		Provider:     NewBurmeseProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, syllablesEntry); err != nil {
+		panic(fmt.Sprintf("failed to register burmese-syllables provider: %v", err))
+	}
+	if err := common.Register(Lang, burmeseEntry); err != nil {
+		panic(fmt.Sprintf("failed to register burmese provider: %v", err))
+	}
+
+	for _, scheme := range burmeseSchemes {
+		scheme.Providers = []string{"burmese-syllables", "burmese"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Burmese scheme")
+		}
+	}
+
+	if err := common.RegisterSpacingRule(Lang, SpacingRule); err != nil {
+		panic(fmt.Sprintf("failed to register Burmese spacing rule: %v", err))
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		syllablesEntry,
+		burmeseEntry,
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
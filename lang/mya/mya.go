@@ -0,0 +1,35 @@
+package mya
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptMyanmar = "Mymr" // Myanmar script
+	ScriptLatin   = "Latn" // Romanized/Latin script (BGN/PCGN...)
+)
+
+// Tkn extends the common Token with Burmese-specific features
+type Tkn struct {
+	common.Tkn
+
+	Tone    string // Tone of the syllable: low, high (း), creaky (့) or checked (stop final)
+	Stacked bool   // The syllable contains a stacked consonant (္), e.g. in Pali loanwords or kinzi
+}
+
+// SpacingRule is the spacing rule registered for Burmese. The spaces found
+// between Burmese phrases are kept as tokens, so no space is added around
+// them, and the section marks ၊ and ။, which are not romanized, stay attached
+// to the syllable they follow.
+func SpacingRule(prev, current string) bool {
+	if strings.TrimRightFunc(prev, unicode.IsSpace) != prev || strings.TrimLeftFunc(current, unicode.IsSpace) != current {
+		return false
+	}
+	if strings.HasPrefix(current, "၊") || strings.HasPrefix(current, "။") {
+		return false
+	}
+	return common.DefaultSpacingRule(prev, current)
+}
@@ -0,0 +1,213 @@
+package mya
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	asat     = '်' // kills the inherent vowel of a final consonant
+	virama   = '္' // stacks the following consonant under the preceding one
+	dotBelow = '့' // creaky tone
+	visarga  = 'း' // high tone
+)
+
+// SyllableTokenizerProvider is a pure Go tokenizer splitting Burmese text
+// into syllables. Burmese doesn't separate words, but every syllable starts
+// with a consonant or an independent vowel, unless that consonant is killed
+// by an asat or stacked with a virama, in which case it ends the syllable
+// before it.
+type SyllableTokenizerProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewSyllableTokenizerProvider creates a new Burmese syllable tokenizer
+func NewSyllableTokenizerProvider() *SyllableTokenizerProvider {
+	return &SyllableTokenizerProvider{}
+}
+
+func (p *SyllableTokenizerProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *SyllableTokenizerProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the tokenizer has no resources to download
+}
+
+// SaveConfig stores configuration for later application during initialization
+func (p *SyllableTokenizerProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *SyllableTokenizerProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *SyllableTokenizerProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *SyllableTokenizerProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *SyllableTokenizerProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// ProcessFlowController segments the raw input chunks into syllables
+func (p *SyllableTokenizerProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("burmese-syllables: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("burmese-syllables only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("burmese-syllables: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("burmese-syllables: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tokens, err := common.IntegrateProviderTokensV2(chunk, SegmentSyllables(chunk))
+		if err != nil {
+			common.Log.Debug().
+				Err(err).
+				Msg("Token integration had issues, continuing with partial results")
+		}
+		for _, token := range tokens {
+			tsw.Append(newSyllableToken(token))
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+func (p *SyllableTokenizerProvider) Name() string {
+	return "burmese-syllables"
+}
+
+func (p *SyllableTokenizerProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *SyllableTokenizerProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// SegmentSyllables splits text into syllables: runs of Burmese letters are
+// split into syllables and runs of other letters and digits are kept whole.
+// Spaces and punctuation are left out.
+func SegmentSyllables(text string) []string {
+	var syllables []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch {
+		case isBurmeseLetter(rs[i]):
+			for j < len(rs) && isBurmeseLetter(rs[j]) && !startsSyllable(rs, j) {
+				j++
+			}
+			syllables = append(syllables, string(rs[i:j]))
+		case unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]):
+			for j < len(rs) && !isBurmeseLetter(rs[j]) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			syllables = append(syllables, string(rs[i:j]))
+		}
+		i = j
+	}
+	return syllables
+}
+
+// startsSyllable reports whether the letter at index i starts a new syllable.
+func startsSyllable(rs []rune, i int) bool {
+	r := rs[i]
+	if isSymbol(r) {
+		return true
+	}
+	if isIndependentVowel(r) {
+		return rs[i-1] != virama
+	}
+	if !isConsonant(r) || rs[i-1] == virama {
+		return false
+	}
+	next := i + 1
+	for next < len(rs) && rs[next] == dotBelow {
+		next++
+	}
+	return next >= len(rs) || rs[next] != asat && rs[next] != virama
+}
+
+// isBurmeseLetter reports whether r is a Burmese consonant, vowel or mark,
+// or one of the symbols standing for a whole word (၌ ၍ ၎ ၏).
+func isBurmeseLetter(r rune) bool {
+	return r >= 0x1000 && r <= 0x103F || isSymbol(r)
+}
+
+func isConsonant(r rune) bool {
+	return r >= 0x1000 && r <= 0x1021 || r == 0x103F
+}
+
+func isIndependentVowel(r rune) bool {
+	return r >= 0x1023 && r <= 0x102A
+}
+
+func isSymbol(r rune) bool {
+	return r >= 0x104C && r <= 0x104F
+}
+
+// newSyllableToken wraps a token and finds the tone of its syllable
+func newSyllableToken(token *common.Tkn) *Tkn {
+	tkn := &Tkn{Tkn: *token}
+	rs := []rune(token.Surface)
+	if !token.IsLexical || len(rs) == 0 || !isBurmeseLetter(rs[0]) {
+		return tkn
+	}
+	tkn.Script = ScriptMyanmar
+	tkn.Stacked = strings.ContainsRune(token.Surface, virama)
+	switch {
+	case strings.ContainsRune(token.Surface, visarga):
+		tkn.Tone = "high"
+	case strings.ContainsRune(token.Surface, dotBelow):
+		tkn.Tone = "creaky"
+	case hasStopFinal(token.Surface):
+		tkn.Tone = "checked"
+	default:
+		tkn.Tone = "low"
+	}
+	return tkn
+}
+
+// hasStopFinal reports whether a syllable ends with one of the stop finals
+// က် စ် တ် ပ်, which are pronounced as a glottal stop.
+func hasStopFinal(syllable string) bool {
+	for _, final := range []string{"က်", "စ်", "တ်", "ပ်"} {
+		if strings.HasSuffix(syllable, final) {
+			return true
+		}
+	}
+	return false
+}
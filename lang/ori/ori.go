@@ -0,0 +1,15 @@
+package ori
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Tkn extends common.Tkn with Odia-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Script features
+	HasPhala   bool // ଫଳା - Subjoined consonant of a cluster
+	HasHasanta bool // ହସନ୍ତ - Virama marking a pure consonant
+	HasNukta   bool // ଡ଼ ଢ଼ - Flapped consonants written with a nukta
+}
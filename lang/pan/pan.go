@@ -1,6 +1,8 @@
 package pan
 
 import (
+	"strconv"
+
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
 )
 
@@ -137,7 +139,7 @@ func (t *Tkn) GetDialectName() string {
 func (t *Tkn) GetVerbAgreement() string {
 	agreement := t.VerbStructure.Gender + " " +
 		t.VerbStructure.Number + " " +
-		"Person-" + string(t.VerbStructure.Person)
+		"Person-" + strconv.Itoa(t.VerbStructure.Person)
 	if t.VerbStructure.HasErgative {
 		agreement += " (Ergative)"
 	}
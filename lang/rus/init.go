@@ -11,11 +11,11 @@ func init() {
 	defaultProviders := []common.ProviderEntry{
 		{
 			Provider:     &mul.UnisegProvider{},
-			Capabilities: []string{"tokenization"},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
 		},
 		{
 			Provider:     mul.NewIuliiaProvider(Lang),
-			Capabilities: []string{"transliteration"},
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
 		},
 	}
 
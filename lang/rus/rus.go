@@ -28,6 +28,8 @@ type Tkn struct {
 	
 	// Stress and phonetic features
 	StressPos     int            // Position of stressed syllable (important for correct pronunciation)
+	Accent        int            // Rune index of the stressed vowel in Surface, -1 if unknown (see StressProvider)
+	Stressed      string         // Surface with an acute accent on the stressed vowel (молоко́)
 	HasYo         bool           // Contains ё (often written as е but pronounced differently)
 	YoPositions   []int          // Positions of ё in the token
 	
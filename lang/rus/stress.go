@@ -0,0 +1,369 @@
+package rus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// acute is the combining acute accent used to mark stress (молоко́)
+const acute = "́"
+
+const vowels = "аеёиоуыэюя"
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune(vowels, unicode.ToLower(r))
+}
+
+// StressDictionary maps Russian words to the index of their stressed vowel.
+type StressDictionary struct {
+	mu      sync.RWMutex
+	entries map[string]int // lowercase word → rune index of the stressed vowel, -1 if ambiguous
+}
+
+// NewStressDictionary creates an empty stress dictionary.
+func NewStressDictionary() *StressDictionary {
+	return &StressDictionary{entries: make(map[string]int)}
+}
+
+// Add records a word written with its stress marked by a combining acute
+// accent or an apostrophe after the stressed vowel (молоко́, молоко'), or a
+// plus sign before it (молок+о). Homographs stressed differently (за́мок,
+// замо́к) are recorded as ambiguous and never annotated.
+func (d *StressDictionary) Add(stressed string) error {
+	word, accent := parseStressed(stressed)
+	if accent < 0 {
+		return fmt.Errorf("no stress mark in %q", stressed)
+	}
+	key := strings.ToLower(word)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if prev, ok := d.entries[key]; ok && prev != accent {
+		accent = -1
+	}
+	d.entries[key] = accent
+	return nil
+}
+
+// Lookup returns the rune index of the stressed vowel of word.
+func (d *StressDictionary) Lookup(word string) (int, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	accent, ok := d.entries[strings.ToLower(word)]
+	return accent, ok && accent >= 0
+}
+
+// Len returns the number of words in the dictionary.
+func (d *StressDictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// parseStressed removes the stress mark of a word and returns the index of
+// the stressed vowel, or -1 if there is no mark.
+func parseStressed(stressed string) (string, int) {
+	var word []rune
+	accent := -1
+	plus := false
+	for _, r := range norm.NFC.String(stressed) {
+		switch {
+		case r == '́' || r == '\'':
+			if n := len(word); n > 0 && isVowel(word[n-1]) {
+				accent = n - 1
+			}
+		case r == '+':
+			plus = true
+		default:
+			if plus && isVowel(r) {
+				accent = len(word)
+			}
+			plus = false
+			word = append(word, r)
+		}
+	}
+	return string(word), accent
+}
+
+// LoadStressDictionary reads a stress dictionary, such as the wordlists derived
+// from Zaliznyak's grammatical dictionary, with one word per line and the stress
+// marked as accepted by StressDictionary.Add. When a line has several fields
+// (separated by tabs or spaces), the first one bearing a stress mark is used;
+// lines without any are skipped, as are comments starting with "#".
+func LoadStressDictionary(r io.Reader) (*StressDictionary, error) {
+	d := NewStressDictionary()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			if d.Add(field) == nil {
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("stress dictionary: %w", err)
+	}
+	return d, nil
+}
+
+// stressOf returns the rune index of the stressed vowel of a word: from the
+// dictionary, or the ё or sole vowel of the word, which are always stressed.
+func (d *StressDictionary) stressOf(word string) (int, bool) {
+	if d != nil {
+		if accent, ok := d.Lookup(word); ok {
+			return accent, true
+		}
+	}
+	only := -1
+	for i, r := range []rune(word) {
+		switch {
+		case r == 'ё' || r == 'Ё':
+			return i, true
+		case isVowel(r) && only >= 0:
+			return -1, false
+		case isVowel(r):
+			only = i
+		}
+	}
+	return only, only >= 0
+}
+
+// StressOptions are the typed options of StressProvider, see common.Configure.
+type StressOptions struct {
+	// AccentRoman marks the stressed vowel of the romanization with an acute
+	// accent (molokó). Only effective when decorating a transliterator.
+	AccentRoman bool
+}
+
+// StressProvider annotates the stress of Russian words from a StressDictionary.
+// Stressed tokens get their StressPos, Accent and Stressed form set.
+// Words containing ё and words of a single vowel need no dictionary entry.
+//
+// It can be used as an enricher, or decorate the transliterator of the module
+// to also mark the stress in the romanization, see Module.WithStress.
+type StressProvider struct {
+	Dictionary *StressDictionary
+	Options    StressOptions
+
+	inner common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]
+}
+
+// NewStressProvider creates a stress provider for use as an enricher.
+func NewStressProvider(dict *StressDictionary) *StressProvider {
+	return &StressProvider{Dictionary: dict}
+}
+
+// WithStress makes the module annotate the stress of the words found in dict,
+// by decorating its transliterator with a StressProvider.
+func (m *Module) WithStress(dict *StressDictionary, opts StressOptions) *Module {
+	m.WrapProvider(common.TransliteratorMode, func(inner common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return &StressProvider{Dictionary: dict, Options: opts, inner: inner}
+	})
+	return m
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *StressProvider) ConfigureWith(opts StressOptions) error {
+	p.Options = opts
+	return nil
+}
+
+// Unwrap returns the transliterator decorated by the provider, if any.
+func (p *StressProvider) Unwrap() common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+	return p.inner
+}
+
+// ProcessFlowController annotates the stress of the lexical tokens of the input.
+// In transliterator mode, the decorated transliterator romanizes the tokens,
+// from their stressed form if Options.AccentRoman is set.
+func (p *StressProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	switch mode {
+	case common.EnricherMode:
+		return p.annotate(input), nil
+	case common.TransliteratorMode:
+		if p.inner == nil {
+			return nil, fmt.Errorf("rus-stress: no transliterator to decorate")
+		}
+	default:
+		return nil, fmt.Errorf("rus-stress doesn't support %s mode", mode)
+	}
+
+	if !p.Options.AccentRoman {
+		out, err := p.inner.ProcessFlowController(ctx, mode, input)
+		if err != nil {
+			return nil, err
+		}
+		return p.annotate(out), nil
+	}
+
+	// The transliterator is given the stressed forms, whose accent it passes
+	// through to the romanization, then the surfaces are restored.
+	// Transliterators such as iuliia expect a *common.TknSliceWrapper.
+	stressed := p.annotate(input)
+	surfaces := make(map[*Tkn]string)
+	for _, tkn := range stressed.NativeSlice {
+		if tkn.Stressed != "" {
+			surfaces[tkn] = tkn.Surface
+			tkn.Surface = tkn.Stressed
+		}
+	}
+	out, err := p.inner.ProcessFlowController(ctx, mode, &stressed.TknSliceWrapper)
+	for tkn, surface := range surfaces {
+		tkn.Surface = surface
+		tkn.Romanization = norm.NFC.String(tkn.Romanization)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.annotate(out), nil
+}
+
+// annotate converts the tokens to rus.Tkn and sets their stress.
+func (p *StressProvider) annotate(input common.AnyTokenSliceWrapper) *TknSliceWrapper {
+	tsw, ok := input.(*TknSliceWrapper)
+	if !ok {
+		tsw = &TknSliceWrapper{}
+		for i := 0; i < input.Len(); i++ {
+			token := input.GetIdx(i)
+			tkn, ok := token.(*Tkn)
+			if !ok {
+				tkn = &Tkn{StressPos: -1}
+				if base := common.BaseToken(token); base != nil {
+					tkn.Tkn = *base
+				} else {
+					tkn.Surface, tkn.IsLexical = token.GetSurface(), token.IsLexicalContent()
+					tkn.Romanization = token.Roman()
+				}
+			}
+			tsw.Append(tkn)
+			tsw.NativeSlice = append(tsw.NativeSlice, tkn)
+		}
+		tsw.SetChunks(common.ChunksOf(input))
+	}
+	for _, tkn := range tsw.NativeSlice {
+		if tkn.IsLexical {
+			p.annotateToken(tkn)
+		}
+	}
+	return tsw
+}
+
+func (p *StressProvider) annotateToken(tkn *Tkn) {
+	runes := []rune(tkn.Surface)
+	tkn.HasYo, tkn.YoPositions = false, nil
+	for i, r := range runes {
+		if r == 'ё' || r == 'Ё' {
+			tkn.HasYo = true
+			tkn.YoPositions = append(tkn.YoPositions, i)
+		}
+	}
+	accent, ok := p.Dictionary.stressOf(tkn.Surface)
+	if !ok || accent >= len(runes) || !isVowel(runes[accent]) {
+		tkn.StressPos, tkn.Accent = -1, -1
+		return
+	}
+	tkn.Accent = accent
+	tkn.StressPos = 0
+	multi := false
+	for i, r := range runes {
+		if !isVowel(r) {
+			continue
+		}
+		if i < accent {
+			tkn.StressPos++
+		} else if i > accent {
+			multi = true
+		}
+	}
+	// By convention, ё and monosyllables aren't marked
+	if (tkn.StressPos > 0 || multi) && runes[accent] != 'ё' && runes[accent] != 'Ё' {
+		tkn.Stressed = string(runes[:accent+1]) + acute + string(runes[accent+1:])
+	}
+}
+
+func (p *StressProvider) SaveConfig(cfg map[string]interface{}) error {
+	if p.inner != nil {
+		return p.inner.SaveConfig(cfg)
+	}
+	return nil
+}
+
+func (p *StressProvider) InitWithContext(ctx context.Context) error {
+	if p.inner != nil {
+		return p.inner.InitWithContext(ctx)
+	}
+	return nil
+}
+
+func (p *StressProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *StressProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	if p.inner != nil {
+		return p.inner.InitRecreateWithContext(ctx, noCache)
+	}
+	return nil
+}
+
+func (p *StressProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *StressProvider) CloseWithContext(ctx context.Context) error {
+	if p.inner != nil {
+		return p.inner.CloseWithContext(ctx)
+	}
+	return nil
+}
+
+func (p *StressProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *StressProvider) WithProgressCallback(callback common.ProgressCallback) {
+	if p.inner != nil {
+		p.inner.WithProgressCallback(callback)
+	}
+}
+
+func (p *StressProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	if p.inner != nil {
+		p.inner.WithDownloadProgressCallback(callback)
+	}
+}
+
+func (p *StressProvider) Name() string {
+	// a decorator goes by the name of the provider it decorates, as CircuitBreaker
+	if p.inner != nil {
+		return p.inner.Name()
+	}
+	return "rus-stress"
+}
+
+func (p *StressProvider) SupportedModes() []common.OperatingMode {
+	if p.inner != nil {
+		return []common.OperatingMode{common.TransliteratorMode, common.EnricherMode}
+	}
+	return []common.OperatingMode{common.EnricherMode}
+}
+
+func (p *StressProvider) GetMaxQueryLen() int {
+	if p.inner != nil {
+		return p.inner.GetMaxQueryLen()
+	}
+	return math.MaxInt32
+}
@@ -0,0 +1,73 @@
+package rus
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestStress(t *testing.T) {
+	dict, err := LoadStressDictionary(strings.NewReader("# stress\nмолоко́\nмолоко\tмолок+о\nхоро'ший\nза́мок\nзамо́к\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, dict.Len())
+	_, ok := dict.Lookup("замок")
+	assert.False(t, ok, "homographs stressed differently are ambiguous")
+
+	m, err := common.DefaultModule(Lang)
+	require.NoError(t, err)
+	rm := (&Module{Module: m}).WithStress(dict, StressOptions{AccentRoman: true})
+	require.NoError(t, rm.Init())
+	defer rm.Close()
+
+	tkns, err := rm.LexicalTokens("Хорошее молоко, ёж и кот.")
+	require.NoError(t, err)
+	require.Len(t, tkns.NativeSlice, 5)
+
+	unknown := tkns.NativeSlice[0]
+	assert.Equal(t, -1, unknown.Accent)
+	assert.Empty(t, unknown.Stressed)
+
+	moloko := tkns.NativeSlice[1]
+	assert.Equal(t, 5, moloko.Accent)
+	assert.Equal(t, 2, moloko.StressPos)
+	assert.Equal(t, "молоко́", moloko.Stressed)
+	assert.Equal(t, "молоко", moloko.Surface)
+	assert.Equal(t, "molokó", moloko.Romanization)
+
+	yozh := tkns.NativeSlice[2]
+	assert.Equal(t, 0, yozh.Accent, "ё is always stressed")
+	assert.Empty(t, yozh.Stressed, "ё isn't marked")
+	assert.True(t, yozh.HasYo)
+}
+
+func TestCapitalizeEntities(t *testing.T) {
+	m, err := common.DefaultModule(Lang)
+	require.NoError(t, err)
+	// stands for a NER provider
+	m.WithEnricher(common.NewFuncEnricher("test-ner", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		common.AnnotateEntities(tsw, []common.EntitySpan{
+			{Start: 0, End: len("иван"), Label: "PER"},
+			{Start: len("иван живёт в "), End: len("иван живёт в москве"), Label: "GPE"},
+		})
+		return nil
+	}))
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	plain, err := m.Roman("иван живёт в москве")
+	require.NoError(t, err)
+	m.WithRomanizationOptions(common.RomanizationOptions{CapitalizeEntities: true})
+	roman, err := m.Roman("иван живёт в москве")
+	require.NoError(t, err)
+	t.Log(plain, "→", roman)
+	words, plainWords := strings.Fields(roman), strings.Fields(plain)
+	require.Len(t, words, 4)
+	assert.Regexp(t, `^\p{Lu}`, words[0])
+	assert.Equal(t, plainWords[1:3], words[1:3])
+	assert.Regexp(t, `^\p{Lu}`, words[3])
+	assert.Equal(t, strings.ToLower(roman), strings.ToLower(plain))
+}
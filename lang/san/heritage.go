@@ -0,0 +1,448 @@
+package san
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	// heritageImage runs the segmenter of the Sanskrit Heritage engine
+	// (https://sanskrit.inria.fr) behind the HTTP API described on HeritageProvider
+	heritageImage         = "ghcr.io/tassa-yoniso-manasi-karoto/langkit-heritage:latest"
+	heritageContainerName = "translitkit-heritage"
+	heritageHostPort      = "8086"
+	heritageStartTimeout  = 2 * time.Minute
+
+	// heritageDownloadSize is the approximate size in bytes of heritageImage
+	heritageDownloadSize int64 = 300 << 20
+
+	// heritageMaxQueryLen bounds the chunks: segmentation is combinatorial
+	// and long sentences take the engine a long time
+	heritageMaxQueryLen = 500
+)
+
+// HeritageProvider is a sandhi-aware tokenizer backed by the segmenter of the
+// Sanskrit Heritage engine running in a Docker container. Written Sanskrit
+// joins words by sandhi and compounding: each written word (pada) is split
+// into the words it is made of, restored to their form before sandhi
+// (रामोऽपि → रामः अपि), with their stem and morphological analysis.
+// Since restored forms don't appear as such in the input, the offsets of
+// split tokens span their whole pada.
+//
+// Instead of managing its own container, it can use an existing server given
+// by the "endpoint" config key. The server answers
+//
+//	GET /segment?text=<pada in Devanagari>
+//
+// with the best segmentation of the pada, in Devanagari:
+//
+//	{"words": [{"form": "रामः", "stem": "राम", "morph": "nom. sg. m."}, ...]}
+//
+// and GET /health with 200 once it is ready.
+type HeritageProvider struct {
+	config                   map[string]interface{}
+	endpoint                 string
+	httpClient               *http.Client
+	managed                  bool // the provider started the container itself
+	cache                    sync.Map // pada → []heritageWord
+	progressCallback         common.ProgressCallback
+	downloadProgressCallback common.DownloadProgressCallback
+}
+
+// heritageWord is a word of the segmentation returned by the server
+type heritageWord struct {
+	Form  string `json:"form"`
+	Stem  string `json:"stem"`
+	Morph string `json:"morph"`
+}
+
+// NewHeritageProvider creates a new Sanskrit Heritage tokenizer
+func NewHeritageProvider() *HeritageProvider {
+	return &HeritageProvider{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// SaveConfig stores the configuration. The recognized key is "endpoint", the
+// URL of an existing server (e.g. "http://localhost:8080").
+func (p *HeritageProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	if endpoint, ok := cfg["endpoint"].(string); ok {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+	return nil
+}
+
+// InitWithContext pulls the Heritage image and starts its container unless an
+// endpoint was configured, then waits for the server to answer.
+//
+// Returns an error if Docker is unreachable, the server doesn't start or the context is canceled.
+func (p *HeritageProvider) InitWithContext(ctx context.Context) error {
+	if p.httpClient == nil {
+		p.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	if p.endpoint == "" {
+		if err := p.startContainer(ctx, false, false); err != nil {
+			return fmt.Errorf("heritage: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + heritageHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("heritage: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *HeritageProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext removes the container and starts a new one.
+// When noCache is true, the image is pulled again and the cache of
+// segmentations is cleared.
+func (p *HeritageProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	if noCache {
+		p.cache = sync.Map{}
+	}
+	if p.managed || p.endpoint == "" {
+		if err := p.startContainer(ctx, true, noCache); err != nil {
+			return fmt.Errorf("heritage: %w", err)
+		}
+		p.endpoint = "http://127.0.0.1:" + heritageHostPort
+		p.managed = true
+	}
+	if err := p.waitReady(ctx); err != nil {
+		return fmt.Errorf("heritage: %w", err)
+	}
+	return nil
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *HeritageProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// startContainer makes sure the Heritage container is running. With recreate,
+// an existing container is removed first; with pull, the image is pulled
+// even if present.
+func (p *HeritageProvider) startContainer(ctx context.Context, recreate, pull bool) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	info, err := cli.ContainerInspect(ctx, heritageContainerName)
+	switch {
+	case err == nil && recreate:
+		if err := cli.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("failed to remove container: %w", err)
+		}
+	case err == nil && info.State != nil && info.State.Running:
+		return nil
+	case err == nil:
+		if err := cli.ContainerStart(ctx, info.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("failed to start container: %w", err)
+		}
+		return nil
+	case !cerrdefs.IsNotFound(err):
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if _, err := cli.ImageInspect(ctx, heritageImage); err != nil || pull {
+		if err := p.pullImage(ctx, cli); err != nil {
+			return err
+		}
+	}
+
+	port := nat.Port("8080/tcp")
+	created, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        heritageImage,
+			ExposedPorts: nat.PortSet{port: struct{}{}},
+		},
+		&container.HostConfig{
+			PortBindings:  nat.PortMap{port: []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: heritageHostPort}}},
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		nil, nil, heritageContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	return nil
+}
+
+// pullImage pulls heritageImage, reporting progress to the download progress callback.
+func (p *HeritageProvider) pullImage(ctx context.Context, cli *client.Client) error {
+	rc, err := cli.ImagePull(ctx, heritageImage, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", heritageImage, err)
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	for {
+		var msg struct {
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", heritageImage, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("failed to pull image %s: %s", heritageImage, msg.Error)
+		}
+		if p.downloadProgressCallback != nil && msg.ProgressDetail.Total > 0 {
+			p.downloadProgressCallback(p.Name(), msg.ProgressDetail.Current, msg.ProgressDetail.Total, msg.Status)
+		}
+	}
+}
+
+// waitReady polls the server until it reports being healthy.
+func (p *HeritageProvider) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, heritageStartTimeout)
+	defer cancel()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := p.httpClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server at %s not ready: %w", p.endpoint, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// ProcessFlowController segments the raw input chunks into words.
+func (p *HeritageProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("heritage: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("heritage only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("heritage: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("heritage: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		for _, pada := range splitPadas(chunk) {
+			if !pada.lexical {
+				if strings.TrimSpace(pada.text) != "" {
+					tsw.Append(&Tkn{Tkn: common.Tkn{Surface: pada.text}})
+				}
+				continue
+			}
+			words, err := p.segment(ctx, pada.text)
+			if err != nil {
+				return nil, fmt.Errorf("heritage: chunk %d: %w", idx, err)
+			}
+			for _, tkn := range padaTokens(pada.text, words) {
+				tsw.Append(tkn)
+			}
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+// segment returns the segmentation of a pada, from the cache if possible.
+func (p *HeritageProvider) segment(ctx context.Context, pada string) ([]heritageWord, error) {
+	if words, ok := p.cache.Load(pada); ok {
+		return words.([]heritageWord), nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/segment?text="+url.QueryEscape(pada), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("server returned %s for %q: %s", resp.Status, pada, msg)
+	}
+	var result struct {
+		Words []heritageWord `json:"words"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response for %q: %w", pada, err)
+	}
+	p.cache.Store(pada, result.Words)
+	return result.Words, nil
+}
+
+// padaTokens makes the tokens of a pada from its segmentation. A pada the
+// engine couldn't analyse is kept whole.
+func padaTokens(pada string, words []heritageWord) []*Tkn {
+	if len(words) == 0 {
+		return []*Tkn{{Tkn: common.Tkn{Surface: pada, IsLexical: true}, Pada: pada}}
+	}
+	split := len(words) > 1 || words[0].Form != pada
+	tokens := make([]*Tkn, 0, len(words))
+	for i, w := range words {
+		tkn := &Tkn{
+			Tkn: common.Tkn{
+				Surface:   w.Form,
+				IsLexical: true,
+				Lemma:     w.Stem,
+				Script:    ScriptDevanagari,
+				Language:  Lang,
+			},
+			Pada:        pada,
+			SandhiSplit: split,
+			Stem:        w.Stem,
+			Morphology:  w.Morph,
+		}
+		// the engine marks the members of a compound with "iic." (in initio compositi)
+		tkn.IsCompoundMember = strings.HasPrefix(w.Morph, "iic") && i < len(words)-1
+		tokens = append(tokens, tkn)
+	}
+	return tokens
+}
+
+// pada is a run of the input: a written word or the text between two words
+type pada struct {
+	text    string
+	lexical bool
+}
+
+// splitPadas splits text into written words and the whitespace and
+// punctuation between them.
+func splitPadas(text string) []pada {
+	var padas []pada
+	var current strings.Builder
+	class := -1
+	for _, r := range text {
+		c := runeClass(r)
+		if c != class && current.Len() > 0 {
+			padas = append(padas, pada{text: current.String(), lexical: class == wordClass})
+			current.Reset()
+		}
+		class = c
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		padas = append(padas, pada{text: current.String(), lexical: class == wordClass})
+	}
+	return padas
+}
+
+const (
+	wordClass = iota
+	spaceClass
+	otherClass
+)
+
+func runeClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsMark(r) || r == 'ऽ':
+		return wordClass
+	case unicode.IsSpace(r):
+		return spaceClass
+	}
+	return otherClass
+}
+
+// CloseWithContext stops the container if the provider started it.
+func (p *HeritageProvider) CloseWithContext(ctx context.Context) error {
+	if !p.managed {
+		return nil
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("heritage: failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+	if err := cli.ContainerStop(ctx, heritageContainerName, container.StopOptions{}); err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("heritage: failed to stop container: %w", err)
+	}
+	p.managed = false
+	p.endpoint = ""
+	return nil
+}
+
+// Close stops the container with a background context.
+func (p *HeritageProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+func (p *HeritageProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *HeritageProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+// ResourceVersions returns the digest of the Heritage Docker image,
+// implementing common.VersionReporter.
+func (p *HeritageProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if !p.managed {
+		return nil, nil
+	}
+	return common.DockerImageVersions(ctx, heritageImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// the Heritage engine runs in a Docker container.
+func (p *HeritageProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
+func (p *HeritageProvider) Name() string {
+	return "heritage"
+}
+
+func (p *HeritageProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *HeritageProvider) GetMaxQueryLen() int {
+	return heritageMaxQueryLen
+}
@@ -0,0 +1,54 @@
+package san
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestHeritageTokenizer(t *testing.T) {
+	segmentations := map[string][]heritageWord{
+		"रामोऽपि": {{Form: "रामः", Stem: "राम", Morph: "nom. sg. m."}, {Form: "अपि", Stem: "अपि", Morph: "ind."}},
+		"वनं":    {{Form: "वनम्", Stem: "वन", Morph: "acc. sg. n."}},
+		"गच्छति":  {{Form: "गच्छति", Stem: "गम्", Morph: "pr. sg. 3"}},
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{"words": segmentations[r.URL.Query().Get("text")]})
+	}))
+	defer server.Close()
+
+	p := NewHeritageProvider()
+	require.NoError(t, p.SaveConfig(map[string]interface{}{"endpoint": server.URL}))
+	require.NoError(t, p.Init())
+	defer p.Close()
+
+	input := "रामोऽपि वनं गच्छति। गच्छति"
+	out, err := p.ProcessFlowController(context.Background(), common.TokenizerMode, &common.TknSliceWrapper{Raw: []string{input}})
+	require.NoError(t, err)
+
+	var surfaces []string
+	for i := 0; i < out.Len(); i++ {
+		surfaces = append(surfaces, out.GetIdx(i).GetSurface())
+	}
+	assert.Equal(t, []string{"रामः", "अपि", "वनम्", "गच्छति", "।", "गच्छति"}, surfaces)
+	assert.Equal(t, 3, requests, "segmentations should be cached")
+
+	tkn := out.GetIdx(0).(*Tkn)
+	assert.True(t, tkn.SandhiSplit)
+	assert.Equal(t, "रामोऽपि", tkn.Pada)
+	assert.Equal(t, "राम", tkn.Lemma)
+	assert.Equal(t, "nom. sg. m.", tkn.Morphology)
+	assert.False(t, out.GetIdx(3).(*Tkn).SandhiSplit)
+	assert.False(t, out.GetIdx(4).IsLexicalContent())
+}
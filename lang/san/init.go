@@ -0,0 +1,44 @@
+package san
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	heritageEntry := common.ProviderEntry{
+		Provider:     NewHeritageProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+	}
+
+	if err := common.Register(Lang, heritageEntry); err != nil {
+		panic(fmt.Sprintf("failed to register heritage provider: %v", err))
+	}
+
+	// Aksharamukha's schemes, on words split by the Heritage segmenter
+	for _, scheme := range mul.IndicSchemes() {
+		scheme.Providers = []string{"heritage", "aksharamukha"}
+		scheme.NeedsDocker = true
+		scheme.Requirements.DownloadSize = heritageDownloadSize + mul.AksharamukhaDownloadSize
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Sanskrit scheme")
+		}
+	}
+
+	defaultProviders := []common.ProviderEntry{
+		heritageEntry,
+		{
+			Provider:     mul.NewAksharamukhaProvider(Lang),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
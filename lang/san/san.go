@@ -0,0 +1,26 @@
+package san
+
+import (
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	ScriptDevanagari = "Deva" // Devanagari script
+	ScriptLatin      = "Latn" // Romanized/Latin script (IAST...)
+)
+
+// Tkn extends the common Token with Sanskrit-specific features
+type Tkn struct {
+	common.Tkn
+
+	// Sandhi
+	Pada        string // The written word the token was split from, sandhi applied (e.g. रामोऽपि)
+	SandhiSplit bool   // The token was split from its pada or its form was restored from sandhi
+
+	// Morphology, as analysed by the segmenter
+	Stem       string // Nominal or verbal stem (prātipadika or dhātu)
+	Morphology string // Morphological analysis (e.g. "nom. sg. m.")
+
+	// Compounds
+	IsCompoundMember bool // Member of a compound (samāsa) other than the last one
+}
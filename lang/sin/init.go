@@ -0,0 +1,46 @@
+package sin
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mul"
+)
+
+func init() {
+	sinhalaEntry := common.ProviderEntry{
+		Provider:     NewSinhalaProvider(),
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+	}
+
+	if err := common.Register(Lang, sinhalaEntry); err != nil {
+		panic(fmt.Sprintf("failed to register sinhala provider: %v", err))
+	}
+
+	for _, scheme := range sinhalaSchemes {
+		scheme.Providers = []string{"sinhala"}
+		if err := common.RegisterScheme(Lang, scheme); err != nil {
+			common.Log.Warn().
+				Str("pkg", Lang).
+				Str("scheme", scheme.Name).
+				Msg("Failed to register Sinhala scheme")
+		}
+	}
+
+	// The built-in romanizer is the default so that Sinhala works without
+	// Docker; the aksharamukha schemes remain available by name
+	defaultProviders := []common.ProviderEntry{
+		{
+			Provider:     &mul.UnisegProvider{},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
+		},
+		{
+			Provider:     NewSinhalaProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+
+	if err := common.SetDefault(Lang, defaultProviders); err != nil {
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
+	}
+}
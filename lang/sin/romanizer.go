@@ -0,0 +1,288 @@
+package sin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	defaultScheme    = "iso15919"
+	colloquialScheme = "sinhala-colloquial"
+)
+
+// sinhalaSchemes lists the romanization schemes offered by the SinhalaProvider.
+var sinhalaSchemes = []common.TranslitScheme{
+	{Name: defaultScheme, Description: "ISO 15919, letter by letter without Docker, with the Sinhala æ and prenasalized stops (siṁhala, koḷam̆ba)"},
+	{Name: colloquialScheme, Description: "Colloquial romanization without diacritics nor vowel length, as Sinhala is typed in Latin letters (sinhala, ayubowan)"},
+}
+
+const (
+	alLakuna = '්'      // virama
+	zwj      = '\u200D' // joins a consonant with al-lakuna to the next (ශ්‍රී)
+	zwnj     = '\u200C'
+)
+
+// schemeTables map the Sinhala letters to each scheme: the consonants without
+// their inherent vowel, the independent vowels, the vowel signs and the other
+// signs. Letters missing from a table are kept as is.
+var schemeTables = map[string]map[rune]string{
+	defaultScheme: {
+		'ක': "k", 'ඛ': "kh", 'ග': "g", 'ඝ': "gh", 'ඞ': "ṅ", 'ඟ': "n̆g",
+		'ච': "c", 'ඡ': "ch", 'ජ': "j", 'ඣ': "jh", 'ඤ': "ñ", 'ඥ': "jñ", 'ඦ': "n̆j",
+		'ට': "ṭ", 'ඨ': "ṭh", 'ඩ': "ḍ", 'ඪ': "ḍh", 'ණ': "ṇ", 'ඬ': "n̆ḍ",
+		'ත': "t", 'ථ': "th", 'ද': "d", 'ධ': "dh", 'න': "n", 'ඳ': "n̆d",
+		'ප': "p", 'ඵ': "ph", 'බ': "b", 'භ': "bh", 'ම': "m", 'ඹ': "m̆b",
+		'ය': "y", 'ර': "r", 'ල': "l", 'ව': "v", 'ශ': "ś", 'ෂ': "ṣ", 'ස': "s",
+		'හ': "h", 'ළ': "ḷ", 'ෆ': "f",
+		'අ': "a", 'ආ': "ā", 'ඇ': "æ", 'ඈ': "ǣ", 'ඉ': "i", 'ඊ': "ī", 'උ': "u", 'ඌ': "ū",
+		'ඍ': "r̥", 'ඎ': "r̥̄", 'ඏ': "l̥", 'ඐ': "l̥̄",
+		'එ': "e", 'ඒ': "ē", 'ඓ': "ai", 'ඔ': "o", 'ඕ': "ō", 'ඖ': "au",
+		'ා': "ā", 'ැ': "æ", 'ෑ': "ǣ", 'ි': "i", 'ී': "ī", 'ු': "u", 'ූ': "ū",
+		'ෘ': "r̥", 'ෲ': "r̥̄", 'ෟ': "l̥", 'ෳ': "l̥̄",
+		'ෙ': "e", 'ේ': "ē", 'ෛ': "ai", 'ො': "o", 'ෝ': "ō", 'ෞ': "au",
+		'ං': "ṁ", 'ඃ': "ḥ", 'ඁ': "m̐", '෴': ".",
+	},
+	colloquialScheme: {
+		'ක': "k", 'ඛ': "kh", 'ග': "g", 'ඝ': "gh", 'ඞ': "n", 'ඟ': "ng",
+		'ච': "ch", 'ඡ': "chh", 'ජ': "j", 'ඣ': "jh", 'ඤ': "ny", 'ඥ': "gn", 'ඦ': "nj",
+		'ට': "t", 'ඨ': "th", 'ඩ': "d", 'ඪ': "dh", 'ණ': "n", 'ඬ': "nd",
+		'ත': "th", 'ථ': "th", 'ද': "d", 'ධ': "dh", 'න': "n", 'ඳ': "nd",
+		'ප': "p", 'ඵ': "ph", 'බ': "b", 'භ': "bh", 'ම': "m", 'ඹ': "mb",
+		'ය': "y", 'ර': "r", 'ල': "l", 'ව': "w", 'ශ': "sh", 'ෂ': "sh", 'ස': "s",
+		'හ': "h", 'ළ': "l", 'ෆ': "f",
+		'අ': "a", 'ආ': "a", 'ඇ': "ae", 'ඈ': "ae", 'ඉ': "i", 'ඊ': "i", 'උ': "u", 'ඌ': "u",
+		'ඍ': "ru", 'ඎ': "ru", 'ඏ': "li", 'ඐ': "li",
+		'එ': "e", 'ඒ': "e", 'ඓ': "ai", 'ඔ': "o", 'ඕ': "o", 'ඖ': "au",
+		'ා': "a", 'ැ': "ae", 'ෑ': "ae", 'ි': "i", 'ී': "i", 'ු': "u", 'ූ': "u",
+		'ෘ': "ru", 'ෲ': "ru", 'ෟ': "li", 'ෳ': "li",
+		'ෙ': "e", 'ේ': "e", 'ෛ': "ai", 'ො': "o", 'ෝ': "o", 'ෞ': "au",
+		'ං': "n", 'ඃ': "h", 'ඁ': "n", '෴': ".",
+	},
+}
+
+// colloquialWords are spelled by convention rather than letter by letter
+var colloquialWords = strings.NewReplacer(
+	"ශ්\u200Dරී", "sri",
+	"ශ්රී", "sri",
+)
+
+func isConsonant(r rune) bool {
+	return r >= 'ක' && r <= 'ෆ'
+}
+
+func isVowelSign(r rune) bool {
+	return (r >= 'ා' && r <= 'ෟ') || r == 'ෲ' || r == 'ෳ'
+}
+
+func isLabial(r rune) bool {
+	return r >= 'ප' && r <= 'ඹ'
+}
+
+// SinhalaProvider romanizes Sinhala tokens letter by letter, in ISO 15919 or
+// in the colloquial romanization used to type Sinhala in Latin letters. As
+// Sinhala writes the al-lakuna (්) on the consonants without vowel, there is
+// no silent inherent vowel to delete.
+type SinhalaProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+	scheme           string
+}
+
+// NewSinhalaProvider creates a new SinhalaProvider using the default scheme.
+func NewSinhalaProvider() *SinhalaProvider {
+	return &SinhalaProvider{
+		scheme: defaultScheme,
+	}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *SinhalaProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Sinhala romanizer).
+func (p *SinhalaProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Sinhala romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+//
+// Returns an error if the configuration is invalid.
+func (p *SinhalaProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// SinhalaOptions are the typed options of SinhalaProvider, see common.Configure.
+type SinhalaOptions struct {
+	// Scheme is the name of a registered Sinhala scheme. Defaults to defaultScheme.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *SinhalaProvider) ConfigureWith(opts SinhalaOptions) error {
+	if _, ok := schemeTables[opts.Scheme]; !ok && opts.Scheme != "" {
+		return fmt.Errorf("unsupported transliteration scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// InitWithContext initializes the provider with the given context.
+// This validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *SinhalaProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("sinhala: context canceled during initialization: %w", err)
+	}
+
+	scheme, _ := p.config["scheme"].(string)
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if _, ok := schemeTables[scheme]; !ok {
+		return fmt.Errorf("sinhala: unsupported transliteration scheme: %s", scheme)
+	}
+	p.scheme = scheme
+	return nil
+}
+
+// Init initializes the provider with a background context.
+//
+// Returns an error if initialization fails.
+func (p *SinhalaProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes the provider from scratch with the given context.
+// For the Sinhala romanizer, this is equivalent to InitWithContext as there are no persistent resources.
+func (p *SinhalaProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *SinhalaProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *SinhalaProvider) Name() string {
+	return "sinhala"
+}
+
+func (p *SinhalaProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *SinhalaProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext is a no-op as there are no persistent resources to release.
+func (p *SinhalaProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op as there are no persistent resources to release.
+func (p *SinhalaProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController processes input tokens using the specified context,
+// adding romanization to Sinhala tokens.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *SinhalaProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("sinhala: context canceled during processing: %w", err)
+	}
+
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("sinhala: raw input not accepted, a tokenizer must run first")
+	}
+
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("sinhala: context canceled while processing token %d: %w", i, err)
+		}
+
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		roman, _ := Romanize(s, p.scheme)
+		tkn.SetRoman(roman)
+	}
+
+	return input, nil
+}
+
+// Romanize converts Sinhala text to the given scheme ("iso15919" or
+// "sinhala-colloquial"). In the colloquial scheme the anusvara assimilates to
+// a following labial (m) and ශ්‍රී is written sri.
+//
+// Returns an error if the scheme is not supported.
+func Romanize(text, scheme string) (string, error) {
+	table, ok := schemeTables[scheme]
+	if !ok {
+		return "", fmt.Errorf("sinhala: unsupported transliteration scheme: %s", scheme)
+	}
+	text = norm.NFC.String(text)
+	if scheme == colloquialScheme {
+		text = colloquialWords.Replace(text)
+	}
+	runes := []rune(text)
+	var out strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isConsonant(r):
+			out.WriteString(table[r])
+			// the inherent vowel is replaced by a vowel sign and dropped by the al-lakuna
+			switch {
+			case i+1 < len(runes) && runes[i+1] == alLakuna:
+				i++
+			case i+1 < len(runes) && isVowelSign(runes[i+1]):
+				out.WriteString(table[runes[i+1]])
+				i++
+			default:
+				out.WriteString(table['අ'])
+			}
+		case r == 'ං' && scheme == colloquialScheme && i+1 < len(runes) && isLabial(runes[i+1]):
+			out.WriteString("m")
+		case r == alLakuna || r == zwj || r == zwnj || isVowelSign(r):
+			// stray sign without consonant, or a joiner
+		default:
+			if s, ok := table[r]; ok {
+				out.WriteString(s)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+	return out.String(), nil
+}
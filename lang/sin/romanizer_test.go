@@ -0,0 +1,60 @@
+package sin_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/sin"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, scheme, expected string
+	}{
+		{"සිංහල", "iso15919", "siṁhala"},
+		{"ආයුබෝවන්", "iso15919", "āyubōvan"},
+		{"කොළඹ", "iso15919", "koḷam̆ba"},
+		{"ස්තූතියි", "iso15919", "stūtiyi"},
+		{"ශ්‍රී ලංකාව", "iso15919", "śrī laṁkāva"},
+		{"බැංකුව", "iso15919", "bæṁkuva"},
+		{"සිංහල", "sinhala-colloquial", "sinhala"},
+		{"ආයුබෝවන්", "sinhala-colloquial", "ayubowan"},
+		{"කොළඹ", "sinhala-colloquial", "kolamba"},
+		{"ස්තූතියි", "sinhala-colloquial", "sthuthiyi"},
+		{"ශ්‍රී ලංකාව", "sinhala-colloquial", "sri lankawa"},
+		{"ගඟ", "sinhala-colloquial", "ganga"},
+		{"සංඝ", "sinhala-colloquial", "sangha"},
+	}
+	for _, c := range cases {
+		roman, err := sin.Romanize(c.input, c.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, roman, "input %q with scheme %s", c.input, c.scheme)
+	}
+
+	_, err := sin.Romanize("සිංහල", "unknown")
+	assert.Error(t, err)
+}
+
+func TestDefaultModule(t *testing.T) {
+	m, err := sin.DefaultModule()
+	require.NoError(t, err)
+	assert.Equal(t, "uniseg→sinhala", m.ProviderNames())
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	roman, err := m.Roman("මම සිංහල කියවමි.")
+	require.NoError(t, err)
+	assert.Equal(t, "mama siṁhala kiyavami.", strings.Join(strings.Fields(roman), " "))
+
+	colloquial, err := common.GetSchemeModule("si", "sinhala-colloquial")
+	require.NoError(t, err)
+	require.NoError(t, colloquial.Init())
+	defer colloquial.Close()
+	roman, err = colloquial.Roman("ආයුබෝවන්")
+	require.NoError(t, err)
+	assert.Equal(t, "ayubowan", strings.TrimSpace(roman))
+}
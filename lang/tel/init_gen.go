@@ -12,16 +12,16 @@ func init() {
 	defaultProviders := []common.ProviderEntry{
 		{
 			Provider:     &mul.UnisegProvider{},
-			Capabilities: []string{"tokenization"},
+			Capabilities: []common.Capability{common.CapabilityTokenization},
 		},
 		{
 			Provider:     mul.NewAksharamukhaProvider(Lang),
-			Capabilities: []string{"transliteration"},
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
 		},
 	}
 
 	err := common.SetDefault(Lang, defaultProviders)
 	if err != nil {
-		panic(fmt.Sprintf("failed to set default providers: %w", err))
+		panic(fmt.Sprintf("failed to set default providers: %v", err))
 	}
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package tha
 import (
 	"unicode"
 	
+	"github.com/tassa-yoniso-manasi-karoto/paiboonizer"
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
 )
 
@@ -28,6 +29,20 @@ func convertToThaiToken(token *common.Tkn) *Tkn {
 	// Override IsLexical to properly detect non-lexical tokens
 	// PyThaiNLP includes punctuation as tokens, but they should not be lexical
 	thaiToken.IsLexical = isLexicalContent(token.Surface)
+
+	// Thai words don't inflect: they are their own dictionary form
+	if thaiToken.IsLexical {
+		thaiToken.Lemma = thaiToken.Surface
+	}
+
+	// Neither segmenter scores its words: a Thai word missing from the
+	// dictionary is likely a missegmentation
+	if thaiToken.IsLexical && containsThai(token.Surface) {
+		thaiToken.Confidence = common.ConfidenceGuessed
+		if _, found := paiboonizer.LookupDictionary(token.Surface); found {
+			thaiToken.Confidence = common.ConfidenceDictionary
+		}
+	}
 	
 	return thaiToken
 }
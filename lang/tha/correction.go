@@ -0,0 +1,187 @@
+package tha
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// CorrectTokenization fixes the words that pythainlp commonly missegments in
+// the output of a tokenizer: the final consonants split from their word
+// (["แม่", "ง"] → ["แม่ง"]) and the known missegmentations (["บอ", "กว่า"] →
+// ["บอก", "ว่า"], see Missegmentation). The corrected tokens are dictionary
+// words and their lemma is set. It returns a new wrapper of *Tkn, keeping
+// the annotations of the tokens.
+func CorrectTokenization(input common.AnyTokenSliceWrapper) *TknSliceWrapper {
+	// Collect the lexical tokens and correct their surfaces
+	var lexicals []int
+	var surfaces []string
+	for i := 0; i < input.Len(); i++ {
+		if token := input.GetIdx(i); token != nil && token.IsLexicalContent() {
+			lexicals = append(lexicals, i)
+			surfaces = append(surfaces, token.GetSurface())
+		}
+	}
+	corrected := correctTokenization(append([]string(nil), surfaces...))
+
+	// Map the corrections back to the tokens: "" marks the tokens merged
+	// into the previous one
+	correctedMap := make(map[int]string, len(lexicals))
+	correctedIdx := 0
+	for i, idx := range lexicals {
+		switch {
+		case correctedIdx >= len(corrected):
+			// This token was merged away
+			correctedMap[idx] = ""
+		case corrected[correctedIdx] == surfaces[i]:
+			// Unchanged
+			correctedMap[idx] = surfaces[i]
+			correctedIdx++
+		case i > 0 && correctedIdx > 0 && strings.HasSuffix(corrected[correctedIdx-1], surfaces[i]):
+			// This token was merged into previous - skip it
+			correctedMap[idx] = ""
+		default:
+			// The corrected surface is different (merged or modified)
+			correctedMap[idx] = corrected[correctedIdx]
+			correctedIdx++
+		}
+	}
+
+	tsw := &TknSliceWrapper{}
+	for i := 0; i < input.Len(); i++ {
+		token := input.GetIdx(i)
+		if token == nil {
+			continue
+		}
+		surface, isLexical := correctedMap[i]
+		if isLexical && surface == "" {
+			continue
+		}
+		thaiToken := &Tkn{}
+		if t, ok := token.(*Tkn); ok {
+			*thaiToken = *t
+		} else if base := common.BaseToken(token); base != nil {
+			thaiToken.Tkn = *base
+		}
+		thaiToken.Surface = token.GetSurface()
+		thaiToken.IsLexical = token.IsLexicalContent()
+		if isLexical && surface != thaiToken.Surface {
+			thaiToken.Surface = surface
+			thaiToken.Lemma = surface
+			// corrections only produce dictionary words
+			thaiToken.Confidence = common.ConfidenceDictionary
+		}
+		tsw.Append(thaiToken)
+	}
+	return tsw
+}
+
+// CorrectingTokenizer wraps a Thai tokenizer so that its output is corrected
+// with CorrectTokenization before it is passed to the transliterator, e.g.
+// to fix the output of pythainlp for another transliterator than
+// paiboonizer, which corrects its input itself.
+//
+// CorrectingTokenizer implements Provider and takes the name of the wrapped
+// provider.
+type CorrectingTokenizer struct {
+	inner common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]
+}
+
+// NewCorrectingTokenizer wraps the tokenizer inner, see CorrectingTokenizer.
+func NewCorrectingTokenizer(inner common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) *CorrectingTokenizer {
+	return &CorrectingTokenizer{inner: inner}
+}
+
+// WithTokenizationCorrection wraps the tokenizer of the module with
+// NewCorrectingTokenizer. It has no effect on the modules using a combined
+// provider.
+func (m *Module) WithTokenizationCorrection() *Module {
+	m.WrapProvider(common.TokenizerMode, func(p common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper]) common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+		return NewCorrectingTokenizer(p)
+	})
+	return m
+}
+
+// Unwrap returns the wrapped tokenizer.
+func (p *CorrectingTokenizer) Unwrap() common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+	return p.inner
+}
+
+// ProcessFlowController tokenizes the input with the wrapped tokenizer and
+// corrects its output.
+func (p *CorrectingTokenizer) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	output, err := p.inner.ProcessFlowController(ctx, mode, input)
+	if err != nil || mode != common.TokenizerMode {
+		return output, err
+	}
+	return CorrectTokenization(output), nil
+}
+
+func (p *CorrectingTokenizer) SaveConfig(cfg map[string]interface{}) error {
+	return p.inner.SaveConfig(cfg)
+}
+
+// ApplyConfig applies the configuration to the wrapped provider, see common.ApplyConfig.
+func (p *CorrectingTokenizer) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	return common.ApplyConfig(ctx, p.inner, cfg)
+}
+
+func (p *CorrectingTokenizer) InitWithContext(ctx context.Context) error {
+	return p.inner.InitWithContext(ctx)
+}
+
+func (p *CorrectingTokenizer) Init() error {
+	return p.inner.Init()
+}
+
+func (p *CorrectingTokenizer) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.inner.InitRecreateWithContext(ctx, noCache)
+}
+
+func (p *CorrectingTokenizer) InitRecreate(noCache bool) error {
+	return p.inner.InitRecreate(noCache)
+}
+
+func (p *CorrectingTokenizer) CloseWithContext(ctx context.Context) error {
+	return p.inner.CloseWithContext(ctx)
+}
+
+func (p *CorrectingTokenizer) Close() error {
+	return p.inner.Close()
+}
+
+func (p *CorrectingTokenizer) WithProgressCallback(callback common.ProgressCallback) {
+	p.inner.WithProgressCallback(callback)
+}
+
+func (p *CorrectingTokenizer) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.inner.WithDownloadProgressCallback(callback)
+}
+
+// Name returns the name of the wrapped provider.
+func (p *CorrectingTokenizer) Name() string {
+	return p.inner.Name()
+}
+
+func (p *CorrectingTokenizer) SupportedModes() []common.OperatingMode {
+	return p.inner.SupportedModes()
+}
+
+func (p *CorrectingTokenizer) GetMaxQueryLen() int {
+	return p.inner.GetMaxQueryLen()
+}
+
+// PlatformRequirements returns the requirements of the wrapped provider.
+func (p *CorrectingTokenizer) PlatformRequirements() common.PlatformRequirements {
+	return common.RequirementsOf(p.inner)
+}
+
+// ResourceVersions returns the resource versions of the wrapped provider,
+// implementing common.VersionReporter.
+func (p *CorrectingTokenizer) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	if reporter, ok := p.inner.(common.VersionReporter); ok {
+		return reporter.ResourceVersions(ctx)
+	}
+	return map[string]string{}, nil
+}
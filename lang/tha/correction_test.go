@@ -0,0 +1,39 @@
+package tha
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestCorrectingTokenizer(t *testing.T) {
+	input := &TknSliceWrapper{}
+	for _, s := range []string{"เขา", "บอ", "กว่า", " ", "ไป"} {
+		input.Append(&Tkn{Tkn: common.Tkn{Surface: s, IsLexical: s != " ", Confidence: 0.5}})
+	}
+	corrected := CorrectTokenization(input)
+	var surfaces []string
+	for _, tkn := range corrected.Slice {
+		surfaces = append(surfaces, tkn.GetSurface())
+	}
+	assert.Equal(t, []string{"เขา", "บอก", "ว่า", " ", "ไป"}, surfaces)
+	fixed := corrected.Slice[1].(*Tkn)
+	assert.Equal(t, "บอก", fixed.Lemma)
+	assert.Equal(t, common.ConfidenceDictionary, fixed.Confidence)
+	assert.Equal(t, 0.5, corrected.Slice[0].(*Tkn).Confidence, "the other tokens are kept as they are")
+
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	tm := &Module{Module: m}
+	tm.WithTokenizationCorrection()
+	assert.IsType(t, &CorrectingTokenizer{}, m.ProviderRoles[common.TokenizerMode])
+	assert.Equal(t, "thai-dict", m.ProviderRoles[common.TokenizerMode].Name())
+	require.NoError(t, m.Init())
+	defer m.Close()
+	tokenized, err := m.Tokenized("เขาบอกว่าไป")
+	require.NoError(t, err)
+	assert.Contains(t, tokenized, "บอก")
+}
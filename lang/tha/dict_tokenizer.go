@@ -0,0 +1,243 @@
+package tha
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/paiboonizer"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// DictTokenizerProvider is a pure Go Thai word segmenter that needs neither
+// Docker nor network access. It performs maximal matching over paiboonizer's
+// word dictionary: among the segmentations into dictionary words, it picks the
+// one with the fewest unknown character clusters, then the fewest words.
+// It is less accurate than pythainlp's newmm on out-of-vocabulary words but is
+// the tokenizer used on platforms where Docker isn't available.
+type DictTokenizerProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+}
+
+// NewDictTokenizerProvider creates a new dictionary-based tokenizer
+func NewDictTokenizerProvider() *DictTokenizerProvider {
+	return &DictTokenizerProvider{}
+}
+
+// Clone implements common.ProviderCloner, the dictionary is shared.
+func (p *DictTokenizerProvider) Clone() common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+	return &DictTokenizerProvider{config: p.config}
+}
+
+func (p *DictTokenizerProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+func (p *DictTokenizerProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the dictionary is embedded in paiboonizer
+}
+
+// SaveConfig stores configuration for later application during initialization
+func (p *DictTokenizerProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+func (p *DictTokenizerProvider) InitWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *DictTokenizerProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+func (p *DictTokenizerProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+func (p *DictTokenizerProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *DictTokenizerProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+func (p *DictTokenizerProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// ProcessFlowController segments the raw input chunks into words
+func (p *DictTokenizerProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	raw := input.GetRaw()
+	if input.Len() == 0 && len(raw) == 0 {
+		return nil, fmt.Errorf("thai-dict: empty input")
+	}
+	if mode != common.TokenizerMode {
+		return nil, fmt.Errorf("thai-dict only supports tokenizer mode, got %s", mode)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("thai-dict: provider requires raw text input")
+	}
+
+	tsw := &TknSliceWrapper{}
+	for idx, chunk := range raw {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("thai-dict: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(raw))
+		}
+		tokens, err := common.IntegrateProviderTokensV2(chunk, SegmentWords(chunk))
+		if err != nil {
+			common.Log.Debug().
+				Err(err).
+				Msg("Token integration had issues, continuing with partial results")
+		}
+		for _, token := range tokens {
+			tsw.Append(convertToThaiToken(token))
+		}
+	}
+	input.ClearRaw()
+	return tsw, nil
+}
+
+func (p *DictTokenizerProvider) Name() string {
+	return "thai-dict"
+}
+
+func (p *DictTokenizerProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+func (p *DictTokenizerProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// maxWordClusters bounds the length, in character clusters, of the dictionary
+// words looked up during segmentation.
+const maxWordClusters = 20
+
+// SegmentWords splits text into words: runs of Thai script are segmented with
+// the dictionary, runs of other letters and digits are kept whole, and
+// everything else (spaces, punctuation) is left out.
+func SegmentWords(text string) []string {
+	var words []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch {
+		case isThaiLetter(rs[i]):
+			for j < len(rs) && isThaiLetter(rs[j]) {
+				j++
+			}
+			words = append(words, segmentThaiRun(rs[i:j])...)
+		case unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]):
+			for j < len(rs) && !isThaiLetter(rs[j]) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			words = append(words, string(rs[i:j]))
+		}
+		i = j
+	}
+	return words
+}
+
+// segmentThaiRun segments a run of Thai letters by maximal matching. Words may
+// only start and end on character cluster boundaries, so that a vowel or tone
+// mark is never separated from its consonant.
+func segmentThaiRun(rs []rune) []string {
+	bounds := clusterBounds(rs)
+	n := len(bounds) - 1 // number of clusters
+
+	// best[i] is the cost of segmenting clusters i..n: unknown clusters first, then words
+	type cost struct {
+		unknown, words int
+		next           int
+		known          bool
+	}
+	better := func(a, b cost) bool {
+		return a.unknown < b.unknown || a.unknown == b.unknown && a.words < b.words
+	}
+	best := make([]cost, n+1)
+	for i := n - 1; i >= 0; i-- {
+		// An unknown cluster is always a candidate
+		best[i] = cost{unknown: best[i+1].unknown + 1, words: best[i+1].words + 1, next: i + 1}
+		for l := 1; l <= maxWordClusters && i+l <= n; l++ {
+			if _, ok := paiboonizer.LookupDictionary(string(rs[bounds[i]:bounds[i+l]])); !ok {
+				continue
+			}
+			c := cost{unknown: best[i+l].unknown, words: best[i+l].words + 1, next: i + l, known: true}
+			if better(c, best[i]) {
+				best[i] = c
+			}
+		}
+	}
+
+	// Consecutive unknown clusters form a single word
+	var words []string
+	start := -1
+	for i := 0; i < n; i = best[i].next {
+		if !best[i].known {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			words = append(words, string(rs[bounds[start]:bounds[i]]))
+			start = -1
+		}
+		words = append(words, string(rs[bounds[i]:bounds[best[i].next]]))
+	}
+	if start >= 0 {
+		words = append(words, string(rs[bounds[start]:]))
+	}
+	return words
+}
+
+// clusterBounds returns the rune offsets at which Thai character clusters
+// start, followed by len(rs). A cluster is made of the leading vowels, a
+// consonant and the marks and vowels that can't begin a syllable after it.
+func clusterBounds(rs []rune) []int {
+	var bounds []int
+	for i := 0; i < len(rs); {
+		bounds = append(bounds, i)
+		for i < len(rs) && isLeadingVowel(rs[i]) {
+			i++
+		}
+		if i < len(rs) {
+			i++
+		}
+		for i < len(rs) && isDependent(rs[i]) {
+			i++
+		}
+	}
+	return append(bounds, len(rs))
+}
+
+// isThaiLetter reports whether r is a Thai consonant, vowel or mark.
+func isThaiLetter(r rune) bool {
+	return r >= 0x0E00 && r <= 0x0E7F && (unicode.IsLetter(r) || unicode.IsMark(r))
+}
+
+// isLeadingVowel reports whether r is one of เ แ โ ใ ไ, written before their consonant.
+func isLeadingVowel(r rune) bool {
+	return r >= 0x0E40 && r <= 0x0E44
+}
+
+// isDependent reports whether r can't start a cluster: above/below vowels,
+// tone marks, the thanthakhat and the vowels ะ า ำ ๅ following a consonant.
+func isDependent(r rune) bool {
+	switch {
+	case r == 0x0E30, r == 0x0E31, r == 0x0E32, r == 0x0E33, r == 0x0E45:
+		return true
+	case r >= 0x0E34 && r <= 0x0E3A:
+		return true
+	case r >= 0x0E47 && r <= 0x0E4E:
+		return true
+	}
+	return false
+}
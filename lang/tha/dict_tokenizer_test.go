@@ -0,0 +1,74 @@
+package tha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestSegmentWords(t *testing.T) {
+	assert.Equal(t, []string{"ผม", "ชอบ", "กินข้าว"}, SegmentWords("ผมชอบกินข้าว"))
+	assert.Equal(t, []string{"สวัสดี", "ครับ", "Bangkok", "2024"}, SegmentWords("สวัสดีครับ Bangkok 2024!"))
+}
+
+func TestPlatformFallback(t *testing.T) {
+	common.SetPlatform(&common.Platform{OS: "linux", Arch: "arm64", Browser: true})
+	defer common.SetPlatform(nil)
+
+	m, err := common.DefaultModule(Lang)
+	require.NoError(t, err)
+	assert.Equal(t, "thai-dict→paiboonizer", m.ProviderNames())
+	defer m.Close()
+
+	roman, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	assert.NotContains(t, roman, "ผ")
+	t.Log(roman)
+}
+
+func TestPolitenessTagging(t *testing.T) {
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	tm := (&Module{Module: m}).WithPolitenessTagging()
+	require.NoError(t, tm.Init())
+	defer tm.Close()
+
+	tkns, err := tm.LexicalTokens("สวัสดีครับ ขอบคุณค่ะ")
+	require.NoError(t, err)
+	var particles []string
+	for _, tkn := range tkns.NativeSlice {
+		if tkn.IsPoliteParticle {
+			particles = append(particles, tkn.Surface+":"+tkn.MorphFeatures["speaker"])
+			assert.Equal(t, RegisterPolite, tkn.RegisterLevel)
+		}
+	}
+	assert.Equal(t, []string{"ครับ:male", "ค่ะ:female"}, particles)
+}
+
+func TestFrequencyRanks(t *testing.T) {
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	tm := (&Module{Module: m}).WithFrequencyRanks()
+	require.NoError(t, tm.Init())
+	defer tm.Close()
+
+	tkns, err := tm.LexicalTokens("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	require.Len(t, tkns.NativeSlice, 3)
+	rank, ok := common.FreqRank(&tkns.NativeSlice[0].Tkn)
+	assert.True(t, ok)
+	assert.Equal(t, FrequencyList().Rank("ผม", Lang), rank)
+	_, ok = common.FreqRank(&tkns.NativeSlice[2].Tkn)
+	assert.False(t, ok, "กินข้าว isn't in the built-in list")
+
+	list, err := common.LoadFrequencyTSV(strings.NewReader("# rank\tword\n3\tกินข้าว\t120\n1\tผม\n"), Lang)
+	require.NoError(t, err)
+	tm.WithFrequencyRanks(list)
+	tkns, err = tm.LexicalTokens("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	rank, _ = common.FreqRank(&tkns.NativeSlice[2].Tkn)
+	assert.Equal(t, 3, rank)
+}
@@ -8,22 +8,26 @@ import (
 
 func init() {
 	// Register thai2english.com provider
-	th2enProvider := &TH2ENProvider{}
+	th2enProvider := registeredTH2EN
 	th2enEntry := common.ProviderEntry{
 		Provider:     th2enProvider,
-		Capabilities: []string{"tokenization", "transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityTransliteration},
+		Aliases:      []string{"th2en", "thai2english"},
 	}
 
 	if err := common.Register(Lang, th2enEntry); err != nil {
 		panic(fmt.Sprintf("failed to register thai2english.com: %v", err))
 	}
+	// Spread the queries over time so that the website doesn't block us,
+	// see common.SetRateLimiter to change or remove the limit
+	common.SetRateLimiter(th2enProvider.Name(), common.NewRateLimiter(th2enRequestsPerSecond, th2enBurst))
 
 	// Register PyThaiNLP provider (supports both tokenizer and combined modes)
 	// NOTE: PyThaiNLPProvider OWNS the Docker container lifecycle - see pythainlp.go
 	pythainlpProvider := NewPyThaiNLPProvider()
 	pythainlpEntry := common.ProviderEntry{
 		Provider:     pythainlpProvider,
-		Capabilities: []string{"tokenization", "transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityTransliteration, common.CapabilityLemmatization},
 	}
 
 	if err := common.Register(Lang, pythainlpEntry); err != nil {
@@ -37,17 +41,53 @@ func init() {
 	paiboonizerProvider := NewPaiboonizerProvider()
 	paiboonizerEntry := common.ProviderEntry{
 		Provider:     paiboonizerProvider,
-		Capabilities: []string{"transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
 	}
 
 	if err := common.Register(Lang, paiboonizerEntry); err != nil {
 		panic(fmt.Sprintf("failed to register paiboonizer: %v", err))
 	}
 
+	// Register the dictionary-based tokenizer (pure Go, no Docker)
+	dictTokenizerEntry := common.ProviderEntry{
+		Provider:     NewDictTokenizerProvider(),
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+	}
+
+	if err := common.Register(Lang, dictTokenizerEntry); err != nil {
+		panic(fmt.Sprintf("failed to register thai-dict: %v", err))
+	}
+
 	registerThaiSchemes()
 	setDefaultProviders()
+
+	if err := common.RegisterSpacingRule(Lang, SpacingRule); err != nil {
+		panic(fmt.Sprintf("failed to register Thai spacing rule: %v", err))
+	}
+	if err := common.RegisterToneNumberer(Lang, PaiboonToneNumbers); err != nil {
+		panic(fmt.Sprintf("failed to register Thai tone numberer: %v", err))
+	}
+	if err := common.RegisterSchemeConverter(Lang, convertTokenScheme); err != nil {
+		panic(fmt.Sprintf("failed to register Thai scheme converter: %v", err))
+	}
 }
 
+// Default rate limit of the queries to thai2english.com
+const (
+	th2enRequestsPerSecond = 1
+	th2enBurst             = 3
+)
+
+// offlineSchemeName is the scheme combining the thai-dict tokenizer with a
+// rules-only paiboonizer.
+const offlineSchemeName = "paiboon-offline"
+
+// Approximate sizes of what the schemes download on first use
+const (
+	pythainlpDownloadSize int64 = 1 << 30   // langkit-pythainlp image
+	browserDownloadSize   int64 = 150 << 20 // Chromium fetched by go-rod
+)
+
 func registerThaiSchemes() {
 	// ==========================================================================
 	// HYBRID SCHEME: PyThaiNLP tokenizer + Paiboonizer transliterator
@@ -61,10 +101,11 @@ func registerThaiSchemes() {
 	// go-pythainlp functions. See pythainlp.go and paiboonizer.go for details.
 	// ==========================================================================
 	hybridScheme := common.TranslitScheme{
-		Name:        "paiboon-hybrid",
-		Description: "Paiboon (exp.🧪, accuracy ~95%, local, fast)",
-		Providers:   []string{"pythainlp", "paiboonizer"},
-		NeedsDocker: true,
+		Name:         "paiboon-hybrid",
+		Description:  "Paiboon (exp.🧪, accuracy ~95%, local, fast)",
+		Providers:    []string{"pythainlp", "paiboonizer"},
+		NeedsDocker:  true,
+		Requirements: common.SchemeRequirements{DownloadSize: pythainlpDownloadSize},
 	}
 
 	if err := common.RegisterScheme(Lang, hybridScheme); err != nil {
@@ -75,24 +116,46 @@ func registerThaiSchemes() {
 	}
 
 	// PyThaiNLP (lightweight mode only)
+	// ==========================================================================
+	// OFFLINE SCHEME: thai-dict tokenizer + rules-only Paiboonizer
+	// ==========================================================================
+	// Pure Go: no Docker, no network. Less accurate than paiboon-hybrid on words
+	// missing from paiboonizer's dictionary. Used as the default on platforms
+	// where Docker isn't available.
+	offlineScheme := common.TranslitScheme{
+		Name:        offlineSchemeName,
+		Description: "Paiboon (exp.🧪, pure Go, no Docker, lower accuracy)",
+		Providers:   []string{"thai-dict", "paiboonizer"},
+	}
+
+	if err := common.RegisterScheme(Lang, offlineScheme); err != nil {
+		common.Log.Warn().
+			Str("pkg", Lang).
+			Str("scheme", offlineScheme.Name).
+			Msg("Failed to register offline paiboonizer scheme")
+	}
+
 	pythainlpSchemes := []common.TranslitScheme{
 		{
-			Name:        "royin",
-			Description: "Royal Thai General System of Transcription (pythainlp)",
-			Providers:   []string{"pythainlp"},
-			NeedsDocker: true,
+			Name:         "royin",
+			Description:  "Royal Thai General System of Transcription (pythainlp)",
+			Providers:    []string{"pythainlp"},
+			NeedsDocker:  true,
+			Requirements: common.SchemeRequirements{DownloadSize: pythainlpDownloadSize},
 		},
 		{
-			Name:        "tltk",
-			Description: "Thai Language Toolkit romanization (pythainlp)",
-			Providers:   []string{"pythainlp"},
-			NeedsDocker: true,
+			Name:         "tltk",
+			Description:  "Thai Language Toolkit romanization (pythainlp)",
+			Providers:    []string{"pythainlp"},
+			NeedsDocker:  true,
+			Requirements: common.SchemeRequirements{DownloadSize: pythainlpDownloadSize},
 		},
 		{
-			Name:        "lookup",
-			Description: "Dictionary-based romanization with fallback (pythainlp)",
-			Providers:   []string{"pythainlp"},
-			NeedsDocker: true,
+			Name:         "lookup",
+			Description:  "Dictionary-based romanization with fallback (pythainlp)",
+			Providers:    []string{"pythainlp"},
+			NeedsDocker:  true,
+			Requirements: common.SchemeRequirements{DownloadSize: pythainlpDownloadSize},
 		},
 	}
 
@@ -111,30 +174,36 @@ func registerThaiSchemes() {
 			Description:  "Paiboon-esque transliteration (thai2english.com)",
 			Providers:    []string{"thai2english.com"},
 			NeedsScraper: true,
+			Requirements: common.SchemeRequirements{DownloadSize: browserDownloadSize},
 		},
 		{
 			Name:         "thai2english",
 			Description:  "thai2english's custom transliteration system",
 			Providers:    []string{"thai2english.com"},
 			NeedsScraper: true,
+			Requirements: common.SchemeRequirements{DownloadSize: browserDownloadSize},
 		},
 		{
 			Name:         "rtgs",
 			Description:  "Royal Thai General System of Transcription (thai2english.com)",
 			Providers:    []string{"thai2english.com"},
 			NeedsScraper: true,
+			Requirements: common.SchemeRequirements{DownloadSize: browserDownloadSize},
 		},
 		{
 			Name:         "ipa",
 			Description:  "International Phonetic Alphabet representation (thai2english.com)",
 			Providers:    []string{"thai2english.com"},
 			NeedsScraper: true,
+			IPA:          true,
+			Requirements: common.SchemeRequirements{DownloadSize: browserDownloadSize},
 		},
 		{
 			Name:         "simplified-ipa",
 			Description:  "Simplified phonetic notation (thai2english.com)",
 			Providers:    []string{"thai2english.com"},
 			NeedsScraper: true,
+			Requirements: common.SchemeRequirements{DownloadSize: browserDownloadSize},
 		},
 	}
 
@@ -154,13 +223,13 @@ func setDefaultProviders() {
 	pythainlpProvider := NewPyThaiNLPProvider()
 	tokenizerEntry := common.ProviderEntry{
 		Provider:     pythainlpProvider,
-		Capabilities: []string{"tokenization"},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
 	}
 
 	paiboonizerProvider := NewPaiboonizerProvider()
 	transliteratorEntry := common.ProviderEntry{
 		Provider:     paiboonizerProvider,
-		Capabilities: []string{"transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
 	}
 
 	// Set paiboon-hybrid (pythainlp + paiboonizer) as default
@@ -174,4 +243,21 @@ func setDefaultProviders() {
 		Str("lang", Lang).
 		Str("scheme", "paiboon-hybrid").
 		Msg("Set paiboon-hybrid as default Thai provider.")
+
+	// Without Docker, fall back to the pure Go equivalent of paiboon-hybrid
+	fallback := []common.ProviderEntry{
+		{
+			Provider:     NewDictTokenizerProvider(),
+			Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+		},
+		{
+			Provider:     NewRulesOnlyPaiboonizerProvider(),
+			Capabilities: []common.Capability{common.CapabilityTransliteration},
+		},
+	}
+	if err := common.SetPlatformFallback(Lang, fallback); err != nil {
+		common.Log.Error().
+			Err(err).
+			Msg("Failed to set fallback provider")
+	}
 }
@@ -0,0 +1,67 @@
+package tha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// ErrNoGlossBackend is returned by Lookup when the word isn't cached and no
+// provider able to query glosses is initialized.
+var ErrNoGlossBackend = fmt.Errorf("no Thai gloss backend initialized: %w", errors.ErrUnsupported)
+
+// registeredTH2EN is the thai2english.com provider registered for Thai, i.e.
+// the one used by the modules of its schemes and whose word cache is shared
+// by their copies in a common.ModulePool.
+var registeredTH2EN = &TH2ENProvider{}
+
+// Lookup returns the English meanings of a single Thai word without running
+// a module, e.g. for flashcards.
+//
+// The words scraped from thai2english.com by the modules of the current
+// process, and those of its disk cache once a module was initialized, are
+// answered from the cache. Other words are queried on thai2english.com if a
+// module using it is initialized; as the query goes through the page of that
+// module, Lookup must not be called while the module processes an input.
+// PyThaiNLP has no dictionary, so the pythainlp schemes don't provide glosses.
+//
+// Returns nil glosses if the word is unknown to thai2english.com, and
+// ErrNoGlossBackend if it isn't cached and thai2english.com isn't initialized.
+//
+// Example usage:
+//
+//	m, err := common.GetSchemeModule("tha", "paiboon")
+//	...
+//	glosses, err := tha.Lookup(ctx, "ภาษา")
+func Lookup(ctx context.Context, word string) ([]common.Gloss, error) {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return nil, fmt.Errorf("empty word")
+	}
+	return registeredTH2EN.lookup(ctx, word)
+}
+
+func (p *TH2ENProvider) lookup(ctx context.Context, word string) ([]common.Gloss, error) {
+	if p.cache != nil {
+		if glosses, ok := p.cache.Glosses(word, p.targetScheme); ok {
+			return glosses, nil
+		}
+	}
+	if p.page == nil {
+		return nil, ErrNoGlossBackend
+	}
+
+	if err := p.rateLimiter().Wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.query(ctx, word); err != nil {
+		p.releasePage()
+		return nil, fmt.Errorf("failed to look up %s: %w", word, err)
+	}
+	// a word that thai2english.com splits into several words has no meaning of its own
+	glosses, _ := p.cache.Glosses(word, p.targetScheme)
+	return glosses, nil
+}
@@ -0,0 +1,153 @@
+package tha
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/adrg/xdg"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+//go:embed data/missegmentations.tsv
+var missegmentationsTSV string
+
+// Missegmentation is a word that pythainlp commonly splits with its final
+// consonant attached to the next word, e.g. บอกว่า as ["บอ", "กว่า"]
+// instead of ["บอก", "ว่า"]. The paiboonizer provider fixes these splits.
+type Missegmentation struct {
+	Truncated string // the word without its final consonant, e.g. "บอ"
+	Word      string // the full word, e.g. "บอก"
+}
+
+// splitChar returns the consonant that gets attached to the next word.
+func (f Missegmentation) splitChar() rune {
+	r, _ := utf8.DecodeRuneInString(strings.TrimPrefix(f.Word, f.Truncated))
+	return r
+}
+
+func (f Missegmentation) validate() error {
+	if f.Truncated == "" || !strings.HasPrefix(f.Word, f.Truncated) {
+		return fmt.Errorf("%q isn't a truncated form of %q", f.Truncated, f.Word)
+	}
+	if _, ok := isSingleThaiConsonant(strings.TrimPrefix(f.Word, f.Truncated)); !ok {
+		return fmt.Errorf("%q must be %q followed by a single consonant", f.Word, f.Truncated)
+	}
+	return nil
+}
+
+var missegmentations = struct {
+	sync.RWMutex
+	once  sync.Once
+	fixes map[string]Missegmentation
+}{fixes: make(map[string]Missegmentation)}
+
+// UserMissegmentationsFile returns the path of the file of missegmentations
+// loaded on top of the built-in ones (data/missegmentations.tsv), if it
+// exists, following the XDG base directory specification:
+// - Linux: ~/.config/langkit/translitkit/tha/missegmentations.tsv
+// - macOS: ~/Library/Application Support/langkit/translitkit/tha/missegmentations.tsv
+// - Windows: %LOCALAPPDATA%\langkit\translitkit\tha\missegmentations.tsv
+func UserMissegmentationsFile() string {
+	return filepath.Join(xdg.ConfigHome, "langkit", "translitkit", Lang, "missegmentations.tsv")
+}
+
+// loadMissegmentations loads the built-in missegmentations, then those of
+// UserMissegmentationsFile, once.
+func loadMissegmentations() {
+	missegmentations.once.Do(func() {
+		if err := readMissegmentations(strings.NewReader(missegmentationsTSV)); err != nil {
+			common.Log.Error().Err(err).Msg("tha: built-in missegmentations are invalid")
+		}
+		path := UserMissegmentationsFile()
+		if err := loadMissegmentationsFile(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			common.Log.Warn().Err(err).Str("path", path).Msg("tha: failed to load the missegmentations of the user")
+		}
+	})
+}
+
+// RegisterMissegmentation adds a fix for a word that pythainlp splits
+// incorrectly, or replaces the fix of the same truncated form.
+//
+// Example usage:
+//
+//	err := tha.RegisterMissegmentation(tha.Missegmentation{Truncated: "บอ", Word: "บอก"})
+func RegisterMissegmentation(fix Missegmentation) error {
+	if err := fix.validate(); err != nil {
+		return fmt.Errorf("invalid missegmentation: %w", err)
+	}
+	loadMissegmentations()
+	missegmentations.Lock()
+	defer missegmentations.Unlock()
+	missegmentations.fixes[fix.Truncated] = fix
+	return nil
+}
+
+// LoadMissegmentations registers the missegmentations read from r, one per
+// line: the truncated form, a tab, then the full word. Empty lines and lines
+// starting with # are ignored.
+func LoadMissegmentations(r io.Reader) error {
+	loadMissegmentations()
+	return readMissegmentations(r)
+}
+
+// LoadMissegmentationsFile registers the missegmentations of the file at
+// path, see LoadMissegmentations.
+func LoadMissegmentationsFile(path string) error {
+	loadMissegmentations()
+	return loadMissegmentationsFile(path)
+}
+
+func loadMissegmentationsFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return readMissegmentations(f)
+}
+
+func readMissegmentations(r io.Reader) error {
+	var fixes []Missegmentation
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: expected 2 tab-separated fields, got %d", n, len(fields))
+		}
+		fix := Missegmentation{Truncated: fields[0], Word: fields[1]}
+		if err := fix.validate(); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		fixes = append(fixes, fix)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	missegmentations.Lock()
+	defer missegmentations.Unlock()
+	for _, fix := range fixes {
+		missegmentations.fixes[fix.Truncated] = fix
+	}
+	return nil
+}
+
+func lookupMissegmentation(truncated string) (Missegmentation, bool) {
+	loadMissegmentations()
+	missegmentations.RLock()
+	defer missegmentations.RUnlock()
+	fix, ok := missegmentations.fixes[truncated]
+	return fix, ok
+}
@@ -0,0 +1,27 @@
+package tha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissegmentations(t *testing.T) {
+	// built-in
+	assert.Equal(t, []string{"บอก", "ว่า"}, correctTokenization([]string{"บอ", "กว่า"}))
+
+	assert.Equal(t, []string{"ทำงา", "นหนัก"}, correctTokenization([]string{"ทำงา", "นหนัก"}))
+	require.NoError(t, RegisterMissegmentation(Missegmentation{Truncated: "ทำงา", Word: "ทำงาน"}))
+	assert.Equal(t, []string{"ทำงาน", "หนัก"}, correctTokenization([]string{"ทำงา", "นหนัก"}))
+
+	assert.Error(t, RegisterMissegmentation(Missegmentation{Truncated: "บอ", Word: "บอกว่า"}))
+	assert.Error(t, RegisterMissegmentation(Missegmentation{Truncated: "ไป", Word: "บอก"}))
+
+	require.NoError(t, LoadMissegmentations(strings.NewReader("# comment\n\nกินข้า\tกินข้าว\n")))
+	fix, ok := lookupMissegmentation("กินข้า")
+	require.True(t, ok)
+	assert.Equal(t, 'ว', fix.splitChar())
+	assert.ErrorContains(t, LoadMissegmentations(strings.NewReader("บอ บอก\n")), "line 1")
+}
@@ -0,0 +1,158 @@
+package tha
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+var (
+	thaDigits = []string{"sǔun", "nʉ̀ng", "sɔ̌ɔng", "sǎam", "sìi", "hâa", "hòk", "jèt", "bpɛ̀ɛt", "gâo"}
+	// units of the positions of a group of six digits, from แสน (10⁵) to the ones
+	thaUnits = []string{"sɛ̌ɛn", "mʉ̀ʉn", "pan", "rɔ́ɔi", "sìp", ""}
+)
+
+// NumberSpeller spells out numbers in Paiboon romanization, e.g. 2024 → "sɔ̌ɔng pan yîi sìp sìi".
+var NumberSpeller = common.NumberSpeller{
+	Integer: SpellInteger,
+	Point:   "jùt",
+}
+
+// SpellInteger returns the reading of a non-negative integer given as ASCII
+// digits in Paiboon romanization. Numbers are read by groups of six digits
+// joined by láan (ล้าน), with the irregular สิบ (10), ยี่สิบ (20) and เอ็ด
+// (a final 1 after tens or more) forms.
+func SpellInteger(digits string) (string, bool) {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return thaDigits[0], true
+	}
+	var groups []string
+	for end := len(digits); end > 0; end -= 6 {
+		start := max(0, end-6)
+		groups = append([]string{strings.Repeat("0", 6-(end-start)) + digits[start:end]}, groups...)
+	}
+	var words []string
+	for i, g := range groups {
+		words = append(words, spellThaGroup(g)...)
+		if i < len(groups)-1 {
+			words = append(words, "láan")
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+func spellThaGroup(g string) (words []string) {
+	for i := 0; i < len(g); i++ {
+		d := g[i] - '0'
+		switch {
+		case d == 0:
+			continue
+		case i == 4 && d == 1:
+			words = append(words, "sìp")
+			continue
+		case i == 4 && d == 2:
+			words = append(words, "yîi", "sìp")
+			continue
+		case i == 5 && d == 1 && strings.Trim(g[:5], "0") != "":
+			words = append(words, "èt")
+			continue
+		}
+		words = append(words, thaDigits[d])
+		if thaUnits[i] != "" {
+			words = append(words, thaUnits[i])
+		}
+	}
+	return words
+}
+
+// WithNumberExpansion appends the enricher spelling out the numbers of the
+// tokens in Paiboon romanization, see common.NewNumberExpander.
+func (m *Module) WithNumberExpansion() *Module {
+	m.WithEnricher(common.NewNumberExpander(NumberSpeller))
+	return m
+}
+
+// MetadataThaiDigits is the key of Tkn.Metadata holding the surface of the
+// tokens written with Thai digits (e.g. "๑๒๓") whose romanization was
+// normalized by NormalizeThaiDigits.
+const MetadataThaiDigits = "thai_digits"
+
+// ThaiDigitMode is how NormalizeThaiDigits romanizes the Thai numerals.
+type ThaiDigitMode int
+
+const (
+	// ThaiDigitsArabic romanizes Thai numerals as Arabic digits: ๑๒๓ → "123".
+	ThaiDigitsArabic ThaiDigitMode = iota
+	// ThaiDigitsSpelled spells them out in Paiboon: ๑๒๓ → "nʉ̀ng rɔ́ɔi yîi sìp sǎam".
+	ThaiDigitsSpelled
+)
+
+// ToArabicDigits replaces the Thai digits (๐ to ๙) of s by ASCII digits.
+func ToArabicDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '๐' && r <= '๙' {
+			return '0' + r - '๐'
+		}
+		return r
+	}, s)
+}
+
+// isThaiNumeral reports whether s is a number written with Thai digits,
+// possibly with ASCII digits and separators.
+func isThaiNumeral(s string) bool {
+	thai := false
+	for _, r := range s {
+		switch {
+		case r >= '๐' && r <= '๙':
+			thai = true
+		case r >= '0' && r <= '9', r == ',', r == '.':
+		default:
+			return false
+		}
+	}
+	return thai
+}
+
+// NormalizeThaiDigits sets the romanization of the tokens that are numbers
+// written with Thai digits, which transliterators don't handle, to the number
+// in Arabic digits or spelled out in Paiboon, according to mode. The Surface
+// of the tokens is kept, and their Metadata[MetadataThaiDigits] set to it.
+func NormalizeThaiDigits(tkns []*Tkn, mode ThaiDigitMode) {
+	for _, tkn := range tkns {
+		if !isThaiNumeral(tkn.Surface) {
+			continue
+		}
+		tkn.Romanization = ""
+		if mode != ThaiDigitsSpelled || !common.ExpandNumbers(&tkn.Tkn, NumberSpeller) {
+			tkn.Romanization = ToArabicDigits(tkn.Surface)
+		}
+		tkn.IsLexical = true
+		if tkn.Metadata == nil {
+			tkn.Metadata = make(map[string]interface{})
+		}
+		tkn.Metadata[MetadataThaiDigits] = tkn.Surface
+	}
+}
+
+// NewThaiDigitNormalizer returns an enricher running NormalizeThaiDigits on the tokens.
+func NewThaiDigitNormalizer(mode ThaiDigitMode) *common.FuncEnricher {
+	return common.NewFuncEnricher("tha-digits", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		tkns := make([]*Tkn, 0, tsw.Len())
+		for i := 0; i < tsw.Len(); i++ {
+			if tkn, ok := tsw.GetIdx(i).(*Tkn); ok {
+				tkns = append(tkns, tkn)
+			}
+		}
+		NormalizeThaiDigits(tkns, mode)
+		return nil
+	})
+}
+
+// WithThaiDigits appends the enricher romanizing the numbers written with
+// Thai digits as Arabic digits or spelled out, see NormalizeThaiDigits.
+func (m *Module) WithThaiDigits(mode ThaiDigitMode) *Module {
+	m.WithEnricher(NewThaiDigitNormalizer(mode))
+	return m
+}
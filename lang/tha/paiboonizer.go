@@ -41,6 +41,7 @@ import (
 type PaiboonizerProvider struct {
 	config           map[string]interface{}
 	progressCallback common.ProgressCallback
+	rulesOnly        bool // never call pythainlp, see NewRulesOnlyPaiboonizerProvider
 	// NOTE: No pythainlp manager here - we use package-level functions
 }
 
@@ -51,12 +52,56 @@ func NewPaiboonizerProvider() *PaiboonizerProvider {
 	}
 }
 
+// NewRulesOnlyPaiboonizerProvider creates a provider that splits words missing
+// from the dictionary into syllables with paiboonizer's own rules instead of
+// pythainlp, so that it doesn't need Docker. The same behavior is selected by
+// the "paiboon-offline" scheme.
+func NewRulesOnlyPaiboonizerProvider() *PaiboonizerProvider {
+	return &PaiboonizerProvider{
+		config:    make(map[string]interface{}),
+		rulesOnly: true,
+	}
+}
+
+// Clone implements common.ProviderCloner. The copies share the pythainlp
+// container through the package-level go-pythainlp functions.
+func (p *PaiboonizerProvider) Clone() common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+	return &PaiboonizerProvider{
+		config:    p.config,
+		rulesOnly: p.rulesOnly,
+	}
+}
+
+// isRulesOnly reports whether pythainlp must not be used for syllable tokenization.
+func (p *PaiboonizerProvider) isRulesOnly() bool {
+	return p.rulesOnly || p.config["scheme"] == offlineSchemeName
+}
+
+// PlatformRequirements implements common.PlatformConstrained: unless it runs
+// rules-only, paiboonizer uses the pythainlp container for syllable tokenization.
+func (p *PaiboonizerProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: !p.isRulesOnly()}
+}
+
 // SaveConfig stores configuration for later application during initialization
 func (p *PaiboonizerProvider) SaveConfig(cfg map[string]interface{}) error {
 	p.config = cfg
 	return nil
 }
 
+// PaiboonizerOptions are the typed options of PaiboonizerProvider, see common.Configure.
+type PaiboonizerOptions struct {
+	// RulesOnly splits words missing from the dictionary into syllables with
+	// paiboonizer's own rules instead of pythainlp, so that no Docker is needed.
+	RulesOnly bool
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *PaiboonizerProvider) ConfigureWith(opts PaiboonizerOptions) error {
+	p.rulesOnly = opts.RulesOnly
+	return p.SaveConfig(make(map[string]interface{}))
+}
+
 // InitWithContext initializes the provider with context
 // NOTE: This does NOT start any Docker container - we rely on PyThaiNLPProvider
 // having already started the pythainlp container in hybrid schemes.
@@ -109,158 +154,86 @@ func (p *PaiboonizerProvider) ProcessFlowController(ctx context.Context, mode co
 		return nil, fmt.Errorf("paiboonizer requires tokenized input")
 	}
 
-	totalTokens := input.Len()
+	// Fix pythainlp segmentation errors before transliteration
+	input = CorrectTokenization(input)
 
 	// =======================================================================
-	// TOKENIZATION CORRECTION PASS
+	// TRANSLITERATION PASS
 	// =======================================================================
-	// Collect lexical tokens, apply correction, then map back to indices.
-	// This fixes pythainlp segmentation errors before transliteration.
 
-	// Step 1: Collect lexical token indices and surfaces
-	type lexicalInfo struct {
-		index   int
-		surface string
-	}
-	var lexicals []lexicalInfo
-	for i := 0; i < totalTokens; i++ {
-		token := input.GetIdx(i)
-		if token != nil && token.IsLexicalContent() {
-			lexicals = append(lexicals, lexicalInfo{index: i, surface: token.GetSurface()})
+	// The words are transliterated independently of each other, concurrently
+	// if the module allows it (see common.Module.WithTokenWorkers)
+	err := common.ForEachToken(ctx, input, p.progressCallback, func(ctx context.Context, _ int, tkn common.AnyToken) error {
+		thaiToken := tkn.(*Tkn)
+		if !thaiToken.IsLexical || thaiToken.Surface == "ๆ" {
+			return nil
 		}
-	}
-
-	// Step 2: Extract surfaces and apply correction
-	surfaces := make([]string, len(lexicals))
-	for i, lex := range lexicals {
-		surfaces[i] = lex.surface
-	}
-	correctedSurfaces := correctTokenization(surfaces)
-
-	// Step 3: Build mapping from original index to corrected surface
-	// If correction merged tokens, some indices will map to "" (skip)
-	correctedMap := make(map[int]string)
-
-	// After correction, we may have fewer surfaces than original lexicals.
-	// Walk through original lexicals and match with corrected.
-	correctedIdx := 0
-	for i := 0; i < len(lexicals); i++ {
-		if correctedIdx >= len(correctedSurfaces) {
-			// This token was merged away
-			correctedMap[lexicals[i].index] = ""
-			continue
-		}
-
-		// Check if this token matches the current corrected surface
-		// or if it was merged into the previous one
-		if correctedSurfaces[correctedIdx] == lexicals[i].surface {
-			// Unchanged
-			correctedMap[lexicals[i].index] = lexicals[i].surface
-			correctedIdx++
-		} else if i > 0 && strings.HasSuffix(correctedSurfaces[correctedIdx-1], lexicals[i].surface) {
-			// This token was merged into previous - skip it
-			correctedMap[lexicals[i].index] = ""
+		text := thaiToken.Surface
+		if containsThai(text) {
+			romanized, surfaces := p.transliterateWord(ctx, text)
+			thaiToken.Romanization = romanized
+			if surfaces == nil {
+				surfaces = paiboonizer.ExtractSyllables(text)
+			}
+			thaiToken.Syllables = buildSyllables(surfaces, romanized)
 		} else {
-			// The corrected surface is different (merged or modified)
-			correctedMap[lexicals[i].index] = correctedSurfaces[correctedIdx]
-			correctedIdx++
+			// Non-Thai text passes through unchanged
+			thaiToken.Romanization = text
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// =======================================================================
-	// TRANSLITERATION PASS
-	// =======================================================================
-
+	// Handle ๆ (mai yamok) as standalone token from word tokenizer: it
+	// repeats the last syllable of the previous Thai word
 	tsw := &TknSliceWrapper{}
-
-	// Track previous romanization for ๆ (mai yamok) handling
 	var lastRomanization string
-
-	// Process each token
-	for i := 0; i < totalTokens; i++ {
-		if p.progressCallback != nil {
-			p.progressCallback(i, totalTokens)
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		token := input.GetIdx(i)
-		if token == nil {
-			continue
-		}
-
-		// Check if this lexical token should be skipped (merged into previous)
-		if token.IsLexicalContent() {
-			if corrected, ok := correctedMap[i]; ok && corrected == "" {
-				// Token was merged - skip it entirely
-				continue
-			}
-		}
-
-		// Create Thai token
-		thaiToken := &Tkn{
-			Tkn: common.Tkn{
-				Surface:   token.GetSurface(),
-				IsLexical: token.IsLexicalContent(),
-			},
-		}
-
-		// Transliterate if it's a lexical token with Thai text
-		if token.IsLexicalContent() {
-			// Use corrected surface if available
-			text := token.GetSurface()
-			if corrected, ok := correctedMap[i]; ok && corrected != "" {
-				text = corrected
-				thaiToken.Surface = corrected // Update surface to corrected form
-			}
-
-			// Handle ๆ (mai yamok) as standalone token from word tokenizer
-			if text == "ๆ" {
+	for i := 0; i < input.Len(); i++ {
+		thaiToken := input.GetIdx(i).(*Tkn)
+		if thaiToken.IsLexical {
+			if thaiToken.Surface == "ๆ" {
 				if lastRomanization != "" {
 					lastParts := strings.Split(lastRomanization, "-")
 					lastSyl := lastParts[len(lastParts)-1]
 					thaiToken.Romanization = lastSyl
+					thaiToken.Syllables = buildSyllables([]string{thaiToken.Surface}, lastSyl)
 				}
-			} else if containsThai(text) {
-				romanized := p.transliterateWord(ctx, text)
-				thaiToken.Romanization = romanized
-				lastRomanization = romanized
-			} else {
-				// Non-Thai text passes through unchanged
-				thaiToken.Romanization = text
+			} else if containsThai(thaiToken.Surface) {
+				lastRomanization = thaiToken.Romanization
 			}
 		}
-
 		tsw.Append(thaiToken)
 	}
 
 	return tsw, nil
 }
 
-// transliterateWord transliterates a single Thai word.
+// transliterateWord transliterates a single Thai word and returns the Thai
+// syllables matching the syllables of the romanization, if it knows them.
 // Flow:
 //   1. Handle ๆ (mai yamok) repetition marker at word level
 //   2. Check the word dictionary (~5000 entries) for exact match
-//   3. If not found, use pythainlp syllable tokenization + paiboonizer rules
+//   3. If not found, use pythainlp (or rule-based) syllable tokenization + paiboonizer rules
 //
 // IMPORTANT: Uses package-level pythainlp.SyllableTokenize() to reuse existing container.
-func (p *PaiboonizerProvider) transliterateWord(ctx context.Context, word string) string {
+func (p *PaiboonizerProvider) transliterateWord(ctx context.Context, word string) (string, []string) {
 	// STEP 0: Handle ๆ (mai yamok) at word level
 	// Words like "ชิ้นๆ" should become "chín-chín"
 	// This handles cases where pythainlp doesn't separate ๆ as its own syllable
 	if strings.HasSuffix(word, "ๆ") {
 		baseWord := strings.TrimSuffix(word, "ๆ")
 		if baseWord != "" {
-			baseTrans := p.transliterateWord(ctx, baseWord)
+			baseTrans, baseSurfaces := p.transliterateWord(ctx, baseWord)
 			if baseTrans != "" {
 				// Get the last syllable to repeat
 				lastParts := strings.Split(baseTrans, "-")
 				lastSyl := lastParts[len(lastParts)-1]
-				return baseTrans + "-" + lastSyl
+				if baseSurfaces != nil {
+					baseSurfaces = append(baseSurfaces, "ๆ")
+				}
+				return baseTrans + "-" + lastSyl, baseSurfaces
 			}
 		}
 	}
@@ -268,28 +241,35 @@ func (p *PaiboonizerProvider) transliterateWord(ctx context.Context, word string
 	// STEP 1: Check word dictionary first (has ~5000 whole word entries)
 	// This handles common words like หน้าต่าง → nâa-dtàang correctly
 	if trans, found := paiboonizer.LookupDictionary(word); found {
-		return trans
+		return trans, nil
 	}
 
 	// STEP 2: Word not in dictionary - use pythainlp syllable tokenization
 	// Use go-pythainlp's package-level function - this reuses the default manager
 	// which connects to the already-running Docker container started by PyThaiNLPProvider.
-	result, err := pythainlp.SyllableTokenize(word)
-	if err != nil || result == nil || len(result.Syllables) == 0 {
+	// Rules-only providers use paiboonizer's rule-based syllable extraction instead.
+	var syllables []string
+	if p.isRulesOnly() {
+		syllables = paiboonizer.ExtractSyllables(word)
+	} else if result, err := pythainlp.SyllableTokenize(word); err == nil && result != nil {
+		syllables = result.Syllables
+	}
+	if len(syllables) == 0 {
 		// Fall back to pure rule-based transliteration using paiboonizer package
-		return paiboonizer.ComprehensiveTransliterate(word)
+		return paiboonizer.ComprehensiveTransliterate(word), nil
 	}
 
 	// STEP 3: Transliterate each syllable using the paiboonizer package
-	var parts []string
+	var parts, surfaces []string
 	var lastTrans string
 
-	for _, syllable := range result.Syllables {
+	for _, syllable := range syllables {
 		// Handle ๆ (mai yamok) - repeat previous syllable
 		// This catches cases where pythainlp returns ๆ as separate syllable
 		if syllable == "ๆ" {
 			if lastTrans != "" {
 				parts = append(parts, lastTrans)
+				surfaces = append(surfaces, syllable)
 			}
 			continue
 		}
@@ -299,10 +279,11 @@ func (p *PaiboonizerProvider) transliterateWord(ctx context.Context, word string
 			baseSyl := strings.TrimSuffix(syllable, "ๆ")
 			cleanSyl := paiboonizer.RemoveSilentConsonants(baseSyl)
 			if cleanSyl != "" {
-				trans := p.transliterateSyllable(cleanSyl)
+				trans := transliterateSyllable(cleanSyl)
 				if trans != "" {
 					parts = append(parts, trans)
 					parts = append(parts, trans) // Repeat for ๆ
+					surfaces = append(surfaces, baseSyl, "ๆ")
 					lastTrans = trans
 				}
 			}
@@ -315,21 +296,22 @@ func (p *PaiboonizerProvider) transliterateWord(ctx context.Context, word string
 			continue
 		}
 
-		trans := p.transliterateSyllable(cleanSyllable)
+		trans := transliterateSyllable(cleanSyllable)
 		if trans != "" {
 			parts = append(parts, trans)
+			surfaces = append(surfaces, syllable)
 			lastTrans = trans
 		}
 	}
 
 	if len(parts) == 0 {
-		return ""
+		return "", nil
 	}
-	return strings.Join(parts, "-")
+	return strings.Join(parts, "-"), surfaces
 }
 
 // transliterateSyllable transliterates a single syllable using dictionary lookup then rules
-func (p *PaiboonizerProvider) transliterateSyllable(syllable string) string {
+func transliterateSyllable(syllable string) string {
 	// Try syllable dictionary first, then special cases, then rules
 	if t, found := paiboonizer.LookupSyllable(syllable); found {
 		return t
@@ -405,19 +387,6 @@ var closingConsonants = map[rune]bool{
 	'ว': true, // w - in diphthongs
 }
 
-// knownMissegmentation describes a word that pythainlp commonly splits incorrectly.
-type knownMissegmentation struct {
-	fullWord  string // The correct merged word
-	splitChar rune   // The consonant that gets incorrectly attached to next word
-}
-
-// knownMissegmentations maps truncated forms to their correct full forms.
-// Used to fix Pattern B errors where closing consonant attaches to next word.
-var knownMissegmentations = map[string]knownMissegmentation{
-	"บอ": {"บอก", 'ก'}, // บอกว่า → ["บอ", "กว่า"] should be ["บอก", "ว่า"]
-	// Add more as discovered from test failures
-}
-
 // isSingleThaiConsonant checks if the string is exactly one Thai consonant.
 func isSingleThaiConsonant(s string) (rune, bool) {
 	runes := []rune(s)
@@ -460,7 +429,7 @@ func correctTokenization(tokens []string) []string {
 	// Pattern B: Fix known missegmentations where consonant attaches to next word
 	// e.g., ["บอ", "กว่า"] → ["บอก", "ว่า"]
 	for i := 0; i < len(tokens)-1; i++ {
-		fix, ok := knownMissegmentations[tokens[i]]
+		fix, ok := lookupMissegmentation(tokens[i])
 		if !ok {
 			continue
 		}
@@ -471,7 +440,7 @@ func correctTokenization(tokens []string) []string {
 		}
 
 		// Check if next token starts with the expected split character
-		if nextRunes[0] != fix.splitChar {
+		if nextRunes[0] != fix.splitChar() {
 			continue
 		}
 
@@ -481,7 +450,7 @@ func correctTokenization(tokens []string) []string {
 		// Only fix if remainder is non-empty and contains Thai
 		// (empty remainder would mean the whole next token was just the consonant)
 		if len(remainder) > 0 && containsThai(remainder) {
-			tokens[i] = fix.fullWord
+			tokens[i] = fix.Word
 			tokens[i+1] = remainder
 		}
 	}
@@ -0,0 +1,84 @@
+package tha
+
+import (
+	"context"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// Register levels set in Tkn.RegisterLevel by TagPoliteness.
+const (
+	RegisterPolite   = "polite"
+	RegisterInformal = "informal"
+)
+
+// Speakers of the politeness particles, which depend on the gender of the speaker.
+const (
+	SpeakerMale   = "male"
+	SpeakerFemale = "female"
+	SpeakerAny    = "any"
+)
+
+// PoliteParticle describes a politeness particle (คำลงท้าย).
+type PoliteParticle struct {
+	Register string
+	Speaker  string
+}
+
+// PoliteParticles are the politeness particles recognized by TagPoliteness,
+// by spelling. It can be extended before processing.
+var PoliteParticles = map[string]PoliteParticle{
+	"ครับ":   {RegisterPolite, SpeakerMale},
+	"ครับผม": {RegisterPolite, SpeakerMale},
+	"นะครับ": {RegisterPolite, SpeakerMale},
+	"ค่ะ":    {RegisterPolite, SpeakerFemale},
+	"คะ":    {RegisterPolite, SpeakerFemale},
+	"นะคะ":  {RegisterPolite, SpeakerFemale},
+	"ขา":    {RegisterPolite, SpeakerFemale},
+	"คับ":   {RegisterInformal, SpeakerMale},
+	"ฮะ":    {RegisterInformal, SpeakerMale},
+	"ค่า":    {RegisterInformal, SpeakerFemale},
+	"จ้ะ":    {RegisterInformal, SpeakerAny},
+	"จ้า":    {RegisterInformal, SpeakerAny},
+	"จ๊ะ":    {RegisterInformal, SpeakerAny},
+	"จ๋า":    {RegisterInformal, SpeakerAny},
+}
+
+// TagPoliteness flags the politeness particles among the tokens (ครับ, ค่ะ,
+// จ้ะ...) and sets their RegisterLevel. The gender of the speaker the particle
+// implies is stored in the "speaker" morphological feature.
+func TagPoliteness(tkns []*Tkn) {
+	for _, tkn := range tkns {
+		particle, ok := PoliteParticles[tkn.Surface]
+		if !ok || !tkn.IsLexical {
+			continue
+		}
+		tkn.IsPoliteParticle = true
+		tkn.RegisterLevel = particle.Register
+		if tkn.MorphFeatures == nil {
+			tkn.MorphFeatures = make(map[string]string)
+		}
+		tkn.MorphFeatures["speaker"] = particle.Speaker
+	}
+}
+
+// NewPolitenessTagger returns an enricher running TagPoliteness on the tokens.
+func NewPolitenessTagger() *common.FuncEnricher {
+	return common.NewFuncEnricher("tha-politeness", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		tkns := make([]*Tkn, 0, tsw.Len())
+		for i := 0; i < tsw.Len(); i++ {
+			if tkn, ok := tsw.GetIdx(i).(*Tkn); ok {
+				tkns = append(tkns, tkn)
+			}
+		}
+		TagPoliteness(tkns)
+		return nil
+	})
+}
+
+// WithPolitenessTagging appends the enricher flagging the politeness
+// particles of the tokens, see TagPoliteness.
+func (m *Module) WithPolitenessTagging() *Module {
+	m.WithEnricher(NewPolitenessTagger())
+	return m
+}
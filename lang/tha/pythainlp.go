@@ -35,6 +35,9 @@ import (
 //
 // =============================================================================
 
+// pythainlpImage is the Docker image pulled by go-pythainlp
+const pythainlpImage = "ghcr.io/tassa-yoniso-manasi-karoto/langkit-pythainlp:latest"
+
 // PyThaiNLPProvider implements the Provider interface using go-pythainlp
 // It can operate in two modes:
 // - TokenizerMode: Only tokenization
@@ -88,6 +91,22 @@ func (p *PyThaiNLPProvider) SaveConfig(cfg map[string]interface{}) error {
 	return nil
 }
 
+// PyThaiNLPOptions are the typed options of PyThaiNLPProvider, see common.Configure.
+type PyThaiNLPOptions struct {
+	// RomanEngine is the romanization engine used in combined mode:
+	// pythainlp.EngineRoyin (default), pythainlp.EngineTLTKRom or pythainlp.EngineLookup.
+	RomanEngine string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *PyThaiNLPProvider) ConfigureWith(opts PyThaiNLPOptions) error {
+	cfg := map[string]interface{}{}
+	if opts.RomanEngine != "" {
+		cfg["roman_engine"] = opts.RomanEngine
+	}
+	return p.SaveConfig(cfg)
+}
+
 // InitWithContext initializes the provider with context
 func (p *PyThaiNLPProvider) InitWithContext(ctx context.Context) error {
 	// Build manager options
@@ -243,11 +262,9 @@ func (p *PyThaiNLPProvider) tokenizeOnly(ctx context.Context, text string) ([]*T
 	}
 	
 	// Convert to Tkn using token integration
-	tokens, err := common.IntegrateProviderTokensV2(text, result.Raw)
+	tokens, err := common.IntegrateProviderTokensWithContext(ctx, text, result.Raw)
 	if err != nil {
-		common.Log.Debug().
-			Err(err).
-			Msg("Token integration had issues, continuing with partial results")
+		return nil, fmt.Errorf("tokenization failed: %w", err)
 	}
 	
 	// Convert common.Tkn to tha.Tkn
@@ -273,11 +290,9 @@ func (p *PyThaiNLPProvider) analyzeText(ctx context.Context, text string) ([]*Tk
 	}
 	
 	// Convert to Tkn using token integration
-	tokens, err := common.IntegrateProviderTokensV2(text, result.RawTokens)
+	tokens, err := common.IntegrateProviderTokensWithContext(ctx, text, result.RawTokens)
 	if err != nil {
-		common.Log.Debug().
-			Err(err).
-			Msg("Token integration had issues, continuing with partial results")
+		return nil, fmt.Errorf("analysis failed: %w", err)
 	}
 	
 	// Convert to Thai tokens with romanization
@@ -289,6 +304,9 @@ func (p *PyThaiNLPProvider) analyzeText(ctx context.Context, text string) ([]*Tk
 		if i < len(result.RomanizedParts) && token.IsLexical {
 			thaiToken.Romanization = result.RomanizedParts[i]
 		}
+		if token.IsLexical && containsThai(token.Surface) {
+			thaiToken.Syllables = Syllables(token.Surface)
+		}
 		
 		thaiTokens[i] = thaiToken
 	}
@@ -307,6 +325,18 @@ func (p *PyThaiNLPProvider) WithDownloadProgressCallback(callback common.Downloa
 	p.downloadProgressCallback = callback
 }
 
+// ResourceVersions returns the digest of the pythainlp Docker image,
+// implementing common.VersionReporter.
+func (p *PyThaiNLPProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	return common.DockerImageVersions(ctx, pythainlpImage)
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// pythainlp runs in a Docker container.
+func (p *PyThaiNLPProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Docker: true}
+}
+
 // Name returns the provider name
 func (p *PyThaiNLPProvider) Name() string {
 	return "pythainlp"
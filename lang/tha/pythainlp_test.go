@@ -116,7 +116,7 @@ func TestPyThaiNLPProvider(t *testing.T) {
 			},
 		}
 		
-		_, err = provider.ProcessFlowController(ctx, input)
+		_, err = provider.ProcessFlowController(ctx, common.TokenizerMode, input)
 		assert.NoError(t, err)
 		assert.True(t, progressCalled, "Progress callback should have been called")
 	})
@@ -130,7 +130,7 @@ func TestPyThaiNLPProvider(t *testing.T) {
 		
 		// Test empty input
 		input := &TknSliceWrapper{}
-		_, err = provider.ProcessFlowController(ctx, input)
+		_, err = provider.ProcessFlowController(ctx, common.TokenizerMode, input)
 		assert.Error(t, err, "Expected error for empty input")
 	})
 
@@ -151,7 +151,7 @@ func TestPyThaiNLPProvider(t *testing.T) {
 			},
 		}
 		
-		_, err = provider.ProcessFlowController(cancelCtx, input)
+		_, err = provider.ProcessFlowController(cancelCtx, common.TokenizerMode, input)
 		assert.Error(t, err, "Expected error due to cancelled context")
 	})
 }
@@ -0,0 +1,383 @@
+package tha
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Romanization schemes ConvertRomanization converts between.
+const (
+	SchemePaiboon = "paiboon"
+	SchemeRTGS    = "rtgs"
+	SchemeIPA     = "ipa" // broad IPA: aspiration, vowel length and tones as in Paiboon
+)
+
+// ErrSchemeConversionUnsupported is returned by ConvertRomanization for the
+// pairs of schemes it can't convert between, e.g. from RTGS, which doesn't
+// note tones and vowel length. It wraps errors.ErrUnsupported.
+var ErrSchemeConversionUnsupported = fmt.Errorf("unsupported Thai romanization conversion: %w", errors.ErrUnsupported)
+
+// canonicalSchemes maps the schemes of the modules (see common.Module.Schemes)
+// to the scheme they output.
+var canonicalSchemes = map[string]string{
+	SchemePaiboon:     SchemePaiboon,
+	"paiboon-hybrid":  SchemePaiboon,
+	offlineSchemeName: SchemePaiboon,
+	"paiboonizer":     SchemePaiboon,
+	SchemeRTGS:        SchemeRTGS,
+	"royin":           SchemeRTGS,
+	SchemeIPA:         SchemeIPA,
+}
+
+// canonicalScheme returns the scheme of ConvertRomanization output by the scheme of a module.
+func canonicalScheme(scheme string) (string, bool) {
+	canonical, ok := canonicalSchemes[strings.ToLower(scheme)]
+	return canonical, ok
+}
+
+// paiboonSyllable is a syllable romanized in the Paiboon scheme split into
+// its parts, without tone mark.
+type paiboonSyllable struct {
+	initial, vowel, final string
+	tone                  Tone
+}
+
+// paiboonInitials are the initial consonants of the Paiboon scheme with their
+// RTGS and IPA counterparts, longest first so that "bp" isn't read as "b".
+var paiboonInitials = []struct{ paiboon, rtgs, ipa string }{
+	{"bp", "p", "p"}, {"dt", "t", "t"}, {"ng", "ng", "ŋ"}, {"ch", "ch", "tɕʰ"},
+	{"b", "b", "b"}, {"p", "ph", "pʰ"}, {"d", "d", "d"}, {"t", "th", "tʰ"},
+	{"g", "k", "k"}, {"k", "kh", "kʰ"}, {"j", "ch", "tɕ"}, {"n", "n", "n"},
+	{"m", "m", "m"}, {"y", "y", "j"}, {"r", "r", "r"}, {"l", "l", "l"},
+	{"w", "w", "w"}, {"f", "f", "f"}, {"s", "s", "s"}, {"h", "h", "h"},
+}
+
+// paiboonFinals are the final consonants of the Paiboon scheme with their IPA
+// counterparts; RTGS writes them as Paiboon does.
+var paiboonFinals = map[string]string{"k": "k", "t": "t", "p": "p", "ng": "ŋ", "n": "n", "m": "m"}
+
+// rtgsVowels are the RTGS vowels of the short Paiboon vowels, RTGS doesn't
+// note vowel length.
+var rtgsVowels = map[string]string{
+	"a": "a", "i": "i", "ʉ": "ue", "u": "u", "e": "e", "ɛ": "ae", "o": "o", "ɔ": "o", "ə": "oe",
+	"ia": "ia", "ʉa": "uea", "ua": "ua",
+	"ai": "ai", "ao": "ao", "ui": "ui", "oi": "oi", "ɔi": "oi", "əi": "oei",
+	"iu": "io", "eo": "eo", "ɛo": "aeo", "iao": "iao", "ʉai": "ueai", "uai": "uai",
+}
+
+// ipaVowels are the IPA vowels of the Paiboon ones, length excluded
+var ipaVowels = map[rune]string{'ʉ': "ɯ", 'ə': "ɤ"}
+
+// parsePaiboonSyllable splits a syllable romanized in the Paiboon scheme.
+func parsePaiboonSyllable(roman string) (paiboonSyllable, error) {
+	var s paiboonSyllable
+	s.tone, _, _ = analyzePaiboon(roman)
+	base := norm.NFC.String(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(paiboonToneMarks, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(strings.ToLower(roman))))
+
+	for _, c := range paiboonInitials {
+		if rest, ok := strings.CutPrefix(base, c.paiboon); ok {
+			s.initial, base = c.paiboon, rest
+			// clusters: gr, kl, bpr, dtr...
+			if len(base) > 0 && strings.ContainsRune("rlw", rune(base[0])) {
+				s.initial, base = s.initial+base[:1], base[1:]
+			}
+			break
+		}
+	}
+	i := strings.IndexFunc(base, func(r rune) bool { return !strings.ContainsRune(paiboonVowels, r) })
+	if i < 0 {
+		i = len(base)
+	}
+	s.vowel, s.final = base[:i], base[i:]
+	if s.vowel == "" {
+		return s, fmt.Errorf("no vowel in Paiboon syllable %q", roman)
+	}
+	if _, ok := paiboonFinals[s.final]; !ok && s.final != "" {
+		return s, fmt.Errorf("invalid final %q in Paiboon syllable %q", s.final, roman)
+	}
+	return s, nil
+}
+
+// shortVowel removes the doubling of the long vowels of Paiboon: "iia" → "ia".
+func shortVowel(vowel string) (short string, long bool) {
+	var b strings.Builder
+	var prev rune
+	for _, r := range vowel {
+		if r == prev {
+			long = true
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String(), long
+}
+
+// convertInitial converts the initial consonant (cluster) of Paiboon with pick.
+func convertInitial(initial string, pick func(rtgs, ipa string) string) string {
+	var out strings.Builder
+	for initial != "" {
+		for _, c := range paiboonInitials {
+			if rest, ok := strings.CutPrefix(initial, c.paiboon); ok {
+				out.WriteString(pick(c.rtgs, c.ipa))
+				initial = rest
+				break
+			}
+		}
+	}
+	return out.String()
+}
+
+func (s paiboonSyllable) rtgs() (string, error) {
+	short, _ := shortVowel(s.vowel)
+	vowel, ok := rtgsVowels[short]
+	if !ok {
+		return "", fmt.Errorf("unknown Paiboon vowel %q", s.vowel)
+	}
+	return convertInitial(s.initial, func(rtgs, _ string) string { return rtgs }) + vowel + s.final, nil
+}
+
+func (s paiboonSyllable) ipa() string {
+	var b strings.Builder
+	if s.initial == "" {
+		b.WriteString("ʔ")
+	} else {
+		b.WriteString(convertInitial(s.initial, func(_, ipa string) string { return ipa }))
+	}
+	runes := []rune(s.vowel)
+	marked := false
+	for i, r := range runes {
+		if i > 0 && r == runes[i-1] {
+			b.WriteString("ː")
+			continue
+		}
+		// the last letter of a diphthong ending in a glide is a consonant in IPA
+		if i == len(runes)-1 && i > 0 && r != runes[i-1] && (r == 'i' || r == 'o' || r == 'u') {
+			if r == 'i' {
+				b.WriteString("j")
+			} else {
+				b.WriteString("w")
+			}
+			continue
+		}
+		if ipa, ok := ipaVowels[r]; ok {
+			b.WriteString(ipa)
+		} else {
+			b.WriteRune(r)
+		}
+		if !marked {
+			b.WriteString(toneMark(s.tone))
+			marked = true
+		}
+	}
+	if s.final != "" {
+		b.WriteString(paiboonFinals[s.final])
+	} else if _, long := shortVowel(s.vowel); !long && len(runes) == 1 {
+		// short vowels are closed by a glottal stop
+		b.WriteString("ʔ")
+	}
+	return norm.NFC.String(b.String())
+}
+
+// toneMark returns the combining mark of the tone, the same in Paiboon and IPA.
+func toneMark(tone Tone) string {
+	switch tone {
+	case ToneLow:
+		return "\u0300"
+	case ToneFalling:
+		return "\u0302"
+	case ToneHigh:
+		return "\u0301"
+	case ToneRising:
+		return "\u030C"
+	}
+	return ""
+}
+
+// ipaInitials are the IPA initial consonants with their Paiboon counterparts,
+// longest first.
+var ipaInitials = []struct{ ipa, paiboon string }{
+	{"tɕʰ", "ch"}, {"tɕ", "j"}, {"pʰ", "p"}, {"tʰ", "t"}, {"kʰ", "k"},
+	{"p", "bp"}, {"t", "dt"}, {"k", "g"}, {"ŋ", "ng"}, {"j", "y"}, {"ʔ", ""},
+	{"b", "b"}, {"d", "d"}, {"m", "m"}, {"n", "n"}, {"f", "f"}, {"s", "s"},
+	{"h", "h"}, {"r", "r"}, {"l", "l"}, {"w", "w"},
+}
+
+// ipaToPaiboon converts a syllable transcribed in broad IPA to Paiboon.
+func ipaToPaiboon(syllable string) (string, error) {
+	tone, _, _ := analyzePaiboon(syllable)
+	base := norm.NFC.String(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(paiboonToneMarks, r) || r == '\u031A' { // unreleased stop
+			return -1
+		}
+		return r
+	}, norm.NFD.String(syllable)))
+
+	var b strings.Builder
+	for i := 0; i < 2; i++ { // initial and second consonant of a cluster
+		matched := false
+		for _, c := range ipaInitials {
+			if i == 1 && c.ipa != "r" && c.ipa != "l" && c.ipa != "w" {
+				continue
+			}
+			if rest, ok := strings.CutPrefix(base, c.ipa); ok {
+				b.WriteString(c.paiboon)
+				base, matched = rest, true
+				break
+			}
+		}
+		if !matched {
+			break
+		}
+	}
+
+	vowelStart := b.Len()
+	runes := []rune(base)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == 'ː':
+			last := []rune(b.String()[vowelStart:])
+			if len(last) == 0 {
+				return "", fmt.Errorf("misplaced length mark in IPA syllable %q", syllable)
+			}
+			b.WriteRune(last[len(last)-1])
+		case r == 'ɯ':
+			b.WriteRune('ʉ')
+		case r == 'ɤ':
+			b.WriteRune('ə')
+		case strings.ContainsRune(paiboonVowels, r):
+			b.WriteRune(r)
+		case r == 'j' && b.Len() > vowelStart:
+			b.WriteRune('i')
+		case r == 'w' && b.Len() > vowelStart:
+			if strings.HasSuffix(b.String(), "i") && !strings.HasSuffix(b.String(), "ia") {
+				b.WriteRune('u')
+			} else {
+				b.WriteRune('o')
+			}
+		case r == 'ʔ' && i == len(runes)-1:
+		case r == 'ŋ':
+			b.WriteString("ng")
+		case r == 'k' || r == 't' || r == 'p' || r == 'n' || r == 'm':
+			b.WriteRune(r)
+		default:
+			return "", fmt.Errorf("unexpected %q in IPA syllable %q", r, syllable)
+		}
+	}
+
+	// the tone mark goes on the first vowel
+	out := b.String()
+	if i := strings.IndexFunc(out[vowelStart:], func(r rune) bool { return strings.ContainsRune(paiboonVowels, r) }); i >= 0 {
+		at := vowelStart + i
+		_, size := utf8.DecodeRuneInString(out[at:])
+		out = out[:at+size] + toneMark(tone) + out[at+size:]
+	}
+	return norm.NFC.String(out), nil
+}
+
+// ConvertRomanization converts a romanized Thai text from a scheme to another
+// syllable by syllable: from Paiboon to RTGS or IPA, and from IPA to Paiboon or
+// RTGS. Words are separated by spaces, syllables by "-" or "~" in Paiboon and
+// by "." in IPA; RTGS words are written without separators. The names of the
+// schemes of the modules are accepted too, e.g. "paiboon-hybrid" or "royin".
+//
+// Returns ErrSchemeConversionUnsupported if the scheme of the text is RTGS,
+// which lacks the tones and the vowel length the other schemes need, or if a
+// scheme isn't one of SchemePaiboon, SchemeRTGS and SchemeIPA.
+//
+// Example usage:
+//
+//	rtgs, err := tha.ConvertRomanization("sà~wàt-dii", tha.SchemePaiboon, tha.SchemeRTGS) // "sawatdi"
+func ConvertRomanization(roman, fromScheme, toScheme string) (string, error) {
+	from, okFrom := canonicalScheme(fromScheme)
+	to, okTo := canonicalScheme(toScheme)
+	if !okFrom || !okTo || (from == SchemeRTGS && to != SchemeRTGS) {
+		return "", fmt.Errorf("%w: %s to %s", ErrSchemeConversionUnsupported, fromScheme, toScheme)
+	}
+	if from == to {
+		return roman, nil
+	}
+	words := strings.Split(roman, " ")
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		var syllables []string
+		if from == SchemeIPA {
+			for _, s := range strings.Split(word, ".") {
+				p, err := ipaToPaiboon(s)
+				if err != nil {
+					return "", err
+				}
+				syllables = append(syllables, p)
+			}
+		} else {
+			syllables = splitPaiboon(word)
+		}
+		converted, err := convertPaiboonSyllables(syllables, to)
+		if err != nil {
+			return "", err
+		}
+		words[i] = converted
+	}
+	return strings.Join(words, " "), nil
+}
+
+// convertPaiboonSyllables converts the Paiboon syllables of a word to the
+// given scheme and joins them with the separator of the scheme.
+func convertPaiboonSyllables(syllables []string, to string) (string, error) {
+	if to == SchemePaiboon {
+		return strings.Join(syllables, "-"), nil
+	}
+	out := make([]string, len(syllables))
+	for i, roman := range syllables {
+		s, err := parsePaiboonSyllable(roman)
+		if err != nil {
+			return "", err
+		}
+		if to == SchemeIPA {
+			out[i] = s.ipa()
+		} else if out[i], err = s.rtgs(); err != nil {
+			return "", err
+		}
+	}
+	if to == SchemeIPA {
+		return strings.Join(out, "."), nil
+	}
+	return strings.Join(out, ""), nil
+}
+
+// convertTokenScheme is the scheme converter registered for Thai: the tokens
+// whose syllables were analyzed are converted from their Paiboon syllables,
+// whatever the scheme of the module, the others from their romanization.
+func convertTokenScheme(token common.AnyToken, fromScheme, toScheme string) (string, bool) {
+	to, ok := canonicalScheme(toScheme)
+	if !ok {
+		return "", false
+	}
+	if tkn, ok := token.(*Tkn); ok && len(tkn.Syllables) > 0 {
+		syllables := make([]string, len(tkn.Syllables))
+		for i, s := range tkn.Syllables {
+			syllables[i] = s.Romanization
+		}
+		if converted, err := convertPaiboonSyllables(syllables, to); err == nil {
+			return converted, true
+		}
+	}
+	converted, err := ConvertRomanization(token.Roman(), fromScheme, to)
+	if err != nil {
+		return "", false
+	}
+	return converted, true
+}
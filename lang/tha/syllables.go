@@ -0,0 +1,235 @@
+package tha
+
+import (
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/paiboonizer"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tone is one of the five tones of Thai, numbered as the tone marks of Tkn.Tone.
+type Tone int
+
+const (
+	ToneMid     Tone = iota // สามัญ
+	ToneLow                 // เอก
+	ToneFalling             // โท
+	ToneHigh                // ตรี
+	ToneRising              // จัตวา
+)
+
+func (t Tone) String() string {
+	switch t {
+	case ToneLow:
+		return "low"
+	case ToneFalling:
+		return "falling"
+	case ToneHigh:
+		return "high"
+	case ToneRising:
+		return "rising"
+	}
+	return "mid"
+}
+
+// Consonant classes (อักษรสูง, อักษรกลาง, อักษรต่ำ) which, with the tone mark and
+// the ending of the syllable, determine its tone.
+const (
+	ClassHigh = "high"
+	ClassMid  = "mid"
+	ClassLow  = "low"
+)
+
+// Syllable is a syllable of a Thai word, with what language-learning apps need
+// to color-code tones.
+type Syllable struct {
+	// Surface is the Thai spelling of the syllable. It is empty when the
+	// syllables of the word couldn't be aligned with its romanization.
+	Surface string
+	// Romanization in the Paiboon scheme, whatever the scheme of the token.
+	Romanization string
+	Tone         Tone
+	LongVowel    bool
+	// InitialClass is the class of the initial consonant (ClassHigh, ClassMid
+	// or ClassLow), ห and อ leading a sonorant included (หน, อย...). Empty
+	// when Surface is.
+	InitialClass string
+	// FinalClass is the มาตราตัวสะกด of the syllable, the class of its final
+	// sound: แม่กก, แม่กด, แม่กบ (stops), แม่กง, แม่กน, แม่กม, แม่เกย, แม่เกอว
+	// (sonorants) or แม่ ก กา (no final).
+	FinalClass string
+}
+
+// Dead reports whether the syllable is a dead syllable (คำตาย): ending in a
+// stop or in a short vowel.
+func (s Syllable) Dead() bool {
+	switch s.FinalClass {
+	case "แม่กก", "แม่กด", "แม่กบ":
+		return true
+	case "แม่ ก กา":
+		return !s.LongVowel
+	}
+	return false
+}
+
+var (
+	highClass = "ขฃฉฐถผฝศษสห"
+	midClass  = "กจฎฏดตบปอ"
+)
+
+// consonantClass returns the class of the initial consonant of a Thai syllable.
+func consonantClass(syllable string) string {
+	runes := []rune(syllable)
+	// skip the leading vowels เ แ โ ใ ไ
+	for len(runes) > 0 && runes[0] >= 'เ' && runes[0] <= 'ไ' {
+		runes = runes[1:]
+	}
+	if len(runes) == 0 || runes[0] < 'ก' || runes[0] > 'ฮ' {
+		return ""
+	}
+	// the first consonant gives the class, which covers clusters and the
+	// silent ห and อ leading a sonorant (หนู, อยู่)
+	return consonantClassOf(runes[0])
+}
+
+func consonantClassOf(r rune) string {
+	switch {
+	case strings.ContainsRune(highClass, r):
+		return ClassHigh
+	case strings.ContainsRune(midClass, r):
+		return ClassMid
+	}
+	return ClassLow
+}
+
+// paiboonToneMarks are the combining marks of the low, high, falling and rising tones.
+const paiboonToneMarks = "\u0300\u0301\u0302\u030C"
+
+// paiboonVowels are the vowel letters of the Paiboon scheme; long vowels are doubled.
+const paiboonVowels = "aeiouɛɔəʉ"
+
+// analyzePaiboon returns the tone, vowel length and final class of a syllable
+// romanized in the Paiboon scheme, whose tone marks are diacritics on the vowel.
+func analyzePaiboon(roman string) (tone Tone, long bool, final string) {
+	var base []rune
+	for _, r := range norm.NFD.String(strings.ToLower(roman)) {
+		switch r {
+		case '̀':
+			tone = ToneLow
+		case '́':
+			tone = ToneHigh
+		case '̂':
+			tone = ToneFalling
+		case '̌':
+			tone = ToneRising
+		default:
+			base = append(base, r)
+		}
+	}
+	for i := 1; i < len(base); i++ {
+		if base[i] == base[i-1] && strings.ContainsRune(paiboonVowels, base[i]) {
+			long = true
+		}
+	}
+	s := string(base)
+	isVowel := func(i int) bool { return i >= 0 && strings.ContainsRune(paiboonVowels, base[i]) }
+	n := len(base)
+	switch {
+	case strings.HasSuffix(s, "ng"):
+		final = "แม่กง"
+	case strings.HasSuffix(s, "k"):
+		final = "แม่กก"
+	case strings.HasSuffix(s, "t"):
+		final = "แม่กด"
+	case strings.HasSuffix(s, "p"):
+		final = "แม่กบ"
+	case strings.HasSuffix(s, "n"):
+		final = "แม่กน"
+	case strings.HasSuffix(s, "m"):
+		final = "แม่กม"
+	case n > 1 && base[n-1] == 'i' && isVowel(n-2) && base[n-2] != 'i':
+		final = "แม่เกย"
+	case n > 1 && (base[n-1] == 'o' || base[n-1] == 'u') && isVowel(n-2) && base[n-2] != base[n-1]:
+		final = "แม่เกอว"
+	default:
+		final = "แม่ ก กา"
+	}
+	return
+}
+
+// splitPaiboon splits the Paiboon romanization of a word into syllables,
+// which are separated by "-" or, within a group, by "~" (sà~wàt-dii).
+func splitPaiboon(roman string) []string {
+	return strings.FieldsFunc(roman, func(r rune) bool { return r == '-' || r == '~' })
+}
+
+// buildSyllables pairs the syllables of a word with their Paiboon romanization.
+// If the surfaces don't line up with the romanization, they are left empty.
+func buildSyllables(surfaces []string, roman string) []Syllable {
+	romans := splitPaiboon(roman)
+	if len(surfaces) != len(romans) {
+		surfaces = nil
+	}
+	syllables := make([]Syllable, len(romans))
+	for i, r := range romans {
+		s := Syllable{Romanization: r}
+		s.Tone, s.LongVowel, s.FinalClass = analyzePaiboon(r)
+		if surfaces != nil {
+			s.Surface = surfaces[i]
+			s.InitialClass = consonantClass(surfaces[i])
+		}
+		syllables[i] = s
+	}
+	return syllables
+}
+
+// Syllables analyzes the syllables of a Thai word with paiboonizer's dictionary
+// and rules, without using pythainlp.
+func Syllables(word string) []Syllable {
+	surfaces := paiboonizer.ExtractSyllables(word)
+	if roman, found := paiboonizer.LookupDictionary(word); found {
+		return buildSyllables(surfaces, roman)
+	}
+	var kept, romans []string
+	for _, surface := range surfaces {
+		if clean := paiboonizer.RemoveSilentConsonants(surface); clean != "" {
+			kept = append(kept, surface)
+			romans = append(romans, transliterateSyllable(clean))
+		}
+	}
+	return buildSyllables(kept, strings.Join(romans, "-"))
+}
+
+// PaiboonToneNumbers converts the tone marks of a word romanized in the Paiboon
+// scheme into tone numbers (see Tone) at the end of each syllable:
+// "sà~wàt-dii" becomes "sa1~wat1-dii0". Words without any tone mark are
+// returned as is, since they may come from a scheme that doesn't mark tones.
+func PaiboonToneNumbers(roman string) string {
+	if !strings.ContainsAny(norm.NFD.String(roman), paiboonToneMarks) {
+		return roman
+	}
+	var b strings.Builder
+	flush := func(syllable string) {
+		if syllable == "" {
+			return
+		}
+		tone, _, _ := analyzePaiboon(syllable)
+		b.WriteString(norm.NFC.String(strings.Map(func(r rune) rune {
+			if strings.ContainsRune(paiboonToneMarks, r) {
+				return -1
+			}
+			return r
+		}, norm.NFD.String(syllable))))
+		b.WriteByte(byte('0' + tone))
+	}
+	start := 0
+	for i, r := range roman {
+		if r == '-' || r == '~' || r == ' ' {
+			flush(roman[start:i])
+			b.WriteRune(r)
+			start = i + 1
+		}
+	}
+	flush(roman[start:])
+	return b.String()
+}
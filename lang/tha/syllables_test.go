@@ -0,0 +1,165 @@
+package tha
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestSyllables(t *testing.T) {
+	syllables := Syllables("หน้าต่าง")
+	require.Len(t, syllables, 2)
+	assert.Equal(t, "nâa", norm.NFC.String(syllables[0].Romanization))
+	assert.Equal(t, ToneFalling, syllables[0].Tone)
+	assert.True(t, syllables[0].LongVowel)
+	assert.Equal(t, "แม่ ก กา", syllables[0].FinalClass)
+	assert.Equal(t, ToneLow, syllables[1].Tone)
+	assert.Equal(t, "แม่กง", syllables[1].FinalClass)
+
+	syllables = Syllables("เด็ก")
+	require.Len(t, syllables, 1)
+	syllables[0].Romanization = norm.NFC.String(syllables[0].Romanization)
+	assert.Equal(t, Syllable{Surface: "เด็ก", Romanization: "dèk", Tone: ToneLow, InitialClass: ClassMid, FinalClass: "แม่กก"}, syllables[0])
+	assert.True(t, syllables[0].Dead())
+
+	syllables = Syllables("เขา")
+	require.Len(t, syllables, 1)
+	assert.Equal(t, ToneRising, syllables[0].Tone)
+	assert.Equal(t, ClassHigh, syllables[0].InitialClass)
+	assert.Equal(t, "แม่เกอว", syllables[0].FinalClass)
+	assert.False(t, syllables[0].Dead())
+}
+
+func TestPaiboonizerSyllables(t *testing.T) {
+	p := NewRulesOnlyPaiboonizerProvider()
+	input := &TknSliceWrapper{}
+	input.Append(&Tkn{Tkn: common.Tkn{Surface: "ภาษา", IsLexical: true}})
+
+	out, err := p.ProcessFlowController(context.Background(), common.TransliteratorMode, input)
+	require.NoError(t, err)
+	tkn := out.GetIdx(0).(*Tkn)
+	require.Len(t, tkn.Syllables, 2)
+	assert.Equal(t, []Tone{ToneMid, ToneRising}, []Tone{tkn.Syllables[0].Tone, tkn.Syllables[1].Tone})
+}
+
+func TestSpellInteger(t *testing.T) {
+	cases := map[string]string{
+		"0":       "sǔun",
+		"11":      "sìp èt",
+		"21":      "yîi sìp èt",
+		"2024":    "sɔ̌ɔng pan yîi sìp sìi",
+		"101":     "nʉ̀ng rɔ́ɔi èt",
+		"1000000": "nʉ̀ng láan",
+		"1500000": "nʉ̀ng láan hâa sɛ̌ɛn",
+	}
+	for digits, expected := range cases {
+		spoken, ok := SpellInteger(digits)
+		assert.True(t, ok, digits)
+		assert.Equal(t, expected, spoken, digits)
+	}
+
+	tkn := &common.Tkn{Surface: " ๒๕๖๗ "}
+	require.True(t, common.ExpandNumbers(tkn, NumberSpeller))
+	assert.Equal(t, "sɔ̌ɔng pan hâa rɔ́ɔi hòk sìp jèt", tkn.Roman())
+}
+
+func TestThaiDigits(t *testing.T) {
+	assert.Equal(t, "2567-01-15", ToArabicDigits("๒๕๖๗-๐๑-๑๕"))
+
+	tkns := []*Tkn{
+		{Tkn: common.Tkn{Surface: "๑,๕๐๐", Romanization: "ɔɔɔɔ", IsLexical: true}},
+		{Tkn: common.Tkn{Surface: "๒๕๖๗", IsLexical: true}},
+		{Tkn: common.Tkn{Surface: "บาท", Romanization: "bàat", IsLexical: true}},
+	}
+	NormalizeThaiDigits(tkns[:1], ThaiDigitsArabic)
+	NormalizeThaiDigits(tkns[1:], ThaiDigitsSpelled)
+	assert.Equal(t, "1,500", tkns[0].Roman())
+	assert.Equal(t, "๑,๕๐๐", tkns[0].Metadata[MetadataThaiDigits])
+	assert.Equal(t, "sɔ̌ɔng pan hâa rɔ́ɔi hòk sìp jèt", tkns[1].Roman())
+	assert.Equal(t, "bàat", tkns[2].Roman())
+	assert.Nil(t, tkns[2].Metadata)
+
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	tm := (&Module{Module: m}).WithThaiDigits(ThaiDigitsArabic)
+	require.NoError(t, tm.Init())
+	defer tm.Close()
+
+	parts, err := tm.RomanParts("ราคา ๑๒๓ บาท")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"raa-kaa", "123", "bàat"}, parts)
+}
+
+func TestRomanizationOptions(t *testing.T) {
+	assert.Equal(t, "sa1~wat1-dii0", PaiboonToneNumbers("sà~wàt-dii"))
+
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	plain, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+
+	m.WithRomanizationOptions(common.RomanizationOptions{Case: common.CaseSentence, StripDiacritics: true})
+	roman, err := m.Roman("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	t.Log(plain, "→", roman)
+	assert.Regexp(t, `^\p{Lu}`, roman)
+	assert.Equal(t, strings.ToLower(roman[:1])+roman[1:], stripMarks(plain))
+
+	m.WithRomanizationOptions(common.RomanizationOptions{Case: common.CaseTitle, ToneNumbers: true})
+	parts, err := m.RomanParts("ผมชอบกินข้าว")
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	for _, part := range parts {
+		assert.Regexp(t, `^\p{Lu}.*[0-4]$`, part)
+	}
+}
+
+func stripMarks(s string) string {
+	return norm.NFC.String(strings.Map(func(r rune) rune {
+		if r >= 0x300 && r <= 0x36f {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(s)))
+}
+
+func TestConvertRomanization(t *testing.T) {
+	tests := []struct {
+		roman, from, to, want string
+	}{
+		{"sà~wàt-dii", SchemePaiboon, SchemeRTGS, "sawatdi"},
+		{"sà~wàt-dii", "paiboon-hybrid", SchemeIPA, "sàʔ.wàt.diː"},
+		{"kɔ̀ɔp-kun kráp", SchemePaiboon, SchemeRTGS, "khopkhun khrap"},
+		{"kɔ̀ɔp-kun", SchemePaiboon, SchemeIPA, "kʰɔ̀ːp.kʰun"},
+		{"dtɔɔn-cháo", SchemePaiboon, SchemeIPA, "tɔːn.tɕʰáw"},
+		{"mʉʉang", SchemePaiboon, SchemeRTGS, "mueang"},
+		{"kʰɔ̀ːp.kʰun", SchemeIPA, SchemePaiboon, "kɔ̀ɔp-kun"},
+		{"paj tʰáːw", SchemeIPA, "rtgs", "pai thao"},
+	}
+	for _, tt := range tests {
+		got, err := ConvertRomanization(norm.NFC.String(tt.roman), tt.from, tt.to)
+		require.NoError(t, err, tt.roman)
+		assert.Equal(t, norm.NFC.String(tt.want), got, tt.roman)
+	}
+
+	_, err := ConvertRomanization("sawatdi", "royin", SchemePaiboon)
+	assert.ErrorIs(t, err, ErrSchemeConversionUnsupported)
+
+	m, err := common.NewModule(Lang, "thai-dict", "paiboonizer")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	m.WithRomanizationOptions(common.RomanizationOptions{Scheme: SchemeRTGS})
+	roman, err := m.Roman("กินข้าว")
+	require.NoError(t, err)
+	assert.Equal(t, "kinkhao", roman) // "gin-kâao" in Paiboon
+}
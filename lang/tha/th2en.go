@@ -9,81 +9,146 @@ import (
 	"time"
 	"context"
 	"regexp"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/proto"
-	"github.com/go-rod/rod/lib/launcher"
 	
 	"github.com/gookit/color"
 	"github.com/k0kubun/pp"
+	"github.com/rs/zerolog"
 	
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
 )
 
 
-var (
-	logger = common.Log.With().Str("provider", "thai2english.com").Logger()
-	reRepetitionMark = regexp.MustCompile(`\s+(ๆ)`)
-)
+var reRepetitionMark = regexp.MustCompile(`\s+(ๆ)`)
+
+// logger returns the logger of the provider, derived from common.Log when it
+// is called so that it follows common.SetLogger.
+func logger() *zerolog.Logger {
+	l := common.Log.With().Str("provider", "thai2english.com").Logger()
+	return &l
+}
 
-// TH2ENProvider satisfies the Provider interface
+// TH2ENProvider satisfies the Provider interface.
+//
+// It borrows a single page from the shared common.BrowserPool for its whole
+// lifetime and caches every word it scrapes, keyed by surface and scheme: chunks made
+// only of known vocabulary are served from the cache and the others are
+// batched together into as few queries as GetMaxQueryLen allows. The cache is
+// persisted in a common.DiskCache so the words scraped once are never scraped
+// again, see SaveConfig.
 type TH2ENProvider struct {
 	config           map[string]interface{}
-	browser          *rod.Browser
+	pool             *common.BrowserPool
+	page             *rod.Page
+	cache            *th2enCache
 	targetScheme     string
+	limiter          *common.RateLimiter // set from the config, see rateLimiter
 	progressCallback common.ProgressCallback
 }
 
-// SaveConfig merely stores the config to apply after init
+// SaveConfig stores the config to apply after init. The rate of the queries
+// can be limited with the "rate_limit" and "rate_burst" keys, see
+// common.RateLimiterFromConfig, and the disk cache of the scraped words is
+// set with the "disk_cache*" keys, see common.DiskCacheFromConfig. The
+// "target_gloss_lang" key can only be English.
 func (p *TH2ENProvider) SaveConfig(cfg map[string]interface{}) error {
+	if _, err := common.TargetGlossLang(cfg, p.GlossLanguages()...); err != nil {
+		return err
+	}
+	limiter, err := common.RateLimiterFromConfig(cfg)
+	if err != nil {
+		return err
+	}
 	p.config = cfg
+	p.limiter = limiter
 	return nil
 }
 
+// GlossLanguages implements common.GlossLanguageReporter.
+func (p *TH2ENProvider) GlossLanguages() []string {
+	return []string{common.DefaultGlossLang}
+}
 
-// InitWithContext initializes with the provided context
-func (p *TH2ENProvider) InitWithContext(ctx context.Context) (err error) {
-	// Get a browser instance (either via BrowserAccessURL or automatic download)
-	var browserURL string
+// TH2ENOptions are the typed options of TH2ENProvider, see common.Configure.
+type TH2ENOptions struct {
+	// Scheme is the name of one of the thai2english.com schemes registered for Thai
+	// (e.g. "paiboon", "rtgs", "ipa").
+	Scheme string
+
+	// RequestsPerSecond and Burst limit the rate of the queries of this
+	// provider. Zero means the rate limiter set for all the instances of
+	// the provider with common.SetRateLimiter, if any.
+	RequestsPerSecond float64
+	Burst             int
+
+	// NoDiskCache disables the cache of the scraped words on disk, whose
+	// location, TTL and size are set by DiskCache otherwise.
+	NoDiskCache bool
+	DiskCache   common.DiskCacheOptions
+
+	// TargetGlossLang is the language of the glosses. thai2english.com only
+	// has English ones, any other language is rejected.
+	TargetGlossLang string
+}
 
-	if common.BrowserAccessURL == "" {
-		// Use launcher to automatically download and manage browser
-		logger.Info().Msg("BrowserAccessURL not set, using automatic browser management")
+// ConfigureWith implements common.Configurable.
+func (p *TH2ENProvider) ConfigureWith(opts TH2ENOptions) error {
+	if opts.Scheme == "" {
+		return fmt.Errorf("scheme name not provided in options")
+	}
+	cfg := map[string]interface{}{"scheme": opts.Scheme}
+	if opts.RequestsPerSecond > 0 {
+		cfg["rate_limit"] = opts.RequestsPerSecond
+		cfg["rate_burst"] = opts.Burst
+	}
+	if opts.NoDiskCache {
+		cfg["disk_cache"] = false
+	}
+	if opts.DiskCache.Dir != "" {
+		cfg["disk_cache_dir"] = opts.DiskCache.Dir
+	}
+	if opts.DiskCache.TTL > 0 {
+		cfg["disk_cache_ttl"] = opts.DiskCache.TTL
+	}
+	if opts.DiskCache.MaxEntries > 0 {
+		cfg["disk_cache_max_entries"] = opts.DiskCache.MaxEntries
+	}
+	if opts.TargetGlossLang != "" {
+		cfg["target_gloss_lang"] = opts.TargetGlossLang
+	}
+	return p.SaveConfig(cfg)
+}
 
-		// Create a new launcher that will download the browser if needed
-		l := launcher.New()
-		// Configure the launcher with appropriate options
-		l = l.Headless(true)
+// rateLimiter returns the rate limiter of the queries: the one of the config
+// if any, else the one set for all instances of the provider.
+func (p *TH2ENProvider) rateLimiter() *common.RateLimiter {
+	if p.limiter != nil {
+		return p.limiter
+	}
+	return common.GetRateLimiter(p.Name())
+}
 
-		// Launch the browser and get the WebSocket URL
-		url, err := l.Launch()
-		if err != nil {
-			return fmt.Errorf("failed to launch browser automatically: %w", err)
-		}
 
-		browserURL = url
-		logger.Info().Str("browser_url", url).Msg("Browser launched automatically")
-	} else {
-		// Use provided BrowserAccessURL
-		browserURL = common.BrowserAccessURL
-		logger.Info().Str("browser_url", browserURL).Msg("Using provided browser URL")
+// InitWithContext initializes with the provided context
+func (p *TH2ENProvider) InitWithContext(ctx context.Context) (err error) {
+	if p.cache == nil {
+		p.cache = newTH2ENCache()
 	}
+	p.persistCache()
 
-	// Initialize browser with proper error handling
-	p.browser = rod.New().ControlURL(browserURL).Context(ctx)
-	if p.browser == nil {
-		return fmt.Errorf("failed to create browser instance")
+	if p.pool == nil {
+		p.pool = common.SharedBrowserPool()
 	}
 
-	// Connect to the browser - this is a critical step
-	if err = p.browser.Connect(); err != nil {
-		return fmt.Errorf("go-rod failed to connect to browser: %w", err)
+	// Borrow the page now so that a browser that can't be launched fails the init
+	if _, err = p.acquirePage(ctx); err != nil {
+		return err
 	}
 
-	// Apply config only after successful connection
 	if err = p.applyConfig(ctx); err != nil {
-		p.browser.Close() // Clean up on error
-		p.browser = nil
+		p.releasePage()
 		return fmt.Errorf("failed to apply config: %w", err)
 	}
 
@@ -91,50 +156,41 @@ func (p *TH2ENProvider) InitWithContext(ctx context.Context) (err error) {
 }
 
 
+// persistCache backs the word cache with the disk cache of the config. A disk
+// cache that can't be opened (e.g. used by another process) only costs the
+// words scraped in previous runs, so it doesn't fail the initialization.
+func (p *TH2ENProvider) persistCache() {
+	disk, err := common.DiskCacheFromConfig(p.Name(), p.config)
+	if err == nil && disk != nil {
+		err = p.cache.persist(disk)
+	}
+	if err != nil {
+		logger().Warn().Err(err).Msg("disk cache unavailable, the words scraped in previous runs will be scraped again")
+	}
+}
+
 // Init initializes with background context
 func (p *TH2ENProvider) Init() (err error) {
 	return p.InitWithContext(context.Background())
 }
 
-// InitRecreateWithContext reinitializes with the provided context
+// InitRecreateWithContext reinitializes with the provided context.
+// If noCache is true, the words scraped so far are forgotten.
 func (p *TH2ENProvider) InitRecreateWithContext(ctx context.Context, noCache bool) (err error) {
+	if noCache && p.cache != nil {
+		p.cache.Clear()
+	}
+	if err := p.CloseWithContext(ctx); err != nil {
+		logger().Warn().Err(err).Msg("failed to release previous page")
+	}
 	return p.InitWithContext(ctx)
 }
 
 // InitRecreate reinitializes with background context
-func (p *TH2ENProvider) InitRecreate(bool) (err error) {
-	return p.Init()
-}
-
-// init initializes the provider with the given context
-func (p *TH2ENProvider) init(ctx context.Context) (err error) {
-	// Check if BrowserAccessURL is available
-	if common.BrowserAccessURL == "" {
-		return fmt.Errorf("BrowserAccessURL is not set - required for web scraping")
-	}
-
-	// Initialize browser with proper error handling
-	p.browser = rod.New().ControlURL(common.BrowserAccessURL).Context(ctx)
-	if p.browser == nil {
-		return fmt.Errorf("failed to create browser instance")
-	}
-	
-	// Connect to the browser - this is a critical step
-	if err = p.browser.Connect(); err != nil {
-		return fmt.Errorf("go-rod failed to connect to browser: %w", err)
-	}
-	
-	// Apply config only after successful connection
-	if err = p.applyConfig(ctx); err != nil {
-		p.browser.Close() // Clean up on error
-		p.browser = nil
-		return fmt.Errorf("failed to apply config: %w", err) 
-	}
-	
-	return nil
+func (p *TH2ENProvider) InitRecreate(noCache bool) (err error) {
+	return p.InitRecreateWithContext(context.Background(), noCache)
 }
 
-
 // applyConfig applies the stored configuration to the provider.
 // This includes selecting the transliteration scheme if specified.
 // The context is used for cancellation during configuration.
@@ -156,6 +212,28 @@ func (p *TH2ENProvider) applyConfig(ctx context.Context) error {
 	return nil
 }
 
+// ApplyConfig implements common.ConfigApplier: the new scheme is selected in
+// the page already borrowed from the browser pool instead of launching a new one.
+func (p *TH2ENProvider) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	if err := p.SaveConfig(cfg); err != nil {
+		return err
+	}
+	if p.page == nil {
+		// applied on initialization
+		return nil
+	}
+	if err := p.applyConfig(ctx); err != nil {
+		return fmt.Errorf("failed to apply config: %w", err)
+	}
+	return nil
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// thai2english.com is scraped with a headless browser.
+func (p *TH2ENProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{Browser: true, Network: true}
+}
+
 func (p *TH2ENProvider) Name() string {
 	return "thai2english.com"
 }
@@ -169,11 +247,10 @@ func (p *TH2ENProvider) GetMaxQueryLen() int {
 	return 120
 }
 
-// CloseWithContext closes the provider with the given context
+// CloseWithContext gives the page back to the browser pool. The browser itself
+// belongs to the pool and the word cache is kept for the next initialization.
 func (p *TH2ENProvider) CloseWithContext(ctx context.Context) error {
-	if p.browser != nil {
-		return p.browser.Context(ctx).Close()
-	}
+	p.releasePage()
 	return nil
 }
 
@@ -182,6 +259,20 @@ func (p *TH2ENProvider) Close() error {
 	return p.CloseWithContext(context.Background())
 }
 
+// Clone implements common.ProviderCloner: the copy borrows its own page from
+// the same browser pool and shares the word cache and the rate limiter of p.
+func (p *TH2ENProvider) Clone() common.Provider[common.AnyTokenSliceWrapper, common.AnyTokenSliceWrapper] {
+	if p.cache == nil {
+		p.cache = newTH2ENCache()
+	}
+	return &TH2ENProvider{
+		config:  p.config,
+		pool:    p.pool,
+		cache:   p.cache,
+		limiter: p.limiter,
+	}
+}
+
 
 func (p *TH2ENProvider) WithProgressCallback(callback common.ProgressCallback) {
 	p.progressCallback = callback
@@ -192,13 +283,38 @@ func (p *TH2ENProvider) WithDownloadProgressCallback(callback common.DownloadPro
 	// No-op: TH2EN uses web scraping, doesn't require Docker downloads
 }
 
-// selectTranslitScheme selects the transliteration scheme with provided context
-func (p *TH2ENProvider) selectTranslitScheme(ctx context.Context, scheme string) error {
-	// Protect against nil browser
-	if p.browser == nil {
-		return fmt.Errorf("browser not initialized, call Init first")
+// acquirePage returns the page shared by all queries, borrowing it from the
+// browser pool on first use. The scheme selected in the settings of the website
+// is stored client-side, so it persists across the navigations made on this page.
+func (p *TH2ENProvider) acquirePage(ctx context.Context) (*rod.Page, error) {
+	if p.pool == nil {
+		return nil, fmt.Errorf("browser pool not initialized, call Init first")
+	}
+	if p.page != nil {
+		return p.page, nil
 	}
+	logger().Trace().Msg("Borrowing page from browser pool")
+	page, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire browser page: %w", err)
+	}
+	p.page = page
+	return page, nil
+}
 
+// releasePage gives the shared page back to the pool, e.g. after a failed
+// query as it may be left in an unusable state. The next query borrows a
+// fresh page.
+func (p *TH2ENProvider) releasePage() {
+	if p.page == nil {
+		return
+	}
+	p.pool.Release(p.page)
+	p.page = nil
+}
+
+// selectTranslitScheme selects the transliteration scheme with provided context
+func (p *TH2ENProvider) selectTranslitScheme(ctx context.Context, scheme string) error {
 	// Create a derived context with timeout
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -211,27 +327,22 @@ func (p *TH2ENProvider) selectTranslitScheme(ctx context.Context, scheme string)
 		return fmt.Errorf("invalid transliteration scheme: %s", scheme)
 	}
 	
-	logger.Trace().Msg("Creating new page")
-	// IMPORTANT: We use the original browser instance directly, not a new one with context
-	// The context is already set in the main browser instance during init
-	// Trying to slap a new one on top will cause runtime panics
-	page, err := p.browser.Page(proto.TargetCreateTarget{})
+	page, err := p.acquirePage(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create page: %w", err)
+		return err
 	}
-	defer page.Close()
 
-	logger.Trace().Msg("Navigating to website")
+	logger().Trace().Msg("Navigating to website")
 	if err := page.Navigate("https://www.thai2english.com/"); err != nil {
 		return fmt.Errorf("failed to navigate to website: %w", err)
 	}
 
-	logger.Trace().Msg("Waiting for page to load")
+	logger().Trace().Msg("Waiting for page to load")
 	if err := page.WaitLoad(); err != nil {
 		return fmt.Errorf("failed to wait for page load: %w", err)
 	}
 
-	logger.Trace().Msg("Looking for settings button and clicking via JavaScript")
+	logger().Trace().Msg("Looking for settings button and clicking via JavaScript")
 	select {
 	case <-ctxWithTimeout.Done():
 		return fmt.Errorf("context cancelled while trying to click settings button: %v", ctxWithTimeout.Err())
@@ -250,14 +361,14 @@ func (p *TH2ENProvider) selectTranslitScheme(ctx context.Context, scheme string)
 		}
 	}
 
-	logger.Trace().Msg("Waiting for dialog to appear")
+	logger().Trace().Msg("Waiting for dialog to appear")
 	select {
 	case <-ctxWithTimeout.Done():
 		return fmt.Errorf("context cancelled while waiting for dialog: %w", ctxWithTimeout.Err())
 	case <-time.After(500 * time.Millisecond):
 	}
 
-	logger.Trace().Msgf("Looking for radio button with value %s and clicking via JavaScript", scheme)
+	logger().Trace().Msgf("Looking for radio button with value %s and clicking via JavaScript", scheme)
 	select {
 	case <-ctxWithTimeout.Done():
 		return fmt.Errorf("context cancelled while trying to click radio button: %w", ctxWithTimeout.Err())
@@ -275,7 +386,7 @@ func (p *TH2ENProvider) selectTranslitScheme(ctx context.Context, scheme string)
 		}
 	}
 
-	logger.Trace().Msg("Successfully changed transliteration scheme")
+	logger().Trace().Msg("Successfully changed transliteration scheme")
 	return nil
 }
 
@@ -308,146 +419,187 @@ func (p *TH2ENProvider) ProcessFlowController(ctx context.Context, mode common.O
 	return nil, fmt.Errorf("handling not implemented for '%s' with OperatingMode '%s'", p.Name(), mode)
 }
 
-// process processes chunks with the given context
+// process processes chunks with the given context.
+// Chunks that the cache can fully segment are resolved without a query; the
+// others are joined into batches no longer than GetMaxQueryLen, the words of
+// each batch are scraped into the cache and the chunks are then segmented
+// from the cache.
 func (p *TH2ENProvider) process(ctx context.Context, chunks []string) (common.AnyTokenSliceWrapper, error) {
 	tsw := &TknSliceWrapper{}
 	totalChunks := len(chunks)
-	
+	if p.cache == nil {
+		p.cache = newTH2ENCache()
+	}
+
 	for idx, chunk := range chunks {
 		chunks[idx] = reRepetitionMark.ReplaceAllString(chunk, "$1")
 	}
-	
+
+	var pending []string
+	seen := make(map[string]bool)
+	for _, chunk := range chunks {
+		if seen[chunk] {
+			continue
+		}
+		seen[chunk] = true
+		if _, ok := p.cache.Segment(chunk, p.targetScheme); !ok {
+			pending = append(pending, chunk)
+		}
+	}
+
+	batches := batchQueries(pending, p.GetMaxQueryLen())
+	logger().Debug().
+		Int("chunks", totalChunks).
+		Int("uncached_chunks", len(pending)).
+		Int("queries", len(batches)).
+		Msg("Resolving chunks")
+
+	for idx, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := p.rateLimiter().Wait(ctx); err != nil {
+			return nil, err
+		}
+		logger().Trace().Msgf("Querying batch %d/%d: %s", idx+1, len(batches), batch)
+		if err := p.query(ctx, batch); err != nil {
+			p.releasePage()
+			return nil, fmt.Errorf("query %d/%d failed: %w", idx+1, len(batches), err)
+		}
+	}
+
 	for idx, chunk := range chunks {
 		if p.progressCallback != nil {
 			p.progressCallback(idx, totalChunks)
 		}
-		
-		if err := ctx.Err(); err != nil {
-			return nil, err
+		words, ok := p.cache.Segment(chunk, p.targetScheme)
+		if !ok {
+			logger().Warn().
+				Str("chunk", chunk).
+				Msg("thai2english.com didn't return all words of chunk, romanization may be incomplete")
 		}
+		// Simple interleaving of the strings (joined chunks) that
+		//	- allows to discriminate true lexical content from what isn't
+		//	- retain non-lexical content, properly tagged
 
-		logger.Trace().Msgf("Processing chunk %d/%d: %s", idx+1, totalChunks, chunk)
-		
-		// IMPORTANT: We use the original browser instance directly, not a new one with context
-		// The context is already set in the main browser instance during init
-		// Trying to slap a new one on top will cause runtime panics
-		page, err := p.browser.Page(proto.TargetCreateTarget{})
+		// IMPORTANT: keep this in the for loop to prevent mysterious bug, see commit msg 6bf9a50
+		tkns, err := common.IntegrateProviderTokensWithContext(ctx, chunk, words)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create page: %w", err)
+			return nil, fmt.Errorf("chunk %d/%d: %w", idx+1, totalChunks, err)
 		}
-		defer page.Close()
 
-		logger.Trace().Msg("Navigate to URL")
-		url := fmt.Sprintf("https://www.thai2english.com/?q=%s", url.QueryEscape(chunk))
-		if err := page.Navigate(url); err != nil {
-			return nil, fmt.Errorf("failed to navigate to URL: %w", err)
+		for _, tkn := range tkns {
+			if e, found := p.cache.Get(tkn.Surface, p.targetScheme); found {
+				tkn.Romanization = e.Romanization
+				tkn.Glosses = e.Glosses
+			}
+			tsw.Append(tkn)
 		}
+	}
 
-		// Waits for the `window.onload` event
-		logger.Trace().Msg("Wait for page load")
-		if err := page.WaitLoad(); err != nil {
-			return nil, fmt.Errorf("failed to wait for page load: %w", err)
-		}
+	return tsw, nil
+}
 
-		// Waits until all network requests including dynamic requests
-		// (AJAX, fetch, or WebSockets) stop for a set duration
-		logger.Trace().Msg("Wait for RequestIdle (300 ms)")
-		page.MustWaitRequestIdle()
-		
-		logger.Trace().Msg("Wait for main element to be present")
-		_, err = page.Element(".word-breakdown_line-meanings__1RADe")
+// query loads the result page of q in the shared page and stores every word
+// of the breakdown in the cache.
+func (p *TH2ENProvider) query(ctx context.Context, q string) error {
+	page, err := p.acquirePage(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger().Trace().Msg("Navigate to URL")
+	url := fmt.Sprintf("https://www.thai2english.com/?q=%s", url.QueryEscape(q))
+	if err := page.Navigate(url); err != nil {
+		return fmt.Errorf("failed to navigate to URL: %w", err)
+	}
+
+	// Waits for the `window.onload` event
+	logger().Trace().Msg("Wait for page load")
+	if err := page.WaitLoad(); err != nil {
+		return fmt.Errorf("failed to wait for page load: %w", err)
+	}
+
+	// Waits until all network requests including dynamic requests
+	// (AJAX, fetch, or WebSockets) stop for a set duration
+	logger().Trace().Msg("Wait for RequestIdle (300 ms)")
+	page.WaitRequestIdle(300*time.Millisecond, nil, nil, nil)()
+
+	logger().Trace().Msg("Wait for main element to be present")
+	if _, err = page.Element(".word-breakdown_line-meanings__1RADe"); err != nil {
+		return fmt.Errorf("failed to find main element: %w", err)
+	}
+
+	logger().Trace().Msg("Get all meaning elements")
+	elements, err := page.Elements(".word-breakdown_line-meaning__NARMM")
+	if err != nil {
+		return fmt.Errorf("failed to get meaning elements: %w", err)
+	}
+	if len(elements) == 0 {
+		return fmt.Errorf("elements are empty")
+	}
+
+	for _, element := range elements {
+		thNode, err := element.Element(".thai")
+		if err != nil {
+			// seems to be caused by punctuation
+			continue
+		}
+		th, err := thNode.Text()
 		if err != nil {
-			return nil, fmt.Errorf("failed to find main element: %w", err)
+			logger().Warn().Err(err).Msg("failed to get Thai text, skipping")
+			continue
 		}
 
-		logger.Trace().Msg("Get all meaning elements")
-		elements, err := page.Elements(".word-breakdown_line-meaning__NARMM")
+		var entry th2enEntry
+		tlitNode, err := element.Element(".tlit")
 		if err != nil {
-			return nil, fmt.Errorf("failed to get meaning elements: %w", err)
+			logger().Warn().Err(err).Msg("no transliteration element exists, skipping")
+			continue
 		}
-		if len(elements) == 0 {
-			return tsw, fmt.Errorf("elements are empty. idx=%d", idx)
+		if entry.Romanization, err = tlitNode.Text(); err != nil {
+			logger().Warn().Err(err).Msg("failed to get transliteration text, skipping")
+			continue
 		}
 
-		providerTokenSlice := []string{}
-		dicTlit := make(map[string]string)
-		dicGloss := make(map[string][]common.Gloss)
-		// Process each element
-		for _, element := range elements {
-			thNode, err := element.Element(".thai")
-			if err != nil {
-				// seems to be caused by punctuation
-				//logger.Warn().Err(err).Msg("no Thai element exists, skipping")
-				continue
-			}
-			th, err := thNode.Text()
-			if err != nil {
-				logger.Warn().Err(err).Msg("failed to get Thai text, skipping")
-				continue
-			}
-			providerTokenSlice = append(providerTokenSlice, th)
-			
-			tlitNode, err := element.Element(".tlit")
-			if err != nil {
-				logger.Warn().Err(err).Msg("no transliteration element exists, skipping")
-				continue
-			}
-			tlit, err := tlitNode.Text()
-			if err != nil {
-				logger.Warn().Err(err).Msg("failed to get transliteration text, skipping")
-				continue
-			}
-			dicTlit[th] = tlit
-			
-			// Get gloss
-			glossNode, err := element.Element(".meanings")
-			if err != nil {
-				logger.Warn().Err(err).Msg("no gloss element exists, skipping")
-				continue
-			}
-			glossText, err := glossNode.Text()
-			if err != nil {
-				logger.Warn().Err(err).Msg("failed to get gloss text, skipping")
-				continue
-			}
-
-			// Process gloss text
-			glossRaw := strings.Split(glossText, "\n")
-			glossRaw = removeEmptyStrings(glossRaw)
-			
-			for _, gloss := range glossRaw {
-				dicGloss[th] = append(dicGloss[th], common.Gloss{
-					Definition: gloss,
-				})
+		if glossNode, err := element.Element(".meanings"); err != nil {
+			logger().Warn().Err(err).Msg("no gloss element exists")
+		} else if glossText, err := glossNode.Text(); err != nil {
+			logger().Warn().Err(err).Msg("failed to get gloss text")
+		} else {
+			for _, gloss := range removeEmptyStrings(strings.Split(glossText, "\n")) {
+				entry.Glosses = append(entry.Glosses, common.Gloss{Definition: gloss, Language: common.DefaultGlossLang})
 			}
 		}
-		// Simple interleaving of the strings (joined chunks) that
-		//	- allows to discriminate true lexical content from what isn't
-		//	- retain non-lexical content, properly tagged
-		
-		// IMPORTANT: keep this in the for loop to prevent mysterious bug, see commit msg 6bf9a50
-		tkns, err := common.IntegrateProviderTokensV2(chunk, providerTokenSlice)
-		if err != nil {
-			logger.Error().
-				Err(err).
-				Msg("Token integration had issues, romanization may be incomplete")
-			// Continue despite errors - we still want to return partial results
-		}
-
+		p.cache.Put(th, p.targetScheme, entry)
+	}
+	return nil
+}
 
-		for _, tkn := range tkns {
-			tkn.Romanization = dicTlit[tkn.Surface]
-			tkn.Glosses = dicGloss[tkn.Surface]
-			tsw.Append(tkn)
+// batchQueries joins consecutive chunks with a space into queries whose length
+// doesn't exceed maxLen runes. A chunk longer than maxLen is queried alone.
+func batchQueries(chunks []string, maxLen int) []string {
+	var batches []string
+	var cur strings.Builder
+	curLen := 0
+	for _, chunk := range chunks {
+		n := utf8.RuneCountInString(chunk)
+		if cur.Len() > 0 && curLen+1+n > maxLen {
+			batches = append(batches, cur.String())
+			cur.Reset()
+			curLen = 0
 		}
-
-		// Close page after processing
-		if err := page.Close(); err != nil {
-			logger.Warn().Err(err).Msg("failed to close page")
+		if cur.Len() > 0 {
+			cur.WriteString(" ")
+			curLen++
 		}
+		cur.WriteString(chunk)
+		curLen += n
 	}
-	
-	return tsw, nil
+	if cur.Len() > 0 {
+		batches = append(batches, cur.String())
+	}
+	return batches
 }
 
 
@@ -476,13 +628,13 @@ func checkWebsiteReachable(ctx context.Context) error {
 
 	resp, err := client.Do(req)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Could not reach thai2english.com - will attempt to proceed anyway using automatic browser management")
+		logger().Warn().Err(err).Msg("Could not reach thai2english.com - will attempt to proceed anyway using automatic browser management")
 		return nil // Return nil instead of error to allow automatic browser management to try
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Warn().Int("status_code", resp.StatusCode).Msg("Website returned non-200 status code - will attempt to proceed anyway")
+		logger().Warn().Int("status_code", resp.StatusCode).Msg("Website returned non-200 status code - will attempt to proceed anyway")
 		return nil // Return nil to allow automatic browser management to try
 	}
 
@@ -0,0 +1,158 @@
+package tha
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// th2enEntry is what thai2english.com returned for a single word.
+type th2enEntry struct {
+	Romanization string
+	Glosses      []common.Gloss
+}
+
+// th2enCache stores the words scraped from thai2english.com, keyed by their
+// surface and the transliteration scheme that was selected when they were
+// scraped, so that chunks made of known vocabulary don't need a page load.
+// Once backed by a disk cache (see persist), the words scraped in previous
+// runs are known too.
+type th2enCache struct {
+	mu       sync.RWMutex
+	entries  map[string]th2enEntry
+	maxRunes int
+	disk     *common.DiskCache
+}
+
+func newTH2ENCache() *th2enCache {
+	return &th2enCache{entries: make(map[string]th2enEntry)}
+}
+
+func th2enCacheKey(surface, scheme string) string {
+	return scheme + "\x00" + surface
+}
+
+// Get returns the entry of the word surface for the given scheme.
+func (c *th2enCache) Get(surface, scheme string) (th2enEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[th2enCacheKey(surface, scheme)]
+	return e, ok
+}
+
+// Put stores the entry of the word surface for the given scheme, on disk too
+// if the cache is persisted.
+func (c *th2enCache) Put(surface, scheme string, e th2enEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(th2enCacheKey(surface, scheme), surface, e)
+	if c.disk != nil {
+		if err := c.disk.Put(th2enCacheKey(surface, scheme), e); err != nil {
+			logger().Warn().Err(err).Str("word", surface).Msg("failed to persist word")
+		}
+	}
+}
+
+func (c *th2enCache) put(key, surface string, e th2enEntry) {
+	c.entries[key] = e
+	if n := utf8.RuneCountInString(surface); n > c.maxRunes {
+		c.maxRunes = n
+	}
+}
+
+// persist loads the words stored in the disk cache and writes the words put
+// from now on to it. It is a no-op if the cache is already persisted.
+func (c *th2enCache) persist(disk *common.DiskCache) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disk != nil {
+		return nil
+	}
+	err := disk.ForEach(func(key string, value json.RawMessage) error {
+		_, surface, ok := strings.Cut(key, "\x00")
+		if !ok {
+			return nil
+		}
+		var e th2enEntry
+		if err := json.Unmarshal(value, &e); err != nil {
+			return err
+		}
+		c.put(key, surface, e)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", disk.Path(), err)
+	}
+	c.disk = disk
+	return nil
+}
+
+// Len returns the number of cached words, all schemes included.
+func (c *th2enCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Clear drops all cached words, on disk too if the cache is persisted.
+func (c *th2enCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]th2enEntry)
+	c.maxRunes = 0
+	if c.disk != nil {
+		if err := c.disk.Clear(); err != nil {
+			logger().Warn().Err(err).Msg("failed to clear persisted words")
+		}
+	}
+}
+
+// Segment splits chunk into cached words of the given scheme by forward
+// longest matching. Text that isn't Thai (spaces, punctuation, digits...) is
+// skipped as thai2english.com doesn't return it as words either.
+//
+// ok is false if some Thai text of the chunk isn't covered by the cache,
+// in which case the chunk must be queried.
+func (c *th2enCache) Segment(chunk, scheme string) (words []string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	runes := []rune(chunk)
+	for i := 0; i < len(runes); {
+		if !unicode.Is(unicode.Thai, runes[i]) {
+			i++
+			continue
+		}
+		n := min(c.maxRunes, len(runes)-i)
+		for ; n > 0; n-- {
+			if _, found := c.entries[th2enCacheKey(string(runes[i:i+n]), scheme)]; found {
+				break
+			}
+		}
+		if n == 0 {
+			return nil, false
+		}
+		words = append(words, string(runes[i:i+n]))
+		i += n
+	}
+	return words, true
+}
+
+// Glosses returns the glosses of the word surface, whichever scheme it was
+// scraped with: the meanings given by thai2english.com don't depend on the
+// scheme. preferred is looked up first.
+func (c *th2enCache) Glosses(surface, preferred string) ([]common.Gloss, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	schemes := append([]string{preferred}, common.GetSchemesNames(translitSchemes)...)
+	for _, scheme := range schemes {
+		if e, ok := c.entries[th2enCacheKey(surface, scheme)]; ok {
+			return e.Glosses, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,124 @@
+package tha
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestTH2ENCacheSegment(t *testing.T) {
+	c := newTH2ENCache()
+	c.Put("ภาษา", "paiboon", th2enEntry{Romanization: "paa-sǎa"})
+	c.Put("ภาษาไทย", "paiboon", th2enEntry{Romanization: "paa-sǎa tai"})
+	c.Put("ง่าย", "paiboon", th2enEntry{Romanization: "ngâai"})
+
+	words, ok := c.Segment("ภาษาไทย ง่าย!", "paiboon")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"ภาษาไทย", "ง่าย"}, words)
+
+	_, ok = c.Segment("ภาษาไทย ยาก", "paiboon")
+	assert.False(t, ok, "unknown Thai words must be queried")
+
+	_, ok = c.Segment("ภาษาไทย", "rtgs")
+	assert.False(t, ok, "entries are specific to a scheme")
+
+	c.Clear()
+	assert.Zero(t, c.Len())
+}
+
+func TestBatchQueries(t *testing.T) {
+	assert.Equal(t, []string{"ab cd", "efgh"}, batchQueries([]string{"ab", "cd", "efgh"}, 5))
+	assert.Equal(t, []string{"abcdef", "g"}, batchQueries([]string{"abcdef", "g"}, 5))
+	assert.Nil(t, batchQueries(nil, 5))
+}
+
+func TestTH2ENRateLimiter(t *testing.T) {
+	p := &TH2ENProvider{}
+	assert.Same(t, common.GetRateLimiter("thai2english.com"), p.rateLimiter(), "limited by default")
+
+	require.NoError(t, p.ConfigureWith(TH2ENOptions{Scheme: "paiboon", RequestsPerSecond: 20, Burst: 2}))
+	limiter := p.rateLimiter()
+	require.NotNil(t, limiter)
+	assert.Same(t, limiter, p.Clone().(*TH2ENProvider).rateLimiter(), "copies share the limit")
+
+	ctx := context.Background()
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	// the burst goes through at once, the 2 other requests wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(canceled), context.Canceled)
+
+	assert.Error(t, p.SaveConfig(map[string]interface{}{"scheme": "paiboon", "rate_limit": "fast"}))
+}
+
+func TestTH2ENDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := common.OpenDiskCache("thai2english.com", common.DiskCacheOptions{Dir: dir})
+	require.NoError(t, err)
+
+	c := newTH2ENCache()
+	require.NoError(t, c.persist(disk))
+	c.Put("ภาษาไทย", "paiboon", th2enEntry{Romanization: "paa-sǎa tai", Glosses: []common.Gloss{{Definition: "Thai language"}}})
+	c.Put("ง่าย", "paiboon", th2enEntry{Romanization: "ngâai"})
+	require.NoError(t, disk.Close())
+
+	// the next run knows the words without scraping them
+	disk, err = common.DiskCacheFromConfig("thai2english.com", map[string]interface{}{"disk_cache_dir": dir})
+	require.NoError(t, err)
+	c = newTH2ENCache()
+	require.NoError(t, c.persist(disk))
+	words, ok := c.Segment("ภาษาไทย ง่าย", "paiboon")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"ภาษาไทย", "ง่าย"}, words)
+	e, ok := c.Get("ภาษาไทย", "paiboon")
+	require.True(t, ok)
+	assert.Equal(t, "Thai language", e.Glosses[0].Definition)
+	require.NoError(t, disk.Close())
+
+	// the oldest words are evicted beyond the max and expired ones are dropped
+	disk, err = common.OpenDiskCache("thai2english.com", common.DiskCacheOptions{Dir: dir, MaxEntries: 1, TTL: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 1, disk.Len())
+	var entry th2enEntry
+	found, err := disk.Get(th2enCacheKey("ง่าย", "paiboon"), &entry)
+	require.NoError(t, err)
+	assert.True(t, found)
+	require.NoError(t, disk.Close())
+
+	disk, err = common.OpenDiskCache("thai2english.com", common.DiskCacheOptions{Dir: dir, TTL: time.Nanosecond})
+	require.NoError(t, err)
+	assert.Zero(t, disk.Len())
+	require.NoError(t, disk.Close())
+
+	disk, err = common.DiskCacheFromConfig("thai2english.com", map[string]interface{}{"disk_cache": false})
+	assert.NoError(t, err)
+	assert.Nil(t, disk)
+}
+
+func TestLookup(t *testing.T) {
+	p := &TH2ENProvider{cache: newTH2ENCache(), targetScheme: "paiboon"}
+	meaning := []common.Gloss{{Definition: "language", Language: common.DefaultGlossLang}}
+	p.cache.Put("ภาษา", "rtgs", th2enEntry{Romanization: "phasa", Glosses: meaning})
+
+	glosses, err := p.lookup(context.Background(), "ภาษา")
+	require.NoError(t, err)
+	assert.Equal(t, meaning, glosses, "glosses don't depend on the scheme")
+
+	_, err = p.lookup(context.Background(), "ง่าย")
+	assert.ErrorIs(t, err, ErrNoGlossBackend, "uncached words need an initialized provider")
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	_, err = Lookup(context.Background(), " ")
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package tha
 
 import (
+	"strings"
+
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
 )
 
@@ -15,6 +17,10 @@ type Tkn struct {
 	FinalConsonant   string // ตัวสะกด
 	Tone             int    // วรรณยุกต์ (0-4)
 
+	// Syllables of the token, with their tone, set by the paiboonizer and
+	// pythainlp providers
+	Syllables []Syllable
+
 	// Thai-specific Classifications
 	ConsonantClass string // อักษรสูง, อักษรกลาง, อักษรต่ำ (high, mid, low class)
 	SyllableType   string // แม่ ก กา, แม่ กง, etc.
@@ -37,6 +43,8 @@ type Tkn struct {
 
 	// Additional Thai Analysis
 	RegisterLevel string // ระดับภาษา (formal, informal, etc.)
+	// IsPoliteParticle marks politeness particles (ครับ, ค่ะ, จ้ะ...), see TagPoliteness
+	IsPoliteParticle bool
 	Etymology     string // ที่มาของคำ (Thai, Pali, Sanskrit, etc.)
 
 	// Alternative Analyses
@@ -44,3 +52,13 @@ type Tkn struct {
 	AlternativeTones []int    // Possible tone variations
 }
 
+
+// SpacingRule is the spacing rule registered for Thai. The repetition mark
+// ไม้ยมก (ๆ) and the abbreviation mark ไปยาลน้อย (ฯ) stay attached to the
+// word they follow (เด็กๆ, กรุงเทพฯ) instead of being separated by a space.
+func SpacingRule(prev, current string) bool {
+	if strings.HasPrefix(current, "ๆ") || strings.HasPrefix(current, "ฯ") {
+		return false
+	}
+	return common.DefaultSpacingRule(prev, current)
+}
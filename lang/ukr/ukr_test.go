@@ -0,0 +1,29 @@
+package ukr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+func TestSchemes(t *testing.T) {
+	tests := []struct {
+		scheme string
+		input  string
+		want   []string
+	}{
+		{"kmu_2010", "Юрій Гагарін з Києва", []string{"Yurii", "Haharin", "z", "Kyieva"}},
+		{"kmu_2010", "Згорани Знам'янка їжак", []string{"Zghorany", "Znamianka", "yizhak"}},
+		{"bgn_pcgn", "Київ Львів", []string{"Kyyiv", "L’viv"}},
+	}
+	for _, tt := range tests {
+		m, err := common.GetSchemeModule(Lang, tt.scheme)
+		require.NoError(t, err)
+		require.NoError(t, m.Init())
+		parts, err := m.RomanParts(tt.input)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, parts, tt.scheme)
+	}
+}
@@ -0,0 +1,108 @@
+package urd
+
+// lexicon holds the pronunciation of frequent words whose short vowels cannot be
+// recovered from the unvocalized script. Entries are in ALA-LC notation and are
+// converted to the target scheme like the output of the letter-by-letter romanizer.
+var lexicon = map[string]string{
+	// Postpositions and particles
+	"کے":   "ke",
+	"کی":   "kī",
+	"کا":   "kā",
+	"کو":   "ko",
+	"سے":   "se",
+	"نے":   "ne",
+	"میں":  "meṉ",
+	"پر":   "par",
+	"تک":   "tak",
+	"بھی":  "bhī",
+	"ہی":   "hī",
+	"تو":   "to",
+	"لیے":  "liye",
+	"ساتھ": "sāth",
+	"بعد":  "baʻd",
+	"پہلے": "pahle",
+
+	// Conjunctions and adverbs
+	"اور":  "aur",
+	"کہ":   "kih",
+	"یا":   "yā",
+	"لیکن": "lekin",
+	"اگر":  "agar",
+	"نہیں": "nahīṉ",
+	"نہ":   "nah",
+	"بہت":  "bahut",
+	"اب":   "ab",
+	"جب":   "jab",
+	"کب":   "kab",
+	"آج":   "āj",
+	"کل":   "kal",
+	"یہاں": "yahāṉ",
+	"وہاں": "vahāṉ",
+	"کہاں": "kahāṉ",
+
+	// Pronouns and determiners
+	"ہم":   "ham",
+	"تم":   "tum",
+	"آپ":   "āp",
+	"یہ":   "yih",
+	"وہ":   "vuh",
+	"اس":   "is",
+	"ان":   "in",
+	"اُس":  "us",
+	"اُن":  "un",
+	"جو":   "jo",
+	"کیا":  "kyā",
+	"کون":  "kaun",
+	"کیوں": "kyoṉ",
+	"میرا": "merā",
+	"میری": "merī",
+	"تیرا": "terā",
+	"اپنا": "apnā",
+	"اپنی": "apnī",
+
+	// Copula and frequent verbs
+	"ہے":   "hai",
+	"ہیں":  "haiṉ",
+	"ہوں":  "hūṉ",
+	"ہو":   "ho",
+	"تھا":  "thā",
+	"تھی":  "thī",
+	"تھے":  "the",
+	"گیا":  "gayā",
+	"گئی":  "gaʼī",
+	"کر":   "kar",
+	"کرنا": "karnā",
+	"ہونا": "honā",
+	"جانا": "jānā",
+
+	// Numerals
+	"ایک":  "ek",
+	"دو":   "do",
+	"تین":  "tīn",
+	"چار":  "chār",
+	"پانچ": "pāṉch",
+
+	// Common nouns and adjectives
+	"اردو":     "urdū",
+	"زبان":     "zabān",
+	"پاکستان":  "pākistān",
+	"ہندوستان": "hindūstān",
+	"شکریہ":    "shukriyah",
+	"سلام":     "salām",
+	"خدا":      "k͟hudā",
+	"محبت":     "muḥabbat",
+	"دوست":     "dost",
+	"اچھا":     "achchhā",
+	"پانی":     "pānī",
+	"کتاب":     "kitāb",
+	"دل":       "dil",
+	"دن":       "din",
+	"رات":      "rāt",
+	"گھر":      "ghar",
+	"لوگ":      "log",
+	"بات":      "bāt",
+	"وقت":      "vaqt",
+	"کام":      "kām",
+	"دنیا":     "dunyā",
+	"شہر":      "shahr",
+}
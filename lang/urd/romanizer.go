@@ -0,0 +1,436 @@
+package urd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+const (
+	zwnj          = '\u200c' // Zero-width non-joiner
+	zabar         = 'َ'
+	zer           = 'ِ'
+	pesh          = 'ُ'
+	tashdid       = 'ّ'
+	jazm          = 'ْ'
+	doZabar       = 'ً'
+	hamzaAbove    = 'ٔ'
+	khariZabar    = 'ٰ'
+	tatweel       = 'ـ'
+	defaultScheme = "ala-lc"
+)
+
+// urduSchemes lists the romanization schemes offered by the UrduProvider.
+var urduSchemes = []common.TranslitScheme{
+	{Name: "ala-lc", Description: "ALA-LC romanization of Urdu (ā, ī, ū, ṭ, ḍ, ṛ, ṉ...)"},
+	{Name: "simplified", Description: "Simplified romanization without diacritics"},
+}
+
+// schemeConverters turn the ALA-LC notation produced by the romanizer into
+// the target scheme. A nil converter means the ALA-LC notation is kept as is.
+var schemeConverters = map[string]*strings.Replacer{
+	"ala-lc": nil,
+	"simplified": strings.NewReplacer(
+		"k͟h", "kh", "g͟h", "gh", "ā", "a", "ī", "i", "ū", "u",
+		"ṭ", "t", "ḍ", "d", "ṛ", "r", "ṉ", "n", "s̱", "s", "ẕ", "z",
+		"ż", "z", "ẓ", "z", "t̤", "t", "ḥ", "h", "ṣ", "s", "ʻ", "", "ʼ", "",
+	),
+}
+
+// consonants maps Urdu letters with a fixed value to their ALA-LC notation.
+// Alif, vāʼo, choṭī ye, baṛī ye and gol he are handled contextually by romanizePart.
+var consonants = map[rune]string{
+	'ب': "b", 'پ': "p", 'ت': "t", 'ٹ': "ṭ", 'ث': "s̱", 'ج': "j",
+	'چ': "ch", 'ح': "ḥ", 'خ': "k͟h", 'د': "d", 'ڈ': "ḍ", 'ذ': "ẕ",
+	'ر': "r", 'ڑ': "ṛ", 'ز': "z", 'ژ': "zh", 'س': "s", 'ش': "sh",
+	'ص': "ṣ", 'ض': "ż", 'ط': "t̤", 'ظ': "ẓ", 'ع': "ʻ", 'غ': "g͟h",
+	'ف': "f", 'ق': "q", 'ک': "k", 'گ': "g", 'ل': "l", 'م': "m",
+	'ن': "n", 'ں': "ṉ", 'ء': "ʼ", 'ئ': "ʼ", 'ؤ': "ʼ", 'ة': "t",
+	// Do-chashmī he marks the aspiration of the preceding consonant: بھ bh, کھ kh
+	'ھ': "h",
+}
+
+// arabicVariants normalizes Arabic and Persian code points commonly found in
+// Urdu text to their Urdu counterparts.
+var arabicVariants = strings.NewReplacer(
+	"ك", "ک",
+	"ي", "ی",
+	"ى", "ی",
+	"ه", "ہ",
+	"ۀ", "ہ"+string(hamzaAbove),
+	"ۂ", "ہ"+string(hamzaAbove),
+	string(tatweel), "",
+)
+
+// UrduProvider romanizes Urdu tokens written in the Nastaliq (Perso-Arabic) script.
+// It handles the Urdu-specific letters (ٹ ڈ ڑ ں ے ھ), aspirated consonants, the
+// word-final gol he and the izāfat. Short vowels are rarely written in Urdu: they
+// are taken from the aʻrāb when present and from a lexicon of frequent words otherwise.
+type UrduProvider struct {
+	config           map[string]interface{}
+	progressCallback common.ProgressCallback
+	scheme           string
+}
+
+// NewUrduProvider creates a new UrduProvider using the default scheme.
+func NewUrduProvider() *UrduProvider {
+	return &UrduProvider{
+		scheme: defaultScheme,
+	}
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *UrduProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress (no-op for the Urdu romanizer).
+func (p *UrduProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	// No-op: the Urdu romanizer doesn't require Docker downloads
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+// This allows the provider to be configured before being initialized.
+//
+// Returns an error if the configuration is invalid.
+func (p *UrduProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// UrduOptions are the typed options of UrduProvider, see common.Configure.
+type UrduOptions struct {
+	// Scheme is the name of a registered Urdu scheme. Defaults to defaultScheme.
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+func (p *UrduProvider) ConfigureWith(opts UrduOptions) error {
+	if _, ok := schemeConverters[opts.Scheme]; !ok && opts.Scheme != "" {
+		return fmt.Errorf("unsupported transliteration scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// InitWithContext initializes the provider with the given context.
+// This validates the romanization scheme found in the stored configuration.
+//
+// Returns an error if the scheme is not supported or the context is canceled.
+func (p *UrduProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("urdu: context canceled during initialization: %w", err)
+	}
+
+	scheme, _ := p.config["scheme"].(string)
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if _, ok := schemeConverters[scheme]; !ok {
+		return fmt.Errorf("urdu: unsupported transliteration scheme: %s", scheme)
+	}
+	p.scheme = scheme
+	return nil
+}
+
+// Init initializes the provider with a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Returns an error if initialization fails.
+func (p *UrduProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reinitializes the provider from scratch with the given context.
+// For the Urdu romanizer, this is equivalent to InitWithContext as there are no persistent resources.
+//
+// Returns an error if reinitialization fails or the context is canceled.
+func (p *UrduProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+// This is a convenience method for operations that don't need cancellation control.
+//
+// Returns an error if reinitialization fails.
+func (p *UrduProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+func (p *UrduProvider) Name() string {
+	return "urdu"
+}
+
+func (p *UrduProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TransliteratorMode}
+}
+
+func (p *UrduProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// CloseWithContext releases resources used by the provider with the given context.
+// For the Urdu romanizer, this is a no-op as there are no persistent resources to release.
+//
+// Returns nil as there are no resources to release.
+func (p *UrduProvider) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// Close releases resources used by the provider with a background context.
+// For the Urdu romanizer, this is a no-op as there are no persistent resources to release.
+//
+// Returns nil as there are no resources to release.
+func (p *UrduProvider) Close() error {
+	return nil
+}
+
+// ProcessFlowController processes input tokens using the specified context.
+// This processes pre-tokenized input, adding romanization to Urdu tokens.
+// The context is used for cancellation during processing.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - mode: The operating mode, only TransliteratorMode is supported
+//   - input: The token slice wrapper to process
+//
+// Returns:
+//   - AnyTokenSliceWrapper: A wrapper containing the processed tokens
+//   - error: An error if processing fails, the context is canceled, or input format is invalid
+func (p *UrduProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("urdu: context canceled during processing: %w", err)
+	}
+
+	if mode != common.TransliteratorMode {
+		return nil, fmt.Errorf("operating mode %s not supported", mode)
+	}
+	if len(input.GetRaw()) != 0 {
+		return nil, fmt.Errorf("urdu: raw input not accepted, a tokenizer must run first")
+	}
+
+	if err := p.InitWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	total := input.Len()
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("urdu: context canceled while processing token %d: %w", i, err)
+		}
+
+		if p.progressCallback != nil {
+			p.progressCallback(i, total)
+		}
+
+		tkn := input.GetIdx(i)
+		s := tkn.GetSurface()
+		if !tkn.IsLexicalContent() || s == "" || tkn.Roman() != "" {
+			continue
+		}
+		roman, _ := Romanize(s, p.scheme)
+		tkn.SetRoman(roman)
+	}
+
+	return input, nil
+}
+
+// Romanize converts an Urdu word or phrase to the given scheme ("ala-lc" or "simplified").
+//
+// Returns an error if the scheme is not supported.
+func Romanize(text, scheme string) (string, error) {
+	conv, ok := schemeConverters[scheme]
+	if !ok {
+		return "", fmt.Errorf("urdu: unsupported transliteration scheme: %s", scheme)
+	}
+
+	words := strings.Fields(arabicVariants.Replace(text))
+	for i, word := range words {
+		// A ZWNJ only prevents joining in Urdu: romanize the word as a whole
+		words[i] = romanizePart(strings.ReplaceAll(word, string(zwnj), ""))
+	}
+
+	roman := strings.Join(words, " ")
+	if conv != nil {
+		roman = conv.Replace(roman)
+	}
+	return roman, nil
+}
+
+// romanizePart romanizes a single word in ALA-LC notation.
+func romanizePart(word string) string {
+	if word == "" {
+		return ""
+	}
+
+	// Detect an explicitly marked izāfat and strip it before the lexicon lookup
+	izafat := ""
+	switch {
+	case strings.HasSuffix(word, string(zer)):
+		word = strings.TrimSuffix(word, string(zer))
+		izafat = "-i"
+	case strings.HasSuffix(word, "ہ"+string(hamzaAbove)):
+		word = strings.TrimSuffix(word, string(hamzaAbove))
+		izafat = "-yi"
+	}
+
+	if roman, ok := lexicon[word]; ok {
+		return roman + izafat
+	}
+
+	rs := []rune(word)
+	var out strings.Builder
+	atStart := true
+	prevVowel := false
+	prevZabar := false
+	lastCons := ""
+
+	emitCons := func(s string) {
+		out.WriteString(s)
+		lastCons = s
+		prevVowel = false
+		prevZabar = false
+		atStart = false
+	}
+	emitVowel := func(s string) {
+		out.WriteString(s)
+		prevVowel = true
+		prevZabar = false
+		atStart = false
+	}
+	at := func(i int) rune {
+		if i >= 0 && i < len(rs) {
+			return rs[i]
+		}
+		return 0
+	}
+	isFinal := func(i int) bool {
+		for _, r := range rs[i+1:] {
+			if !unicode.Is(unicode.Mn, r) {
+				return false
+			}
+		}
+		return true
+	}
+	// vowelFollows reports whether the letter at i is followed by a vowel,
+	// in which case vāʼo and ye are consonants.
+	vowelFollows := func(i int) bool {
+		switch at(i + 1) {
+		case zabar, zer, pesh, doZabar, 'ا', 'آ', 'ے':
+			return true
+		}
+		return false
+	}
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if cons, ok := consonants[r]; ok {
+			emitCons(cons)
+			continue
+		}
+
+		switch r {
+		case 'ا':
+			switch {
+			case at(i+1) == doZabar:
+				emitVowel("an")
+				i++
+			case !atStart:
+				emitVowel("ā")
+			case at(i+1) == 'ی':
+				emitVowel("ī")
+				i++
+			case at(i+1) == 'و':
+				emitVowel("ū")
+				i++
+			case unicode.Is(unicode.Mn, at(i+1)):
+				// Alif only carries the vowel written on it
+				atStart = false
+			default:
+				emitVowel("a")
+			}
+		case 'آ':
+			emitVowel("ā")
+		case 'و':
+			switch {
+			case prevZabar:
+				// Diphthong: zabar + vāʼo
+				emitVowel("u")
+			case atStart || prevVowel || vowelFollows(i) || at(i+1) == tashdid:
+				emitCons("v")
+			default:
+				emitVowel("ū")
+			}
+		case 'ی':
+			switch {
+			case prevZabar:
+				// Diphthong: zabar + ye
+				emitVowel("i")
+			case atStart || prevVowel || vowelFollows(i) || at(i+1) == tashdid:
+				emitCons("y")
+			default:
+				emitVowel("ī")
+			}
+		case 'ے':
+			if prevZabar {
+				emitVowel("i")
+			} else {
+				emitVowel("e")
+			}
+		case 'ہ':
+			// Word-final gol he after a consonant stands for a short vowel: شکریہ shukriyah
+			if isFinal(i) && !atStart && !prevVowel {
+				emitVowel("ah")
+			} else {
+				emitCons("h")
+			}
+		case zabar:
+			emitVowel("a")
+			prevZabar = true
+		case zer:
+			if at(i+1) == 'ی' && !vowelFollows(i+1) {
+				emitVowel("ī")
+				i++
+			} else {
+				emitVowel("i")
+			}
+		case pesh:
+			if at(i+1) == 'و' && !vowelFollows(i+1) {
+				emitVowel("ū")
+				i++
+			} else {
+				emitVowel("u")
+			}
+		case tashdid:
+			if lastCons != "" {
+				out.WriteString(lastCons)
+				prevVowel = false
+			}
+		case doZabar:
+			emitVowel("an")
+			if at(i+1) == 'ا' {
+				i++
+			}
+		case khariZabar:
+			emitVowel("ā")
+		case jazm, hamzaAbove:
+		default:
+			switch {
+			case r >= '۰' && r <= '۹':
+				out.WriteRune('0' + r - '۰')
+			case r >= '٠' && r <= '٩':
+				out.WriteRune('0' + r - '٠')
+			default:
+				out.WriteRune(r)
+			}
+			prevVowel = false
+			prevZabar = false
+			atStart = false
+		}
+	}
+
+	return out.String() + izafat
+}
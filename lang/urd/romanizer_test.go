@@ -0,0 +1,35 @@
+package urd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/urd"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input, scheme, expected string
+	}{
+		{"سلام", "ala-lc", "salām"},
+		{"اردو", "ala-lc", "urdū"},     // lexicon
+		{"خدا", "simplified", "khuda"}, // lexicon, simplified
+		{"ٹوپی", "ala-lc", "ṭūpī"},     // retroflex ṭ
+		{"بھارت", "ala-lc", "bhārt"},   // aspirated consonant
+		{"گاڑی", "ala-lc", "gāṛī"},     // retroflex flap
+		{"کتابِ", "ala-lc", "kitāb-i"}, // izāfat marked by zer
+		{"ہیں", "simplified", "hain"},  // nasalization
+		{"كتاب", "ala-lc", "kitāb"},    // Arabic kaf normalized
+		{"۲۰۲۴", "ala-lc", "2024"},
+	}
+	for _, c := range cases {
+		roman, err := urd.Romanize(c.input, c.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, roman, "input %q with scheme %s", c.input, c.scheme)
+	}
+
+	_, err := urd.Romanize("سلام", "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package zho
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/unihan.tsv
+var unihanTSV string
+
+var (
+	charInfoOnce sync.Once
+	charInfoDB   *CharInfoDB
+)
+
+// kangxiRadicals is the first code point of the Kangxi Radicals block,
+// whose characters are in the order of the 214 radicals.
+const kangxiRadicals = 0x2F00
+
+// CharInfo is the metadata of a Han character.
+type CharInfo struct {
+	Char          string
+	Radical       string // Kangxi radical (部首), e.g. 言 for 说, whose radical is written 讠
+	RadicalNumber int    // number of the radical, from 1 to 214
+	NumStrokes    int    // total number of strokes
+	FreqRank      int    // rank among the most frequent characters, from 1; 0 if not ranked
+}
+
+// CharInfoDB holds the metadata of Han characters, see LoadCharInfoTSV.
+type CharInfoDB struct {
+	chars map[rune]CharInfo
+}
+
+// BuiltinCharInfo returns the built-in metadata of the most common Han
+// characters, a small subset of Unihan, see data/unihan.tsv.
+func BuiltinCharInfo() *CharInfoDB {
+	charInfoOnce.Do(func() {
+		db, err := LoadCharInfoTSV(strings.NewReader(unihanTSV))
+		if err != nil {
+			common.Log.Error().Err(err).Msg("zho: built-in character metadata is invalid")
+			db = &CharInfoDB{chars: make(map[rune]CharInfo)}
+		}
+		charInfoDB = db
+	})
+	return charInfoDB
+}
+
+// LoadCharInfoTSV reads character metadata in the format of data/unihan.tsv:
+// one character per line followed by its Kangxi radical number, its total
+// number of strokes and its frequency rank (0 if not ranked), separated by
+// tabs. Empty lines and lines starting with # are ignored.
+func LoadCharInfoTSV(r io.Reader) (*CharInfoDB, error) {
+	db := &CharInfoDB{chars: make(map[rune]CharInfo)}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 || utf8.RuneCountInString(fields[0]) != 1 {
+			return nil, fmt.Errorf("character metadata: line %d: expected a character and 3 numbers", lineNum)
+		}
+		var nums [3]int
+		for i, field := range fields[1:] {
+			n, err := strconv.Atoi(field)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("character metadata: line %d: invalid number %q", lineNum, field)
+			}
+			nums[i] = n
+		}
+		if nums[0] < 1 || nums[0] > 214 {
+			return nil, fmt.Errorf("character metadata: line %d: invalid radical number %d", lineNum, nums[0])
+		}
+		r, _ := utf8.DecodeRuneInString(fields[0])
+		db.chars[r] = CharInfo{
+			Char:          fields[0],
+			Radical:       norm.NFKC.String(string(rune(kangxiRadicals + nums[0] - 1))),
+			RadicalNumber: nums[0],
+			NumStrokes:    nums[1],
+			FreqRank:      nums[2],
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("character metadata: %w", err)
+	}
+	return db, nil
+}
+
+// Lookup returns the metadata of a Han character, if it is known.
+func (db *CharInfoDB) Lookup(char rune) (CharInfo, bool) {
+	info, ok := db.chars[char]
+	return info, ok
+}
+
+// Len returns the number of characters of the database.
+func (db *CharInfoDB) Len() int {
+	return len(db.chars)
+}
+
+// annotate sets the Han characters of the token as its Components, with
+// their metadata in Characters. Single characters also get their Radical and
+// the token the total NumStrokes of its characters, if they are all known.
+func (db *CharInfoDB) annotate(tkn *Tkn) {
+	var components []string
+	var chars []CharInfo
+	strokes, allKnown := 0, true
+	for _, r := range tkn.Surface {
+		if !unicode.Is(unicode.Han, r) {
+			continue
+		}
+		info, ok := db.Lookup(r)
+		if !ok {
+			info = CharInfo{Char: string(r)}
+			allKnown = false
+		}
+		components = append(components, info.Char)
+		chars = append(chars, info)
+		strokes += info.NumStrokes
+	}
+	if len(chars) == 0 {
+		return
+	}
+	tkn.Components = components
+	tkn.Characters = chars
+	if allKnown {
+		tkn.NumStrokes = strokes
+	}
+	if len(chars) == 1 && utf8.RuneCountInString(tkn.Surface) == 1 {
+		tkn.Radical = chars[0].Radical
+	}
+}
+
+// NewCharInfoEnricher returns an enricher setting the Components of the
+// lexical tokens to their Han characters and Characters to their metadata
+// (radical, strokes, frequency) from db, or from the built-in metadata if db
+// is nil.
+func NewCharInfoEnricher(db *CharInfoDB) *common.FuncEnricher {
+	if db == nil {
+		db = BuiltinCharInfo()
+	}
+	return common.NewFuncEnricher("zho-charinfo", func(ctx context.Context, tsw common.AnyTokenSliceWrapper) error {
+		for i := 0; i < tsw.Len(); i++ {
+			if tkn, ok := tsw.GetIdx(i).(*Tkn); ok && tkn.IsLexical {
+				db.annotate(tkn)
+			}
+		}
+		return nil
+	})
+}
+
+// WithCharInfo appends the enricher setting the metadata of the Han
+// characters of the tokens, see NewCharInfoEnricher.
+func (m *Module) WithCharInfo(db *CharInfoDB) *Module {
+	m.WithEnricher(NewCharInfoEnricher(db))
+	return m
+}
@@ -0,0 +1,187 @@
+package zho
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// chengyuTag is the jieba POS tag for idioms.
+const chengyuTag = "i"
+
+// chengyuFreq is the frequency given to chengyu registered in the jieba dictionary.
+// It is high enough to prevent the segmenter from splitting them, without
+// overriding the segmentation of unrelated text.
+const chengyuFreq = 1000
+
+var chengyuMu sync.RWMutex
+
+// chengyuDict maps common chengyu (成语) to an English gloss.
+var chengyuDict = map[string]string{
+	"一石二鸟": "kill two birds with one stone",
+	"一举两得": "achieve two things at once",
+	"一心一意": "wholeheartedly",
+	"一路平安": "have a safe trip",
+	"一帆风顺": "plain sailing; smooth progress",
+	"一见钟情": "love at first sight",
+	"一模一样": "exactly alike",
+	"一无所知": "know nothing at all",
+	"一言为定": "it's a deal",
+	"一日千里": "make rapid progress",
+	"一针见血": "hit the nail on the head",
+	"三心二意": "half-hearted; undecided",
+	"不可思议": "inconceivable, unimaginable",
+	"不知不觉": "unconsciously, without realizing",
+	"井底之蛙": "a frog at the bottom of a well; a person of limited outlook",
+	"亡羊补牢": "better late than never; mend the fold after the sheep are lost",
+	"人山人海": "a sea of people",
+	"入乡随俗": "when in Rome, do as the Romans do",
+	"千方百计": "by every possible means",
+	"千军万马": "a powerful army; a mighty force",
+	"半途而废": "give up halfway",
+	"卧虎藏龙": "hidden talents; crouching tiger, hidden dragon",
+	"守株待兔": "wait idly for windfalls",
+	"对牛弹琴": "cast pearls before swine",
+	"画蛇添足": "ruin something by adding superfluous detail",
+	"画龙点睛": "add the finishing touch",
+	"自相矛盾": "self-contradictory",
+	"马马虎虎": "so-so; careless",
+	"马到成功": "achieve immediate success",
+	"胸有成竹": "have a well-thought-out plan",
+	"刻舟求剑": "act foolishly without regard to changed circumstances",
+	"叶公好龙": "professed love of what one actually fears",
+	"狐假虎威": "bully others by flaunting powerful connections",
+	"杯弓蛇影": "be frightened by imaginary fears",
+	"塞翁失马": "a blessing in disguise",
+	"掩耳盗铃": "deceive oneself",
+	"拔苗助长": "spoil things through excessive enthusiasm",
+	"揠苗助长": "spoil things through excessive enthusiasm",
+	"纸上谈兵": "armchair strategy; theorizing without practice",
+	"望梅止渴": "console oneself with false hopes",
+	"四面楚歌": "besieged on all sides",
+	"卧薪尝胆": "endure hardship to accomplish a goal",
+	"破釜沉舟": "burn one's bridges",
+	"背水一战": "fight with one's back to the wall",
+	"草木皆兵": "be extremely nervous, seeing enemies everywhere",
+	"名列前茅": "be among the best",
+	"津津有味": "with great relish",
+	"迫不及待": "too impatient to wait",
+	"莫名其妙": "baffling, inexplicable",
+	"脚踏实地": "down-to-earth",
+	"全心全意": "wholeheartedly",
+	"自言自语": "talk to oneself",
+	"成千上万": "tens of thousands",
+	"与众不同": "out of the ordinary",
+	"五颜六色": "multicolored",
+	"七上八下": "be on tenterhooks",
+	"乱七八糟": "in a mess",
+	"十全十美": "perfect",
+	"百发百中": "hit the target every time",
+	"众所周知": "as everyone knows",
+	"理所当然": "as it should be; naturally",
+	"实事求是": "seek truth from facts",
+	"得不偿失": "the loss outweighs the gain",
+	"一丝不苟": "meticulous",
+	"滥竽充数": "be there just to make up the number",
+	"自以为是": "self-righteous",
+	"目瞪口呆": "dumbstruck",
+	"手忙脚乱": "in a frantic rush",
+	"熙熙攘攘": "bustling with activity",
+	"兴高采烈": "in high spirits",
+	"心平气和": "calm and composed",
+	"恍然大悟": "suddenly realize",
+	"如鱼得水": "like a fish in water",
+	"对症下药": "prescribe the right remedy",
+	"水落石出": "the truth comes to light",
+	"风和日丽": "sunny and breezy",
+	"日新月异": "change with each passing day",
+	"知足常乐": "contentment brings happiness",
+	"温故知新": "learn new things by reviewing the old",
+	"学而不厌": "insatiable in learning",
+	"青出于蓝": "the pupil surpasses the master",
+	"坐井观天": "have a narrow view",
+	"班门弄斧": "show off in front of an expert",
+	"爱不释手": "love something too much to part with it",
+	"废寝忘食": "forget to eat and sleep; be absorbed in work",
+	"持之以恒": "persevere",
+	"有条不紊": "methodical, orderly",
+	"各抒己见": "each airs their own views",
+}
+
+// LookupChengyu returns the English gloss of a chengyu, if it is known.
+func LookupChengyu(idiom string) (string, bool) {
+	chengyuMu.RLock()
+	defer chengyuMu.RUnlock()
+	gloss, ok := chengyuDict[idiom]
+	return gloss, ok
+}
+
+// RegisterChengyu adds or replaces a chengyu and its gloss in the idiom dictionary.
+// It only affects providers initialized after the call.
+func RegisterChengyu(idiom, gloss string) {
+	chengyuMu.Lock()
+	defer chengyuMu.Unlock()
+	chengyuDict[idiom] = gloss
+}
+
+// forEachChengyu calls fn for every chengyu of the idiom dictionary.
+func forEachChengyu(fn func(idiom string)) {
+	chengyuMu.RLock()
+	defer chengyuMu.RUnlock()
+	for idiom := range chengyuDict {
+		fn(idiom)
+	}
+}
+
+// mergeChengyu rejoins the chengyu that the segmenter split into several words,
+// e.g. ["画蛇", "添足"] → ["画蛇添足"]. The POS tag of merged words is set to "i".
+func mergeChengyu(words, tags []string) ([]string, []string) {
+	var outWords, outTags []string
+	for i := 0; i < len(words); i++ {
+		merged := false
+		var b strings.Builder
+		b.WriteString(words[i])
+		for j := i + 1; j < len(words); j++ {
+			b.WriteString(words[j])
+			n := utf8.RuneCountInString(b.String())
+			if n > 4 {
+				break
+			}
+			if _, ok := LookupChengyu(b.String()); ok && n == 4 {
+				outWords = append(outWords, b.String())
+				outTags = append(outTags, chengyuTag)
+				i = j
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			outWords = append(outWords, words[i])
+			outTags = append(outTags, tags[i])
+		}
+	}
+	return outWords, outTags
+}
+
+// annotateIdiom marks idiomatic tokens and attaches the gloss of known chengyu.
+func annotateIdiom(tkn *Tkn, pos string) {
+	gloss, known := LookupChengyu(tkn.Surface)
+	if !known && pos != chengyuTag {
+		return
+	}
+	if utf8.RuneCountInString(tkn.Surface) == 4 {
+		tkn.Chengyu = true
+	} else {
+		tkn.Idiom = true
+	}
+	if known {
+		tkn.Glosses = append(tkn.Glosses, common.Gloss{
+			PartOfSpeech: "idiom",
+			Definition:   gloss,
+			Info:         "chengyu",
+			Language:     common.DefaultGlossLang,
+		})
+	}
+}
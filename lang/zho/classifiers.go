@@ -0,0 +1,320 @@
+package zho
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ClassifierInfo describes a Chinese measure word (量词).
+type ClassifierInfo struct {
+	Classifier string         // The measure word itself, e.g. "张"
+	Pinyin     string         // Reading of the measure word
+	Type       ClassifierType // Individual, collective, measure or temporary
+	Category   string         // Semantic category of the nouns it counts, e.g. "flat objects"
+}
+
+// Semantic categories of classifiers, used in ClassifierInfo.Category.
+const (
+	CategoryGeneral      = "general"
+	CategoryPeople       = "people (polite)"
+	CategoryAnimals      = "animals"
+	CategoryFlat         = "flat objects"
+	CategoryLongThin     = "long, thin objects"
+	CategoryLongRigid    = "long, rigid objects"
+	CategoryBound        = "bound volumes"
+	CategoryVehicles     = "vehicles"
+	CategoryMachines     = "machines, appliances"
+	CategoryClothing     = "clothing, matters, affairs"
+	CategoryBuildings    = "buildings"
+	CategoryRoundSmall   = "small round objects"
+	CategoryPairs        = "pairs"
+	CategoryGroups       = "groups"
+	CategorySets         = "sets"
+	CategoryContainers   = "containers"
+	CategoryWeight       = "weight"
+	CategoryLength       = "length, distance"
+	CategoryMoney        = "money"
+	CategoryTime         = "time"
+	CategoryEvents       = "events, occurrences"
+	CategoryPlants       = "plants"
+	CategoryOpenings     = "things with openings"
+	CategoryHandles      = "things with handles"
+	CategoryVolumes      = "volume"
+	CategoryFamilies     = "families, businesses"
+	CategoryDrops        = "drops"
+	CategoryPieces       = "pieces, pieces of text"
+	CategoryKinds        = "kinds, types"
+	CategoryVerbalCounts = "verbal measures"
+)
+
+var classifierMu sync.RWMutex
+
+// classifierDB maps a measure word to its description.
+var classifierDB = map[string]ClassifierInfo{
+	"个":  {"个", "gè", Individual, CategoryGeneral},
+	"位":  {"位", "wèi", Individual, CategoryPeople},
+	"名":  {"名", "míng", Individual, CategoryPeople},
+	"口":  {"口", "kǒu", Individual, CategoryPeople},
+	"只":  {"只", "zhī", Individual, CategoryAnimals},
+	"头":  {"头", "tóu", Individual, CategoryAnimals},
+	"匹":  {"匹", "pǐ", Individual, CategoryAnimals},
+	"张":  {"张", "zhāng", Individual, CategoryFlat},
+	"片":  {"片", "piàn", Individual, CategoryFlat},
+	"面":  {"面", "miàn", Individual, CategoryFlat},
+	"条":  {"条", "tiáo", Individual, CategoryLongThin},
+	"根":  {"根", "gēn", Individual, CategoryLongThin},
+	"支":  {"支", "zhī", Individual, CategoryLongRigid},
+	"枝":  {"枝", "zhī", Individual, CategoryLongRigid},
+	"本":  {"本", "běn", Individual, CategoryBound},
+	"册":  {"册", "cè", Individual, CategoryBound},
+	"辆":  {"辆", "liàng", Individual, CategoryVehicles},
+	"架":  {"架", "jià", Individual, CategoryVehicles},
+	"艘":  {"艘", "sōu", Individual, CategoryVehicles},
+	"台":  {"台", "tái", Individual, CategoryMachines},
+	"部":  {"部", "bù", Individual, CategoryMachines},
+	"件":  {"件", "jiàn", Individual, CategoryClothing},
+	"座":  {"座", "zuò", Individual, CategoryBuildings},
+	"栋":  {"栋", "dòng", Individual, CategoryBuildings},
+	"间":  {"间", "jiān", Individual, CategoryBuildings},
+	"颗":  {"颗", "kē", Individual, CategoryRoundSmall},
+	"粒":  {"粒", "lì", Individual, CategoryRoundSmall},
+	"棵":  {"棵", "kē", Individual, CategoryPlants},
+	"朵":  {"朵", "duǒ", Individual, CategoryPlants},
+	"把":  {"把", "bǎ", Individual, CategoryHandles},
+	"扇":  {"扇", "shàn", Individual, CategoryOpenings},
+	"家":  {"家", "jiā", Individual, CategoryFamilies},
+	"封":  {"封", "fēng", Individual, CategoryPieces},
+	"篇":  {"篇", "piān", Individual, CategoryPieces},
+	"首":  {"首", "shǒu", Individual, CategoryPieces},
+	"块":  {"块", "kuài", Individual, CategoryPieces},
+	"场":  {"场", "chǎng", Individual, CategoryEvents},
+	"节":  {"节", "jié", Individual, CategoryEvents},
+	"门":  {"门", "mén", Individual, CategoryEvents},
+	"道":  {"道", "dào", Individual, CategoryEvents},
+	"双":  {"双", "shuāng", Collective, CategoryPairs},
+	"对":  {"对", "duì", Collective, CategoryPairs},
+	"副":  {"副", "fù", Collective, CategoryPairs},
+	"群":  {"群", "qún", Collective, CategoryGroups},
+	"批":  {"批", "pī", Collective, CategoryGroups},
+	"套":  {"套", "tào", Collective, CategorySets},
+	"种":  {"种", "zhǒng", Collective, CategoryKinds},
+	"些":  {"些", "xiē", Collective, CategoryGeneral},
+	"滴":  {"滴", "dī", Collective, CategoryDrops},
+	"斤":  {"斤", "jīn", Measure, CategoryWeight},
+	"公斤": {"公斤", "gōngjīn", Measure, CategoryWeight},
+	"克":  {"克", "kè", Measure, CategoryWeight},
+	"吨":  {"吨", "dūn", Measure, CategoryWeight},
+	"米":  {"米", "mǐ", Measure, CategoryLength},
+	"公里": {"公里", "gōnglǐ", Measure, CategoryLength},
+	"厘米": {"厘米", "límǐ", Measure, CategoryLength},
+	"里":  {"里", "lǐ", Measure, CategoryLength},
+	"升":  {"升", "shēng", Measure, CategoryVolumes},
+	"元":  {"元", "yuán", Measure, CategoryMoney},
+	"角":  {"角", "jiǎo", Measure, CategoryMoney},
+	"分":  {"分", "fēn", Measure, CategoryMoney},
+	"年":  {"年", "nián", Measure, CategoryTime},
+	"天":  {"天", "tiān", Measure, CategoryTime},
+	"杯":  {"杯", "bēi", Temporary, CategoryContainers},
+	"碗":  {"碗", "wǎn", Temporary, CategoryContainers},
+	"瓶":  {"瓶", "píng", Temporary, CategoryContainers},
+	"盒":  {"盒", "hé", Temporary, CategoryContainers},
+	"箱":  {"箱", "xiāng", Temporary, CategoryContainers},
+	"袋":  {"袋", "dài", Temporary, CategoryContainers},
+	"盘":  {"盘", "pán", Temporary, CategoryContainers},
+	"桶":  {"桶", "tǒng", Temporary, CategoryContainers},
+	"壶":  {"壶", "hú", Temporary, CategoryContainers},
+	"次":  {"次", "cì", Individual, CategoryVerbalCounts},
+	"遍":  {"遍", "biàn", Individual, CategoryVerbalCounts},
+	"趟":  {"趟", "tàng", Individual, CategoryVerbalCounts},
+}
+
+// classifierMaxLen is the length in runes of the longest classifier of classifierDB.
+var classifierMaxLen = longestClassifier()
+
+func longestClassifier() (n int) {
+	for cl := range classifierDB {
+		n = max(n, utf8.RuneCountInString(cl))
+	}
+	return
+}
+
+// nounMeasureWords maps nouns to their standard measure words, the most common first.
+var nounMeasureWords = map[string][]string{
+	"人":   {"个", "位", "名"},
+	"老师":  {"位", "个"},
+	"学生":  {"个", "名"},
+	"朋友":  {"个", "位"},
+	"孩子":  {"个"},
+	"医生":  {"位", "个"},
+	"客人":  {"位"},
+	"狗":   {"只", "条"},
+	"猫":   {"只"},
+	"鸟":   {"只"},
+	"鱼":   {"条"},
+	"蛇":   {"条"},
+	"牛":   {"头"},
+	"猪":   {"头"},
+	"马":   {"匹"},
+	"书":   {"本"},
+	"杂志":  {"本"},
+	"词典":  {"本"},
+	"字典":  {"本"},
+	"纸":   {"张"},
+	"桌子":  {"张"},
+	"床":   {"张"},
+	"照片":  {"张"},
+	"票":   {"张"},
+	"地图":  {"张"},
+	"脸":   {"张"},
+	"河":   {"条"},
+	"路":   {"条"},
+	"裤子":  {"条"},
+	"裙子":  {"条"},
+	"新闻":  {"条"},
+	"消息":  {"条"},
+	"笔":   {"支"},
+	"铅笔":  {"支"},
+	"歌":   {"首"},
+	"诗":   {"首"},
+	"车":   {"辆"},
+	"汽车":  {"辆"},
+	"自行车": {"辆"},
+	"飞机":  {"架"},
+	"船":   {"艘", "条"},
+	"电脑":  {"台"},
+	"电视":  {"台"},
+	"机器":  {"台"},
+	"手机":  {"部", "个"},
+	"电影":  {"部"},
+	"衣服":  {"件"},
+	"衬衫":  {"件"},
+	"事":   {"件"},
+	"事情":  {"件"},
+	"礼物":  {"件", "个"},
+	"山":   {"座"},
+	"桥":   {"座"},
+	"城市":  {"座"},
+	"楼":   {"栋", "座"},
+	"房间":  {"间"},
+	"房子":  {"栋", "间"},
+	"树":   {"棵"},
+	"花":   {"朵"},
+	"星星":  {"颗"},
+	"心":   {"颗"},
+	"米饭":  {"碗"},
+	"苹果":  {"个"},
+	"香蕉":  {"根"},
+	"椅子":  {"把"},
+	"刀":   {"把"},
+	"伞":   {"把"},
+	"钥匙":  {"把"},
+	"门":   {"扇"},
+	"窗户":  {"扇"},
+	"公司":  {"家"},
+	"商店":  {"家"},
+	"饭馆":  {"家"},
+	"银行":  {"家"},
+	"信":   {"封"},
+	"文章":  {"篇"},
+	"手表":  {"块"},
+	"蛋糕":  {"块"},
+	"钱":   {"块"},
+	"电影票": {"张"},
+	"比赛":  {"场"},
+	"雨":   {"场"},
+	"课":   {"节", "门"},
+	"问题":  {"个", "道"},
+	"鞋":   {"双"},
+	"袜子":  {"双"},
+	"筷子":  {"双"},
+	"眼睛":  {"双"},
+	"手":   {"双", "只"},
+	"眼镜":  {"副"},
+	"家具":  {"套"},
+	"房":   {"套", "间"},
+	"水":   {"杯", "瓶"},
+	"茶":   {"杯", "壶"},
+	"咖啡":  {"杯"},
+	"啤酒":  {"瓶", "杯"},
+	"酒":   {"瓶", "杯"},
+}
+
+// LookupClassifier returns the description of a measure word, if it is known.
+func LookupClassifier(classifier string) (ClassifierInfo, bool) {
+	classifierMu.RLock()
+	defer classifierMu.RUnlock()
+	info, ok := classifierDB[classifier]
+	return info, ok
+}
+
+// MeasureWordsFor returns the standard measure words for a noun, the most common first.
+// It returns nil if the noun is unknown.
+func MeasureWordsFor(noun string) []string {
+	classifierMu.RLock()
+	defer classifierMu.RUnlock()
+	return append([]string(nil), nounMeasureWords[noun]...)
+}
+
+// RegisterClassifier adds or replaces a measure word in the classifier database.
+func RegisterClassifier(info ClassifierInfo) {
+	classifierMu.Lock()
+	defer classifierMu.Unlock()
+	classifierDB[info.Classifier] = info
+	classifierMaxLen = max(classifierMaxLen, utf8.RuneCountInString(info.Classifier))
+}
+
+// RegisterMeasureWords sets the standard measure words of a noun, the most common first.
+func RegisterMeasureWords(noun string, classifiers ...string) {
+	classifierMu.Lock()
+	defer classifierMu.Unlock()
+	nounMeasureWords[noun] = append([]string(nil), classifiers...)
+}
+
+// annotateClassifier fills the classifier-related fields of a token based on its
+// jieba POS tag and the classifier database:
+//   - classifier tokens ("q") get their ClassifierType and ClassifierCategory
+//   - numeral+classifier compounds ("m", e.g. "一本") get the same, plus Measure
+//   - nouns ("n*") get their standard measure word in Measure
+func annotateClassifier(tkn *Tkn, pos string) {
+	switch {
+	case pos == "q":
+		tkn.Measure = tkn.Surface
+		if info, ok := LookupClassifier(tkn.Surface); ok {
+			tkn.ClassifierType = info.Type
+			tkn.ClassifierCategory = info.Category
+		} else {
+			// Tagged as a classifier by jieba but unknown to our database
+			tkn.ClassifierType = Individual
+		}
+	case pos == "m":
+		if cl, info, ok := trailingClassifier(tkn.Surface); ok {
+			tkn.Measure = cl
+			tkn.ClassifierType = info.Type
+			tkn.ClassifierCategory = info.Category
+		}
+	case strings.HasPrefix(pos, "n"):
+		if mws := MeasureWordsFor(tkn.Surface); len(mws) > 0 {
+			tkn.Measure = mws[0]
+		}
+	}
+}
+
+// trailingClassifier detects a known classifier at the end of a numeral compound
+// such as "一本" or "三公斤". The part before the classifier must be non-empty.
+func trailingClassifier(s string) (string, ClassifierInfo, bool) {
+	runes := []rune(s)
+	classifierMu.RLock()
+	maxLen := classifierMaxLen
+	classifierMu.RUnlock()
+	for n := maxLen; n >= 1; n-- {
+		if len(runes) <= n {
+			continue
+		}
+		cl := string(runes[len(runes)-n:])
+		if info, ok := LookupClassifier(cl); ok {
+			return cl, info, true
+		}
+	}
+	return "", ClassifierInfo{}, false
+}
@@ -0,0 +1,239 @@
+package zho
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// dictFile is a jieba dictionary file with its expected size for progress tracking.
+type dictFile struct {
+	name string
+	size int64
+}
+
+// Dictionary files required by gojieba
+var dictFiles = []dictFile{
+	{"jieba.dict.utf8", 5079385},
+	{"hmm_model.utf8", 519568},
+	{"user.dict.utf8", 49},
+	{"idf.utf8", 6083765},
+	{"stop_words.utf8", 8987},
+}
+
+// dictBaseURL is the base URL for downloading dictionary files from gojieba's GitHub repo
+const dictBaseURL = "https://raw.githubusercontent.com/yanyiwu/gojieba/v1.4.6/deps/cppjieba/dict/"
+
+// ensureDictDir creates and returns the dictionary directory path.
+// Uses XDG base directory specification for cross-platform support:
+// - Linux: ~/.local/share/langkit/gojieba/dict/
+// - macOS: ~/Library/Application Support/langkit/gojieba/dict/
+// - Windows: %APPDATA%\langkit\gojieba\dict\
+func ensureDictDir() (string, error) {
+	dictDir := filepath.Join(xdg.DataHome, "langkit", "gojieba", "dict")
+	return dictDir, os.MkdirAll(dictDir, 0755)
+}
+
+// ensureDictionaries checks if the given dictionary files exist, and downloads any missing ones.
+// onProgress, if not nil, is called with the number of bytes downloaded so far and the total.
+func ensureDictionaries(ctx context.Context, dictDir string, files []dictFile, onProgress func(downloaded, total int64)) error {
+	// Check if all files already exist
+	allExist := true
+	for _, df := range files {
+		if _, err := os.Stat(filepath.Join(dictDir, df.name)); os.IsNotExist(err) {
+			allExist = false
+			break
+		}
+	}
+	if allExist {
+		return nil
+	}
+	if common.OfflineMode() {
+		return fmt.Errorf("jieba dictionaries missing from %s and offline mode is enabled", dictDir)
+	}
+
+	// Calculate total size for progress tracking
+	var totalSize int64
+	for _, df := range files {
+		totalSize += df.size
+	}
+
+	// Download each file with progress
+	var downloaded int64
+	for _, df := range files {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("context canceled: %w", err)
+		}
+
+		destPath := filepath.Join(dictDir, df.name)
+		if _, err := os.Stat(destPath); err == nil {
+			// File already exists, count it as downloaded for progress
+			downloaded += df.size
+			continue
+		}
+
+		if err := downloadFile(ctx, dictBaseURL+df.name, destPath, &downloaded, totalSize, onProgress); err != nil {
+			return fmt.Errorf("failed to download %s: %w", df.name, err)
+		}
+	}
+	return nil
+}
+
+// downloadFile downloads a single file from url to destPath, updating progress.
+func downloadFile(ctx context.Context, url, destPath string, downloaded *int64, totalSize int64, onProgress func(downloaded, total int64)) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	// Create temp file first, then rename for atomicity
+	tmpPath := destPath + ".tmp"
+	out, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() {
+		out.Close()
+		os.Remove(tmpPath) // Clean up temp file on error
+	}()
+
+	// Copy with progress tracking
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := out.Write(buf[:n]); writeErr != nil {
+				return fmt.Errorf("failed to write: %w", writeErr)
+			}
+			*downloaded += int64(n)
+			if onProgress != nil {
+				onProgress(*downloaded, totalSize)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read: %w", readErr)
+		}
+	}
+
+	// Close before rename
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	// Atomic rename
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("failed to rename: %w", err)
+	}
+
+	return nil
+}
+
+// dictionaryVersions returns the SHA-256 checksums of the given dictionary files.
+func dictionaryVersions(files []dictFile) (map[string]string, error) {
+	dictDir, err := ensureDictDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access dictionary directory: %w", err)
+	}
+	versions := make(map[string]string, len(files))
+	for _, df := range files {
+		data, err := os.ReadFile(filepath.Join(dictDir, df.name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dictionary %s: %w", df.name, err)
+		}
+		versions[df.name] = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+	return versions, nil
+}
+
+// jiebaConfidence returns the confidence in a word from its jieba POS tag:
+// words missing from the dictionary, found by the HMM or left as single
+// characters, are tagged "x".
+func jiebaConfidence(pos string) float64 {
+	switch pos {
+	case "x":
+		return common.ConfidenceGuessed
+	case "eng", "m":
+		// runs of letters or digits
+		return 1
+	}
+	return common.ConfidenceDictionary
+}
+
+// buildTokens integrates the words found by a segmenter in chunk with the
+// intervening filler and annotates the lexical tokens from their jieba POS tag.
+func buildTokens(chunk string, words, tags []string) []*Tkn {
+	// Rejoin the chengyu the segmenter may still have split
+	words, tags = mergeChengyu(words, tags)
+
+	integrated, err := common.IntegrateProviderTokensV2(chunk, words)
+	if err != nil {
+		common.Log.Warn().Err(err).Msg("zho: words missing from the chunk")
+	}
+
+	// We'll attach each recognized lexical token's POS from 'tags' in order
+	tkns := make([]*Tkn, 0, len(integrated))
+	lexCount := 0
+	for _, fillerOrLex := range integrated {
+		// Build a new zho.Tkn from the integrated token
+		zhoTkn := &Tkn{
+			Tkn: *fillerOrLex,
+
+			// For Chinese tokens, we can at least guess that 'Surface' is both
+			// the simplified and traditional form if we have no external DB:
+			Simplified:  fillerOrLex.Surface,
+			Traditional: fillerOrLex.Surface,
+
+			// We won't fill `NumStrokes`, `Radical`, etc. because jieba
+			// doesn't supply stroke or radical data, see WithCharInfo.
+			// We'll also leave morphological fields at defaults.
+		}
+
+		if fillerOrLex.IsLexical && lexCount < len(tags) {
+			// The next POS tag in 'tags' corresponds to this lexical word
+			pos := tags[lexCount]
+			lexCount++
+
+			// Chinese words don't inflect: they are their own dictionary form
+			zhoTkn.Lemma = zhoTkn.Surface
+
+			// Store generic POS in Tkn.PartOfSpeech
+			zhoTkn.PartOfSpeech = pos
+			zhoTkn.Confidence = jiebaConfidence(pos)
+			zhoTkn.UPOS = common.ToUPOS("jieba", pos)
+
+			// Classifiers get their type and semantic category from the
+			// classifier database, nouns get their standard measure word.
+			annotateClassifier(zhoTkn, pos)
+
+			// Idioms are marked and known chengyu get a gloss
+			annotateIdiom(zhoTkn, pos)
+
+			// If we see 'a' (形容词), we might guess it's a stative verb in Chinese:
+			if pos == "a" {
+				zhoTkn.IsStative = true
+			}
+		}
+		tkns = append(tkns, zhoTkn)
+	}
+	return tkns
+}
@@ -0,0 +1,42 @@
+package zho
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+//go:embed data/frequency.tsv
+var frequencyTSV string
+
+var (
+	frequencyOnce sync.Once
+	frequencyList *common.MemoryFrequencyList
+)
+
+// FrequencyList returns the built-in frequency list of Chinese words: a short,
+// approximate list of the most frequent ones, see data/frequency.tsv.
+func FrequencyList() *common.MemoryFrequencyList {
+	frequencyOnce.Do(func() {
+		list, err := common.LoadFrequencyTSV(strings.NewReader(frequencyTSV), Lang)
+		if err != nil {
+			common.Log.Error().Err(err).Msg("zho: built-in frequency list is invalid")
+			list = common.NewMemoryFrequencyList(Lang)
+		}
+		frequencyList = list
+	})
+	return frequencyList
+}
+
+// WithFrequencyRanks appends the enricher setting the frequency rank of the
+// tokens (Metadata["freq_rank"], see common.FreqRank) from the given lists,
+// or from the built-in list if none is given.
+func (m *Module) WithFrequencyRanks(lists ...common.FrequencyList) *Module {
+	if len(lists) == 0 {
+		lists = []common.FrequencyList{FrequencyList()}
+	}
+	m.WithEnricher(common.NewFrequencyEnricher(lists...))
+	return m
+}
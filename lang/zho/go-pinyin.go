@@ -9,21 +9,26 @@ import (
 
 	"github.com/mozillazg/go-pinyin"
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"golang.org/x/text/unicode/norm"
 )
 
-// toneNumberRegex extracts the tone number from numeric pinyin notation like "hao3"
-var toneNumberRegex = regexp.MustCompile(`(\d)$`)
+// toneNumberRegex extracts the tone number from numeric pinyin notation like "hao3".
+// The tone2 style places the digit right after the vowel ("ha3o", "she1ng"), so the
+// digit isn't necessarily at the end of the syllable.
+var toneNumberRegex = regexp.MustCompile(`([1-5])`)
 
 // GoPinyinProvider implements the Provider interface for Chinese Pinyin transliteration.
 // It uses the go-pinyin library to convert Chinese characters to Pinyin romanization.
 // This provider chooses the "most frequent" reading for Tkn.Pinyin while also storing
-// all alternative readings in Tkn.PinyinAll and Tkn.PinyinNumAll.
+// all alternative readings in Tkn.PinyinAll and Tkn.PinyinNumAll, and the reading
+// of each individual character in Tkn.CharReadings.
 type GoPinyinProvider struct {
 	config           map[string]interface{}
 	progressCallback common.ProgressCallback
 	initialized      bool
 
 	chosenScheme string
+	ipa          bool // the "ipa" scheme outputs IPA instead of pinyin
 	mainStyle    int
 	numStyle     int
 
@@ -48,9 +53,40 @@ func (p *GoPinyinProvider) WithDownloadProgressCallback(callback common.Download
 // Returns an error if the configuration is invalid.
 func (p *GoPinyinProvider) SaveConfig(cfg map[string]interface{}) error {
 	p.config = cfg
+	// the new configuration is applied on the next initialization
+	p.initialized = false
 	return nil
 }
 
+// GoPinyinOptions are the typed options of GoPinyinProvider, see common.Configure.
+type GoPinyinOptions struct {
+	// Scheme is one of the keys of PinyinSchemes or "ipa". Defaults to "tone".
+	Scheme string
+}
+
+// ConfigureWith implements common.Configurable.
+// The options are applied on the next initialization.
+func (p *GoPinyinProvider) ConfigureWith(opts GoPinyinOptions) error {
+	scheme := strings.ToLower(opts.Scheme)
+	if _, ok := PinyinSchemes[scheme]; !ok && scheme != "" && scheme != "ipa" {
+		return fmt.Errorf("unknown pinyin scheme: %s", opts.Scheme)
+	}
+	return p.SaveConfig(map[string]interface{}{"scheme": opts.Scheme})
+}
+
+// ApplyConfig implements common.ConfigApplier: the new scheme only changes
+// the style of the pinyin, so the provider is simply initialized again.
+func (p *GoPinyinProvider) ApplyConfig(ctx context.Context, cfg map[string]interface{}) error {
+	scheme, _ := cfg["scheme"].(string)
+	if _, ok := PinyinSchemes[strings.ToLower(scheme)]; !ok && scheme != "" && strings.ToLower(scheme) != "ipa" {
+		return fmt.Errorf("unknown pinyin scheme: %s", scheme)
+	}
+	if err := p.SaveConfig(cfg); err != nil {
+		return err
+	}
+	return p.InitWithContext(ctx)
+}
+
 // InitWithContext initializes the provider with the given context.
 // This sets up the pinyin styles and configurations based on the stored configuration.
 // The context can be used for cancellation, though initialization is typically quick.
@@ -71,6 +107,7 @@ func (p *GoPinyinProvider) InitWithContext(ctx context.Context) error {
 		schemeName = "tone" // default diacritic
 	}
 	p.chosenScheme = schemeName
+	p.ipa = strings.ToLower(schemeName) == "ipa"
 
 	style, ok := PinyinSchemes[strings.ToLower(schemeName)]
 	if !ok {
@@ -149,66 +186,72 @@ func (p *GoPinyinProvider) ProcessFlowController(ctx context.Context, mode commo
 		return nil, fmt.Errorf("gopinyin init failed: %w", err)
 	}
 
-	tokens := input.Len()
-	for i := 0; i < tokens; i++ {
-		// Check for context cancellation
-		if err := ctx.Err(); err != nil {
-			return nil, fmt.Errorf("gopinyin: context canceled while processing token %d: %w", i, err)
-		}
-		
-		// Report progress if callback is set (throttler handles batching)
-		if p.progressCallback != nil {
-			p.progressCallback(i, tokens)
-		}
-		
-		anyTkn := input.GetIdx(i)
+	err := common.ForEachToken(ctx, input, p.progressCallback, func(ctx context.Context, _ int, anyTkn common.AnyToken) error {
 		if !anyTkn.IsLexicalContent() {
-			continue
+			return nil
 		}
 
 		zhoTkn, ok := anyTkn.(*Tkn)
 		if !ok {
 			// Not our specialized token => fallback
 			anyTkn.SetRoman(anyTkn.GetSurface())
-			continue
+			return nil
 		}
 
 		if !zhoTkn.IsChinese() {
 			zhoTkn.SetRoman(zhoTkn.Surface)
-			continue
+			return nil
 		}
 
-		// 1) Retrieve diacritic multi-pronunciation data
-		allSyllables := pinyin.Pinyin(zhoTkn.Surface, p.mainArgs) // 2D slice
-		zhoTkn.PinyinAll = allSyllables
+		// 1) Retrieve the multi-pronunciation data character by character.
+		// pinyin.Pinyin() silently drops characters it has no reading for,
+		// which would break the alignment of CharReadings with the surface.
+		var allSyllables, allNumSyllables [][]string
+		zhoTkn.CharReadings = zhoTkn.CharReadings[:0]
+		for _, r := range zhoTkn.Surface {
+			readings := pinyin.SinglePinyin(r, p.mainArgs)
+			numReadings := pinyin.SinglePinyin(r, p.numArgs)
+			// Like pinyin.Pinyin(), PinyinAll and PinyinNumAll leave out the
+			// characters without a reading
+			if len(readings) > 0 {
+				allSyllables = append(allSyllables, readings)
+			}
+			if len(numReadings) > 0 {
+				allNumSyllables = append(allNumSyllables, numReadings)
+			}
 
-		// 2) Retrieve numeric multi-pronunciation data
-		allNumSyllables := pinyin.Pinyin(zhoTkn.Surface, p.numArgs)
+			// 2) The "most frequent" reading is the *first* in each sub-slice.
+			cr := CharReading{Char: string(r)}
+			if len(readings) > 0 {
+				cr.Pinyin = readings[0]
+				cr.Alternatives = readings[1:]
+			}
+			if len(numReadings) > 0 {
+				cr.PinyinNum = numReadings[0]
+				cr.Tone = Tone(parseToneNumber(numReadings[0]))
+				cr.IPA = numericPinyinToIPA(numReadings[0])
+			}
+			zhoTkn.CharReadings = append(zhoTkn.CharReadings, cr)
+		}
+		zhoTkn.PinyinAll = allSyllables
 		zhoTkn.PinyinNumAll = allNumSyllables
 
-		// 3) The "most frequent" reading is the *first* in each sub-slice.
-		// We'll build Tkn.Pinyin from that.
-		var chosenDiacritic []string
-		var chosenNumeric []string
-
-		for idxChar, arr := range allSyllables {
-			if len(arr) > 0 {
-				chosenDiacritic = append(chosenDiacritic, arr[0])
-			} else {
-				// fallback if no reading
-				chosenDiacritic = append(chosenDiacritic, "")
+		// 3) We'll build Tkn.Pinyin from the chosen reading of each character,
+		// skipping the characters without a reading.
+		var chosenDiacritic, chosenNumeric, chosenIPA []string
+		for _, cr := range zhoTkn.CharReadings {
+			if cr.Pinyin != "" {
+				chosenDiacritic = append(chosenDiacritic, cr.Pinyin)
 			}
-
-			numArr := allNumSyllables[idxChar]
-			if len(numArr) > 0 {
-				chosenNumeric = append(chosenNumeric, numArr[0])
-			} else {
-				chosenNumeric = append(chosenNumeric, "")
+			if cr.PinyinNum != "" {
+				chosenNumeric = append(chosenNumeric, cr.PinyinNum)
+				chosenIPA = append(chosenIPA, cr.IPA)
 			}
 		}
 
 		zhoTkn.Pinyin = strings.Join(chosenDiacritic, " ")
 		zhoTkn.PinyinNum = strings.Join(chosenNumeric, " ")
+		zhoTkn.IPA = strings.Join(chosenIPA, " ")
 
 		// 4) If single-syllable, parse numeric tone
 		if len(chosenNumeric) == 1 {
@@ -221,9 +264,16 @@ func (p *GoPinyinProvider) ProcessFlowController(ctx context.Context, mode commo
 		}
 
 		// 5) Put the final reading in Tkn.Romanization
-		zhoTkn.SetRoman(zhoTkn.Pinyin)
+		if p.ipa {
+			zhoTkn.SetRoman(zhoTkn.IPA)
+		} else {
+			zhoTkn.SetRoman(zhoTkn.Pinyin)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gopinyin: %w", err)
 	}
-
 	return input, nil
 }
 
@@ -272,7 +322,7 @@ var PinyinSchemes = map[string]int{
 	"finalstone3":  pinyin.FinalsTone3,
 }
 
-// parseToneNumber picks the last digit [1..5] from a tone2 syllable like "hao3".
+// parseToneNumber picks the digit [1..5] from a tone2 syllable like "ha3o".
 // This is a helper function for extracting tone numbers from numeric Pinyin notation.
 //
 // Parameters:
@@ -288,3 +338,28 @@ func parseToneNumber(s string) int {
 	num, _ := strconv.Atoi(match[1])
 	return num
 }
+
+// pinyinToneMarks maps the combining tone marks of pinyin to their tone number.
+var pinyinToneMarks = map[rune]int{'̄': 1, '́': 2, '̌': 3, '̀': 4}
+
+// PinyinToneNumbers converts pinyin with tone marks, whose syllables are
+// separated by spaces as in Tkn.Pinyin, to pinyin with the tone number at the
+// end of each syllable: "nǐ hǎo" becomes "ni3 hao3". Neutral tones get no number.
+func PinyinToneNumbers(pinyin string) string {
+	syllables := strings.Split(pinyin, " ")
+	for i, syllable := range syllables {
+		tone := 0
+		base := strings.Map(func(r rune) rune {
+			if n, ok := pinyinToneMarks[r]; ok {
+				tone = n
+				return -1
+			}
+			return r
+		}, norm.NFD.String(syllable))
+		syllables[i] = norm.NFC.String(base)
+		if tone > 0 {
+			syllables[i] += strconv.Itoa(tone)
+		}
+	}
+	return strings.Join(syllables, " ")
+}
@@ -1,42 +1,27 @@
+//go:build cgo
+
 package zho
 
 import (
 	"context"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
-	"os"
-	"path/filepath"
+	"strings"
 
-	"github.com/adrg/xdg"
 	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
-	"github.com/yanyiwu/gojieba"
 )
 
-// Dictionary files required by gojieba with their expected sizes for progress tracking
-var dictFiles = []struct {
-	name string
-	size int64
-}{
-	{"jieba.dict.utf8", 5079385},
-	{"hmm_model.utf8", 519568},
-	{"user.dict.utf8", 49},
-	{"idf.utf8", 6083765},
-	{"stop_words.utf8", 8987},
-}
-
-// dictBaseURL is the base URL for downloading dictionary files from gojieba's GitHub repo
-const dictBaseURL = "https://raw.githubusercontent.com/yanyiwu/gojieba/v1.4.6/deps/cppjieba/dict/"
-
 // GoJiebaProvider implements the Provider interface for Chinese text segmentation.
 // It uses the gojieba library to tokenize Chinese text with word boundaries and
 // part-of-speech tagging, while preserving non-lexical tokens like punctuation.
+//
+// All providers share a single gojieba instance whose dictionaries are only
+// loaded on first use, see SetGoJiebaIdleTTL and UnloadGoJieba.
 type GoJiebaProvider struct {
 	config                   map[string]interface{}
 	progressCallback         common.ProgressCallback
 	downloadProgressCallback common.DownloadProgressCallback
-	jieba                    *gojieba.Jieba
+	retained                 bool // holds a reference on the shared gojieba instance
 }
 
 // WithProgressCallback sets a callback function for reporting progress during processing.
@@ -62,6 +47,7 @@ func (p *GoJiebaProvider) SaveConfig(cfg map[string]interface{}) error {
 // InitWithContext initializes the gojieba engine with the given context.
 // This is called automatically before processing if the engine is not already initialized.
 // On first run, it downloads the required dictionary files (~14MB) to the user's data directory.
+// The dictionaries themselves are only loaded in memory on first segmentation.
 // The context can be used for cancellation during initialization or download.
 //
 // Returns an error if initialization fails, download fails, or the context is canceled.
@@ -71,7 +57,7 @@ func (p *GoJiebaProvider) InitWithContext(ctx context.Context) error {
 		return fmt.Errorf("gojieba: context canceled during initialization: %w", err)
 	}
 
-	if p.jieba != nil {
+	if p.retained {
 		return nil
 	}
 
@@ -82,18 +68,17 @@ func (p *GoJiebaProvider) InitWithContext(ctx context.Context) error {
 	}
 
 	// Download dictionaries if needed
-	if err := p.ensureDictionaries(ctx, dictDir); err != nil {
+	onProgress := func(downloaded, total int64) {
+		if p.downloadProgressCallback != nil {
+			p.downloadProgressCallback(p.Name(), downloaded, total, "Downloading GoJieba dictionaries...")
+		}
+	}
+	if err := ensureDictionaries(ctx, dictDir, dictFiles, onProgress); err != nil {
 		return fmt.Errorf("gojieba: failed to download dictionaries: %w", err)
 	}
 
-	// Pass explicit paths to NewJieba to avoid runtime.Caller path issues
-	p.jieba = gojieba.NewJieba(
-		filepath.Join(dictDir, "jieba.dict.utf8"),
-		filepath.Join(dictDir, "hmm_model.utf8"),
-		filepath.Join(dictDir, "user.dict.utf8"),
-		filepath.Join(dictDir, "idf.utf8"),
-		filepath.Join(dictDir, "stop_words.utf8"),
-	)
+	jiebaInstance.retain(dictDir)
+	p.retained = true
 	return nil
 }
 
@@ -116,10 +101,8 @@ func (p *GoJiebaProvider) InitRecreateWithContext(ctx context.Context, noCache b
 		return fmt.Errorf("gojieba: context canceled during reinitialization: %w", err)
 	}
 	
-	if p.jieba != nil {
-		p.jieba.Free()
-		p.jieba = nil
-	}
+	// The dictionaries are reloaded from disk on next use
+	UnloadGoJieba()
 	return p.InitWithContext(ctx)
 }
 
@@ -149,11 +132,13 @@ func (p *GoJiebaProvider) ProcessFlowController(ctx context.Context, mode common
 	}
 	
 	// Ensure gojieba is initialized
-	if p.jieba == nil {
+	if !p.retained {
 		if err := p.InitWithContext(ctx); err != nil {
 			return nil, fmt.Errorf("failed to init gojieba: %w", err)
 		}
 	}
+	jieba, done := jiebaInstance.acquire()
+	defer done()
 
 	rawChunks := input.GetRaw()
 	if len(rawChunks) == 0 {
@@ -178,60 +163,23 @@ func (p *GoJiebaProvider) ProcessFlowController(ctx context.Context, mode common
 			continue
 		}
 
-		// 1) Use gojieba for lexical segmentation + POS tags
-		words := p.jieba.Cut(chunk, true) // "precise" mode with HMM
-		tags := p.jieba.Tag(chunk)
-		if len(words) != len(tags) {
-			return nil, fmt.Errorf("gojieba mismatch: len(words)=%d, len(tags)=%d", len(words), len(tags))
+		// 1) Use gojieba for lexical segmentation + POS tags.
+		// Tag() segments like Cut() in "precise" mode with HMM and returns "word/pos" pairs.
+		tagged := jieba.Tag(chunk)
+		words := make([]string, len(tagged))
+		tags := make([]string, len(tagged))
+		for i, wt := range tagged {
+			sep := strings.LastIndex(wt, "/")
+			if sep <= 0 {
+				words[i] = wt
+				continue
+			}
+			words[i], tags[i] = wt[:sep], wt[sep+1:]
 		}
 
 		// 2) Integrate lexical tokens with filler
-		integrated := common.IntegrateProviderTokens(chunk, words)
-
-		// We'll attach each recognized lexical token's POS from 'tags' in order
-		lexCount := 0
-		for _, fillerOrLex := range integrated {
-			// Build a new zho.Tkn from the integrated token
-			zhoTkn := &Tkn{
-				Tkn: *fillerOrLex,
-
-				// For Chinese tokens, we can at least guess that 'Surface' is both
-				// the simplified and traditional form if we have no external DB:
-				Simplified:  fillerOrLex.Surface,
-				Traditional: fillerOrLex.Surface,
-
-				// We won't fill `NumStrokes`, `Radical`, etc. because gojieba
-				// doesn't supply stroke or radical data.
-				// We'll also leave morphological + idiomatic fields at defaults.
-			}
-
-			if fillerOrLex.IsLexical {
-				// The next POS tag in 'tags' corresponds to this lexical word
-				pos := tags[lexCount]
-				lexCount++
-
-				// Store generic POS in Tkn.PartOfSpeech
-				zhoTkn.PartOfSpeech = pos
-
-				// If the user wants, store that same POS in some custom field, or
-				// interpret it further:
-				// For instance, if POS == "q", we might guess it's a classifier.
-				if pos == "q" {
-					// Mark it as a classifier
-					zhoTkn.ClassifierType = "indiv" // naive assumption
-				}
-
-				// If you want to guess if it's an idiom or something from the POS,
-				// you could do so here, though gojieba's default dict rarely marks that.
-
-				// If we see 'a' (形容词), we might guess it's a stative verb in Chinese:
-				if pos == "a" {
-					zhoTkn.IsStative = true
-				}
-			}
-
-			// Append the new token
-			outWrapper.Append(zhoTkn)
+		for _, tkn := range buildTokens(chunk, words, tags) {
+			outWrapper.Append(tkn)
 		}
 	}
 
@@ -256,8 +204,31 @@ func (p *GoJiebaProvider) GetMaxQueryLen() int {
 	return math.MaxInt32
 }
 
+// ResourceVersions returns the SHA-256 checksums of the dictionary files,
+// implementing common.VersionReporter.
+func (p *GoJiebaProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	versions, err := dictionaryVersions(dictFiles)
+	if err != nil {
+		return nil, fmt.Errorf("gojieba: %w", err)
+	}
+	return versions, nil
+}
+
+// PlatformRequirements implements common.PlatformConstrained:
+// gojieba wraps the C++ cppjieba library.
+func (p *GoJiebaProvider) PlatformRequirements() common.PlatformRequirements {
+	return common.PlatformRequirements{CGO: true}
+}
+
+// Unload releases the gojieba dictionaries from memory until the next
+// segmentation, without closing the provider. See UnloadGoJieba.
+func (p *GoJiebaProvider) Unload() bool {
+	return UnloadGoJieba()
+}
+
 // CloseWithContext releases resources used by the provider with the given context.
-// This frees the gojieba instance to release memory.
+// This frees the shared gojieba instance to release memory once no other
+// provider uses it.
 // The context can be used for cancellation during resource release.
 //
 // Returns an error if closing fails or the context is canceled.
@@ -267,9 +238,9 @@ func (p *GoJiebaProvider) CloseWithContext(ctx context.Context) error {
 		return fmt.Errorf("gojieba: context canceled during close: %w", err)
 	}
 	
-	if p.jieba != nil {
-		p.jieba.Free()
-		p.jieba = nil
+	if p.retained {
+		jiebaInstance.releaseRef()
+		p.retained = false
 	}
 	return nil
 }
@@ -282,115 +253,13 @@ func (p *GoJiebaProvider) Close() error {
 	return p.CloseWithContext(context.Background())
 }
 
-// ensureDictDir creates and returns the dictionary directory path.
-// Uses XDG base directory specification for cross-platform support:
-// - Linux: ~/.local/share/langkit/gojieba/dict/
-// - macOS: ~/Library/Application Support/langkit/gojieba/dict/
-// - Windows: %APPDATA%\langkit\gojieba\dict\
-func ensureDictDir() (string, error) {
-	dictDir := filepath.Join(xdg.DataHome, "langkit", "gojieba", "dict")
-	return dictDir, os.MkdirAll(dictDir, 0755)
-}
-
-// ensureDictionaries checks if all dictionary files exist, and downloads any missing ones.
-func (p *GoJiebaProvider) ensureDictionaries(ctx context.Context, dictDir string) error {
-	// Check if all files already exist
-	allExist := true
-	for _, df := range dictFiles {
-		if _, err := os.Stat(filepath.Join(dictDir, df.name)); os.IsNotExist(err) {
-			allExist = false
-			break
-		}
-	}
-	if allExist {
-		return nil
-	}
-
-	// Calculate total size for progress tracking
-	var totalSize int64
-	for _, df := range dictFiles {
-		totalSize += df.size
-	}
-
-	// Download each file with progress
-	var downloaded int64
-	for _, df := range dictFiles {
-		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("context canceled: %w", err)
-		}
-
-		destPath := filepath.Join(dictDir, df.name)
-		if _, err := os.Stat(destPath); err == nil {
-			// File already exists, count it as downloaded for progress
-			downloaded += df.size
-			continue
-		}
-
-		if err := p.downloadFile(ctx, dictBaseURL+df.name, destPath, &downloaded, totalSize); err != nil {
-			return fmt.Errorf("failed to download %s: %w", df.name, err)
-		}
-	}
-	return nil
-}
-
-// downloadFile downloads a single file from url to destPath, updating progress.
-func (p *GoJiebaProvider) downloadFile(ctx context.Context, url, destPath string, downloaded *int64, totalSize int64) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
-	// Create temp file first, then rename for atomicity
-	tmpPath := destPath + ".tmp"
-	out, err := os.Create(tmpPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer func() {
-		out.Close()
-		os.Remove(tmpPath) // Clean up temp file on error
-	}()
-
-	// Copy with progress tracking
-	buf := make([]byte, 32*1024)
-	for {
-		n, readErr := resp.Body.Read(buf)
-		if n > 0 {
-			if _, writeErr := out.Write(buf[:n]); writeErr != nil {
-				return fmt.Errorf("failed to write: %w", writeErr)
-			}
-			*downloaded += int64(n)
-			if p.downloadProgressCallback != nil {
-				p.downloadProgressCallback(p.Name(), *downloaded, totalSize, "Downloading GoJieba dictionaries...")
-			}
-		}
-		if readErr == io.EOF {
-			break
-		}
-		if readErr != nil {
-			return fmt.Errorf("failed to read: %w", readErr)
-		}
-	}
-
-	// Close before rename
-	if err := out.Close(); err != nil {
-		return fmt.Errorf("failed to close file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, destPath); err != nil {
-		return fmt.Errorf("failed to rename: %w", err)
-	}
-
-	return nil
+// gojiebaEntry returns the provider entry of gojieba, which is only available
+// in builds with CGO (see gojieba_nocgo.go).
+func gojiebaEntry() (common.ProviderEntry, bool) {
+	return common.ProviderEntry{
+		Provider:     &GoJiebaProvider{},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+		Priority:     100,
+		Aliases:      []string{"jieba"},
+	}, true
 }
@@ -0,0 +1,30 @@
+//go:build !cgo
+
+package zho
+
+import (
+	"time"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// gojieba wraps a C++ library and can't be built without CGO, in which case
+// JiebaLiteProvider takes its place. The functions below keep the package API
+// identical in both builds.
+
+// SetGoJiebaIdleTTL is a no-op in builds without CGO.
+func SetGoJiebaIdleTTL(ttl time.Duration) {}
+
+// UnloadGoJieba is a no-op in builds without CGO and returns false.
+func UnloadGoJieba() bool {
+	return false
+}
+
+// GoJiebaLoaded always returns false in builds without CGO.
+func GoJiebaLoaded() bool {
+	return false
+}
+
+func gojiebaEntry() (common.ProviderEntry, bool) {
+	return common.ProviderEntry{}, false
+}
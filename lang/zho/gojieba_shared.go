@@ -0,0 +1,155 @@
+//go:build cgo
+
+package zho
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/yanyiwu/gojieba"
+)
+
+// sharedJieba holds the gojieba instance shared by all GoJiebaProviders.
+// The C++ dictionaries take tens of MB of resident memory, so they are loaded
+// lazily on first use, shared between modules and released when the last
+// provider is closed, when Unload is called or after an idle period.
+type sharedJieba struct {
+	mu       sync.Mutex
+	jieba    *gojieba.Jieba
+	dictDir  string
+	refs     int // initialized providers holding a reference
+	active   int // segmentations in progress
+	lastUsed time.Time
+	idleTTL  time.Duration
+	timer    *time.Timer
+}
+
+var jiebaInstance = &sharedJieba{}
+
+// SetGoJiebaIdleTTL sets how long the gojieba dictionaries may stay in memory
+// without being used before they are released. They are reloaded transparently
+// on the next segmentation. A zero or negative TTL (the default) keeps them
+// loaded until the last provider is closed or UnloadGoJieba is called.
+func SetGoJiebaIdleTTL(ttl time.Duration) {
+	s := jiebaInstance
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idleTTL = ttl
+	s.scheduleIdleUnload()
+}
+
+// UnloadGoJieba releases the gojieba dictionaries from memory right away unless a
+// segmentation is in progress. They are reloaded transparently on next use.
+// Returns true if the dictionaries were released.
+func UnloadGoJieba() bool {
+	s := jiebaInstance
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active > 0 {
+		return false
+	}
+	return s.free()
+}
+
+// GoJiebaLoaded reports whether the gojieba dictionaries are currently in memory.
+func GoJiebaLoaded() bool {
+	s := jiebaInstance
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jieba != nil
+}
+
+// retain registers a provider as user of the dictionaries found in dictDir.
+func (s *sharedJieba) retain(dictDir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dictDir = dictDir
+	s.refs++
+}
+
+// releaseRef unregisters a provider and frees the dictionaries
+// once no provider uses them anymore.
+func (s *sharedJieba) releaseRef() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs > 0 {
+		s.refs--
+	}
+	if s.refs == 0 && s.active == 0 {
+		s.free()
+	}
+}
+
+// acquire returns the shared instance, loading the dictionaries if needed.
+// The caller must call done once it has finished using the instance.
+func (s *sharedJieba) acquire() (jieba *gojieba.Jieba, done func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jieba == nil {
+		s.load()
+	}
+	s.active++
+	return s.jieba, s.done
+}
+
+func (s *sharedJieba) done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active--
+	s.lastUsed = time.Now()
+	s.scheduleIdleUnload()
+}
+
+// load must be called with the lock held.
+func (s *sharedJieba) load() {
+	// Pass explicit paths to NewJieba to avoid runtime.Caller path issues
+	s.jieba = gojieba.NewJieba(
+		filepath.Join(s.dictDir, "jieba.dict.utf8"),
+		filepath.Join(s.dictDir, "hmm_model.utf8"),
+		filepath.Join(s.dictDir, "user.dict.utf8"),
+		filepath.Join(s.dictDir, "idf.utf8"),
+		filepath.Join(s.dictDir, "stop_words.utf8"),
+	)
+
+	// Register chengyu so that the segmenter keeps them in one piece
+	forEachChengyu(func(idiom string) {
+		s.jieba.AddWordEx(idiom, chengyuFreq, chengyuTag)
+	})
+	common.Log.Debug().Str("dir", s.dictDir).Msg("gojieba: dictionaries loaded")
+}
+
+// free must be called with the lock held.
+func (s *sharedJieba) free() bool {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if s.jieba == nil {
+		return false
+	}
+	s.jieba.Free()
+	s.jieba = nil
+	common.Log.Debug().Msg("gojieba: dictionaries released")
+	return true
+}
+
+// scheduleIdleUnload must be called with the lock held.
+func (s *sharedJieba) scheduleIdleUnload() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if s.idleTTL <= 0 || s.jieba == nil || s.active > 0 {
+		return
+	}
+	ttl := s.idleTTL
+	s.timer = time.AfterFunc(ttl, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.active == 0 && time.Since(s.lastUsed) >= ttl {
+			s.free()
+		}
+	})
+}
@@ -0,0 +1,126 @@
+//go:build cgo
+
+package zho_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/lang/zho"
+)
+
+func TestGoJieba_TokenizerBasic(t *testing.T) {
+	prov := &zho.GoJiebaProvider{}
+	require.NoError(t, prov.Init())
+
+	wrapper := &zho.TknSliceWrapper{
+		TknSliceWrapper: common.TknSliceWrapper{
+			Raw: []string{sampleText},
+		},
+	}
+	out, err := prov.ProcessFlowController(context.Background(), common.TokenizerMode, wrapper)
+	require.NoError(t, err)
+
+	var surfaces []string
+	for i := 0; i < out.Len(); i++ {
+		surfaces = append(surfaces, out.GetIdx(i).GetSurface())
+	}
+	t.Logf("Tokenizer result surfaces: %#v", surfaces)
+	// Possibly => ["你好", "吗", "，", "世界", "？"] or ["你", "好", "吗", "，", ...] – depends on dictionary
+
+	// We at least expect "你好" or "你" "好"
+	foundNiHao := false
+	for _, s := range surfaces {
+		if strings.Contains(s, "你好") {
+			foundNiHao = true
+			break
+		}
+	}
+	assert.True(t, foundNiHao,
+		"Tokenizer should contain either '你好' as a single token or '你' and '好'")
+}
+
+func TestGoJieba_EdgeCases(t *testing.T) {
+	prov := &zho.GoJiebaProvider{}
+	require.NoError(t, prov.Init())
+
+	// 1) Empty input
+	w1 := &zho.TknSliceWrapper{
+		TknSliceWrapper: common.TknSliceWrapper{Raw: []string{""}},
+	}
+	out1, err1 := prov.ProcessFlowController(context.Background(), common.TokenizerMode, w1)
+	require.NoError(t, err1)
+	assert.Equal(t, 0, out1.Len())
+
+	// 2) ASCII
+	w2 := &zho.TknSliceWrapper{
+		TknSliceWrapper: common.TknSliceWrapper{Raw: []string{"Hello world!"}},
+	}
+	out2, err2 := prov.ProcessFlowController(context.Background(), common.TokenizerMode, w2)
+	require.NoError(t, err2)
+	assert.GreaterOrEqual(t, out2.Len(), 1, "Should produce tokens from ASCII")
+
+	// Just log them
+	var surfaces []string
+	for i := 0; i < out2.Len(); i++ {
+		surfaces = append(surfaces, out2.GetIdx(i).GetSurface())
+	}
+	t.Logf("ASCII token surfaces: %+v", surfaces)
+}
+
+func TestGoJieba_Chengyu(t *testing.T) {
+	prov := &zho.GoJiebaProvider{}
+	require.NoError(t, prov.Init())
+
+	wrapper := &zho.TknSliceWrapper{
+		TknSliceWrapper: common.TknSliceWrapper{
+			Raw: []string{"他画蛇添足了。"},
+		},
+	}
+	out, err := prov.ProcessFlowController(context.Background(), common.TokenizerMode, wrapper)
+	require.NoError(t, err)
+
+	var idiom *zho.Tkn
+	for i := 0; i < out.Len(); i++ {
+		if tkn := out.GetIdx(i).(*zho.Tkn); tkn.Surface == "画蛇添足" {
+			idiom = tkn
+		}
+	}
+	require.NotNil(t, idiom, "Chengyu should not be split by the segmenter")
+	assert.True(t, idiom.IsIdiom())
+	assert.True(t, idiom.Chengyu)
+	require.NotEmpty(t, idiom.Glosses)
+	assert.NotEmpty(t, idiom.Glosses[0].Definition)
+}
+
+func TestGoJieba_LazyLoad(t *testing.T) {
+	m, err := translitkit.DefaultModule("zho")
+	require.NoError(t, err)
+	require.NoError(t, m.Init())
+	defer m.Close()
+	zho.UnloadGoJieba()
+	assert.False(t, zho.GoJiebaLoaded())
+
+	// Loaded on first use
+	_, err = m.Tokenized(shortText)
+	require.NoError(t, err)
+	assert.True(t, zho.GoJiebaLoaded())
+
+	// Released after the idle TTL and transparently reloaded
+	zho.SetGoJiebaIdleTTL(50 * time.Millisecond)
+	defer zho.SetGoJiebaIdleTTL(0)
+	_, err = m.Tokenized(shortText)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return !zho.GoJiebaLoaded() }, time.Second, 10*time.Millisecond)
+
+	tokenized, err := m.Tokenized(shortText)
+	require.NoError(t, err)
+	assert.Equal(t, "你好", tokenized)
+}
@@ -13,27 +13,43 @@ func init() {
 	// 1) Create the provider entries
 	///////////////////////////////////
 
-	// A) Tokenizer: GoJieba
-	gojiebaProv := &GoJiebaProvider{}
-	gojiebaEntry := common.ProviderEntry{
-		Provider:     gojiebaProv,
-		Capabilities: []string{"tokenization"},
+	// A) Tokenizers: GoJieba needs CGO, jieba-lite is its pure Go counterpart
+	gojiebaEntry, hasGoJieba := gojiebaEntry()
+	liteEntry := common.ProviderEntry{
+		Provider:     &JiebaLiteProvider{},
+		Capabilities: []common.Capability{common.CapabilityTokenization, common.CapabilityLemmatization},
+		Priority:     90, // below gojieba, used where it can't run
 	}
+	tokenizerEntry := liteEntry
+	if hasGoJieba {
+		tokenizerEntry = gojiebaEntry
+	}
+	tokenizer := tokenizerEntry.Provider.Name()
 
 	// B) Transliterator: GoPinyin
 	gopinyinProv := &GoPinyinProvider{}
 	gopinyinEntry := common.ProviderEntry{
 		Provider:     gopinyinProv,
-		Capabilities: []string{"transliteration"},
+		Capabilities: []common.Capability{common.CapabilityTransliteration},
+		Priority:     100,
+		Aliases:      []string{"go-pinyin", "pinyin"},
 	}
 
 	///////////////////////////////////
 	// 2) Register the providers
 	///////////////////////////////////
 
-	// Register gojieba as the tokenizer
-	if err := common.Register("zho", gojiebaEntry); err != nil {
-		panic(fmt.Sprintf("failed to register gojieba: %v", err))
+	// No SetDefault: the default chain is resolved from the priorities, i.e.
+	// gojieba → gopinyin, or jieba-lite → gopinyin where gojieba can't run.
+
+	// Register the tokenizers
+	if hasGoJieba {
+		if err := common.Register("zho", gojiebaEntry); err != nil {
+			panic(fmt.Sprintf("failed to register gojieba: %v", err))
+		}
+	}
+	if err := common.Register("zho", liteEntry); err != nil {
+		panic(fmt.Sprintf("failed to register jieba-lite: %v", err))
 	}
 
 	// Register gopinyin as the transliterator
@@ -42,25 +58,13 @@ func init() {
 	}
 
 	///////////////////////////////////
-	// 3) Set them as default providers
-	///////////////////////////////////
-
-	// The first is the tokenizer, the second is the transliterator.
-	defaultChain := []common.ProviderEntry{
-		gojiebaEntry,
-		gopinyinEntry,
-	}
-	if err := common.SetDefault("zho", defaultChain); err != nil {
-		panic(fmt.Sprintf("failed to set default providers for zho: %v", err))
-	}
-
-	///////////////////////////////////
-	// 4) Register transliteration schemes for "zho"
+	// 3) Register transliteration schemes for "zho"
 	///////////////////////////////////
 
 	// The following "scheme" names map to the GoPinyinProvider. 
 	// They match the keys in PinyinSchemes from gopinyin_provider.go,
 	// e.g. "tone", "tone2", "tone3", "initials", "firstletter", etc.
+	// The "ipa" scheme is handled by GoPinyinProvider itself (see ipa.go).
 	// This lets you do:
 	//   mod, err := common.GetSchemeModule("zho", "tone")
 	// and get a "gopinyin" provider with that scheme set.
@@ -69,22 +73,28 @@ func init() {
 		{
 			Name:        "tone",
 			Description: "Pinyin with diacritic tone marks (mā má mǎ mà)",
-			Providers:   []string{"gojieba", "gopinyin"},
+			Providers:   []string{tokenizer, "gopinyin"},
 		},
 		{
 			Name:        "normal",
 			Description: "Pinyin without tone marks",
-			Providers:   []string{"gojieba", "gopinyin"},
+			Providers:   []string{tokenizer, "gopinyin"},
 		},
 		{
 			Name:        "tone2",
 			Description: "Pinyin with trailing numeric tone (ma1 ma2 ma3 ma4)",
-			Providers:   []string{"gojieba", "gopinyin"},
+			Providers:   []string{tokenizer, "gopinyin"},
 		},
 		{
 			Name:        "tone3",
 			Description: "Pinyin with inline numeric tone",
-			Providers:   []string{"gojieba", "gopinyin"},
+			Providers:   []string{tokenizer, "gopinyin"},
+		},
+		{
+			Name:        "ipa",
+			Description: "IPA transcription with Chao tone letters (ʈʂʊŋ˥)",
+			Providers:   []string{tokenizer, "gopinyin"},
+			IPA:         true,
 		},
 	}
 
@@ -97,11 +107,21 @@ func init() {
 		}
 	}
 
+	// Full-width Latin letters and digits are folded to ASCII so that they
+	// aren't mistaken for Chinese characters by the tokenizer
+	if err := common.RegisterNormalization("zho", common.Normalization{Form: common.NFC, FoldWidth: true}); err != nil {
+		panic(fmt.Sprintf("failed to register normalization for zho: %v", err))
+	}
+
+	if err := common.RegisterToneNumberer("zho", PinyinToneNumbers); err != nil {
+		panic(fmt.Sprintf("failed to register tone numberer for zho: %v", err))
+	}
+
 	// Now "zho" has a set of recognized transliteration scheme names
 	// that map to "gopinyin" in the registry.
 	///////////////////////////////////
 
 	// That’s it! We have:
-	//   - zho default providers: [gojieba -> gopinyin]
+	//   - zho default providers: [gojieba (or jieba-lite without CGO) -> gopinyin], by priority
 	//   - zho transliteration schemes registered: "normal", "tone", "tone2", ...
 }
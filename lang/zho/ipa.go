@@ -0,0 +1,117 @@
+package zho
+
+import (
+	"strings"
+)
+
+// pinyinInitialsIPA maps pinyin initials to IPA. Two-letter initials are listed
+// separately because they must be matched first.
+var (
+	pinyinDigraphInitialsIPA = map[string]string{
+		"zh": "ʈʂ", "ch": "ʈʂʰ", "sh": "ʂ",
+	}
+	pinyinInitialsIPA = map[string]string{
+		"b": "p", "p": "pʰ", "m": "m", "f": "f",
+		"d": "t", "t": "tʰ", "n": "n", "l": "l",
+		"g": "k", "k": "kʰ", "h": "x",
+		"j": "tɕ", "q": "tɕʰ", "x": "ɕ",
+		"r": "ʐ", "z": "ts", "c": "tsʰ", "s": "s",
+	}
+)
+
+// pinyinFinalsIPA maps pinyin finals, in their full form (iou, uei, uen),
+// to IPA.
+var pinyinFinalsIPA = map[string]string{
+	"a": "a", "o": "wo", "e": "ɤ", "ê": "ɛ", "er": "ɚ",
+	"ai": "aɪ", "ei": "eɪ", "ao": "ɑʊ", "ou": "oʊ",
+	"an": "an", "en": "ən", "ang": "ɑŋ", "eng": "əŋ", "ong": "ʊŋ",
+	"i": "i", "ia": "ja", "ie": "jɛ", "iao": "jɑʊ", "iou": "joʊ",
+	"ian": "jɛn", "in": "in", "iang": "jɑŋ", "ing": "iŋ", "iong": "jʊŋ",
+	"u": "u", "ua": "wa", "uo": "wo", "uai": "waɪ", "uei": "weɪ",
+	"uan": "wan", "uen": "wən", "uang": "wɑŋ", "ueng": "wəŋ",
+	"ü": "y", "üe": "ɥɛ", "üan": "ɥɛn", "ün": "yn",
+}
+
+// toneLettersIPA holds the Chao tone letters of the four tones of Mandarin.
+// The neutral tone (5) is left unmarked.
+var toneLettersIPA = map[Tone]string{
+	First:  "˥",
+	Second: "˧˥",
+	Third:  "˨˩˦",
+	Fourth: "˥˩",
+}
+
+// zeroInitialFinals rewrites the y- and w- spellings of zero-initial syllables
+// to their full finals, e.g. "you" → "iou", "wei" → "uei".
+var zeroInitialFinals = map[string]string{
+	"yi": "i", "yin": "in", "ying": "ing", "yu": "ü", "yue": "üe", "yuan": "üan", "yun": "ün",
+	"ya": "ia", "ye": "ie", "yao": "iao", "you": "iou", "yan": "ian", "yang": "iang", "yong": "iong",
+	"wu": "u", "wa": "ua", "wo": "uo", "wai": "uai", "wei": "uei", "wan": "uan", "wen": "uen",
+	"wang": "uang", "weng": "ueng",
+}
+
+// PinyinToIPA converts a single pinyin syllable without tone marks or numbers
+// (e.g. "zhong", "lv", "lü") to IPA, adding the Chao tone letters of the given tone.
+// Syllables that can't be parsed are returned unchanged.
+func PinyinToIPA(syllable string, tone Tone) string {
+	s := strings.ToLower(syllable)
+	s = strings.NewReplacer("u:", "ü", "v", "ü").Replace(s)
+
+	initial, final := "", s
+	if full, ok := zeroInitialFinals[s]; ok {
+		final = full
+	} else if len(s) >= 2 && pinyinDigraphInitialsIPA[s[:2]] != "" {
+		initial, final = s[:2], s[2:]
+	} else if len(s) >= 1 && pinyinInitialsIPA[s[:1]] != "" {
+		initial, final = s[:1], s[1:]
+	}
+
+	// Restore the full form of contracted finals and the ü written as u after j, q, x
+	switch {
+	case initial == "j" || initial == "q" || initial == "x":
+		if strings.HasPrefix(final, "u") {
+			final = "ü" + strings.TrimPrefix(final, "u")
+		}
+	case initial != "":
+		switch final {
+		case "iu":
+			final = "iou"
+		case "ui":
+			final = "uei"
+		case "un":
+			final = "uen"
+		}
+	}
+
+	var finalIPA string
+	switch {
+	case final == "i" && (initial == "z" || initial == "c" || initial == "s"):
+		finalIPA = "ɹ̩"
+	case final == "i" && (initial == "zh" || initial == "ch" || initial == "sh" || initial == "r"):
+		finalIPA = "ɻ̩"
+	case final == "o" && initial != "" && !strings.Contains("bpmf", initial):
+		finalIPA = "o"
+	default:
+		var ok bool
+		if finalIPA, ok = pinyinFinalsIPA[final]; !ok {
+			return syllable
+		}
+	}
+
+	initialIPA := pinyinDigraphInitialsIPA[initial]
+	if initialIPA == "" {
+		initialIPA = pinyinInitialsIPA[initial]
+	}
+	return initialIPA + finalIPA + toneLettersIPA[tone]
+}
+
+// numericPinyinToIPA converts a syllable in go-pinyin's Tone2 style (e.g. "zho1ng")
+// to IPA. Syllables without a tone number get the neutral tone.
+func numericPinyinToIPA(syllable string) string {
+	if syllable == "" {
+		return ""
+	}
+	tone := Tone(parseToneNumber(syllable))
+	base := toneNumberRegex.ReplaceAllString(syllable, "")
+	return PinyinToIPA(base, tone)
+}
@@ -0,0 +1,327 @@
+package zho
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// JiebaLiteProvider is a pure Go Chinese segmenter that doesn't need CGO.
+// It reimplements the dictionary-based part of jieba: it builds the DAG of all
+// the dictionary words found in a sentence and picks the segmentation with the
+// highest probability according to their frequencies. Unlike gojieba it has no
+// HMM model for out-of-vocabulary words, which are left as single characters.
+//
+// It uses the same jieba.dict.utf8 dictionary as gojieba, downloaded on first
+// use, and is the default tokenizer of binaries built with CGO_ENABLED=0.
+type JiebaLiteProvider struct {
+	config                   map[string]interface{}
+	progressCallback         common.ProgressCallback
+	downloadProgressCallback common.DownloadProgressCallback
+	dict                     *liteDict
+}
+
+// WithProgressCallback sets a callback function for reporting progress during processing.
+func (p *JiebaLiteProvider) WithProgressCallback(callback common.ProgressCallback) {
+	p.progressCallback = callback
+}
+
+// WithDownloadProgressCallback sets a callback for download progress during dictionary downloads.
+func (p *JiebaLiteProvider) WithDownloadProgressCallback(callback common.DownloadProgressCallback) {
+	p.downloadProgressCallback = callback
+}
+
+// SaveConfig stores the configuration for later application during initialization.
+func (p *JiebaLiteProvider) SaveConfig(cfg map[string]interface{}) error {
+	p.config = cfg
+	return nil
+}
+
+// InitWithContext downloads the dictionary on first run and loads it in memory.
+// The dictionary is shared by all JiebaLiteProviders.
+//
+// Returns an error if the download or loading fails or the context is canceled.
+func (p *JiebaLiteProvider) InitWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("jieba-lite: context canceled during initialization: %w", err)
+	}
+	if p.dict != nil {
+		return nil
+	}
+
+	dictDir, err := ensureDictDir()
+	if err != nil {
+		return fmt.Errorf("jieba-lite: failed to create dictionary directory: %w", err)
+	}
+	onProgress := func(downloaded, total int64) {
+		if p.downloadProgressCallback != nil {
+			p.downloadProgressCallback(p.Name(), downloaded, total, "Downloading jieba dictionary...")
+		}
+	}
+	if err := ensureDictionaries(ctx, dictDir, liteDictFiles, onProgress); err != nil {
+		return fmt.Errorf("jieba-lite: failed to download dictionary: %w", err)
+	}
+
+	dict, err := loadLiteDict(filepath.Join(dictDir, liteDictFiles[0].name))
+	if err != nil {
+		return fmt.Errorf("jieba-lite: %w", err)
+	}
+	p.dict = dict
+	return nil
+}
+
+// Init initializes the provider with a background context.
+func (p *JiebaLiteProvider) Init() error {
+	return p.InitWithContext(context.Background())
+}
+
+// InitRecreateWithContext reloads the dictionary from disk.
+func (p *JiebaLiteProvider) InitRecreateWithContext(ctx context.Context, noCache bool) error {
+	liteDictMu.Lock()
+	sharedLiteDict = nil
+	liteDictMu.Unlock()
+	p.dict = nil
+	return p.InitWithContext(ctx)
+}
+
+// InitRecreate reinitializes the provider with a background context.
+func (p *JiebaLiteProvider) InitRecreate(noCache bool) error {
+	return p.InitRecreateWithContext(context.Background(), noCache)
+}
+
+// ProcessFlowController segments the raw input chunks and annotates the words
+// with the POS tag of the dictionary.
+func (p *JiebaLiteProvider) ProcessFlowController(ctx context.Context, mode common.OperatingMode, input common.AnyTokenSliceWrapper) (common.AnyTokenSliceWrapper, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("jieba-lite: context canceled during processing: %w", err)
+	}
+	if p.dict == nil {
+		if err := p.InitWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("failed to init jieba-lite: %w", err)
+		}
+	}
+
+	rawChunks := input.GetRaw()
+	if len(rawChunks) == 0 {
+		return input, nil
+	}
+
+	outWrapper := &TknSliceWrapper{}
+	for idx, chunk := range rawChunks {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("jieba-lite: context canceled while processing chunk %d: %w", idx, err)
+		}
+		if p.progressCallback != nil {
+			p.progressCallback(idx, len(rawChunks))
+		}
+		if chunk == "" {
+			continue
+		}
+		words, tags := p.dict.cut(chunk)
+		for _, tkn := range buildTokens(chunk, words, tags) {
+			outWrapper.Append(tkn)
+		}
+	}
+
+	input.ClearRaw()
+	return outWrapper, nil
+}
+
+// Name returns the unique name of this provider.
+func (p *JiebaLiteProvider) Name() string {
+	return "jieba-lite"
+}
+
+// SupportedModes returns the operating modes this provider supports.
+func (p *JiebaLiteProvider) SupportedModes() []common.OperatingMode {
+	return []common.OperatingMode{common.TokenizerMode}
+}
+
+// GetMaxQueryLen returns a large number so the module can handle big input.
+func (p *JiebaLiteProvider) GetMaxQueryLen() int {
+	return math.MaxInt32
+}
+
+// ResourceVersions returns the SHA-256 checksum of the dictionary file,
+// implementing common.VersionReporter.
+func (p *JiebaLiteProvider) ResourceVersions(ctx context.Context) (map[string]string, error) {
+	versions, err := dictionaryVersions(liteDictFiles)
+	if err != nil {
+		return nil, fmt.Errorf("jieba-lite: %w", err)
+	}
+	return versions, nil
+}
+
+// CloseWithContext drops the provider's reference to the shared dictionary.
+func (p *JiebaLiteProvider) CloseWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("jieba-lite: context canceled during close: %w", err)
+	}
+	p.dict = nil
+	return nil
+}
+
+// Close releases resources used by the provider with a background context.
+func (p *JiebaLiteProvider) Close() error {
+	return p.CloseWithContext(context.Background())
+}
+
+// liteDictFiles are the dictionary files used by jieba-lite, a subset of dictFiles.
+var liteDictFiles = dictFiles[:1]
+
+type liteEntry struct {
+	freq float64
+	tag  string
+}
+
+// liteDict is the in-memory jieba dictionary used by JiebaLiteProvider.
+type liteDict struct {
+	words    map[string]liteEntry
+	logTotal float64
+	maxLen   int // length in runes of the longest word
+}
+
+var (
+	liteDictMu     sync.Mutex
+	sharedLiteDict *liteDict
+)
+
+// loadLiteDict loads the jieba dictionary at path, or returns the one already loaded.
+// Each line of the dictionary holds a word, its frequency and its POS tag.
+func loadLiteDict(path string) (*liteDict, error) {
+	liteDictMu.Lock()
+	defer liteDictMu.Unlock()
+	if sharedLiteDict != nil {
+		return sharedLiteDict, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dictionary: %w", err)
+	}
+	defer f.Close()
+
+	d := &liteDict{words: make(map[string]liteEntry, 350000)}
+	var total float64
+	add := func(word string, freq float64, tag string) {
+		if old, ok := d.words[word]; ok {
+			total -= old.freq
+		}
+		d.words[word] = liteEntry{freq: freq, tag: tag}
+		total += freq
+		if n := len([]rune(word)); n > d.maxLen {
+			d.maxLen = n
+		}
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		freq, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		tag := "x"
+		if len(fields) > 2 {
+			tag = fields[2]
+		}
+		add(fields[0], freq, tag)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dictionary: %w", err)
+	}
+
+	// Register chengyu so that the segmenter keeps them in one piece
+	forEachChengyu(func(idiom string) {
+		add(idiom, chengyuFreq, chengyuTag)
+	})
+
+	d.logTotal = math.Log(total)
+	sharedLiteDict = d
+	common.Log.Debug().Str("path", path).Int("words", len(d.words)).Msg("jieba-lite: dictionary loaded")
+	return d, nil
+}
+
+// cut segments text into words with their POS tags. Runs of Han characters are
+// segmented with the dictionary, runs of letters and digits are kept whole and
+// everything else is left out to be integrated as filler.
+func (d *liteDict) cut(text string) (words, tags []string) {
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch {
+		case unicode.Is(unicode.Han, rs[i]):
+			for j < len(rs) && unicode.Is(unicode.Han, rs[j]) {
+				j++
+			}
+			w, t := d.cutHan(rs[i:j])
+			words = append(words, w...)
+			tags = append(tags, t...)
+		case unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]):
+			for j < len(rs) && !unicode.Is(unicode.Han, rs[j]) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			tag := "eng"
+			if unicode.IsDigit(rs[i]) {
+				tag = "m"
+			}
+			words = append(words, string(rs[i:j]))
+			tags = append(tags, tag)
+		}
+		i = j
+	}
+	return words, tags
+}
+
+// cutHan segments a run of Han characters along the most probable path of its
+// word DAG, as jieba's cut without HMM does.
+func (d *liteDict) cutHan(rs []rune) (words, tags []string) {
+	n := len(rs)
+	// route[i] holds the best log probability of rs[i:] and the end of its first word
+	type step struct {
+		logProb float64
+		end     int
+	}
+	route := make([]step, n+1)
+	for i := n - 1; i >= 0; i-- {
+		// A single character is always a candidate, even if not in the dictionary
+		best := step{logProb: math.Inf(-1)}
+		for l := 1; l <= d.maxLen && i+l <= n; l++ {
+			entry, ok := d.words[string(rs[i:i+l])]
+			if !ok && l > 1 {
+				continue
+			}
+			freq := entry.freq
+			if freq <= 0 {
+				freq = 1
+			}
+			if lp := math.Log(freq) - d.logTotal + route[i+l].logProb; lp > best.logProb {
+				best = step{logProb: lp, end: i + l}
+			}
+		}
+		route[i] = best
+	}
+
+	for i := 0; i < n; i = route[i].end {
+		word := string(rs[i:route[i].end])
+		tag := "x"
+		if entry, ok := d.words[word]; ok {
+			tag = entry.tag
+		}
+		words = append(words, word)
+		tags = append(tags, tag)
+	}
+	return words, tags
+}
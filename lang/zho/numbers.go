@@ -0,0 +1,80 @@
+package zho
+
+import (
+	"strings"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+var (
+	zhoDigits = []string{"líng", "yī", "èr", "sān", "sì", "wǔ", "liù", "qī", "bā", "jiǔ"}
+	// units of the groups of four digits: wàn (10⁴), yì (10⁸), zhào (10¹²)
+	zhoGroupUnits = []string{"", "wàn", "yì", "zhào"}
+	zhoUnits      = []string{"qiān", "bǎi", "shí", ""}
+)
+
+// NumberSpeller spells out numbers in pinyin, e.g. 2024 → "liǎng qiān líng èr shí sì".
+var NumberSpeller = common.NumberSpeller{
+	Integer: SpellInteger,
+	Point:   "diǎn",
+}
+
+// SpellInteger returns the reading of a non-negative integer given as ASCII
+// digits in pinyin with tone marks, and false beyond 10¹⁶. Zeros inside the
+// number are read as a single líng, 10 to 19 are read shí, shí yī... and
+// 2 is read liǎng before qiān and the group units.
+func SpellInteger(digits string) (string, bool) {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return zhoDigits[0], true
+	}
+	if len(digits) > 4*len(zhoGroupUnits) {
+		return "", false
+	}
+	var words []string
+	pendingZero := false
+	for i := 0; i < len(digits); i++ {
+		pos := len(digits) - 1 - i // power of ten
+		d := digits[i] - '0'
+		unit := zhoUnits[3-pos%4]
+		if d == 0 {
+			pendingZero = true
+		} else {
+			if pendingZero {
+				words = append(words, zhoDigits[0])
+				pendingZero = false
+			}
+			switch {
+			case d == 1 && unit == "shí" && i == 0:
+				// 十 rather than 一十 at the start of the number
+			case d == 2 && (unit == "qiān" || (unit == "" && pos > 0 && !hasNonZero(digits[max(0, i-3):i]))):
+				words = append(words, "liǎng")
+			default:
+				words = append(words, zhoDigits[d])
+			}
+			if unit != "" {
+				words = append(words, unit)
+			}
+		}
+		if pos%4 == 0 && pos > 0 && hasNonZero(digits[max(0, i-3):i+1]) {
+			words = append(words, zhoGroupUnits[pos/4])
+			// a zero ending a group is only read if more digits follow
+			pendingZero = false
+			if i+1 < len(digits) && digits[i+1] == '0' {
+				pendingZero = true
+			}
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+func hasNonZero(digits string) bool {
+	return strings.Trim(digits, "0") != ""
+}
+
+// WithNumberExpansion appends the enricher spelling out the numbers of the
+// tokens in pinyin, see common.NewNumberExpander.
+func (m *Module) WithNumberExpansion() *Module {
+	m.WithEnricher(common.NewNumberExpander(NumberSpeller))
+	return m
+}
@@ -0,0 +1,52 @@
+package zho
+
+import (
+	"fmt"
+
+	"github.com/tassa-yoniso-manasi-karoto/translitkit/common"
+)
+
+// JiebaPOS maps the POS tags of jieba (gojieba and jieba-lite), derived from
+// the ICTCLAS tag set, to Universal POS tags. Subcategories not listed here
+// (e.g. "nrfg", "vd") map to their category by prefix, see common.ToUPOS.
+var JiebaPOS = map[string]string{
+	"a":   common.UPOSAdj,   // 形容词 adjective
+	"ad":  common.UPOSAdv,   // 副形词 adverbial adjective
+	"an":  common.UPOSNoun,  // 名形词 nominal adjective
+	"b":   common.UPOSAdj,   // 区别词 non-predicate adjective
+	"c":   common.UPOSCconj, // 连词 conjunction
+	"d":   common.UPOSAdv,   // 副词 adverb
+	"e":   common.UPOSIntj,  // 叹词 interjection
+	"eng": common.UPOSX,     // foreign word
+	"f":   common.UPOSNoun,  // 方位词 locality noun
+	"h":   common.UPOSPart,  // 前缀 prefix
+	"i":   common.UPOSX,     // 成语 idiom
+	"j":   common.UPOSNoun,  // 简称 abbreviation
+	"k":   common.UPOSPart,  // 后缀 suffix
+	"l":   common.UPOSX,     // 习用语 set phrase
+	"m":   common.UPOSNum,   // 数词 numeral
+	"n":   common.UPOSNoun,  // 名词 noun
+	"nr":  common.UPOSPropn, // 人名 person name
+	"ns":  common.UPOSPropn, // 地名 place name
+	"nt":  common.UPOSPropn, // 机构团体 organization name
+	"nz":  common.UPOSPropn, // 其他专名 other proper noun
+	"o":   common.UPOSIntj,  // 拟声词 onomatopoeia
+	"p":   common.UPOSAdp,   // 介词 preposition
+	"q":   common.UPOSNoun,  // 量词 classifier, NOUN as in the UD Chinese treebanks
+	"r":   common.UPOSPron,  // 代词 pronoun
+	"s":   common.UPOSNoun,  // 处所词 place word
+	"t":   common.UPOSNoun,  // 时间词 time word
+	"u":   common.UPOSPart,  // 助词 auxiliary particle (的, 了...)
+	"v":   common.UPOSVerb,  // 动词 verb
+	"vn":  common.UPOSNoun,  // 名动词 nominal verb
+	"w":   common.UPOSPunct, // 标点符号 punctuation
+	"x":   common.UPOSX,     // 非语素字 non-morpheme
+	"y":   common.UPOSPart,  // 语气词 modal particle
+	"z":   common.UPOSAdj,   // 状态词 descriptive adjective
+}
+
+func init() {
+	if err := common.RegisterPOSMapping("jieba", JiebaPOS); err != nil {
+		panic(fmt.Sprintf("failed to register jieba POS mapping: %v", err))
+	}
+}
@@ -15,6 +15,7 @@ type Tkn struct {
 	NumStrokes    int           // Number of strokes
 	Radical       string        // Character radical (部首)
 	Components    []string      // Character components
+	Characters    []CharInfo    // Metadata of the characters of Components, see WithCharInfo
 	
 	// Phonological features
 	Pinyin       string         // Standard Pinyin
@@ -26,6 +27,11 @@ type Tkn struct {
 	PinyinNum    string         // Pinyin with tone numbers
 	// PinyinNumAll does the same for numeric pinyin.
 	PinyinNumAll [][]string
+	// CharReadings aligns each character of the token with its own reading,
+	// in order. It is meant for character-level ruby annotation (like furigana).
+	CharReadings []CharReading
+	// IPA holds the IPA transcription of the token, one syllable per character.
+	IPA string
 
 	Zhuyin       string         // Bopomofo/Zhuyin
 	Tone         Tone           // Tone value
@@ -41,6 +47,7 @@ type Tkn struct {
 	// Semantic features
 	Measure      string        // Measure word (量词) if applicable
 	ClassifierType ClassifierType // Type of classifier if applicable
+	ClassifierCategory string    // Semantic category of the classifier (e.g. "flat objects")
 	Register     Register      // Literary/formal/informal/etc.
 	Style        Style         // Written/spoken style
 	Etymology    Etymology     // Word origin
@@ -62,6 +69,17 @@ type Tkn struct {
 	ModernUsage  bool         // Whether used in Modern Chinese
 }
 
+// CharReading holds the reading of a single character within a token.
+// Characters for which no reading is known (e.g. non-Han) have empty readings.
+type CharReading struct {
+	Char         string   // The character itself
+	Pinyin       string   // Chosen reading, in the provider's main style
+	PinyinNum    string   // Chosen reading with numeric tone
+	Tone         Tone     // Tone of the chosen reading (0 if unknown)
+	Alternatives []string // Other possible readings (heteronyms), main style
+	IPA          string   // IPA transcription of the chosen reading
+}
+
 // Morpheme represents a single Chinese morpheme
 type Morpheme struct {
 	Character    string
@@ -167,6 +185,22 @@ func (t *Tkn) HasSimplifiedVariant() bool {
 	return t.Simplified != "" && t.Simplified != t.Surface
 }
 
+// RubyPairs returns the token's characters paired with their chosen reading,
+// ready to be rendered as ruby annotation. Characters without a known reading
+// are paired with an empty string.
+func (t *Tkn) RubyPairs() [][2]string {
+	pairs := make([][2]string, len(t.CharReadings))
+	for i, cr := range t.CharReadings {
+		pairs[i] = [2]string{cr.Char, cr.Pinyin}
+	}
+	return pairs
+}
+
+// IsIdiom returns true if the token is a chengyu, a xiehouyu or another idiom
+func (t *Tkn) IsIdiom() bool {
+	return t.Chengyu || t.Xiehouyu || t.Idiom
+}
+
 // IsClassifier returns true if the token is a classifier
 func (t *Tkn) IsClassifier() bool {
 	return t.ClassifierType != ""
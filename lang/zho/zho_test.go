@@ -2,6 +2,8 @@
 package zho_test
 
 import (
+	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -19,65 +21,6 @@ var longText = "从前，有一个非常勤奋的学生，他每天都早起跑
 var shortText = "你好"
 var mixedText = "Hello 你好 123"
 
-func TestGoJieba_TokenizerBasic(t *testing.T) {
-	prov := &zho.GoJiebaProvider{}
-	require.NoError(t, prov.Init())
-
-	wrapper := &zho.TknSliceWrapper{
-		TknSliceWrapper: common.TknSliceWrapper{
-			Raw: []string{sampleText},
-		},
-	}
-	out, err := prov.ProcessFlowController(wrapper)
-	require.NoError(t, err)
-
-	var surfaces []string
-	for i := 0; i < out.Len(); i++ {
-		surfaces = append(surfaces, out.GetIdx(i).GetSurface())
-	}
-	t.Logf("Tokenizer result surfaces: %#v", surfaces)
-	// Possibly => ["你好", "吗", "，", "世界", "？"] or ["你", "好", "吗", "，", ...] – depends on dictionary
-
-	// We at least expect "你好" or "你" "好"
-	foundNiHao := false
-	for _, s := range surfaces {
-		if strings.Contains(s, "你好") {
-			foundNiHao = true
-			break
-		}
-	}
-	assert.True(t, foundNiHao,
-		"Tokenizer should contain either '你好' as a single token or '你' and '好'")
-}
-
-func TestGoJieba_EdgeCases(t *testing.T) {
-	prov := &zho.GoJiebaProvider{}
-	require.NoError(t, prov.Init())
-
-	// 1) Empty input
-	w1 := &zho.TknSliceWrapper{
-		TknSliceWrapper: common.TknSliceWrapper{Raw: []string{""}},
-	}
-	out1, err1 := prov.ProcessFlowController(w1)
-	require.NoError(t, err1)
-	assert.Equal(t, 0, out1.Len())
-
-	// 2) ASCII
-	w2 := &zho.TknSliceWrapper{
-		TknSliceWrapper: common.TknSliceWrapper{Raw: []string{"Hello world!"}},
-	}
-	out2, err2 := prov.ProcessFlowController(w2)
-	require.NoError(t, err2)
-	assert.GreaterOrEqual(t, out2.Len(), 1, "Should produce tokens from ASCII")
-
-	// Just log them
-	var surfaces []string
-	for i := 0; i < out2.Len(); i++ {
-		surfaces = append(surfaces, out2.GetIdx(i).GetSurface())
-	}
-	t.Logf("ASCII token surfaces: %+v", surfaces)
-}
-
 func TestGoPinyinProvider_BasicTone(t *testing.T) {
 	pprov := &zho.GoPinyinProvider{}
 	pprov.SaveConfig(map[string]interface{}{"scheme": "tone"}) // diacritics
@@ -99,7 +42,7 @@ func TestGoPinyinProvider_BasicTone(t *testing.T) {
 			},
 		},
 	)
-	out, err := pprov.ProcessFlowController(wrapper)
+	out, err := pprov.ProcessFlowController(context.Background(), common.TransliteratorMode, wrapper)
 	require.NoError(t, err)
 	require.Equal(t, 2, out.Len())
 
@@ -127,7 +70,7 @@ func TestGoPinyinProvider_SchemeTone2(t *testing.T) {
 		},
 	)
 
-	out, err := pprov.ProcessFlowController(wrapper)
+	out, err := pprov.ProcessFlowController(context.Background(), common.TransliteratorMode, wrapper)
 	require.NoError(t, err)
 	require.Equal(t, 2, out.Len())
 
@@ -141,6 +84,78 @@ func TestGoPinyinProvider_SchemeTone2(t *testing.T) {
 	assert.Contains(t, tkn2.Pinyin, "3", "Should contain numeric tone")
 }
 
+func TestGoPinyinProvider_TypedOptions(t *testing.T) {
+	pprov := &zho.GoPinyinProvider{}
+	require.NoError(t, common.Configure(pprov, zho.GoPinyinOptions{Scheme: "tone2"}))
+	require.NoError(t, pprov.Init())
+
+	wrapper := &zho.TknSliceWrapper{}
+	wrapper.Append(&zho.Tkn{Tkn: common.Tkn{Surface: "你", IsLexical: true}})
+	out, err := pprov.ProcessFlowController(context.Background(), common.TransliteratorMode, wrapper)
+	require.NoError(t, err)
+	assert.Contains(t, out.GetIdx(0).(*zho.Tkn).Pinyin, "3")
+
+	assert.Error(t, common.Configure(pprov, zho.GoPinyinOptions{Scheme: "nonexistent"}))
+	assert.Error(t, common.Configure(pprov, struct{ Scheme string }{"tone"}), "Options of another type should be refused")
+}
+
+func TestGoPinyinProvider_CharReadings(t *testing.T) {
+	pprov := &zho.GoPinyinProvider{}
+	pprov.SaveConfig(map[string]interface{}{"scheme": "tone"})
+	require.NoError(t, pprov.Init())
+
+	wrapper := &zho.TknSliceWrapper{}
+	wrapper.Append(
+		&zho.Tkn{
+			Tkn: common.Tkn{Surface: "学生", IsLexical: true},
+		},
+	)
+
+	out, err := pprov.ProcessFlowController(context.Background(), common.TransliteratorMode, wrapper)
+	require.NoError(t, err)
+
+	tkn := out.GetIdx(0).(*zho.Tkn)
+	require.Len(t, tkn.CharReadings, 2, "Should have one reading per character")
+
+	assert.Equal(t, "学", tkn.CharReadings[0].Char)
+	assert.Equal(t, "xué", tkn.CharReadings[0].Pinyin)
+	assert.Equal(t, zho.Second, tkn.CharReadings[0].Tone)
+	assert.Equal(t, "生", tkn.CharReadings[1].Char)
+	assert.Equal(t, "shēng", tkn.CharReadings[1].Pinyin)
+	assert.Equal(t, zho.First, tkn.CharReadings[1].Tone)
+
+	assert.Equal(t, [][2]string{{"学", "xué"}, {"生", "shēng"}}, tkn.RubyPairs())
+}
+
+func TestGoPinyinProvider_MixedScript(t *testing.T) {
+	pprov := &zho.GoPinyinProvider{}
+	pprov.SaveConfig(map[string]interface{}{"scheme": "tone"})
+	require.NoError(t, pprov.Init())
+
+	wrapper := &zho.TknSliceWrapper{}
+	wrapper.Append(
+		// 兙 has no reading in go-pinyin
+		&zho.Tkn{Tkn: common.Tkn{Surface: "学兙生", IsLexical: true}},
+		&zho.Tkn{Tkn: common.Tkn{Surface: "A股", IsLexical: true}},
+	)
+
+	out, err := pprov.ProcessFlowController(context.Background(), common.TransliteratorMode, wrapper)
+	require.NoError(t, err)
+
+	tkn := out.GetIdx(0).(*zho.Tkn)
+	require.Len(t, tkn.CharReadings, 3, "Should keep one reading per character")
+	assert.Equal(t, "", tkn.CharReadings[1].Pinyin)
+	assert.Equal(t, "xué shēng", tkn.Pinyin)
+	assert.Len(t, strings.Fields(tkn.PinyinNum), 2)
+	assert.Len(t, strings.Fields(tkn.IPA), 2)
+	assert.Equal(t, [][]string{{"xué"}, {"shēng"}}, tkn.PinyinAll)
+	assert.Len(t, tkn.PinyinNumAll, 2)
+
+	tkn = out.GetIdx(1).(*zho.Tkn)
+	assert.Equal(t, "A股", tkn.Romanization)
+	assert.Empty(t, tkn.Pinyin)
+}
+
 func TestZhoModule_DefaultPipeline(t *testing.T) {
 	m, err := translitkit.DefaultModule("zho")
 	require.NoError(t, err)
@@ -175,6 +190,25 @@ func TestZhoModule_DefaultPipeline(t *testing.T) {
 	assert.Contains(t, romanLong, "shēng", "Should see pinyin for '生'")
 }
 
+func TestZhoModule_Reconfigure(t *testing.T) {
+	m, err := translitkit.DefaultModule("zho")
+	require.NoError(t, err)
+	m.MustInit()
+	defer m.Close()
+
+	roman, err := m.Roman(shortText)
+	require.NoError(t, err)
+	assert.Contains(t, roman, "nǐ")
+
+	require.NoError(t, m.Reconfigure(context.Background(), common.TransliteratorMode, map[string]interface{}{"scheme": "tone3"}))
+	roman, err = m.Roman(shortText)
+	require.NoError(t, err)
+	assert.Contains(t, roman, "ni3")
+
+	err = m.Reconfigure(context.Background(), common.TransliteratorMode, map[string]interface{}{"scheme": "wade-giles"})
+	assert.ErrorContains(t, err, "unknown pinyin scheme")
+}
+
 func TestZhoModule_EdgeCases(t *testing.T) {
 	m, err := translitkit.DefaultModule("zho")
 	require.NoError(t, err)
@@ -201,3 +235,205 @@ func TestZhoModule_EdgeCases(t *testing.T) {
 	assert.True(t, strings.Contains(romanMix, "ni3") || strings.Contains(romanMix, "nǐ"),
 		"Should contain the pinyin for 你")
 }
+
+func TestClassifierDB(t *testing.T) {
+	info, ok := zho.LookupClassifier("张")
+	require.True(t, ok)
+	assert.Equal(t, zho.Individual, info.Type)
+	assert.Equal(t, zho.CategoryFlat, info.Category)
+
+	assert.Equal(t, []string{"本"}, zho.MeasureWordsFor("书"))
+	assert.Nil(t, zho.MeasureWordsFor("不存在"))
+}
+
+func TestPinyinToIPA(t *testing.T) {
+	assert.Equal(t, "ʈʂʊŋ˥", zho.PinyinToIPA("zhong", zho.First))
+	assert.Equal(t, "kwo˧˥", zho.PinyinToIPA("guo", zho.Second))
+	assert.Equal(t, "ɕɥɛ˧˥", zho.PinyinToIPA("xue", zho.Second))
+	assert.Equal(t, "ʂɻ̩˥˩", zho.PinyinToIPA("shi", zho.Fourth))
+	assert.Equal(t, "joʊ˨˩˦", zho.PinyinToIPA("you", zho.Third))
+	assert.Equal(t, "ly˨˩˦", zho.PinyinToIPA("lv", zho.Third))
+}
+
+func TestZhoModule_IPA(t *testing.T) {
+	m, err := common.GetIPAModule("zho")
+	require.NoError(t, err)
+	require.True(t, m.SupportsIPA())
+	m.MustInit()
+	defer m.Close()
+
+	ipa, err := m.IPA(shortText)
+	require.NoError(t, err)
+	assert.Contains(t, ipa, "ni˨˩˦")
+
+	def, err := translitkit.DefaultModule("zho")
+	require.NoError(t, err)
+	_, err = def.IPA(shortText)
+	assert.Error(t, err, "Default pinyin module shouldn't claim IPA support")
+}
+
+func TestZhoModule_Lockfile(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), common.LockfileName)
+
+	// Unpinned resources are refused when pinning is required
+	m, err := translitkit.DefaultModule("zho")
+	require.NoError(t, err)
+	m.WithLockfile(lockPath, true)
+	require.Error(t, m.Init())
+	m.Close()
+
+	// Otherwise they get pinned on first run
+	m.WithLockfile(lockPath, false)
+	require.NoError(t, m.Init())
+	lf, err := common.ReadLockfile(lockPath)
+	require.NoError(t, err)
+	tokenizer := m.Providers[0].Name()
+	assert.Contains(t, lf.Providers[tokenizer], "jieba.dict.utf8")
+
+	// and accepted afterwards, as long as they don't drift
+	m.WithLockfile(lockPath, true)
+	require.NoError(t, m.Init())
+
+	lf.Providers[tokenizer]["jieba.dict.utf8"] = "sha256:0000"
+	require.NoError(t, lf.Write(lockPath))
+	require.Error(t, m.Init())
+	m.Close()
+}
+
+func TestJiebaLite_Tokenizer(t *testing.T) {
+	prov := &zho.JiebaLiteProvider{}
+	require.NoError(t, prov.Init())
+
+	wrapper := &zho.TknSliceWrapper{
+		TknSliceWrapper: common.TknSliceWrapper{
+			Raw: []string{"我们在学校学习中文，他画蛇添足了。C++ 123"},
+		},
+	}
+	out, err := prov.ProcessFlowController(context.Background(), common.TokenizerMode, wrapper)
+	require.NoError(t, err)
+
+	var lexical []string
+	for i := 0; i < out.Len(); i++ {
+		if tkn := out.GetIdx(i).(*zho.Tkn); tkn.IsLexical {
+			lexical = append(lexical, tkn.Surface)
+		}
+	}
+	assert.Equal(t, []string{"我们", "在", "学校", "学习", "中文", "他", "画蛇添足", "了", "C", "123"}, lexical)
+}
+
+func TestZhoModule_PlatformFallback(t *testing.T) {
+	common.SetPlatform(&common.Platform{OS: "windows", Arch: "arm64"})
+	defer common.SetPlatform(nil)
+
+	m, err := translitkit.DefaultModule("zho")
+	require.NoError(t, err)
+	assert.Equal(t, "jieba-lite", m.Providers[0].Name())
+
+	matrix, err := common.PlatformMatrix("zho")
+	require.NoError(t, err)
+	for _, row := range matrix {
+		assert.Equal(t, row.Requirements.PureGo(), row.Usable, row.Provider)
+	}
+
+	tokenized, err := m.Tokenized(longText)
+	require.NoError(t, err)
+	assert.Contains(t, tokenized, "名列前茅")
+}
+
+func TestZhoModule_GlossEnricher(t *testing.T) {
+	cedict, err := common.LoadCEDICT(strings.NewReader(`# CC-CEDICT sample
+學習 学习 [xue2 xi2] /to learn/to study/
+中文 中文 [Zhong1 wen2] /Chinese language/
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, cedict.Len())
+	assert.Equal(t, cedict.Lookup("学习", "zho"), cedict.Lookup("學習", "zho"))
+	assert.Empty(t, cedict.Lookup("学习", "jpn"))
+
+	m, err := common.NewModule("zho", "jieba-lite", "gopinyin")
+	require.NoError(t, err)
+	m.WithEnricher(common.NewGlossEnricher(cedict))
+	assert.Equal(t, "jieba-lite→gopinyin→gloss-enricher", m.ProviderNames())
+	require.NoError(t, m.Init())
+	defer m.Close()
+
+	tsw, err := m.Tokens("我们学习中文")
+	require.NoError(t, err)
+	glosses := map[string][]common.Gloss{}
+	for i := 0; i < tsw.Len(); i++ {
+		tkn := common.BaseToken(tsw.GetIdx(i))
+		glosses[tkn.Surface] = tkn.Glosses
+	}
+	assert.Equal(t, []common.Gloss{
+		{Definition: "to learn", Info: "xue2 xi2"},
+		{Definition: "to study", Info: "xue2 xi2"},
+	}, glosses["学习"])
+	assert.Len(t, glosses["中文"], 1)
+	assert.Empty(t, glosses["我们"])
+}
+
+func TestToUPOS(t *testing.T) {
+	assert.Equal(t, common.UPOSNoun, common.ToUPOS("jieba", "n"))
+	assert.Equal(t, common.UPOSPropn, common.ToUPOS("jieba", "nrfg"), "subcategories map by prefix")
+	assert.Equal(t, common.UPOSX, common.ToUPOS("jieba", "eng"))
+	assert.Equal(t, common.UPOSX, common.ToUPOS("jieba", "?"))
+	assert.Equal(t, common.UPOSVerb, common.ToUPOS("unregistered", "VERB"))
+	assert.Equal(t, common.UPOSX, common.ToUPOS("unregistered", "v"))
+}
+
+func TestSpellInteger(t *testing.T) {
+	cases := map[string]string{
+		"0":         "líng",
+		"12":        "shí èr",
+		"2024":      "liǎng qiān líng èr shí sì",
+		"112":       "yī bǎi yī shí èr",
+		"20000":     "liǎng wàn",
+		"10010":     "yī wàn líng yī shí",
+		"100000001": "yī yì líng yī",
+	}
+	for digits, expected := range cases {
+		spoken, ok := zho.SpellInteger(digits)
+		assert.True(t, ok, digits)
+		assert.Equal(t, expected, spoken, digits)
+	}
+	spoken, ok := zho.NumberSpeller.Spell("3.14")
+	assert.True(t, ok)
+	assert.Equal(t, "sān diǎn yī sì", spoken)
+}
+
+func TestPinyinToneNumbers(t *testing.T) {
+	assert.Equal(t, "ni3 hao3", zho.PinyinToneNumbers("nǐ hǎo"))
+	assert.Equal(t, "lü4 de", zho.PinyinToneNumbers("lǜ de"))
+}
+
+func TestCharInfoEnricher(t *testing.T) {
+	info, ok := zho.BuiltinCharInfo().Lookup('说')
+	require.True(t, ok)
+	assert.Equal(t, zho.CharInfo{Char: "说", Radical: "言", RadicalNumber: 149, NumStrokes: 9, FreqRank: 24}, info)
+
+	wrapper := &zho.TknSliceWrapper{}
+	wrapper.Append(
+		&zho.Tkn{Tkn: common.Tkn{Surface: "中国", IsLexical: true}},
+		&zho.Tkn{Tkn: common.Tkn{Surface: "龍", IsLexical: true}},
+		&zho.Tkn{Tkn: common.Tkn{Surface: "C++", IsLexical: true}},
+	)
+	_, err := zho.NewCharInfoEnricher(nil).ProcessFlowController(context.Background(), common.EnricherMode, wrapper)
+	require.NoError(t, err)
+
+	word := wrapper.GetIdx(0).(*zho.Tkn)
+	assert.Equal(t, []string{"中", "国"}, word.Components)
+	require.Len(t, word.Characters, 2)
+	assert.Equal(t, "囗", word.Characters[1].Radical)
+	assert.Equal(t, 12, word.NumStrokes)
+	assert.Empty(t, word.Radical, "only single characters have a radical")
+
+	char := wrapper.GetIdx(1).(*zho.Tkn)
+	assert.Equal(t, "龍", char.Radical)
+	assert.Equal(t, 16, char.NumStrokes)
+	assert.Zero(t, char.Characters[0].FreqRank, "traditional forms aren't ranked")
+
+	assert.Empty(t, wrapper.GetIdx(2).(*zho.Tkn).Components)
+
+	_, err = zho.LoadCharInfoTSV(strings.NewReader("说\t215\t9\t1\n"))
+	assert.Error(t, err)
+}
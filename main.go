@@ -21,10 +21,30 @@ import (
 	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/urd"
 	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tam"
 	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/tel"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/kan"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mal"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/ori"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/san"
 	
 	// Cyrillic: iuliia
 	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/rus"
 	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/uzb"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/ukr"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/bel"
+
+	// Caucasus: table-based romanizers
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/kat"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/hye"
+
+	// Khmer: khmer-nltk and UNGEGN romanizer
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/khm"
+
+	// Tibetan: syllable tokenizer and Wylie transliterator
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/bod"
+
+	// Lao / Burmese: syllable tokenizers and BGN/PCGN romanizers
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/lao"
+	_ "github.com/tassa-yoniso-manasi-karoto/translitkit/lang/mya"
 )
 
 // DefaultModule returns a new Module configured with the default providers
@@ -52,6 +72,17 @@ func NewModule(lang string, providerNames ...string) (*common.Module, error) {
 	return common.NewModule(lang, providerNames...)
 }
 
+// NewTokenizerModule creates a Module that only segments text with the named
+// tokenizer, without transliteration: its romanization methods return
+// common.ErrRomanizationUnsupported.
+//
+// Example:
+//
+//	module, err := translitkit.NewTokenizerModule("th", "thai-dict")
+func NewTokenizerModule(lang, tokenizerName string) (*common.Module, error) {
+	return common.NewTokenizerModule(lang, tokenizerName)
+}
+
 // NeedsTokenization returns true if the given language doesn't use spaces
 // to separate words and requires tokenization.
 // The language code can be in any ISO 639 code format.